@@ -47,6 +47,7 @@ type CreateAccountRequest struct {
 	Last4          *string      `json:"last4,omitempty"`
 	InitialBalance *float64     `json:"initial_balance,omitempty"`
 	Notes          *string      `json:"notes,omitempty"`
+	IsSharedWithHousehold *bool `json:"is_shared_with_household,omitempty"` // Optional, defaults to true
 }
 
 type UpdateAccountRequest struct {
@@ -55,6 +56,7 @@ type UpdateAccountRequest struct {
 	Last4          *string  `json:"last4,omitempty"`
 	InitialBalance *float64 `json:"initial_balance,omitempty"`
 	Notes          *string  `json:"notes,omitempty"`
+	IsSharedWithHousehold *bool `json:"is_shared_with_household,omitempty"`
 }
 
 type ErrorResponse struct {
@@ -64,12 +66,7 @@ type ErrorResponse struct {
 // Helper methods
 
 func (h *Handler) getUserFromRequest(r *http.Request) (*auth.User, error) {
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		return nil, errors.New("no session cookie")
-	}
-
-	user, err := h.authSvc.GetUserBySession(r.Context(), cookie.Value)
+	user, err := h.authSvc.UserFromRequest(r, h.cookieName)
 	if err != nil {
 		return nil, err
 	}
@@ -77,17 +74,24 @@ func (h *Handler) getUserFromRequest(r *http.Request) (*auth.User, error) {
 	return user, nil
 }
 
-func (h *Handler) getUserHousehold(ctx context.Context, userID string) (*households.Household, error) {
-	households, err := h.householdRepo.ListByUser(ctx, userID)
+// getUserHousehold returns the household the request acts on after checking
+// the user's role there grants perm
+func (h *Handler) getUserHousehold(ctx context.Context, userID string, perm households.Permission) (*households.Household, error) {
+	householdID, err := households.AuthorizeSelected(ctx, h.householdRepo, userID, perm)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(households) == 0 {
-		return nil, errors.New("user has no household")
-	}
+	return h.householdRepo.GetByID(ctx, householdID)
+}
 
-	return households[0], nil
+// respondHouseholdError answers 403 when the user's role does not allow the request
+func (h *Handler) respondHouseholdError(w http.ResponseWriter, err error) {
+	if errors.Is(err, households.ErrNotAuthorized) {
+		h.respondError(w, errors.New("forbidden: your role does not allow this action"), http.StatusForbidden)
+		return
+	}
+	h.respondError(w, errors.New("user has no household"), http.StatusNotFound)
 }
 
 func (h *Handler) respondJSON(w http.ResponseWriter, data interface{}, statusCode int) {
@@ -111,9 +115,9 @@ func (h *Handler) CreateAccount(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	household, err := h.getUserHousehold(r.Context(), user.ID)
+	household, err := h.getUserHousehold(r.Context(), user.ID, households.PermEditAccounts)
 	if err != nil {
-		h.respondError(w, errors.New("user has no household"), http.StatusNotFound)
+		h.respondHouseholdError(w, err)
 		return
 	}
 
@@ -144,6 +148,7 @@ func (h *Handler) CreateAccount(w http.ResponseWriter, r *http.Request) {
 		Last4:          req.Last4,
 		InitialBalance: req.InitialBalance,
 		Notes:          req.Notes,
+		IsSharedWithHousehold: req.IsSharedWithHousehold,
 	}
 
 	account, err := h.service.Create(r.Context(), input)
@@ -168,13 +173,13 @@ func (h *Handler) ListAccounts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	household, err := h.getUserHousehold(r.Context(), user.ID)
+	household, err := h.getUserHousehold(r.Context(), user.ID, households.PermView)
 	if err != nil {
-		h.respondError(w, errors.New("user has no household"), http.StatusNotFound)
+		h.respondHouseholdError(w, err)
 		return
 	}
 
-	accounts, err := h.service.ListByHousehold(r.Context(), household.ID)
+	accounts, err := h.service.ListByHousehold(r.Context(), household.ID, user.ID)
 	if err != nil {
 		h.respondError(w, err, http.StatusInternalServerError)
 		return
@@ -195,6 +200,29 @@ func (h *Handler) ListAccounts(w http.ResponseWriter, r *http.Request) {
 	h.respondJSON(w, accounts, http.StatusOK)
 }
 
+// GetAccountSummary handles GET /api/accounts/summary
+func (h *Handler) GetAccountSummary(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserFromRequest(r)
+	if err != nil {
+		h.respondError(w, errors.New("unauthorized"), http.StatusUnauthorized)
+		return
+	}
+
+	household, err := h.getUserHousehold(r.Context(), user.ID, households.PermView)
+	if err != nil {
+		h.respondHouseholdError(w, err)
+		return
+	}
+
+	summary, err := h.service.GetSummary(r.Context(), household.ID, user.ID)
+	if err != nil {
+		h.respondError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.respondJSON(w, summary, http.StatusOK)
+}
+
 // GetAccount handles GET /api/accounts/:id
 func (h *Handler) GetAccount(w http.ResponseWriter, r *http.Request) {
 	user, err := h.getUserFromRequest(r)
@@ -203,9 +231,9 @@ func (h *Handler) GetAccount(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	household, err := h.getUserHousehold(r.Context(), user.ID)
+	household, err := h.getUserHousehold(r.Context(), user.ID, households.PermView)
 	if err != nil {
-		h.respondError(w, errors.New("user has no household"), http.StatusNotFound)
+		h.respondHouseholdError(w, err)
 		return
 	}
 
@@ -215,7 +243,7 @@ func (h *Handler) GetAccount(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	account, err := h.service.GetByID(r.Context(), id, household.ID)
+	account, err := h.service.GetByID(r.Context(), id, household.ID, user.ID)
 	if err != nil {
 		if errors.Is(err, ErrAccountNotFound) {
 			h.respondError(w, err, http.StatusNotFound)
@@ -240,9 +268,9 @@ func (h *Handler) UpdateAccount(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	household, err := h.getUserHousehold(r.Context(), user.ID)
+	household, err := h.getUserHousehold(r.Context(), user.ID, households.PermEditAccounts)
 	if err != nil {
-		h.respondError(w, errors.New("user has no household"), http.StatusNotFound)
+		h.respondHouseholdError(w, err)
 		return
 	}
 
@@ -260,11 +288,13 @@ func (h *Handler) UpdateAccount(w http.ResponseWriter, r *http.Request) {
 
 	input := UpdateInput{
 		ID:             id,
+		UserID:         user.ID,
 		Name:           req.Name,
 		Institution:    req.Institution,
 		Last4:          req.Last4,
 		InitialBalance: req.InitialBalance,
 		Notes:          req.Notes,
+		IsSharedWithHousehold: req.IsSharedWithHousehold,
 	}
 
 	account, err := h.service.Update(r.Context(), household.ID, input)
@@ -296,9 +326,9 @@ func (h *Handler) DeleteAccount(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	household, err := h.getUserHousehold(r.Context(), user.ID)
+	household, err := h.getUserHousehold(r.Context(), user.ID, households.PermEditAccounts)
 	if err != nil {
-		h.respondError(w, errors.New("user has no household"), http.StatusNotFound)
+		h.respondHouseholdError(w, err)
 		return
 	}
 
@@ -308,7 +338,7 @@ func (h *Handler) DeleteAccount(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.service.Delete(r.Context(), id, household.ID)
+	err = h.service.Delete(r.Context(), id, household.ID, user.ID)
 	if err != nil {
 		if errors.Is(err, ErrAccountNotFound) {
 			h.respondError(w, err, http.StatusNotFound)
@@ -24,13 +24,14 @@ func (r *repository) Create(ctx context.Context, account *Account) (*Account, er
 	var result Account
 	err := r.pool.QueryRow(ctx, `
 		INSERT INTO accounts (
-			household_id, owner_id, name, type, institution, last4, initial_balance, notes
+			household_id, owner_id, name, type, institution, last4, initial_balance, notes,
+			is_shared_with_household
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		RETURNING id, household_id, owner_id, name, type, institution, last4, initial_balance, 
-		          notes, created_at, updated_at
+		          notes, is_shared_with_household, created_at, updated_at
 	`, account.HouseholdID, account.OwnerID, account.Name, account.Type, account.Institution,
-		account.Last4, account.InitialBalance, account.Notes).Scan(
+		account.Last4, account.InitialBalance, account.Notes, account.IsSharedWithHousehold).Scan(
 		&result.ID,
 		&result.HouseholdID,
 		&result.OwnerID,
@@ -40,6 +41,7 @@ func (r *repository) Create(ctx context.Context, account *Account) (*Account, er
 		&result.Last4,
 		&result.InitialBalance,
 		&result.Notes,
+		&result.IsSharedWithHousehold,
 		&result.CreatedAt,
 		&result.UpdatedAt,
 	)
@@ -62,7 +64,7 @@ func (r *repository) GetByID(ctx context.Context, id string) (*Account, error) {
 	err := r.pool.QueryRow(ctx, `
 		SELECT a.id, a.household_id, a.owner_id, u.name as owner_name, a.name, a.type, 
 		       a.institution, a.last4, a.initial_balance, a.notes, 
-		       a.created_at, a.updated_at
+		       a.is_shared_with_household, a.created_at, a.updated_at
 		FROM accounts a
 		JOIN users u ON a.owner_id = u.id
 		WHERE a.id = $1
@@ -77,6 +79,7 @@ func (r *repository) GetByID(ctx context.Context, id string) (*Account, error) {
 		&account.Last4,
 		&account.InitialBalance,
 		&account.Notes,
+		&account.IsSharedWithHousehold,
 		&account.CreatedAt,
 		&account.UpdatedAt,
 	)
@@ -104,12 +107,12 @@ func (r *repository) Update(ctx context.Context, account *Account) (*Account, er
 	err := r.pool.QueryRow(ctx, `
 		UPDATE accounts
 		SET name = $2, institution = $3, last4 = $4, initial_balance = $5, 
-		    notes = $6, updated_at = NOW()
+		    notes = $6, is_shared_with_household = $7, updated_at = NOW()
 		WHERE id = $1
 		RETURNING id, household_id, owner_id, name, type, institution, last4, initial_balance, 
-		          notes, created_at, updated_at
+		          notes, is_shared_with_household, created_at, updated_at
 	`, account.ID, account.Name, account.Institution, account.Last4,
-		account.InitialBalance, account.Notes).Scan(
+		account.InitialBalance, account.Notes, account.IsSharedWithHousehold).Scan(
 		&result.ID,
 		&result.HouseholdID,
 		&result.OwnerID,
@@ -119,6 +122,7 @@ func (r *repository) Update(ctx context.Context, account *Account) (*Account, er
 		&result.Last4,
 		&result.InitialBalance,
 		&result.Notes,
+		&result.IsSharedWithHousehold,
 		&result.CreatedAt,
 		&result.UpdatedAt,
 	)
@@ -191,7 +195,7 @@ func (r *repository) ListByHousehold(ctx context.Context, householdID string) ([
 	rows, err := r.pool.Query(ctx, `
 		SELECT a.id, a.household_id, a.owner_id, u.name as owner_name, a.name, a.type, 
 		       a.institution, a.last4, a.initial_balance, a.notes, 
-		       a.created_at, a.updated_at
+		       a.is_shared_with_household, a.created_at, a.updated_at
 		FROM accounts a
 		JOIN users u ON a.owner_id = u.id
 		WHERE a.household_id = $1
@@ -222,6 +226,7 @@ func (r *repository) ListByHousehold(ctx context.Context, householdID string) ([
 			&account.Last4,
 			&account.InitialBalance,
 			&account.Notes,
+			&account.IsSharedWithHousehold,
 			&account.CreatedAt,
 			&account.UpdatedAt,
 		)
@@ -251,7 +256,7 @@ func (r *repository) FindByName(ctx context.Context, householdID, name string) (
 	var account Account
 	err := r.pool.QueryRow(ctx, `
 		SELECT id, household_id, name, type, institution, last4, initial_balance, 
-		       notes, created_at, updated_at
+		       notes, is_shared_with_household, created_at, updated_at
 		FROM accounts
 		WHERE household_id = $1 AND name = $2
 	`, householdID, name).Scan(
@@ -263,6 +268,7 @@ func (r *repository) FindByName(ctx context.Context, householdID, name string) (
 		&account.Last4,
 		&account.InitialBalance,
 		&account.Notes,
+		&account.IsSharedWithHousehold,
 		&account.CreatedAt,
 		&account.UpdatedAt,
 	)
@@ -24,14 +24,15 @@ func NewService(repo Repository, auditService audit.Service) *Service {
 
 // CreateInput contains the data needed to create an account
 type CreateInput struct {
-	HouseholdID    string
-	OwnerID        string // ID of the member who owns this account
-	Name           string
-	Type           AccountType
-	Institution    *string
-	Last4          *string
-	InitialBalance *float64 // Optional, defaults to 0
-	Notes          *string
+	HouseholdID           string
+	OwnerID               string // ID of the member who owns this account
+	Name                  string
+	Type                  AccountType
+	Institution           *string
+	Last4                 *string
+	InitialBalance        *float64 // Optional, defaults to 0
+	Notes                 *string
+	IsSharedWithHousehold *bool // Optional, defaults to true
 }
 
 // Validate validates the input
@@ -88,15 +89,21 @@ func (s *Service) Create(ctx context.Context, input CreateInput) (*Account, erro
 		initialBalance = *input.InitialBalance
 	}
 
+	isShared := true
+	if input.IsSharedWithHousehold != nil {
+		isShared = *input.IsSharedWithHousehold
+	}
+
 	account := &Account{
-		HouseholdID:    input.HouseholdID,
-		OwnerID:        input.OwnerID,
-		Name:           input.Name,
-		Type:           input.Type,
-		Institution:    input.Institution,
-		Last4:          input.Last4,
-		InitialBalance: initialBalance,
-		Notes:          input.Notes,
+		HouseholdID:           input.HouseholdID,
+		OwnerID:               input.OwnerID,
+		Name:                  input.Name,
+		Type:                  input.Type,
+		Institution:           input.Institution,
+		Last4:                 input.Last4,
+		InitialBalance:        initialBalance,
+		Notes:                 input.Notes,
+		IsSharedWithHousehold: isShared,
 	}
 
 	created, err := s.repo.Create(ctx, account)
@@ -125,12 +132,14 @@ func (s *Service) Create(ctx context.Context, input CreateInput) (*Account, erro
 
 // UpdateInput contains the data needed to update an account
 type UpdateInput struct {
-	ID             string
-	Name           *string
-	Institution    *string
-	Last4          *string
-	InitialBalance *float64
-	Notes          *string
+	ID                    string
+	UserID                string // Member making the change
+	Name                  *string
+	Institution           *string
+	Last4                 *string
+	InitialBalance        *float64
+	Notes                 *string
+	IsSharedWithHousehold *bool // Only the owner may change it
 }
 
 // Validate validates the update input
@@ -178,6 +187,9 @@ func (s *Service) Update(ctx context.Context, householdID string, input UpdateIn
 	if existing.HouseholdID != householdID {
 		return nil, ErrNotAuthorized
 	}
+	if !existing.VisibleTo(input.UserID) {
+		return nil, ErrAccountNotFound
+	}
 
 	// Store old values for audit
 	oldValues := audit.StructToMap(existing)
@@ -210,6 +222,12 @@ func (s *Service) Update(ctx context.Context, householdID string, input UpdateIn
 	if input.InitialBalance != nil {
 		existing.InitialBalance = *input.InitialBalance
 	}
+	if input.IsSharedWithHousehold != nil && *input.IsSharedWithHousehold != existing.IsSharedWithHousehold {
+		if existing.OwnerID != input.UserID {
+			return nil, ErrNotAuthorized
+		}
+		existing.IsSharedWithHousehold = *input.IsSharedWithHousehold
+	}
 	if input.Notes != nil {
 		if *input.Notes == "" {
 			existing.Notes = nil
@@ -244,8 +262,9 @@ func (s *Service) Update(ctx context.Context, householdID string, input UpdateIn
 	return updated, nil
 }
 
-// GetByID retrieves an account by ID
-func (s *Service) GetByID(ctx context.Context, id, householdID string) (*Account, error) {
+// GetByID retrieves an account by ID. Another member's private account
+// reads as not found.
+func (s *Service) GetByID(ctx context.Context, id, householdID, userID string) (*Account, error) {
 	account, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -255,17 +274,61 @@ func (s *Service) GetByID(ctx context.Context, id, householdID string) (*Account
 	if account.HouseholdID != householdID {
 		return nil, ErrNotAuthorized
 	}
+	if !account.VisibleTo(userID) {
+		return nil, ErrAccountNotFound
+	}
 
 	return account, nil
 }
 
-// ListByHousehold retrieves all accounts for a household
-func (s *Service) ListByHousehold(ctx context.Context, householdID string) ([]*Account, error) {
-	return s.repo.ListByHousehold(ctx, householdID)
+// ListByHousehold retrieves the household accounts visible to userID
+func (s *Service) ListByHousehold(ctx context.Context, householdID, userID string) ([]*Account, error) {
+	accounts, err := s.repo.ListByHousehold(ctx, householdID)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make([]*Account, 0, len(accounts))
+	for _, account := range accounts {
+		if account.VisibleTo(userID) {
+			visible = append(visible, account)
+		}
+	}
+	return visible, nil
+}
+
+// GetSummary totals every account balance in the household. Accounts other
+// members keep private count towards the totals but are not listed.
+func (s *Service) GetSummary(ctx context.Context, householdID, userID string) (*AccountSummary, error) {
+	accounts, err := s.repo.ListByHousehold(ctx, householdID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &AccountSummary{
+		AccountCount: len(accounts),
+		Accounts:     make([]*Account, 0, len(accounts)),
+	}
+	for _, account := range accounts {
+		var balance float64
+		if account.CurrentBalance != nil {
+			balance = *account.CurrentBalance
+		}
+		summary.TotalBalance += balance
+
+		if account.VisibleTo(userID) {
+			summary.Accounts = append(summary.Accounts, account)
+			continue
+		}
+		summary.PrivateCount++
+		summary.PrivateBalance += balance
+	}
+
+	return summary, nil
 }
 
 // Delete deletes an account
-func (s *Service) Delete(ctx context.Context, id, householdID string) error {
+func (s *Service) Delete(ctx context.Context, id, householdID, userID string) error {
 	// Get existing account
 	existing, err := s.repo.GetByID(ctx, id)
 	if err != nil {
@@ -276,6 +339,9 @@ func (s *Service) Delete(ctx context.Context, id, householdID string) error {
 	if existing.HouseholdID != householdID {
 		return ErrNotAuthorized
 	}
+	if !existing.VisibleTo(userID) {
+		return ErrAccountNotFound
+	}
 
 	err = s.repo.Delete(ctx, id)
 	if err != nil {
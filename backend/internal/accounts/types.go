@@ -52,6 +52,9 @@ type Account struct {
 	Last4          *string      `json:"last4,omitempty"`
 	InitialBalance float64      `json:"initial_balance"`
 	Notes          *string      `json:"notes,omitempty"`
+	// Private accounts are listed only to their owner; other members see them
+	// in the aggregates of GET /accounts/summary.
+	IsSharedWithHousehold bool `json:"is_shared_with_household"`
 	CreatedAt      time.Time    `json:"created_at"`
 	UpdatedAt      time.Time    `json:"updated_at"`
 	
@@ -61,6 +64,22 @@ type Account struct {
 	ExpenseTotal   *float64     `json:"expense_total,omitempty"`
 }
 
+// VisibleTo reports whether userID may see the account itself, not just its
+// share of the household aggregates.
+func (a *Account) VisibleTo(userID string) bool {
+	return a.IsSharedWithHousehold || a.OwnerID == userID
+}
+
+// AccountSummary aggregates the balances of every account in the household,
+// including the ones the requesting user cannot see individually.
+type AccountSummary struct {
+	TotalBalance   float64    `json:"total_balance"`
+	AccountCount   int        `json:"account_count"`
+	PrivateCount   int        `json:"private_count"`   // Other members' private accounts
+	PrivateBalance float64    `json:"private_balance"` // Their combined current balance
+	Accounts       []*Account `json:"accounts"`        // Only the accounts visible to the user
+}
+
 // Validate validates account fields
 func (a *Account) Validate() error {
 	if a.Name == "" {
@@ -60,14 +60,8 @@ func (h *Handler) HandleChat(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Authenticate via session cookie (same pattern as other handlers)
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
-		return
-	}
-
-	user, err := h.authService.GetUserBySession(r.Context(), cookie.Value)
+	// Authenticate via session cookie or access token (same pattern as other handlers)
+	user, err := h.authService.UserFromRequest(r, h.cookieName)
 	if err != nil {
 		http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
 		return
@@ -75,12 +69,11 @@ func (h *Handler) HandleChat(w http.ResponseWriter, r *http.Request) {
 	userID := user.ID
 
 	// Resolve household
-	hh, err := h.householdRepo.ListByUser(r.Context(), userID)
-	if err != nil || len(hh) == 0 {
+	householdID, err := h.householdRepo.ResolveHouseholdID(r.Context(), userID)
+	if err != nil {
 		http.Error(w, `{"error":"no household found"}`, http.StatusNotFound)
 		return
 	}
-	householdID := hh[0].ID
 
 	// Fetch household members for identity context
 	members, _ := h.householdRepo.GetMembers(r.Context(), householdID)
@@ -137,13 +130,7 @@ func (h *Handler) HandleChat(w http.ResponseWriter, r *http.Request) {
 
 // HandleCreateMovement processes POST /chat/create-movement requests.
 func (h *Handler) HandleCreateMovement(w http.ResponseWriter, r *http.Request) {
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
-		return
-	}
-
-	user, err := h.authService.GetUserBySession(r.Context(), cookie.Value)
+	user, err := h.authService.UserFromRequest(r, h.cookieName)
 	if err != nil {
 		http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
 		return
@@ -148,7 +148,7 @@ func ToolDefinitions() []Tool {
 		},
 		{
 			Name:        "get_spending_by_member",
-			Description: "Get spending breakdown by household member (who paid) for a given month.",
+			Description: "Get spending breakdown by household member (who paid) for a given month, with each member's personal allowance status (budget, spent, remaining) when they have one.",
 			Parameters: map[string]any{
 				"type": "object",
 				"properties": map[string]any{
@@ -242,65 +242,60 @@ func (te *ToolExecutor) ExecuteTool(ctx context.Context, householdID, userID, na
 
 // --- Tool Implementations (all use existing services) ---
 
+// listSpending returns the HOUSEHOLD and SPLIT movements the user may see and
+// the other members' private spending, which is left out of the movements.
+// Totals must add both; evidence only ever comes from the movements.
+func (te *ToolExecutor) listSpending(ctx context.Context, userID string, filters movements.ListMovementsFilters) ([]*movements.Movement, []*movements.PrivateSpending, error) {
+	typeHousehold := movements.TypeHousehold
+	filters.Type = &typeHousehold
+	resp, err := te.movementsService.ListByHousehold(ctx, userID, &filters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Also get SPLIT movements
+	typeSplit := movements.TypeSplit
+	filters.Type = &typeSplit
+	splitResp, err := te.movementsService.ListByHousehold(ctx, userID, &filters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return append(resp.Movements, splitResp.Movements...),
+		append(resp.PrivateSpending, splitResp.PrivateSpending...), nil
+}
+
+// matchesCategory reports whether a category or its group matches the filter
+func matchesCategory(categoryName, groupName *string, filter string) bool {
+	if filter == "" {
+		return true
+	}
+	return (categoryName != nil && containsInsensitive(*categoryName, filter)) ||
+		(groupName != nil && containsInsensitive(*groupName, filter))
+}
+
 func (te *ToolExecutor) getMovementsSummary(ctx context.Context, userID string, args map[string]any) (any, error) {
 	month := getString(args, "month")
 	categoryFilter := getString(args, "category")
 	startDateStr := getString(args, "start_date")
 	endDateStr := getString(args, "end_date")
 
-	// Parse optional date filters
-	var startDate, endDate time.Time
-	var hasDateFilter bool
-	if startDateStr != "" {
-		if t, err := time.ParseInLocation("2006-01-02", startDateStr, Bogota); err == nil {
-			startDate = t
-			hasDateFilter = true
-		}
-	}
-	if endDateStr != "" {
-		if t, err := time.ParseInLocation("2006-01-02", endDateStr, Bogota); err == nil {
-			endDate = t.Add(24*time.Hour - time.Nanosecond) // end of day
-			hasDateFilter = true
-		}
+	// Optional date filters run in the query so private spending is filtered
+	// too. Movement dates carry no time zone; the bounds cover whole days.
+	filters := movements.ListMovementsFilters{Month: &month}
+	if t, err := time.Parse("2006-01-02", startDateStr); err == nil {
+		filters.StartDate = &t
 	}
-
-	typeHousehold := movements.TypeHousehold
-	resp, err := te.movementsService.ListByHousehold(ctx, userID, &movements.ListMovementsFilters{
-		Type:  &typeHousehold,
-		Month: &month,
-	})
-	if err != nil {
-		return nil, err
+	if t, err := time.Parse("2006-01-02", endDateStr); err == nil {
+		end := t.Add(24*time.Hour - time.Nanosecond) // end of day
+		filters.EndDate = &end
 	}
 
-	// Also get SPLIT movements
-	typeSplit := movements.TypeSplit
-	splitResp, err := te.movementsService.ListByHousehold(ctx, userID, &movements.ListMovementsFilters{
-		Type:  &typeSplit,
-		Month: &month,
-	})
+	allMovements, private, err := te.listSpending(ctx, userID, filters)
 	if err != nil {
 		return nil, err
 	}
 
-	allMovements := append(resp.Movements, splitResp.Movements...)
-
-	// Apply date range filter
-	if hasDateFilter {
-		var dateFiltered []*movements.Movement
-		for _, m := range allMovements {
-			mDate := m.MovementDate.In(Bogota)
-			if !startDate.IsZero() && mDate.Before(startDate) {
-				continue
-			}
-			if !endDate.IsZero() && mDate.After(endDate) {
-				continue
-			}
-			dateFiltered = append(dateFiltered, m)
-		}
-		allMovements = dateFiltered
-	}
-
 	// Group by category (with group name), optionally filter
 	type catSummary struct {
 		Group string  `json:"group"`
@@ -309,30 +304,39 @@ func (te *ToolExecutor) getMovementsSummary(ctx context.Context, userID string,
 		Count int     `json:"count"`
 	}
 	catMap := make(map[string]*catSummary)
-
-	for _, m := range allMovements {
-		groupName := ""
-		catName := "Sin categoría"
-		if m.CategoryGroupName != nil {
-			groupName = *m.CategoryGroupName
+	addToCategory := func(categoryName, groupName *string, amount float64, count int) {
+		group := ""
+		name := "Sin categoría"
+		if groupName != nil {
+			group = *groupName
 		}
-		if m.CategoryName != nil {
-			catName = *m.CategoryName
+		if categoryName != nil {
+			name = *categoryName
 		}
-
-		// Apply category/group filter
-		if categoryFilter != "" {
-			if !containsInsensitive(catName, categoryFilter) && !containsInsensitive(groupName, categoryFilter) {
-				continue
-			}
+		key := group + ">" + name
+		if _, ok := catMap[key]; !ok {
+			catMap[key] = &catSummary{Group: group, Name: name}
 		}
+		catMap[key].Total += amount
+		catMap[key].Count += count
+	}
 
-		key := groupName + ">" + catName
-		if _, ok := catMap[key]; !ok {
-			catMap[key] = &catSummary{Group: groupName, Name: catName}
+	// Evidence only lists the movements the user may see
+	var filtered []*movements.Movement
+	for _, m := range allMovements {
+		if !matchesCategory(m.CategoryName, m.CategoryGroupName, categoryFilter) {
+			continue
 		}
-		catMap[key].Total += m.Amount
-		catMap[key].Count++
+		addToCategory(m.CategoryName, m.CategoryGroupName, m.Amount, 1)
+		filtered = append(filtered, m)
+	}
+	var privateTotal float64
+	for _, p := range private {
+		if !matchesCategory(p.CategoryName, p.CategoryGroupName, categoryFilter) {
+			continue
+		}
+		addToCategory(p.CategoryName, p.CategoryGroupName, p.Amount, p.Count)
+		privateTotal += p.Amount
 	}
 
 	var categories []catSummary
@@ -346,23 +350,6 @@ func (te *ToolExecutor) getMovementsSummary(ctx context.Context, userID string,
 	sort.Slice(categories, func(i, j int) bool { return categories[i].Total > categories[j].Total })
 
 	// Top evidence (largest movements matching filter)
-	var filtered []*movements.Movement
-	for _, m := range allMovements {
-		if categoryFilter != "" {
-			groupName := ""
-			catName := ""
-			if m.CategoryGroupName != nil {
-				groupName = *m.CategoryGroupName
-			}
-			if m.CategoryName != nil {
-				catName = *m.CategoryName
-			}
-			if !containsInsensitive(catName, categoryFilter) && !containsInsensitive(groupName, categoryFilter) {
-				continue
-			}
-		}
-		filtered = append(filtered, m)
-	}
 	sort.Slice(filtered, func(i, j int) bool { return filtered[i].Amount > filtered[j].Amount })
 	if len(filtered) > 5 {
 		filtered = filtered[:5]
@@ -374,11 +361,12 @@ func (te *ToolExecutor) getMovementsSummary(ctx context.Context, userID string,
 	}
 
 	return map[string]any{
-		"total":        grandTotal,
-		"count":        grandCount,
-		"month":        month,
-		"by_category":  categories,
-		"top_evidence": evidence,
+		"total":         grandTotal,
+		"count":         grandCount,
+		"private_total": privateTotal,
+		"month":         month,
+		"by_category":   categories,
+		"top_evidence":  evidence,
 	}, nil
 }
 
@@ -438,7 +426,7 @@ func (te *ToolExecutor) getBudgetStatus(ctx context.Context, userID string, args
 
 	var rows []budgetRow
 	for _, b := range resp.Budgets {
-		if b.Amount == 0 && b.Spent == 0 {
+		if b.EffectiveAmount == 0 && b.Spent == 0 {
 			continue
 		}
 		group := ""
@@ -448,9 +436,9 @@ func (te *ToolExecutor) getBudgetStatus(ctx context.Context, userID string, args
 		rows = append(rows, budgetRow{
 			Group:    group,
 			Category: b.CategoryName,
-			Budget:   b.Amount,
+			Budget:   b.EffectiveAmount,
 			Spent:    b.Spent,
-			Diff:     b.Amount - b.Spent,
+			Diff:     b.EffectiveAmount - b.Spent,
 			Status:   b.Status,
 		})
 	}
@@ -474,25 +462,19 @@ func (te *ToolExecutor) getTopExpenses(ctx context.Context, userID string, args
 		limit = 10
 	}
 
-	typeHousehold := movements.TypeHousehold
-	resp, err := te.movementsService.ListByHousehold(ctx, userID, &movements.ListMovementsFilters{
-		Type:  &typeHousehold,
-		Month: &month,
-	})
+	all, private, err := te.listSpending(ctx, userID, movements.ListMovementsFilters{Month: &month})
 	if err != nil {
 		return nil, err
 	}
 
-	typeSplit := movements.TypeSplit
-	splitResp, err := te.movementsService.ListByHousehold(ctx, userID, &movements.ListMovementsFilters{
-		Type:  &typeSplit,
-		Month: &month,
-	})
-	if err != nil {
-		return nil, err
+	// Other members' private movements cannot be listed, only counted
+	var privateTotal float64
+	var privateCount int
+	for _, p := range private {
+		privateTotal += p.Amount
+		privateCount += p.Count
 	}
 
-	all := append(resp.Movements, splitResp.Movements...)
 	sort.Slice(all, func(i, j int) bool { return all[i].Amount > all[j].Amount })
 	if len(all) > limit {
 		all = all[:limit]
@@ -504,9 +486,11 @@ func (te *ToolExecutor) getTopExpenses(ctx context.Context, userID string, args
 	}
 
 	return map[string]any{
-		"month":    month,
-		"count":    len(evidence),
-		"expenses": evidence,
+		"month":         month,
+		"count":         len(evidence),
+		"expenses":      evidence,
+		"private_count": privateCount,
+		"private_total": privateTotal,
 	}, nil
 }
 
@@ -516,42 +500,24 @@ func (te *ToolExecutor) compareMonths(ctx context.Context, userID string, args m
 	categoryFilter := getString(args, "category")
 
 	queryMonth := func(month string) (float64, int, error) {
-		typeHousehold := movements.TypeHousehold
-		resp, err := te.movementsService.ListByHousehold(ctx, userID, &movements.ListMovementsFilters{
-			Type:  &typeHousehold,
-			Month: &month,
-		})
-		if err != nil {
-			return 0, 0, err
-		}
-		typeSplit := movements.TypeSplit
-		splitResp, err := te.movementsService.ListByHousehold(ctx, userID, &movements.ListMovementsFilters{
-			Type:  &typeSplit,
-			Month: &month,
-		})
+		all, private, err := te.listSpending(ctx, userID, movements.ListMovementsFilters{Month: &month})
 		if err != nil {
 			return 0, 0, err
 		}
 
-		all := append(resp.Movements, splitResp.Movements...)
 		var total float64
 		var count int
 		for _, m := range all {
-			if categoryFilter != "" {
-				groupName := ""
-				catName := ""
-				if m.CategoryGroupName != nil {
-					groupName = *m.CategoryGroupName
-				}
-				if m.CategoryName != nil {
-					catName = *m.CategoryName
-				}
-				if !containsInsensitive(catName, categoryFilter) && !containsInsensitive(groupName, categoryFilter) {
-					continue
-				}
+			if matchesCategory(m.CategoryName, m.CategoryGroupName, categoryFilter) {
+				total += m.Amount
+				count++
+			}
+		}
+		for _, p := range private {
+			if matchesCategory(p.CategoryName, p.CategoryGroupName, categoryFilter) {
+				total += p.Amount
+				count += p.Count
 			}
-			total += m.Amount
-			count++
 		}
 		return total, count, nil
 	}
@@ -629,20 +595,7 @@ func (te *ToolExecutor) getDebtSummary(ctx context.Context, userID string, args
 func (te *ToolExecutor) getSpendingByPaymentMethod(ctx context.Context, userID string, args map[string]any) (any, error) {
 	month := getString(args, "month")
 
-	typeHousehold := movements.TypeHousehold
-	resp, err := te.movementsService.ListByHousehold(ctx, userID, &movements.ListMovementsFilters{
-		Type:  &typeHousehold,
-		Month: &month,
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	typeSplit := movements.TypeSplit
-	splitResp, err := te.movementsService.ListByHousehold(ctx, userID, &movements.ListMovementsFilters{
-		Type:  &typeSplit,
-		Month: &month,
-	})
+	all, private, err := te.listSpending(ctx, userID, movements.ListMovementsFilters{Month: &month})
 	if err != nil {
 		return nil, err
 	}
@@ -654,16 +607,22 @@ func (te *ToolExecutor) getSpendingByPaymentMethod(ctx context.Context, userID s
 	}
 
 	pmMap := make(map[string]*pmSummary)
-	for _, m := range append(resp.Movements, splitResp.Movements...) {
+	addToMethod := func(methodName *string, amount float64, count int) {
 		name := "Sin método"
-		if m.PaymentMethodName != nil {
-			name = *m.PaymentMethodName
+		if methodName != nil {
+			name = *methodName
 		}
 		if _, ok := pmMap[name]; !ok {
 			pmMap[name] = &pmSummary{Name: name}
 		}
-		pmMap[name].Total += m.Amount
-		pmMap[name].Count++
+		pmMap[name].Total += amount
+		pmMap[name].Count += count
+	}
+	for _, m := range all {
+		addToMethod(m.PaymentMethodName, m.Amount, 1)
+	}
+	for _, p := range private {
+		addToMethod(p.PaymentMethodName, p.Amount, p.Count)
 	}
 
 	var methods []pmSummary
@@ -684,41 +643,60 @@ func (te *ToolExecutor) getSpendingByPaymentMethod(ctx context.Context, userID s
 func (te *ToolExecutor) getSpendingByMember(ctx context.Context, userID string, args map[string]any) (any, error) {
 	month := getString(args, "month")
 
-	typeHousehold := movements.TypeHousehold
-	resp, err := te.movementsService.ListByHousehold(ctx, userID, &movements.ListMovementsFilters{
-		Type:  &typeHousehold,
-		Month: &month,
-	})
+	all, private, err := te.listSpending(ctx, userID, movements.ListMovementsFilters{Month: &month})
 	if err != nil {
 		return nil, err
 	}
 
-	typeSplit := movements.TypeSplit
-	splitResp, err := te.movementsService.ListByHousehold(ctx, userID, &movements.ListMovementsFilters{
-		Type:  &typeSplit,
-		Month: &month,
-	})
-	if err != nil {
-		return nil, err
+	// Allowance totals only; the movements behind another member's allowance stay private
+	type allowanceSummary struct {
+		Budget     float64 `json:"budget"`
+		Spent      float64 `json:"spent"`
+		Remaining  float64 `json:"remaining"`
+		Percentage float64 `json:"percentage"`
+		Status     string  `json:"status"`
 	}
 
 	type memberSummary struct {
-		Name  string  `json:"member"`
-		Total float64 `json:"total"`
-		Count int     `json:"count"`
+		Name      string            `json:"member"`
+		Total     float64           `json:"total"`
+		Count     int               `json:"count"`
+		Allowance *allowanceSummary `json:"allowance,omitempty"`
 	}
 
 	memMap := make(map[string]*memberSummary)
-	for _, m := range append(resp.Movements, splitResp.Movements...) {
-		name := m.PayerName
+	addToMember := func(name string, amount float64, count int) {
 		if name == "" {
 			name = "Desconocido"
 		}
 		if _, ok := memMap[name]; !ok {
 			memMap[name] = &memberSummary{Name: name}
 		}
-		memMap[name].Total += m.Amount
-		memMap[name].Count++
+		memMap[name].Total += amount
+		memMap[name].Count += count
+	}
+	for _, m := range all {
+		addToMember(m.PayerName, m.Amount, 1)
+	}
+	for _, p := range private {
+		addToMember(p.PayerName, p.Amount, p.Count)
+	}
+
+	allowances, err := te.budgetService.ListAllowances(ctx, userID, month)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range allowances {
+		if _, ok := memMap[a.UserName]; !ok {
+			memMap[a.UserName] = &memberSummary{Name: a.UserName}
+		}
+		memMap[a.UserName].Allowance = &allowanceSummary{
+			Budget:     a.Amount,
+			Spent:      a.Spent,
+			Remaining:  a.Remaining,
+			Percentage: a.Percentage,
+			Status:     a.Status,
+		}
 	}
 
 	var members []memberSummary
@@ -45,6 +45,11 @@ func getUserAgentFromContext(ctx context.Context) *string {
 	return nil
 }
 
+// RequestMetadataFromContext returns the client IP and User Agent stored by WithRequestMetadata
+func RequestMetadataFromContext(ctx context.Context) (ipAddress, userAgent *string) {
+	return getIPAddressFromContext(ctx), getUserAgentFromContext(ctx)
+}
+
 // StructToMap converts any struct to map[string]interface{} for audit logging
 // This uses JSON marshaling/unmarshaling for simplicity and consistency
 func StructToMap(v interface{}) map[string]interface{} {
@@ -17,6 +17,19 @@ ActionAuthLogout               Action = "AUTH_LOGOUT"
 ActionAuthPasswordResetRequest Action = "AUTH_PASSWORD_RESET_REQUEST"
 ActionAuthPasswordResetComplete Action = "AUTH_PASSWORD_RESET_COMPLETE"
 ActionAuthSessionExpired       Action = "AUTH_SESSION_EXPIRED"
+ActionAuthSessionRevoked       Action = "AUTH_SESSION_REVOKED"
+ActionAuthNewDeviceLogin       Action = "AUTH_NEW_DEVICE_LOGIN"
+ActionAuthEmailVerificationRequest Action = "AUTH_EMAIL_VERIFICATION_REQUEST"
+ActionAuthEmailVerified        Action = "AUTH_EMAIL_VERIFIED"
+ActionAuthMagicLinkRequest     Action = "AUTH_MAGIC_LINK_REQUEST"
+ActionAuthAccountLocked        Action = "AUTH_ACCOUNT_LOCKED"
+ActionAuthAccountUnlocked      Action = "AUTH_ACCOUNT_UNLOCKED"
+ActionAccessTokenCreated       Action = "ACCESS_TOKEN_CREATED"
+ActionAccessTokenRevoked       Action = "ACCESS_TOKEN_REVOKED"
+ActionAuthTwoFactorEnabled     Action = "AUTH_2FA_ENABLED"
+ActionAuthTwoFactorDisabled    Action = "AUTH_2FA_DISABLED"
+ActionAuthRecoveryCodesRegenerated Action = "AUTH_2FA_RECOVERY_CODES_REGENERATED"
+ActionAuthRecoveryCodeUsed     Action = "AUTH_2FA_RECOVERY_CODE_USED"
 
 // User management
 ActionUserCreated Action = "USER_CREATED"
@@ -32,6 +45,10 @@ ActionHouseholdMemberRemoved     Action = "HOUSEHOLD_MEMBER_REMOVED"
 ActionHouseholdInvitationSent    Action = "HOUSEHOLD_INVITATION_SENT"
 ActionHouseholdInvitationAccepted Action = "HOUSEHOLD_INVITATION_ACCEPTED"
 ActionHouseholdInvitationDeclined Action = "HOUSEHOLD_INVITATION_DECLINED"
+ActionHouseholdInvitationResent  Action = "HOUSEHOLD_INVITATION_RESENT"
+ActionHouseholdInvitationRevoked Action = "HOUSEHOLD_INVITATION_REVOKED"
+ActionHouseholdMerged            Action = "HOUSEHOLD_MERGED"
+ActionHouseholdOwnershipTransferred Action = "HOUSEHOLD_OWNERSHIP_TRANSFERRED"
 
 // Contacts
 ActionContactCreated     Action = "CONTACT_CREATED"
@@ -39,6 +56,7 @@ ActionContactUpdated     Action = "CONTACT_UPDATED"
 ActionContactDeleted     Action = "CONTACT_DELETED"
 ActionContactActivated   Action = "CONTACT_ACTIVATED"
 ActionContactDeactivated Action = "CONTACT_DEACTIVATED"
+ActionContactMerged      Action = "CONTACT_MERGED"
 
 // Accounts
 ActionAccountCreated Action = "ACCOUNT_CREATED"
@@ -59,6 +77,10 @@ ActionIncomeDeleted Action = "INCOME_DELETED"
 ActionMovementCreated Action = "MOVEMENT_CREATED"
 ActionMovementUpdated Action = "MOVEMENT_UPDATED"
 ActionMovementDeleted Action = "MOVEMENT_DELETED"
+ActionMovementCommentAdded    Action = "MOVEMENT_COMMENT_ADDED"
+ActionMovementCommentDeleted  Action = "MOVEMENT_COMMENT_DELETED"
+ActionMovementDisputed        Action = "MOVEMENT_DISPUTED"
+ActionMovementDisputeResolved Action = "MOVEMENT_DISPUTE_RESOLVED"
 
 // Categories
 ActionCategoryCreated      Action = "CATEGORY_CREATED"
@@ -84,6 +106,12 @@ ActionCreditCardPaymentDeleted Action = "CREDIT_CARD_PAYMENT_DELETED"
 	ActionPocketTransactionCreated Action = "POCKET_TRANSACTION_CREATED"
 	ActionPocketTransactionUpdated Action = "POCKET_TRANSACTION_UPDATED"
 	ActionPocketTransactionDeleted Action = "POCKET_TRANSACTION_DELETED"
+
+	// Webhooks
+	ActionWebhookCreated  Action = "WEBHOOK_CREATED"
+	ActionWebhookUpdated  Action = "WEBHOOK_UPDATED"
+	ActionWebhookDeleted  Action = "WEBHOOK_DELETED"
+	ActionWebhookDisabled Action = "WEBHOOK_DISABLED"
 )
 
 // AuditLog represents a single audit log entry
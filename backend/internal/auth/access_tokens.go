@@ -0,0 +1,280 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/blanquicet/conti/backend/internal/audit"
+)
+
+// AccessTokenPrefix marks personal access tokens so they are easy to spot in scripts and logs.
+const AccessTokenPrefix = "conti_pat_"
+
+// maxAccessTokensPerUser limits how many active tokens a user can hold.
+const maxAccessTokensPerUser = 20
+
+// AccessTokenResourceTypes lists the resource types a token can be restricted to.
+// They match the first path segment of the API routes (ignoring the "/api" prefix).
+var AccessTokenResourceTypes = []string{
+	"accounts",
+	"budget-items",
+	"budgets",
+	"categories",
+	"category-groups",
+	"chat",
+	"contacts",
+	"credit-card-payments",
+	"credit-cards",
+	"events",
+	"households",
+	"income",
+	"invitations",
+	"link-requests",
+	"movement-form-config",
+	"movements",
+	"notifications",
+	"payment-methods",
+	"pocket-transactions",
+	"pockets",
+	"recurring-movements",
+	"reports",
+	"stt",
+	"webhooks",
+}
+
+// CreateAccessTokenInput contains the data needed to create a personal access token.
+type CreateAccessTokenInput struct {
+	Name          string           `json:"name"`
+	Scope         AccessTokenScope `json:"scope"`
+	ResourceTypes []string         `json:"resource_types,omitempty"`
+	ExpiresInDays *int             `json:"expires_in_days,omitempty"` // Nil means the token never expires
+}
+
+// Validate validates the access token input.
+func (i *CreateAccessTokenInput) Validate() error {
+	i.Name = strings.TrimSpace(i.Name)
+	if i.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(i.Name) > 100 {
+		return errors.New("name must be 100 characters or less")
+	}
+	if i.Scope == "" {
+		i.Scope = ScopeRead
+	}
+	if i.Scope != ScopeRead && i.Scope != ScopeReadWrite {
+		return errors.New("scope must be read or read_write")
+	}
+	for _, rt := range i.ResourceTypes {
+		if !isAccessTokenResourceType(rt) {
+			return errors.New("invalid resource type: " + rt)
+		}
+	}
+	if i.ExpiresInDays != nil && (*i.ExpiresInDays < 1 || *i.ExpiresInDays > 365) {
+		return errors.New("expires_in_days must be between 1 and 365")
+	}
+	return nil
+}
+
+// CreateAccessTokenResponse is returned once, when a token is created.
+type CreateAccessTokenResponse struct {
+	*AccessToken
+	Token string `json:"token"` // Plain token; it cannot be retrieved again
+}
+
+func isAccessTokenResourceType(rt string) bool {
+	for _, t := range AccessTokenResourceTypes {
+		if t == rt {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateAccessToken creates a personal access token for the user.
+func (s *Service) CreateAccessToken(ctx context.Context, userID string, input *CreateAccessTokenInput) (*CreateAccessTokenResponse, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.accessTokens.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	active := 0
+	for _, t := range existing {
+		if t.RevokedAt == nil && (t.ExpiresAt == nil || t.ExpiresAt.After(time.Now())) {
+			active++
+		}
+	}
+	if active >= maxAccessTokensPerUser {
+		return nil, ErrTooManyAccessTokens
+	}
+
+	secret, err := GenerateToken(32)
+	if err != nil {
+		return nil, err
+	}
+	plain := AccessTokenPrefix + secret
+
+	var expiresAt *time.Time
+	if input.ExpiresInDays != nil {
+		t := time.Now().AddDate(0, 0, *input.ExpiresInDays)
+		expiresAt = &t
+	}
+
+	token, err := s.accessTokens.Create(ctx, &AccessToken{
+		UserID:        userID,
+		Name:          input.Name,
+		TokenHash:     HashToken(plain),
+		TokenPrefix:   plain[:len(AccessTokenPrefix)+6],
+		Scope:         input.Scope,
+		ResourceTypes: input.ResourceTypes,
+		ExpiresAt:     expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		UserID:       audit.StringPtr(userID),
+		Action:       audit.ActionAccessTokenCreated,
+		ResourceType: "access_token",
+		ResourceID:   audit.StringPtr(token.ID),
+		Success:      true,
+		NewValues: map[string]interface{}{
+			"name":           token.Name,
+			"scope":          token.Scope,
+			"resource_types": token.ResourceTypes,
+			"expires_at":     token.ExpiresAt,
+		},
+	})
+
+	return &CreateAccessTokenResponse{AccessToken: token, Token: plain}, nil
+}
+
+// ListAccessTokens returns the user's personal access tokens, including revoked ones.
+func (s *Service) ListAccessTokens(ctx context.Context, userID string) ([]*AccessToken, error) {
+	return s.accessTokens.ListByUserID(ctx, userID)
+}
+
+// RevokeAccessToken revokes one of the user's personal access tokens.
+func (s *Service) RevokeAccessToken(ctx context.Context, userID, tokenID string) error {
+	if err := s.accessTokens.Revoke(ctx, tokenID, userID); err != nil {
+		return err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		UserID:       audit.StringPtr(userID),
+		Action:       audit.ActionAccessTokenRevoked,
+		ResourceType: "access_token",
+		ResourceID:   audit.StringPtr(tokenID),
+		Success:      true,
+	})
+
+	return nil
+}
+
+// AuthenticateAccessToken validates a personal access token for a request with the
+// given method and path, and records that it was used.
+func (s *Service) AuthenticateAccessToken(ctx context.Context, plain, method, path string) (*AccessToken, error) {
+	if !strings.HasPrefix(plain, AccessTokenPrefix) {
+		return nil, ErrInvalidCredentials
+	}
+
+	token, err := s.accessTokens.GetByTokenHash(ctx, HashToken(plain))
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		return nil, ErrInvalidCredentials
+	}
+	if token.RevokedAt != nil {
+		return nil, ErrTokenRevoked
+	}
+	if token.ExpiresAt != nil && token.ExpiresAt.Before(time.Now()) {
+		return nil, ErrTokenExpired
+	}
+	if !token.Allows(method, path) {
+		return nil, ErrTokenScope
+	}
+
+	// Best effort: failing to record usage must not block the request
+	_ = s.accessTokens.TouchLastUsed(ctx, token.ID)
+
+	return token, nil
+}
+
+// UserFromRequest returns the authenticated user for a request. A personal access
+// token in the Authorization header takes precedence over the session cookie.
+func (s *Service) UserFromRequest(r *http.Request, cookieName string) (*User, error) {
+	if plain, ok := BearerToken(r); ok {
+		token, err := s.AuthenticateAccessToken(r.Context(), plain, r.Method, r.URL.Path)
+		if err != nil {
+			return nil, err
+		}
+		return s.users.GetByID(r.Context(), token.UserID)
+	}
+
+	cookie, err := r.Cookie(cookieName)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetUserBySession(r.Context(), cookie.Value)
+}
+
+// IsUnauthenticated reports whether an error from UserFromRequest means the request
+// carries no valid credentials, as opposed to an internal failure.
+func IsUnauthenticated(err error) bool {
+	return errors.Is(err, http.ErrNoCookie) ||
+		errors.Is(err, ErrSessionExpired) ||
+		errors.Is(err, ErrUserNotFound) ||
+		errors.Is(err, ErrInvalidCredentials) ||
+		errors.Is(err, ErrTokenExpired) ||
+		errors.Is(err, ErrTokenRevoked) ||
+		errors.Is(err, ErrTokenScope)
+}
+
+// BearerToken extracts the token from an "Authorization: Bearer <token>" header.
+func BearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// Allows reports whether the token's scope and resource types permit a request.
+// Tokens can never manage tokens, 2FA, sessions, accounts or admin endpoints.
+func (t *AccessToken) Allows(method, path string) bool {
+	trimmed := strings.TrimPrefix(path, "/")
+	trimmed = strings.TrimPrefix(trimmed, "api/")
+	resource, _, _ := strings.Cut(trimmed, "/")
+
+	switch {
+	case resource == "auth", resource == "admin",
+		strings.HasPrefix(trimmed, "me/tokens"), strings.HasPrefix(trimmed, "me/2fa"),
+		strings.HasPrefix(trimmed, "me/sessions"):
+		return false
+	}
+
+	if t.Scope != ScopeReadWrite && method != http.MethodGet && method != http.MethodHead {
+		return false
+	}
+
+	// Identity lookups are always allowed so scripts can check who they are
+	if resource == "me" || len(t.ResourceTypes) == 0 {
+		return true
+	}
+	for _, rt := range t.ResourceTypes {
+		if rt == resource {
+			return true
+		}
+	}
+	return false
+}
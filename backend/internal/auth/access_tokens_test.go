@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBearerToken(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+		wantOK bool
+	}{
+		{"Bearer conti_pat_abc", "conti_pat_abc", true},
+		{"bearer conti_pat_abc", "conti_pat_abc", true},
+		{"Bearer ", "", false},
+		{"Basic dXNlcjpwYXNz", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/movements", nil)
+		if tt.header != "" {
+			r.Header.Set("Authorization", tt.header)
+		}
+		got, ok := BearerToken(r)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("BearerToken(%q) = %q, %v; want %q, %v", tt.header, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestAccessTokenAllows(t *testing.T) {
+	readAll := &AccessToken{Scope: ScopeRead}
+	writeMovements := &AccessToken{Scope: ScopeReadWrite, ResourceTypes: []string{"movements", "recurring-movements"}}
+	inviteOnly := &AccessToken{Scope: ScopeReadWrite, ResourceTypes: []string{"invitations"}}
+
+	tests := []struct {
+		name   string
+		token  *AccessToken
+		method string
+		path   string
+		want   bool
+	}{
+		{"read get", readAll, http.MethodGet, "/budgets/2025-01", true},
+		{"read post", readAll, http.MethodPost, "/movements", false},
+		{"read me", readAll, http.MethodGet, "/me", true},
+		{"never tokens", readAll, http.MethodGet, "/me/tokens", false},
+		{"never sessions", writeMovements, http.MethodDelete, "/me/sessions/1", false},
+		{"never auth", writeMovements, http.MethodDelete, "/auth/account", false},
+		{"never admin", readAll, http.MethodGet, "/admin/audit-logs", false},
+		{"scoped match", writeMovements, http.MethodPost, "/movements", true},
+		{"scoped api prefix", writeMovements, http.MethodPatch, "/api/recurring-movements/1", true},
+		{"scoped other", writeMovements, http.MethodGet, "/budgets/2025-01", false},
+		{"scoped me", writeMovements, http.MethodGet, "/me", true},
+		{"invitations match", inviteOnly, http.MethodPost, "/invitations/accept", true},
+		{"invitations other", inviteOnly, http.MethodGet, "/households", false},
+		{"reports scoped", &AccessToken{Scope: ScopeRead, ResourceTypes: []string{"reports"}}, http.MethodGet, "/reports/budget-trend", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.token.Allows(tt.method, tt.path); got != tt.want {
+				t.Errorf("Allows(%s %s) = %v, want %v", tt.method, tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateAccessTokenInputValidate(t *testing.T) {
+	days := 0
+	tests := []struct {
+		name    string
+		input   CreateAccessTokenInput
+		wantErr bool
+	}{
+		{"defaults to read", CreateAccessTokenInput{Name: "cron"}, false},
+		{"missing name", CreateAccessTokenInput{Name: "  "}, true},
+		{"bad scope", CreateAccessTokenInput{Name: "cron", Scope: "admin"}, true},
+		{"bad resource", CreateAccessTokenInput{Name: "cron", ResourceTypes: []string{"auth"}}, true},
+		{"invitations resource", CreateAccessTokenInput{Name: "cron", ResourceTypes: []string{"invitations"}}, false},
+		{"reports resource", CreateAccessTokenInput{Name: "cron", ResourceTypes: []string{"reports"}}, false},
+		{"bad expiry", CreateAccessTokenInput{Name: "cron", ExpiresInDays: &days}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	input := CreateAccessTokenInput{Name: "cron"}
+	_ = input.Validate()
+	if input.Scope != ScopeRead {
+		t.Errorf("expected default scope %q, got %q", ScopeRead, input.Scope)
+	}
+}
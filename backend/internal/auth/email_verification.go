@@ -0,0 +1,248 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/blanquicet/conti/backend/internal/audit"
+)
+
+const (
+	// emailVerificationTTL is how long an email verification link stays valid.
+	emailVerificationTTL = 24 * time.Hour
+	// magicLinkTTL is how long a sign-in link stays valid.
+	magicLinkTTL = 15 * time.Minute
+)
+
+// SetMagicLinkEnabled turns passwordless magic-link login on or off.
+func (s *Service) SetMagicLinkEnabled(enabled bool) {
+	s.magicLinkEnabled = enabled
+}
+
+// MagicLinkEnabled reports whether magic-link login is available.
+func (s *Service) MagicLinkEnabled() bool {
+	return s.magicLinkEnabled
+}
+
+// issueEmailToken creates a single-use token and returns the plain value to email.
+func (s *Service) issueEmailToken(ctx context.Context, userID string, purpose EmailTokenPurpose, ttl time.Duration) (string, *EmailToken, error) {
+	token, err := GenerateToken(32)
+	if err != nil {
+		return "", nil, err
+	}
+	record, err := s.emailTokens.Create(ctx, userID, purpose, HashToken(token), time.Now().Add(ttl))
+	if err != nil {
+		return "", nil, err
+	}
+	return token, record, nil
+}
+
+// consumeEmailToken validates a token and marks it used.
+func (s *Service) consumeEmailToken(ctx context.Context, purpose EmailTokenPurpose, token string) (*EmailToken, error) {
+	if strings.TrimSpace(token) == "" {
+		return nil, errors.New("token is required")
+	}
+
+	record, err := s.emailTokens.GetByTokenHash(ctx, purpose, HashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if record == nil || time.Now().After(record.ExpiresAt) {
+		return nil, ErrTokenExpired
+	}
+	if record.UsedAt != nil {
+		return nil, ErrTokenUsed
+	}
+
+	consumed, err := s.emailTokens.MarkUsed(ctx, record.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !consumed {
+		return nil, ErrTokenUsed
+	}
+	return record, nil
+}
+
+// SendEmailVerification emails the user a link to verify their address.
+func (s *Service) SendEmailVerification(ctx context.Context, userID string) error {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.IsEmailVerified() {
+		return ErrEmailAlreadyVerified
+	}
+
+	token, record, err := s.issueEmailToken(ctx, user.ID, EmailTokenVerifyEmail, emailVerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	if err := s.emailSender.SendEmailVerification(ctx, user.Email, token); err != nil {
+		s.auditService.LogAsync(ctx, &audit.LogInput{
+			UserID:       audit.StringPtr(user.ID),
+			Action:       audit.ActionAuthEmailVerificationRequest,
+			ResourceType: "auth",
+			ResourceID:   audit.StringPtr(record.ID),
+			Success:      false,
+			ErrorMessage: audit.StringPtr("email send failed: " + err.Error()),
+		})
+		return err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		UserID:       audit.StringPtr(user.ID),
+		Action:       audit.ActionAuthEmailVerificationRequest,
+		ResourceType: "auth",
+		ResourceID:   audit.StringPtr(record.ID),
+		Success:      true,
+		NewValues: map[string]interface{}{
+			"email":      user.Email,
+			"expires_at": record.ExpiresAt,
+		},
+	})
+	return nil
+}
+
+// VerifyEmail marks the token owner's email as verified.
+func (s *Service) VerifyEmail(ctx context.Context, token string) error {
+	record, err := s.consumeEmailToken(ctx, EmailTokenVerifyEmail, token)
+	if err != nil {
+		s.auditService.LogAsync(ctx, &audit.LogInput{
+			Action:       audit.ActionAuthEmailVerified,
+			ResourceType: "auth",
+			Success:      false,
+			ErrorMessage: audit.StringPtr(err.Error()),
+		})
+		return err
+	}
+
+	if err := s.users.MarkEmailVerified(ctx, record.UserID); err != nil {
+		return err
+	}
+	s.suggestContactLinks(ctx, record.UserID)
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		UserID:       audit.StringPtr(record.UserID),
+		Action:       audit.ActionAuthEmailVerified,
+		ResourceType: "auth",
+		ResourceID:   audit.StringPtr(record.ID),
+		Success:      true,
+	})
+	return nil
+}
+
+// suggestContactLinks offers the user links to contacts kept under their
+// email. It runs once the email is verified rather than at Register, so nobody
+// can claim a contact by signing up with someone else's address. Phone numbers
+// are not matched: users never verify them. Best effort: suggestions only save
+// the user from asking for links manually.
+func (s *Service) suggestContactLinks(ctx context.Context, userID string) {
+	if s.linkSuggester == nil {
+		return
+	}
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return
+	}
+	_ = s.linkSuggester.SuggestLinksForVerifiedUser(ctx, user.ID, user.Email)
+}
+
+// RequestMagicLink emails a one-time sign-in link. Unknown emails succeed
+// silently to prevent enumeration.
+func (s *Service) RequestMagicLink(ctx context.Context, email string) error {
+	if !s.magicLinkEnabled {
+		return ErrMagicLinkDisabled
+	}
+	email = strings.TrimSpace(strings.ToLower(email))
+
+	user, err := s.users.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			s.auditService.LogAsync(ctx, &audit.LogInput{
+				Action:       audit.ActionAuthMagicLinkRequest,
+				ResourceType: "auth",
+				Success:      false,
+				ErrorMessage: audit.StringPtr("user not found"),
+				Metadata: map[string]interface{}{
+					"email": email,
+				},
+			})
+			return nil
+		}
+		return err
+	}
+
+	token, record, err := s.issueEmailToken(ctx, user.ID, EmailTokenMagicLink, magicLinkTTL)
+	if err != nil {
+		return err
+	}
+
+	if err := s.emailSender.SendMagicLink(ctx, user.Email, token); err != nil {
+		s.auditService.LogAsync(ctx, &audit.LogInput{
+			UserID:       audit.StringPtr(user.ID),
+			Action:       audit.ActionAuthMagicLinkRequest,
+			ResourceType: "auth",
+			ResourceID:   audit.StringPtr(record.ID),
+			Success:      false,
+			ErrorMessage: audit.StringPtr("email send failed: " + err.Error()),
+		})
+		return err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		UserID:       audit.StringPtr(user.ID),
+		Action:       audit.ActionAuthMagicLinkRequest,
+		ResourceType: "auth",
+		ResourceID:   audit.StringPtr(record.ID),
+		Success:      true,
+		NewValues: map[string]interface{}{
+			"email":      user.Email,
+			"expires_at": record.ExpiresAt,
+		},
+	})
+	return nil
+}
+
+// LoginWithMagicLink exchanges a sign-in link for a session. Like Login, users
+// with 2FA get a pending challenge instead. Opening the link proves the user
+// owns the mailbox, so it also verifies the email.
+func (s *Service) LoginWithMagicLink(ctx context.Context, token string) (*Session, *TwoFactorChallenge, error) {
+	if !s.magicLinkEnabled {
+		return nil, nil, ErrMagicLinkDisabled
+	}
+
+	record, err := s.consumeEmailToken(ctx, EmailTokenMagicLink, token)
+	if err != nil {
+		s.auditService.LogAsync(ctx, &audit.LogInput{
+			Action:       audit.ActionAuthLogin,
+			ResourceType: "auth",
+			Success:      false,
+			ErrorMessage: audit.StringPtr("magic link: " + err.Error()),
+		})
+		return nil, nil, err
+	}
+
+	if err := s.users.MarkEmailVerified(ctx, record.UserID); err != nil {
+		return nil, nil, err
+	}
+	s.suggestContactLinks(ctx, record.UserID)
+	// Opening the link also proves the user is not the one guessing passwords
+	if err := s.clearLoginFailures(ctx, record.UserID); err != nil {
+		return nil, nil, err
+	}
+
+	challenge, err := s.startTwoFactorChallenge(ctx, record.UserID)
+	if err != nil || challenge != nil {
+		return nil, challenge, err
+	}
+
+	session, err := s.createLoginSession(ctx, record.UserID, map[string]interface{}{"method": "magic_link"})
+	if err != nil {
+		return nil, nil, err
+	}
+	return session, nil, nil
+}
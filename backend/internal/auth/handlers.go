@@ -1,10 +1,14 @@
 package auth
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 // Handler handles HTTP requests for authentication.
@@ -13,6 +17,8 @@ type Handler struct {
 	cookieName string
 	secure     bool
 	logger     *slog.Logger
+
+	listMembershipsFn func(ctx context.Context, userID string) ([]HouseholdMembership, error)
 }
 
 // NewHandler creates a new auth handler.
@@ -25,6 +31,11 @@ func NewHandler(service *Service, cookieName string, secure bool, logger *slog.L
 	}
 }
 
+// SetListMembershipsFn sets the function used to list the user's households on /me
+func (h *Handler) SetListMembershipsFn(fn func(ctx context.Context, userID string) ([]HouseholdMembership, error)) {
+	h.listMembershipsFn = fn
+}
+
 // RegisterRequest is the request body for registration.
 type RegisterRequest struct {
 	Email           string `json:"email"`
@@ -45,6 +56,20 @@ type UserResponse struct {
 	Email               string `json:"email"`
 	Name                string `json:"name"`
 	OnboardingCompleted bool   `json:"onboarding_completed"`
+	EmailVerified       bool   `json:"email_verified"`
+
+	// Memberships is only filled in by GET /me
+	Memberships []HouseholdMembership `json:"memberships,omitempty"`
+}
+
+// HouseholdMembership is one of the households the user belongs to, with what
+// their role lets them do there.
+type HouseholdMembership struct {
+	HouseholdID   string    `json:"household_id"`
+	HouseholdName string    `json:"household_name"`
+	Role          string    `json:"role"`
+	Permissions   []string  `json:"permissions"`
+	JoinedAt      time.Time `json:"joined_at"`
 }
 
 // ForgotPasswordRequest is the request body for forgot password.
@@ -57,6 +82,34 @@ type ResetPasswordRequest struct {
 	Token               string `json:"token"`
 	NewPassword         string `json:"new_password"`
 	NewPasswordConfirm  string `json:"new_password_confirm"`
+	TwoFactorCode       string `json:"two_factor_code,omitempty"`
+}
+
+// MagicLinkRequest is the request body for requesting a sign-in link.
+type MagicLinkRequest struct {
+	Email string `json:"email"`
+}
+
+// EmailTokenRequest is the request body for endpoints that consume an emailed token.
+type EmailTokenRequest struct {
+	Token string `json:"token"`
+}
+
+// TwoFactorLoginRequest is the request body for the second login step.
+type TwoFactorLoginRequest struct {
+	ChallengeID string `json:"challenge_id"`
+	Code        string `json:"code"`
+}
+
+// TwoFactorCodeRequest is the request body for endpoints that confirm a code.
+type TwoFactorCodeRequest struct {
+	Code string `json:"code"`
+}
+
+// DisableTwoFactorRequest is the request body for disabling 2FA.
+type DisableTwoFactorRequest struct {
+	Password string `json:"password"`
+	Code     string `json:"code"`
 }
 
 // ErrorResponse is a standard error response.
@@ -100,7 +153,7 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	session, err := h.service.Login(r.Context(), LoginInput{
+	session, challenge, err := h.service.Login(r.Context(), LoginInput{
 		Email:    req.Email,
 		Password: req.Password,
 	})
@@ -109,10 +162,113 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.respondLogin(w, session, challenge)
+}
+
+// respondLogin sets the session cookie, or asks for the second factor when the
+// login only opened a 2FA challenge.
+func (h *Handler) respondLogin(w http.ResponseWriter, session *Session, challenge *TwoFactorChallenge) {
+	// Second step required: no cookie until the code is verified
+	if challenge != nil {
+		h.respondJSON(w, map[string]any{
+			"two_factor_required": true,
+			"challenge_id":        challenge.ID,
+			"expires_at":          challenge.ExpiresAt,
+		}, http.StatusOK)
+		return
+	}
+
 	h.setSessionCookie(w, session)
 	h.respondJSON(w, map[string]string{"message": "sesión iniciada exitosamente"}, http.StatusOK)
 }
 
+// RequestMagicLink handles POST /auth/magic-link
+func (h *Handler) RequestMagicLink(w http.ResponseWriter, r *http.Request) {
+	var req MagicLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, "cuerpo de solicitud inválido", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.RequestMagicLink(r.Context(), req.Email); err != nil {
+		if errors.Is(err, ErrMagicLinkDisabled) {
+			h.respondError(w, "el inicio de sesión por enlace no está habilitado", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to request magic link", "error", err)
+		// Don't reveal errors to prevent email enumeration
+	}
+
+	h.respondJSON(w, map[string]string{
+		"message": "si ese email existe, se ha enviado un enlace para iniciar sesión",
+	}, http.StatusOK)
+}
+
+// LoginMagicLink handles POST /auth/magic-link/login
+func (h *Handler) LoginMagicLink(w http.ResponseWriter, r *http.Request) {
+	var req EmailTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, "cuerpo de solicitud inválido", http.StatusBadRequest)
+		return
+	}
+
+	session, challenge, err := h.service.LoginWithMagicLink(r.Context(), req.Token)
+	if err != nil {
+		h.handleServiceError(w, err)
+		return
+	}
+
+	h.respondLogin(w, session, challenge)
+}
+
+// UnlockAccount handles POST /auth/unlock-account
+func (h *Handler) UnlockAccount(w http.ResponseWriter, r *http.Request) {
+	var req EmailTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, "cuerpo de solicitud inválido", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.UnlockAccount(r.Context(), req.Token); err != nil {
+		h.handleServiceError(w, err)
+		return
+	}
+
+	h.respondJSON(w, map[string]string{"message": "cuenta desbloqueada, ya puedes iniciar sesión"}, http.StatusOK)
+}
+
+// VerifyEmail handles POST /auth/verify-email
+func (h *Handler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req EmailTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, "cuerpo de solicitud inválido", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.VerifyEmail(r.Context(), req.Token); err != nil {
+		h.handleServiceError(w, err)
+		return
+	}
+
+	h.respondJSON(w, map[string]string{"message": "email verificado exitosamente"}, http.StatusOK)
+}
+
+// ResendEmailVerification handles POST /me/email-verification
+func (h *Handler) ResendEmailVerification(w http.ResponseWriter, r *http.Request) {
+	user, err := h.service.UserFromRequest(r, h.cookieName)
+	if err != nil {
+		h.respondError(w, "no autorizado", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.SendEmailVerification(r.Context(), user.ID); err != nil {
+		h.handleServiceError(w, err)
+		return
+	}
+
+	h.respondJSON(w, map[string]string{"message": "se ha enviado un enlace de verificación a tu email"}, http.StatusOK)
+}
+
 // Logout handles POST /auth/logout
 func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
 	cookie, err := r.Cookie(h.cookieName)
@@ -133,41 +289,45 @@ func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
 
 // Me handles GET /me
 func (h *Handler) Me(w http.ResponseWriter, r *http.Request) {
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		h.respondError(w, "no autorizado", http.StatusUnauthorized)
-		return
-	}
-
-	user, err := h.service.GetUserBySession(r.Context(), cookie.Value)
+	user, err := h.service.UserFromRequest(r, h.cookieName)
 	if err != nil {
 		if errors.Is(err, ErrSessionExpired) || errors.Is(err, ErrUserNotFound) {
 			h.clearSessionCookie(w)
 			h.respondError(w, "no autorizado", http.StatusUnauthorized)
 			return
 		}
+		if IsUnauthenticated(err) {
+			h.respondError(w, "no autorizado", http.StatusUnauthorized)
+			return
+		}
 		h.logger.Error("failed to get user", "error", err)
 		h.respondError(w, "error interno del servidor", http.StatusInternalServerError)
 		return
 	}
 
-	h.respondJSON(w, UserResponse{
+	resp := UserResponse{
 		ID:                  user.ID,
 		Email:               user.Email,
 		Name:                user.Name,
 		OnboardingCompleted: user.OnboardingCompletedAt != nil,
-	}, http.StatusOK)
+		EmailVerified:       user.IsEmailVerified(),
+	}
+	if h.listMembershipsFn != nil {
+		memberships, err := h.listMembershipsFn(r.Context(), user.ID)
+		if err != nil {
+			h.logger.Error("failed to list memberships", "error", err)
+			h.respondError(w, "error interno del servidor", http.StatusInternalServerError)
+			return
+		}
+		resp.Memberships = memberships
+	}
+
+	h.respondJSON(w, resp, http.StatusOK)
 }
 
 // CompleteOnboarding handles POST /me/onboarding/complete
 func (h *Handler) CompleteOnboarding(w http.ResponseWriter, r *http.Request) {
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		h.respondError(w, "no autorizado", http.StatusUnauthorized)
-		return
-	}
-
-	user, err := h.service.GetUserBySession(r.Context(), cookie.Value)
+	user, err := h.service.UserFromRequest(r, h.cookieName)
 	if err != nil {
 		h.respondError(w, "no autorizado", http.StatusUnauthorized)
 		return
@@ -227,8 +387,9 @@ func (h *Handler) ResetPassword(w http.ResponseWriter, r *http.Request) {
 	}
 
 	err := h.service.ResetPassword(r.Context(), ResetPasswordInput{
-		Token:       req.Token,
-		NewPassword: req.NewPassword,
+		Token:         req.Token,
+		NewPassword:   req.NewPassword,
+		TwoFactorCode: req.TwoFactorCode,
 	})
 	if err != nil {
 		h.handleServiceError(w, err)
@@ -275,6 +436,17 @@ func (h *Handler) respondError(w http.ResponseWriter, message string, status int
 }
 
 func (h *Handler) handleServiceError(w http.ResponseWriter, err error) {
+	if blocked, ok := IsLoginBlocked(err); ok {
+		retryAfter := int(blocked.RetryAfter.Seconds()) + 1
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		if errors.Is(err, ErrAccountLocked) {
+			h.respondError(w, "Tu cuenta está bloqueada temporalmente por demasiados intentos fallidos. Revisa tu email para desbloquearla.", http.StatusLocked)
+			return
+		}
+		h.respondError(w, fmt.Sprintf("Demasiados intentos fallidos. Espera %d segundos e intenta de nuevo.", retryAfter), http.StatusTooManyRequests)
+		return
+	}
+
 	switch {
 	case errors.Is(err, ErrUserExists):
 		h.respondError(w, "Email ya registrado", http.StatusConflict)
@@ -286,6 +458,24 @@ func (h *Handler) handleServiceError(w http.ResponseWriter, err error) {
 		h.respondError(w, "Token ya usado", http.StatusBadRequest)
 	case errors.Is(err, ErrUserNotFound):
 		h.respondError(w, "Usuario no encontrado", http.StatusNotFound)
+	case errors.Is(err, ErrTwoFactorRequired):
+		h.respondError(w, "Se requiere el código de verificación en dos pasos", http.StatusForbidden)
+	case errors.Is(err, ErrInvalidTwoFactorCode):
+		h.respondError(w, "Código de verificación inválido", http.StatusUnauthorized)
+	case errors.Is(err, ErrChallengeExpired):
+		h.respondError(w, "El inicio de sesión expiró, vuelve a ingresar tu contraseña", http.StatusUnauthorized)
+	case errors.Is(err, ErrTwoFactorAlreadyEnabled):
+		h.respondError(w, "La verificación en dos pasos ya está activada", http.StatusConflict)
+	case errors.Is(err, ErrTwoFactorNotEnabled):
+		h.respondError(w, "La verificación en dos pasos no está activada", http.StatusBadRequest)
+	case errors.Is(err, ErrTwoFactorNotSetUp):
+		h.respondError(w, "Primero inicia la configuración de la verificación en dos pasos", http.StatusBadRequest)
+	case errors.Is(err, ErrEmailAlreadyVerified):
+		h.respondError(w, "El email ya está verificado", http.StatusConflict)
+	case errors.Is(err, ErrEmailNotVerified):
+		h.respondError(w, "Debes verificar tu email primero", http.StatusForbidden)
+	case errors.Is(err, ErrMagicLinkDisabled):
+		h.respondError(w, "El inicio de sesión por enlace no está habilitado", http.StatusNotFound)
 	default:
 		// Check for validation errors (they're just regular errors with messages)
 		if err != nil {
@@ -318,21 +508,18 @@ func isValidationError(msg string) bool {
 // DeleteAccount handles account deletion requests.
 // DELETE /auth/account
 func (h *Handler) DeleteAccount(w http.ResponseWriter, r *http.Request) {
-	// Get session from cookie
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		h.respondError(w, "No autenticado", http.StatusUnauthorized)
-		return
-	}
-
 	// Get user from session
-	user, err := h.service.GetUserBySession(r.Context(), cookie.Value)
+	user, err := h.service.UserFromRequest(r, h.cookieName)
 	if err != nil {
 		if errors.Is(err, ErrSessionExpired) {
 			h.clearSessionCookie(w)
 			h.respondError(w, "Sesión expirada", http.StatusUnauthorized)
 			return
 		}
+		if IsUnauthenticated(err) {
+			h.respondError(w, "No autenticado", http.StatusUnauthorized)
+			return
+		}
 		h.handleServiceError(w, err)
 		return
 	}
@@ -349,3 +536,305 @@ func (h *Handler) DeleteAccount(w http.ResponseWriter, r *http.Request) {
 	// Return success
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// ListAccessTokens handles GET /me/tokens
+func (h *Handler) ListAccessTokens(w http.ResponseWriter, r *http.Request) {
+	user, err := h.service.UserFromRequest(r, h.cookieName)
+	if err != nil {
+		h.respondError(w, "no autorizado", http.StatusUnauthorized)
+		return
+	}
+
+	tokens, err := h.service.ListAccessTokens(r.Context(), user.ID)
+	if err != nil {
+		h.logger.Error("failed to list access tokens", "error", err, "user_id", user.ID)
+		h.respondError(w, "error interno del servidor", http.StatusInternalServerError)
+		return
+	}
+
+	h.respondJSON(w, map[string]any{"tokens": tokens}, http.StatusOK)
+}
+
+// CreateAccessToken handles POST /me/tokens.
+// The plain token is only included in this response.
+func (h *Handler) CreateAccessToken(w http.ResponseWriter, r *http.Request) {
+	user, err := h.service.UserFromRequest(r, h.cookieName)
+	if err != nil {
+		h.respondError(w, "no autorizado", http.StatusUnauthorized)
+		return
+	}
+
+	var input CreateAccessTokenInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.respondError(w, "cuerpo de solicitud inválido", http.StatusBadRequest)
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.service.CreateAccessToken(r.Context(), user.ID, &input)
+	if err != nil {
+		if errors.Is(err, ErrTooManyAccessTokens) {
+			h.respondError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		h.logger.Error("failed to create access token", "error", err, "user_id", user.ID)
+		h.respondError(w, "error interno del servidor", http.StatusInternalServerError)
+		return
+	}
+
+	h.respondJSON(w, resp, http.StatusCreated)
+}
+
+// RevokeAccessToken handles DELETE /me/tokens/{id}
+func (h *Handler) RevokeAccessToken(w http.ResponseWriter, r *http.Request) {
+	user, err := h.service.UserFromRequest(r, h.cookieName)
+	if err != nil {
+		h.respondError(w, "no autorizado", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.RevokeAccessToken(r.Context(), user.ID, r.PathValue("id")); err != nil {
+		if errors.Is(err, ErrTokenNotFound) {
+			h.respondError(w, "token no encontrado", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to revoke access token", "error", err, "user_id", user.ID)
+		h.respondError(w, "error interno del servidor", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// currentSessionID returns the session ID from the request cookie, or "" if none.
+func (h *Handler) currentSessionID(r *http.Request) string {
+	cookie, err := r.Cookie(h.cookieName)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
+
+// ListSessions handles GET /me/sessions
+func (h *Handler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	user, err := h.service.UserFromRequest(r, h.cookieName)
+	if err != nil {
+		h.respondError(w, "no autorizado", http.StatusUnauthorized)
+		return
+	}
+
+	sessions, err := h.service.ListSessions(r.Context(), user.ID, h.currentSessionID(r))
+	if err != nil {
+		h.logger.Error("failed to list sessions", "error", err, "user_id", user.ID)
+		h.respondError(w, "error interno del servidor", http.StatusInternalServerError)
+		return
+	}
+
+	h.respondJSON(w, map[string]any{"sessions": sessions}, http.StatusOK)
+}
+
+// RevokeSession handles DELETE /me/sessions/{id}
+func (h *Handler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	user, err := h.service.UserFromRequest(r, h.cookieName)
+	if err != nil {
+		h.respondError(w, "no autorizado", http.StatusUnauthorized)
+		return
+	}
+
+	sessionID := r.PathValue("id")
+	if err := h.service.RevokeSession(r.Context(), user.ID, sessionID); err != nil {
+		if errors.Is(err, ErrSessionNotFound) {
+			h.respondError(w, "sesión no encontrada", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to revoke session", "error", err, "user_id", user.ID)
+		h.respondError(w, "error interno del servidor", http.StatusInternalServerError)
+		return
+	}
+
+	// Revoking the current session is a logout
+	if sessionID == h.currentSessionID(r) {
+		h.clearSessionCookie(w)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RevokeOtherSessions handles POST /me/sessions/revoke-others ("log out everywhere else")
+func (h *Handler) RevokeOtherSessions(w http.ResponseWriter, r *http.Request) {
+	user, err := h.service.UserFromRequest(r, h.cookieName)
+	if err != nil {
+		h.respondError(w, "no autorizado", http.StatusUnauthorized)
+		return
+	}
+
+	currentID := h.currentSessionID(r)
+	if currentID == "" {
+		h.respondError(w, "se requiere una sesión activa", http.StatusBadRequest)
+		return
+	}
+
+	revoked, err := h.service.RevokeOtherSessions(r.Context(), user.ID, currentID)
+	if err != nil {
+		h.logger.Error("failed to revoke other sessions", "error", err, "user_id", user.ID)
+		h.respondError(w, "error interno del servidor", http.StatusInternalServerError)
+		return
+	}
+
+	h.respondJSON(w, map[string]int{"revoked": revoked}, http.StatusOK)
+}
+
+// LoginTwoFactor handles POST /auth/login/2fa, the second login step
+func (h *Handler) LoginTwoFactor(w http.ResponseWriter, r *http.Request) {
+	var req TwoFactorLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, "cuerpo de solicitud inválido", http.StatusBadRequest)
+		return
+	}
+	if req.ChallengeID == "" || req.Code == "" {
+		h.respondError(w, "challenge_id y code son requeridos", http.StatusBadRequest)
+		return
+	}
+
+	session, err := h.service.CompleteTwoFactorLogin(r.Context(), req.ChallengeID, req.Code)
+	if err != nil {
+		h.handleServiceError(w, err)
+		return
+	}
+
+	h.setSessionCookie(w, session)
+	h.respondJSON(w, map[string]string{"message": "sesión iniciada exitosamente"}, http.StatusOK)
+}
+
+// TwoFactorStatus handles GET /me/2fa
+func (h *Handler) TwoFactorStatus(w http.ResponseWriter, r *http.Request) {
+	user, err := h.service.UserFromRequest(r, h.cookieName)
+	if err != nil {
+		h.respondError(w, "no autorizado", http.StatusUnauthorized)
+		return
+	}
+
+	status, err := h.service.GetTwoFactorStatus(r.Context(), user.ID)
+	if err != nil {
+		h.handleServiceError(w, err)
+		return
+	}
+
+	h.respondJSON(w, status, http.StatusOK)
+}
+
+// SetupTwoFactor handles POST /me/2fa/setup.
+// Returns the secret and otpauth URI to show as a QR code.
+func (h *Handler) SetupTwoFactor(w http.ResponseWriter, r *http.Request) {
+	user, err := h.service.UserFromRequest(r, h.cookieName)
+	if err != nil {
+		h.respondError(w, "no autorizado", http.StatusUnauthorized)
+		return
+	}
+
+	setup, err := h.service.SetupTwoFactor(r.Context(), user.ID)
+	if err != nil {
+		h.handleServiceError(w, err)
+		return
+	}
+
+	h.respondJSON(w, setup, http.StatusOK)
+}
+
+// EnableTwoFactor handles POST /me/2fa/enable.
+// Returns the recovery codes; they are not shown again.
+func (h *Handler) EnableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	user, err := h.service.UserFromRequest(r, h.cookieName)
+	if err != nil {
+		h.respondError(w, "no autorizado", http.StatusUnauthorized)
+		return
+	}
+
+	var req TwoFactorCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, "cuerpo de solicitud inválido", http.StatusBadRequest)
+		return
+	}
+
+	codes, err := h.service.EnableTwoFactor(r.Context(), user.ID, req.Code)
+	if err != nil {
+		h.handleServiceError(w, err)
+		return
+	}
+
+	h.respondJSON(w, map[string]any{"recovery_codes": codes}, http.StatusOK)
+}
+
+// DisableTwoFactor handles POST /me/2fa/disable
+func (h *Handler) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	user, err := h.service.UserFromRequest(r, h.cookieName)
+	if err != nil {
+		h.respondError(w, "no autorizado", http.StatusUnauthorized)
+		return
+	}
+
+	var req DisableTwoFactorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, "cuerpo de solicitud inválido", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DisableTwoFactor(r.Context(), user.ID, req.Password, req.Code); err != nil {
+		if errors.Is(err, ErrInvalidCredentials) {
+			h.respondError(w, "Contraseña incorrecta", http.StatusUnauthorized)
+			return
+		}
+		h.handleServiceError(w, err)
+		return
+	}
+
+	h.respondJSON(w, map[string]string{"message": "verificación en dos pasos desactivada"}, http.StatusOK)
+}
+
+// RegenerateRecoveryCodes handles POST /me/2fa/recovery-codes
+func (h *Handler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	user, err := h.service.UserFromRequest(r, h.cookieName)
+	if err != nil {
+		h.respondError(w, "no autorizado", http.StatusUnauthorized)
+		return
+	}
+
+	var req TwoFactorCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, "cuerpo de solicitud inválido", http.StatusBadRequest)
+		return
+	}
+
+	codes, err := h.service.RegenerateRecoveryCodes(r.Context(), user.ID, req.Code)
+	if err != nil {
+		h.handleServiceError(w, err)
+		return
+	}
+
+	h.respondJSON(w, map[string]any{"recovery_codes": codes}, http.StatusOK)
+}
+
+// TwoFactorEvents handles GET /me/2fa/events?limit=50&offset=0.
+// Lists when 2FA was enabled, disabled or recovery codes were used.
+func (h *Handler) TwoFactorEvents(w http.ResponseWriter, r *http.Request) {
+	user, err := h.service.UserFromRequest(r, h.cookieName)
+	if err != nil {
+		h.respondError(w, "no autorizado", http.StatusUnauthorized)
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+	events, total, err := h.service.ListTwoFactorEvents(r.Context(), user.ID, limit, offset)
+	if err != nil {
+		h.handleServiceError(w, err)
+		return
+	}
+
+	h.respondJSON(w, map[string]any{"events": events, "total": total}, http.StatusOK)
+}
@@ -0,0 +1,151 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/blanquicet/conti/backend/internal/audit"
+)
+
+// Failed login policy. Failures are counted per account (in the database, so
+// every replica sees them), independently of the per-IP rate limiter.
+const (
+	// loginFailureWindow is how long failures keep counting toward a lockout.
+	loginFailureWindow = 15 * time.Minute
+	// loginDelayAfter failures, each further attempt has to wait a growing delay.
+	loginDelayAfter = 3
+	// maxLoginDelay caps the progressive delay.
+	maxLoginDelay = 30 * time.Second
+	// loginLockoutAfter failures, the account is locked.
+	loginLockoutAfter = 10
+	// loginLockoutDuration is how long a lockout lasts unless unlocked by email.
+	loginLockoutDuration = 15 * time.Minute
+	// unlockTokenTTL is how long the emailed unlock link stays valid.
+	unlockTokenTTL = loginLockoutDuration
+)
+
+// loginDelay returns how long to wait after the last failure before the next
+// attempt: 1s after the third failure, doubling up to maxLoginDelay.
+func loginDelay(failures int) time.Duration {
+	if failures < loginDelayAfter {
+		return 0
+	}
+	shift := failures - loginDelayAfter
+	if shift >= 5 {
+		return maxLoginDelay
+	}
+	delay := time.Second << shift
+	if delay > maxLoginDelay {
+		return maxLoginDelay
+	}
+	return delay
+}
+
+// loginBlock reports whether failures block a login attempt at now.
+func loginBlock(f *LoginFailures, now time.Time) *LoginBlockedError {
+	if f == nil {
+		return nil
+	}
+	if f.LockedUntil != nil && now.Before(*f.LockedUntil) {
+		return &LoginBlockedError{Err: ErrAccountLocked, RetryAfter: f.LockedUntil.Sub(now)}
+	}
+	if now.Sub(f.FirstFailedAt) > loginFailureWindow {
+		return nil
+	}
+	next := f.LastFailedAt.Add(loginDelay(f.FailedCount))
+	if now.Before(next) {
+		return &LoginBlockedError{Err: ErrLoginThrottled, RetryAfter: next.Sub(now)}
+	}
+	return nil
+}
+
+// checkLoginAllowed returns a *LoginBlockedError while the account is
+// throttled or locked.
+func (s *Service) checkLoginAllowed(ctx context.Context, userID string) error {
+	f, err := s.loginFailures.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if blocked := loginBlock(f, time.Now()); blocked != nil {
+		return blocked
+	}
+	return nil
+}
+
+// recordLoginFailure counts a wrong password and locks the account once it
+// reaches loginLockoutAfter failures, emailing the user an unlock link.
+func (s *Service) recordLoginFailure(ctx context.Context, user *User) error {
+	f, err := s.loginFailures.RecordFailure(ctx, user.ID, loginFailureWindow)
+	if err != nil {
+		return err
+	}
+	if f.FailedCount < loginLockoutAfter || (f.LockedUntil != nil && time.Now().Before(*f.LockedUntil)) {
+		return nil
+	}
+
+	lockedUntil := time.Now().Add(loginLockoutDuration)
+	if err := s.loginFailures.Lock(ctx, user.ID, lockedUntil); err != nil {
+		return err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		UserID:       audit.StringPtr(user.ID),
+		Action:       audit.ActionAuthAccountLocked,
+		ResourceType: "auth",
+		Success:      true,
+		NewValues: map[string]interface{}{
+			"failed_count": f.FailedCount,
+			"locked_until": lockedUntil,
+		},
+	})
+
+	token, _, err := s.issueEmailToken(ctx, user.ID, EmailTokenUnlock, unlockTokenTTL)
+	if err != nil {
+		return err
+	}
+	// Best effort: the lock expires on its own if the email does not arrive
+	_ = s.emailSender.SendAccountLocked(ctx, user.Email, token, lockedUntil)
+	return nil
+}
+
+// clearLoginFailures forgets failed attempts after the user proved who they are.
+func (s *Service) clearLoginFailures(ctx context.Context, userID string) error {
+	return s.loginFailures.Reset(ctx, userID)
+}
+
+// UnlockAccount lifts a lockout using the token from the unlock email.
+func (s *Service) UnlockAccount(ctx context.Context, token string) error {
+	record, err := s.consumeEmailToken(ctx, EmailTokenUnlock, token)
+	if err != nil {
+		s.auditService.LogAsync(ctx, &audit.LogInput{
+			Action:       audit.ActionAuthAccountUnlocked,
+			ResourceType: "auth",
+			Success:      false,
+			ErrorMessage: audit.StringPtr(err.Error()),
+		})
+		return err
+	}
+
+	if err := s.clearLoginFailures(ctx, record.UserID); err != nil {
+		return err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		UserID:       audit.StringPtr(record.UserID),
+		Action:       audit.ActionAuthAccountUnlocked,
+		ResourceType: "auth",
+		ResourceID:   audit.StringPtr(record.ID),
+		Success:      true,
+	})
+	return nil
+}
+
+// IsLoginBlocked extracts the blocking details from a Login error.
+func IsLoginBlocked(err error) (*LoginBlockedError, bool) {
+	var blocked *LoginBlockedError
+	if errors.As(err, &blocked) {
+		return blocked, true
+	}
+	return nil, false
+}
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLoginDelay(t *testing.T) {
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{0, 0},
+		{loginDelayAfter - 1, 0},
+		{loginDelayAfter, time.Second},
+		{loginDelayAfter + 1, 2 * time.Second},
+		{loginDelayAfter + 4, 16 * time.Second},
+		{loginDelayAfter + 5, maxLoginDelay},
+		{100, maxLoginDelay},
+	}
+	for _, tt := range tests {
+		if got := loginDelay(tt.failures); got != tt.want {
+			t.Errorf("loginDelay(%d) = %v, want %v", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestLoginBlock(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	lockedUntil := now.Add(5 * time.Minute)
+	expiredLock := now.Add(-time.Minute)
+
+	tests := []struct {
+		name    string
+		f       *LoginFailures
+		wantErr error
+	}{
+		{"no failures", nil, nil},
+		{"below delay threshold", &LoginFailures{FailedCount: 2, FirstFailedAt: now, LastFailedAt: now}, nil},
+		{"delay pending", &LoginFailures{FailedCount: loginDelayAfter + 2, FirstFailedAt: now.Add(-time.Minute), LastFailedAt: now.Add(-time.Second)}, ErrLoginThrottled},
+		{"delay passed", &LoginFailures{FailedCount: loginDelayAfter, FirstFailedAt: now.Add(-time.Minute), LastFailedAt: now.Add(-2 * time.Second)}, nil},
+		{"window passed", &LoginFailures{FailedCount: loginLockoutAfter - 1, FirstFailedAt: now.Add(-loginFailureWindow - time.Minute), LastFailedAt: now}, nil},
+		{"locked", &LoginFailures{FailedCount: loginLockoutAfter, FirstFailedAt: now.Add(-time.Minute), LastFailedAt: now.Add(-time.Minute), LockedUntil: &lockedUntil}, ErrAccountLocked},
+		{"lock expired", &LoginFailures{FailedCount: loginLockoutAfter, FirstFailedAt: now.Add(-loginFailureWindow - time.Minute), LastFailedAt: now.Add(-loginFailureWindow), LockedUntil: &expiredLock}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocked := loginBlock(tt.f, now)
+			if tt.wantErr == nil {
+				if blocked != nil {
+					t.Errorf("expected no block, got %v", blocked)
+				}
+				return
+			}
+			if blocked == nil || !errors.Is(blocked, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, blocked)
+			}
+			if blocked.RetryAfter <= 0 {
+				t.Errorf("expected positive RetryAfter, got %v", blocked.RetryAfter)
+			}
+		})
+	}
+}
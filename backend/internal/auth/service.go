@@ -12,6 +12,16 @@ import (
 // EmailSender defines the interface for sending emails.
 type EmailSender interface {
 	SendPasswordReset(ctx context.Context, to, token string) error
+	SendNewDeviceLogin(ctx context.Context, to, userAgent, ipAddress string, at time.Time) error
+	SendEmailVerification(ctx context.Context, to, token string) error
+	SendMagicLink(ctx context.Context, to, token string) error
+	SendAccountLocked(ctx context.Context, to, token string, lockedUntil time.Time) error
+}
+
+// LinkSuggester offers a user with a verified email links to the contacts
+// other households already keep under that email.
+type LinkSuggester interface {
+	SuggestLinksForVerifiedUser(ctx context.Context, userID, email string) error
 }
 
 // Service handles authentication business logic.
@@ -19,10 +29,17 @@ type Service struct {
 	users         UserRepository
 	sessions      SessionRepository
 	passwordReset PasswordResetRepository
+	accessTokens  AccessTokenRepository
+	twoFactor     TwoFactorRepository
+	emailTokens   EmailTokenRepository
+	loginFailures LoginFailureRepository
 	emailSender   EmailSender
 	auditService  audit.Service
 	sessionTTL    time.Duration
 	resetTokenTTL time.Duration
+
+	magicLinkEnabled bool
+	linkSuggester    LinkSuggester
 }
 
 // NewService creates a new auth service.
@@ -30,6 +47,10 @@ func NewService(
 	users UserRepository,
 	sessions SessionRepository,
 	passwordReset PasswordResetRepository,
+	accessTokens AccessTokenRepository,
+	twoFactor TwoFactorRepository,
+	emailTokens EmailTokenRepository,
+	loginFailures LoginFailureRepository,
 	emailSender EmailSender,
 	auditService audit.Service,
 	sessionTTL time.Duration,
@@ -38,6 +59,10 @@ func NewService(
 		users:         users,
 		sessions:      sessions,
 		passwordReset: passwordReset,
+		accessTokens:  accessTokens,
+		twoFactor:     twoFactor,
+		emailTokens:   emailTokens,
+		loginFailures: loginFailures,
 		emailSender:   emailSender,
 		auditService:  auditService,
 		sessionTTL:    sessionTTL,
@@ -45,6 +70,11 @@ func NewService(
 	}
 }
 
+// SetLinkSuggester sets the hook that matches verified emails against existing contacts.
+func (s *Service) SetLinkSuggester(suggester LinkSuggester) {
+	s.linkSuggester = suggester
+}
+
 // RegisterInput contains the data needed to register a new user.
 type RegisterInput struct {
 	Email    string
@@ -102,7 +132,8 @@ func (i *RegisterInput) Validate() error {
 	return nil
 }
 
-// Register creates a new user account and returns a session.
+// Register creates a new user account and returns a session. Contact link
+// suggestions wait until the email is verified (see suggestContactLinks).
 func (s *Service) Register(ctx context.Context, input RegisterInput) (*Session, error) {
 	if err := input.Validate(); err != nil {
 		return nil, err
@@ -131,11 +162,20 @@ func (s *Service) Register(ctx context.Context, input RegisterInput) (*Session,
 
 	// Create session
 	expiresAt := time.Now().Add(s.sessionTTL)
-	session, err := s.sessions.Create(ctx, user.ID, expiresAt)
+	meta := sessionMetadataFromContext(ctx)
+	session, err := s.sessions.Create(ctx, user.ID, expiresAt, meta)
 	if err != nil {
 		return nil, err
 	}
 
+	// Remember the sign-up device so it does not trigger a new device alert later
+	if _, err := s.sessions.RecordDevice(ctx, user.ID, deviceFingerprint(meta), meta); err != nil {
+		return nil, err
+	}
+
+	// Best effort: the user can ask for a new link, and failures are audited
+	_ = s.SendEmailVerification(ctx, user.ID)
+
 	return session, nil
 }
 
@@ -158,10 +198,11 @@ func (i *LoginInput) Validate() error {
 	return nil
 }
 
-// Login authenticates a user and returns a session.
-func (s *Service) Login(ctx context.Context, input LoginInput) (*Session, error) {
+// Login authenticates a user and returns a session. If the user has two-factor
+// authentication enabled, it returns a pending challenge instead of a session.
+func (s *Service) Login(ctx context.Context, input LoginInput) (*Session, *TwoFactorChallenge, error) {
 	if err := input.Validate(); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Get user by email
@@ -178,17 +219,34 @@ func (s *Service) Login(ctx context.Context, input LoginInput) (*Session, error)
 					"email": input.Email,
 				},
 			})
-			return nil, ErrInvalidCredentials
+			return nil, nil, ErrInvalidCredentials
 		}
-		return nil, err
+		return nil, nil, err
+	}
+
+	// Throttled or locked accounts are rejected before checking the password,
+	// so a lockout cannot be used to keep guessing
+	if err := s.checkLoginAllowed(ctx, user.ID); err != nil {
+		s.auditService.LogAsync(ctx, &audit.LogInput{
+			UserID:       audit.StringPtr(user.ID),
+			Action:       audit.ActionAuthLogin,
+			ResourceType: "auth",
+			Success:      false,
+			ErrorMessage: audit.StringPtr(err.Error()),
+		})
+		return nil, nil, err
 	}
 
 	// Verify password
 	match, err := VerifyPassword(input.Password, user.PasswordHash)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if !match {
+		if err := s.recordLoginFailure(ctx, user); err != nil {
+			return nil, nil, err
+		}
+
 		// Log failed login attempt (invalid password)
 		s.auditService.LogAsync(ctx, &audit.LogInput{
 			UserID:       audit.StringPtr(user.ID),
@@ -200,16 +258,50 @@ func (s *Service) Login(ctx context.Context, input LoginInput) (*Session, error)
 				"email": input.Email,
 			},
 		})
-		return nil, ErrInvalidCredentials
+		return nil, nil, ErrInvalidCredentials
 	}
 
+	if err := s.clearLoginFailures(ctx, user.ID); err != nil {
+		return nil, nil, err
+	}
+
+	// With 2FA enabled the password only opens a short-lived challenge;
+	// the session is created by CompleteTwoFactorLogin.
+	challenge, err := s.startTwoFactorChallenge(ctx, user.ID)
+	if err != nil || challenge != nil {
+		return nil, challenge, err
+	}
+
+	session, err := s.createLoginSession(ctx, user.ID, map[string]interface{}{"email": input.Email})
+	if err != nil {
+		return nil, nil, err
+	}
+	return session, nil, nil
+}
+
+// startTwoFactorChallenge opens a login challenge when the user has 2FA enabled.
+// It returns nil (no error) for users without 2FA.
+func (s *Service) startTwoFactorChallenge(ctx context.Context, userID string) (*TwoFactorChallenge, error) {
+	tf, err := s.twoFactor.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !tf.Enabled() {
+		return nil, nil
+	}
+	return s.twoFactor.CreateChallenge(ctx, userID, time.Now().Add(challengeTTL))
+}
+
+// createLoginSession creates a session for a fully authenticated user and logs the login.
+func (s *Service) createLoginSession(ctx context.Context, userID string, values map[string]interface{}) (*Session, error) {
 	// Create session
 	expiresAt := time.Now().Add(s.sessionTTL)
-	session, err := s.sessions.Create(ctx, user.ID, expiresAt)
+	meta := sessionMetadataFromContext(ctx)
+	session, err := s.sessions.Create(ctx, userID, expiresAt, meta)
 	if err != nil {
 		// Log failed login (session creation failed)
 		s.auditService.LogAsync(ctx, &audit.LogInput{
-			UserID:       audit.StringPtr(user.ID),
+			UserID:       audit.StringPtr(userID),
 			Action:       audit.ActionAuthLogin,
 			ResourceType: "auth",
 			Success:      false,
@@ -218,20 +310,26 @@ func (s *Service) Login(ctx context.Context, input LoginInput) (*Session, error)
 		return nil, err
 	}
 
+	newValues := map[string]interface{}{
+		"session_id": session.ID,
+		"expires_at": expiresAt,
+	}
+	for k, v := range values {
+		newValues[k] = v
+	}
+
 	// Log successful login
 	s.auditService.LogAsync(ctx, &audit.LogInput{
-		UserID:       audit.StringPtr(user.ID),
+		UserID:       audit.StringPtr(userID),
 		Action:       audit.ActionAuthLogin,
 		ResourceType: "auth",
 		ResourceID:   audit.StringPtr(session.ID),
 		Success:      true,
-		NewValues: map[string]interface{}{
-			"session_id": session.ID,
-			"email":      input.Email,
-			"expires_at": expiresAt,
-		},
+		NewValues:    newValues,
 	})
 
+	s.checkNewDevice(ctx, userID, session, meta)
+
 	return session, nil
 }
 
@@ -284,6 +382,9 @@ func (s *Service) GetUserBySession(ctx context.Context, sessionID string) (*User
 		return nil, ErrSessionExpired
 	}
 
+	// Best effort: failing to record activity must not reject the request
+	_ = s.sessions.Touch(ctx, session.ID)
+
 	return s.users.GetByID(ctx, session.UserID)
 }
 
@@ -374,6 +475,9 @@ func (s *Service) RequestPasswordReset(ctx context.Context, email string) (strin
 type ResetPasswordInput struct {
 	Token       string
 	NewPassword string
+	// TwoFactorCode is required when the user has 2FA enabled, so a compromised
+	// mailbox alone is not enough to take over the account.
+	TwoFactorCode string
 }
 
 // Validate validates the reset password input.
@@ -445,6 +549,19 @@ func (s *Service) ResetPassword(ctx context.Context, input ResetPasswordInput) e
 		return ErrTokenUsed
 	}
 
+	// Require the second factor; the reset token stays valid so the user can retry
+	if err := s.requireSecondFactor(ctx, reset.UserID, input.TwoFactorCode); err != nil {
+		s.auditService.LogAsync(ctx, &audit.LogInput{
+			UserID:       audit.StringPtr(reset.UserID),
+			Action:       audit.ActionAuthPasswordResetComplete,
+			ResourceType: "auth",
+			ResourceID:   audit.StringPtr(reset.ID),
+			Success:      false,
+			ErrorMessage: audit.StringPtr(err.Error()),
+		})
+		return err
+	}
+
 	// Hash new password
 	passwordHash, err := HashPassword(input.NewPassword)
 	if err != nil {
@@ -475,6 +592,11 @@ func (s *Service) ResetPassword(ctx context.Context, input ResetPasswordInput) e
 		return err
 	}
 
+	// A new password makes earlier failed attempts irrelevant; lift any lockout
+	if err := s.clearLoginFailures(ctx, reset.UserID); err != nil {
+		return err
+	}
+
 	// Log successful password reset
 	s.auditService.LogAsync(ctx, &audit.LogInput{
 		UserID:       audit.StringPtr(reset.UserID),
@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/blanquicet/conti/backend/internal/audit"
+)
+
+// newDeviceEmailTimeout bounds the background send of the new device alert.
+const newDeviceEmailTimeout = 10 * time.Second
+
+// SessionInfo describes an active session as shown to its owner.
+type SessionInfo struct {
+	ID         string    `json:"id"`
+	UserAgent  *string   `json:"user_agent,omitempty"`
+	IPAddress  *string   `json:"ip_address,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
+
+// sessionMetadataFromContext reads the client details stored by the audit middleware.
+func sessionMetadataFromContext(ctx context.Context) SessionMetadata {
+	ipAddress, userAgent := audit.RequestMetadataFromContext(ctx)
+	return SessionMetadata{UserAgent: userAgent, IPAddress: ipAddress}
+}
+
+// deviceFingerprint identifies a device by its user agent. IP addresses change
+// too often (mobile networks, VPNs) to be part of it.
+func deviceFingerprint(meta SessionMetadata) string {
+	if meta.UserAgent == nil {
+		return HashToken("")
+	}
+	return HashToken(*meta.UserAgent)
+}
+
+// checkNewDevice records the device a login came from and, when the user has
+// never been seen on it, audits the event and emails the user in the background.
+// Failures are never surfaced: the login itself already succeeded.
+func (s *Service) checkNewDevice(ctx context.Context, userID string, session *Session, meta SessionMetadata) {
+	isNew, err := s.sessions.RecordDevice(ctx, userID, deviceFingerprint(meta), meta)
+	if err != nil || !isNew {
+		return
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		UserID:       audit.StringPtr(userID),
+		Action:       audit.ActionAuthNewDeviceLogin,
+		ResourceType: "session",
+		ResourceID:   audit.StringPtr(session.ID),
+		Success:      true,
+		NewValues: map[string]interface{}{
+			"user_agent": meta.UserAgent,
+			"ip_address": meta.IPAddress,
+		},
+	})
+
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return
+	}
+
+	var userAgent, ipAddress string
+	if meta.UserAgent != nil {
+		userAgent = *meta.UserAgent
+	}
+	if meta.IPAddress != nil {
+		ipAddress = *meta.IPAddress
+	}
+
+	go func() {
+		sendCtx, cancel := context.WithTimeout(context.Background(), newDeviceEmailTimeout)
+		defer cancel()
+		_ = s.emailSender.SendNewDeviceLogin(sendCtx, user.Email, userAgent, ipAddress, session.CreatedAt)
+	}()
+}
+
+// ListSessions returns the user's active sessions. currentSessionID marks the
+// session making the request and may be empty (e.g. access token requests).
+func (s *Service) ListSessions(ctx context.Context, userID, currentSessionID string) ([]*SessionInfo, error) {
+	sessions, err := s.sessions.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]*SessionInfo, 0, len(sessions))
+	for _, session := range sessions {
+		infos = append(infos, &SessionInfo{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    session.ID == currentSessionID,
+		})
+	}
+	return infos, nil
+}
+
+// RevokeSession logs out one of the user's sessions.
+func (s *Service) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	if err := s.sessions.DeleteForUser(ctx, sessionID, userID); err != nil {
+		return err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		UserID:       audit.StringPtr(userID),
+		Action:       audit.ActionAuthSessionRevoked,
+		ResourceType: "session",
+		ResourceID:   audit.StringPtr(sessionID),
+		Success:      true,
+	})
+	return nil
+}
+
+// RevokeOtherSessions logs out every session of the user except the current one
+// and returns how many were revoked.
+func (s *Service) RevokeOtherSessions(ctx context.Context, userID, currentSessionID string) (int, error) {
+	revoked, err := s.sessions.DeleteOthers(ctx, userID, currentSessionID)
+	if err != nil {
+		return 0, err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		UserID:       audit.StringPtr(userID),
+		Action:       audit.ActionAuthSessionRevoked,
+		ResourceType: "session",
+		ResourceID:   audit.StringPtr(currentSessionID),
+		Success:      true,
+		Metadata: map[string]interface{}{
+			"scope":   "others",
+			"revoked": revoked,
+		},
+	})
+	return int(revoked), nil
+}
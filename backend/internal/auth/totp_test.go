@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 6238 test secret "12345678901234567890" in base32
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCodeRFCVectors(t *testing.T) {
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, tt := range tests {
+		got, err := totpCode(rfcSecret, totpStep(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("totpCode: %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("code at %d = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+	step := totpStep(now)
+
+	if _, ok := ValidateTOTP(rfcSecret, "081804", now, 0); !ok {
+		t.Fatal("expected current code to be valid")
+	}
+
+	// Previous step is accepted to tolerate clock drift
+	prev, _ := totpCode(rfcSecret, step-1)
+	if got, ok := ValidateTOTP(rfcSecret, prev, now, 0); !ok || got != step-1 {
+		t.Errorf("expected previous step code to be valid, got step %d ok %v", got, ok)
+	}
+
+	// A code whose step was already used is rejected
+	if _, ok := ValidateTOTP(rfcSecret, "081804", now, step); ok {
+		t.Error("expected replayed code to be rejected")
+	}
+
+	// Two steps away is outside the window
+	old, _ := totpCode(rfcSecret, step-2)
+	if _, ok := ValidateTOTP(rfcSecret, old, now, 0); ok {
+		t.Error("expected code outside the window to be rejected")
+	}
+
+	if _, ok := ValidateTOTP(rfcSecret, "12345", now, 0); ok {
+		t.Error("expected short code to be rejected")
+	}
+}
+
+func TestGenerateTOTPSecretRoundTrip(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	code, err := totpCode(secret, totpStep(now))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ValidateTOTP(secret, code, now, 0); !ok {
+		t.Error("expected generated secret to validate its own code")
+	}
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri := TOTPURI("Conti", "ana@example.com", rfcSecret)
+	if !strings.HasPrefix(uri, "otpauth://totp/Conti:ana@example.com?") {
+		t.Errorf("unexpected URI prefix: %s", uri)
+	}
+	for _, part := range []string{"secret=" + rfcSecret, "issuer=Conti", "digits=6", "period=30"} {
+		if !strings.Contains(uri, part) {
+			t.Errorf("URI %s missing %s", uri, part)
+		}
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(codes) != recoveryCodeCount || len(hashes) != recoveryCodeCount {
+		t.Fatalf("expected %d codes, got %d", recoveryCodeCount, len(codes))
+	}
+
+	seen := map[string]bool{}
+	for i, code := range codes {
+		if len(code) != 11 || code[5] != '-' {
+			t.Errorf("unexpected code format %q", code)
+		}
+		if seen[code] {
+			t.Errorf("duplicate code %q", code)
+		}
+		seen[code] = true
+
+		// Users may type codes in upper case or without the dash
+		typed := strings.ToUpper(strings.ReplaceAll(code, "-", " "))
+		if HashToken(normalizeRecoveryCode(typed)) != hashes[i] {
+			t.Errorf("normalized %q does not match stored hash", typed)
+		}
+	}
+}
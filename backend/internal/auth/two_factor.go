@@ -0,0 +1,319 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"strings"
+	"time"
+
+	"github.com/blanquicet/conti/backend/internal/audit"
+)
+
+const (
+	// twoFactorIssuer is shown as the account name in authenticator apps.
+	twoFactorIssuer = "Conti"
+	// challengeTTL is how long a user has to enter the second factor after the password.
+	challengeTTL = 5 * time.Minute
+	// maxChallengeAttempts is how many wrong codes end a pending login.
+	maxChallengeAttempts = 5
+	// recoveryCodeCount is how many recovery codes are issued at a time.
+	recoveryCodeCount = 10
+)
+
+// recoveryCodeAlphabet avoids characters that are easy to confuse (0/o, 1/l/i).
+const recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+// TwoFactorStatus describes a user's two-factor setup.
+type TwoFactorStatus struct {
+	Enabled                bool       `json:"enabled"`
+	EnabledAt              *time.Time `json:"enabled_at,omitempty"`
+	RecoveryCodesRemaining int        `json:"recovery_codes_remaining"`
+}
+
+// TwoFactorSetup is returned when enrolment starts.
+type TwoFactorSetup struct {
+	Secret     string `json:"secret"`
+	OtpauthURI string `json:"otpauth_uri"`
+}
+
+// GetTwoFactorStatus returns the user's two-factor status.
+func (s *Service) GetTwoFactorStatus(ctx context.Context, userID string) (*TwoFactorStatus, error) {
+	tf, err := s.twoFactor.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !tf.Enabled() {
+		return &TwoFactorStatus{}, nil
+	}
+
+	remaining, err := s.twoFactor.CountUnusedRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &TwoFactorStatus{
+		Enabled:                true,
+		EnabledAt:              tf.EnabledAt,
+		RecoveryCodesRemaining: remaining,
+	}, nil
+}
+
+// SetupTwoFactor starts enrolment by generating a new secret. 2FA is not active
+// until EnableTwoFactor confirms a code from the authenticator app.
+func (s *Service) SetupTwoFactor(ctx context.Context, userID string) (*TwoFactorSetup, error) {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.twoFactor.SavePending(ctx, userID, secret); err != nil {
+		return nil, err
+	}
+
+	return &TwoFactorSetup{
+		Secret:     secret,
+		OtpauthURI: TOTPURI(twoFactorIssuer, user.Email, secret),
+	}, nil
+}
+
+// EnableTwoFactor confirms enrolment with a code from the authenticator app and
+// returns the recovery codes. They are only shown this once.
+func (s *Service) EnableTwoFactor(ctx context.Context, userID, code string) ([]string, error) {
+	tf, err := s.twoFactor.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if tf == nil {
+		return nil, ErrTwoFactorNotSetUp
+	}
+	if tf.Enabled() {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	step, ok := ValidateTOTP(tf.Secret, code, time.Now(), tf.LastUsedStep)
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.twoFactor.Enable(ctx, userID, step, hashes); err != nil {
+		return nil, err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		UserID:       audit.StringPtr(userID),
+		Action:       audit.ActionAuthTwoFactorEnabled,
+		ResourceType: "two_factor",
+		ResourceID:   audit.StringPtr(userID),
+		Success:      true,
+	})
+
+	return codes, nil
+}
+
+// DisableTwoFactor turns 2FA off. It requires the password and a current code
+// (or a recovery code) so a stolen session alone cannot remove the second factor.
+func (s *Service) DisableTwoFactor(ctx context.Context, userID, password, code string) error {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	match, err := VerifyPassword(password, user.PasswordHash)
+	if err != nil {
+		return err
+	}
+	if !match {
+		return ErrInvalidCredentials
+	}
+
+	if err := s.verifySecondFactor(ctx, userID, code); err != nil {
+		return err
+	}
+
+	if err := s.twoFactor.Disable(ctx, userID); err != nil {
+		return err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		UserID:       audit.StringPtr(userID),
+		Action:       audit.ActionAuthTwoFactorDisabled,
+		ResourceType: "two_factor",
+		ResourceID:   audit.StringPtr(userID),
+		Success:      true,
+	})
+
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes after verifying a current code.
+func (s *Service) RegenerateRecoveryCodes(ctx context.Context, userID, code string) ([]string, error) {
+	if err := s.verifySecondFactor(ctx, userID, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.twoFactor.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		UserID:       audit.StringPtr(userID),
+		Action:       audit.ActionAuthRecoveryCodesRegenerated,
+		ResourceType: "two_factor",
+		ResourceID:   audit.StringPtr(userID),
+		Success:      true,
+	})
+
+	return codes, nil
+}
+
+// ListTwoFactorEvents returns the audit trail of the user's two-factor changes,
+// so account owners can spot changes they did not make.
+func (s *Service) ListTwoFactorEvents(ctx context.Context, userID string, limit, offset int) ([]*audit.AuditLog, int, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.auditService.Query(ctx, &audit.ListFilters{
+		UserID:       audit.StringPtr(userID),
+		ResourceType: audit.StringPtr("two_factor"),
+		Limit:        limit,
+		Offset:       offset,
+	})
+}
+
+// CompleteTwoFactorLogin finishes a login started by Login when the user has 2FA
+// enabled. The code can be a TOTP code or a recovery code.
+func (s *Service) CompleteTwoFactorLogin(ctx context.Context, challengeID, code string) (*Session, error) {
+	challenge, err := s.twoFactor.GetChallenge(ctx, challengeID)
+	if err != nil {
+		return nil, err
+	}
+	if challenge == nil || challenge.Attempts >= maxChallengeAttempts {
+		return nil, ErrChallengeExpired
+	}
+
+	if err := s.verifySecondFactor(ctx, challenge.UserID, code); err != nil {
+		if err == ErrInvalidTwoFactorCode {
+			if incErr := s.twoFactor.IncrementChallengeAttempts(ctx, challenge.ID); incErr != nil {
+				return nil, incErr
+			}
+			s.auditService.LogAsync(ctx, &audit.LogInput{
+				UserID:       audit.StringPtr(challenge.UserID),
+				Action:       audit.ActionAuthLogin,
+				ResourceType: "auth",
+				Success:      false,
+				ErrorMessage: audit.StringPtr("invalid two-factor code"),
+			})
+		}
+		return nil, err
+	}
+
+	// A challenge can only be completed once
+	if err := s.twoFactor.DeleteChallenge(ctx, challenge.ID); err != nil {
+		return nil, err
+	}
+
+	return s.createLoginSession(ctx, challenge.UserID, map[string]interface{}{"two_factor": true})
+}
+
+// requireSecondFactor checks a code when the user has 2FA enabled. Users without
+// 2FA pass. An empty code for a 2FA user returns ErrTwoFactorRequired.
+func (s *Service) requireSecondFactor(ctx context.Context, userID, code string) error {
+	tf, err := s.twoFactor.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !tf.Enabled() {
+		return nil
+	}
+	if strings.TrimSpace(code) == "" {
+		return ErrTwoFactorRequired
+	}
+	return s.verifySecondFactor(ctx, userID, code)
+}
+
+// verifySecondFactor accepts a current TOTP code or an unused recovery code.
+func (s *Service) verifySecondFactor(ctx context.Context, userID, code string) error {
+	tf, err := s.twoFactor.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !tf.Enabled() {
+		return ErrTwoFactorNotEnabled
+	}
+
+	if step, ok := ValidateTOTP(tf.Secret, code, time.Now(), tf.LastUsedStep); ok {
+		used, err := s.twoFactor.UseStep(ctx, userID, step)
+		if err != nil {
+			return err
+		}
+		if !used {
+			return ErrInvalidTwoFactorCode
+		}
+		return nil
+	}
+
+	used, err := s.twoFactor.UseRecoveryCode(ctx, userID, HashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidTwoFactorCode
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		UserID:       audit.StringPtr(userID),
+		Action:       audit.ActionAuthRecoveryCodeUsed,
+		ResourceType: "two_factor",
+		ResourceID:   audit.StringPtr(userID),
+		Success:      true,
+	})
+	return nil
+}
+
+// generateRecoveryCodes returns plain codes (xxxxx-xxxxx) and their hashes.
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	buf := make([]byte, 10)
+	for i := range codes {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+		var sb strings.Builder
+		for j, b := range buf {
+			if j == 5 {
+				sb.WriteByte('-')
+			}
+			sb.WriteByte(recoveryCodeAlphabet[int(b)%len(recoveryCodeAlphabet)])
+		}
+		codes[i] = sb.String()
+		hashes[i] = HashToken(normalizeRecoveryCode(codes[i]))
+	}
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode makes codes match regardless of case, spaces and dashes.
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, code)
+}
@@ -7,14 +7,43 @@ import (
 )
 
 var (
-	ErrUserNotFound       = errors.New("user not found")
-	ErrUserExists         = errors.New("user already exists")
-	ErrInvalidCredentials = errors.New("invalid credentials")
-	ErrSessionExpired     = errors.New("session expired")
-	ErrTokenExpired       = errors.New("token expired")
-	ErrTokenUsed          = errors.New("token already used")
+	ErrUserNotFound        = errors.New("user not found")
+	ErrUserExists          = errors.New("user already exists")
+	ErrInvalidCredentials  = errors.New("invalid credentials")
+	ErrSessionExpired      = errors.New("session expired")
+	ErrSessionNotFound     = errors.New("session not found")
+	ErrTokenExpired        = errors.New("token expired")
+	ErrTokenUsed           = errors.New("token already used")
+	ErrTokenNotFound       = errors.New("access token not found")
+	ErrTokenRevoked        = errors.New("access token revoked")
+	ErrTokenScope          = errors.New("access token scope does not allow this request")
+	ErrTooManyAccessTokens = errors.New("maximum number of access tokens reached (20)")
+
+	ErrTwoFactorRequired       = errors.New("two-factor code required")
+	ErrInvalidTwoFactorCode    = errors.New("invalid two-factor code")
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication already enabled")
+	ErrTwoFactorNotEnabled     = errors.New("two-factor authentication not enabled")
+	ErrTwoFactorNotSetUp       = errors.New("two-factor enrolment not started")
+	ErrChallengeExpired        = errors.New("login challenge expired or invalid")
+
+	ErrEmailNotVerified     = errors.New("email not verified")
+	ErrEmailAlreadyVerified = errors.New("email already verified")
+	ErrMagicLinkDisabled    = errors.New("magic link login is disabled")
+
+	ErrLoginThrottled = errors.New("too many failed login attempts, try again later")
+	ErrAccountLocked  = errors.New("account temporarily locked")
 )
 
+// LoginBlockedError is returned by Login while an account is throttled
+// (ErrLoginThrottled) or locked (ErrAccountLocked) after failed attempts.
+type LoginBlockedError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *LoginBlockedError) Error() string { return e.Err.Error() }
+func (e *LoginBlockedError) Unwrap() error { return e.Err }
+
 // User represents an authenticated user.
 type User struct {
 	ID                    string
@@ -22,28 +51,122 @@ type User struct {
 	Name                  string
 	PasswordHash          string
 	OnboardingCompletedAt *time.Time
+	EmailVerifiedAt       *time.Time
 	CreatedAt             time.Time
 	UpdatedAt             time.Time
 }
 
+// IsEmailVerified reports whether the user has proven they own their email.
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
 // Session represents an authentication session.
 type Session struct {
+	ID         string
+	UserID     string
+	UserAgent  *string
+	IPAddress  *string
+	ExpiresAt  time.Time
+	LastSeenAt time.Time
+	CreatedAt  time.Time
+}
+
+// SessionMetadata describes the client a session was started from.
+type SessionMetadata struct {
+	UserAgent *string
+	IPAddress *string
+}
+
+// PasswordReset represents a password reset request.
+type PasswordReset struct {
 	ID        string
 	UserID    string
+	TokenHash string
 	ExpiresAt time.Time
+	UsedAt    *time.Time
 	CreatedAt time.Time
 }
 
-// PasswordReset represents a password reset request.
-type PasswordReset struct {
+// EmailTokenPurpose tells what a single-use email token can be exchanged for.
+type EmailTokenPurpose string
+
+const (
+	EmailTokenVerifyEmail EmailTokenPurpose = "VERIFY_EMAIL"
+	EmailTokenMagicLink   EmailTokenPurpose = "MAGIC_LINK"
+	EmailTokenUnlock      EmailTokenPurpose = "UNLOCK_ACCOUNT"
+)
+
+// EmailToken is a single-use token sent to the user's email address.
+type EmailToken struct {
 	ID        string
 	UserID    string
+	Purpose   EmailTokenPurpose
 	TokenHash string
 	ExpiresAt time.Time
 	UsedAt    *time.Time
 	CreatedAt time.Time
 }
 
+// LoginFailures tracks recent failed password logins for one account.
+type LoginFailures struct {
+	UserID        string
+	FailedCount   int
+	FirstFailedAt time.Time
+	LastFailedAt  time.Time
+	LockedUntil   *time.Time
+}
+
+// AccessTokenScope controls which HTTP methods a personal access token may use.
+type AccessTokenScope string
+
+const (
+	// ScopeRead allows GET and HEAD requests only.
+	ScopeRead AccessTokenScope = "read"
+	// ScopeReadWrite allows every method.
+	ScopeReadWrite AccessTokenScope = "read_write"
+)
+
+// AccessToken represents a personal access token used to script the API.
+type AccessToken struct {
+	ID            string           `json:"id"`
+	UserID        string           `json:"-"`
+	Name          string           `json:"name"`
+	TokenHash     string           `json:"-"`
+	TokenPrefix   string           `json:"token_prefix"`
+	Scope         AccessTokenScope `json:"scope"`
+	ResourceTypes []string         `json:"resource_types"` // Empty means every resource type
+	ExpiresAt     *time.Time       `json:"expires_at,omitempty"`
+	LastUsedAt    *time.Time       `json:"last_used_at,omitempty"`
+	RevokedAt     *time.Time       `json:"revoked_at,omitempty"`
+	CreatedAt     time.Time        `json:"created_at"`
+}
+
+// TwoFactor holds a user's TOTP enrolment. EnabledAt is nil until the user
+// confirms the enrolment with a valid code.
+type TwoFactor struct {
+	UserID       string
+	Secret       string
+	EnabledAt    *time.Time
+	LastUsedStep int64
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Enabled reports whether the enrolment has been confirmed.
+func (t *TwoFactor) Enabled() bool {
+	return t != nil && t.EnabledAt != nil
+}
+
+// TwoFactorChallenge is a pending login waiting for the second factor.
+type TwoFactorChallenge struct {
+	ID        string    `json:"challenge_id"`
+	UserID    string    `json:"-"`
+	Attempts  int       `json:"-"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"-"`
+}
+
 // UserRepository defines the interface for user persistence.
 type UserRepository interface {
 	Create(ctx context.Context, email, name, passwordHash string) (*User, error)
@@ -51,16 +174,28 @@ type UserRepository interface {
 	GetByEmail(ctx context.Context, email string) (*User, error)
 	UpdatePassword(ctx context.Context, id, passwordHash string) error
 	CompleteOnboarding(ctx context.Context, id string) error
+	MarkEmailVerified(ctx context.Context, id string) error
 	Delete(ctx context.Context, id string) error
 }
 
 // SessionRepository defines the interface for session persistence.
 type SessionRepository interface {
-	Create(ctx context.Context, userID string, expiresAt time.Time) (*Session, error)
+	Create(ctx context.Context, userID string, expiresAt time.Time, meta SessionMetadata) (*Session, error)
 	Get(ctx context.Context, id string) (*Session, error)
+	// ListByUserID returns the user's unexpired sessions, most recently seen first.
+	ListByUserID(ctx context.Context, userID string) ([]*Session, error)
+	// Touch updates last_seen_at, at most once a minute per session.
+	Touch(ctx context.Context, id string) error
 	Delete(ctx context.Context, id string) error
+	// DeleteForUser deletes one of the user's sessions, or returns ErrSessionNotFound.
+	DeleteForUser(ctx context.Context, id, userID string) error
+	// DeleteOthers deletes every session of the user except keepID.
+	DeleteOthers(ctx context.Context, userID, keepID string) (int64, error)
 	DeleteByUserID(ctx context.Context, userID string) error
 	DeleteExpired(ctx context.Context) error
+	// RecordDevice remembers the device a session was started from. It returns
+	// true the first time the user is seen on that device.
+	RecordDevice(ctx context.Context, userID, fingerprint string, meta SessionMetadata) (bool, error)
 }
 
 // PasswordResetRepository defines the interface for password reset persistence.
@@ -70,3 +205,58 @@ type PasswordResetRepository interface {
 	MarkUsed(ctx context.Context, id string) error
 	DeleteExpired(ctx context.Context) error
 }
+
+// EmailTokenRepository defines the interface for email token persistence.
+type EmailTokenRepository interface {
+	Create(ctx context.Context, userID string, purpose EmailTokenPurpose, tokenHash string, expiresAt time.Time) (*EmailToken, error)
+	// GetByTokenHash returns nil (no error) when no token of that purpose matches.
+	GetByTokenHash(ctx context.Context, purpose EmailTokenPurpose, tokenHash string) (*EmailToken, error)
+	// MarkUsed consumes the token. It returns false if it was already used.
+	MarkUsed(ctx context.Context, id string) (bool, error)
+	DeleteExpired(ctx context.Context) error
+}
+
+// LoginFailureRepository defines the interface for failed login tracking.
+type LoginFailureRepository interface {
+	// Get returns nil (no error) when the account has no recorded failures.
+	Get(ctx context.Context, userID string) (*LoginFailures, error)
+	// RecordFailure counts a failure, starting a new window once the previous
+	// one has passed and the account is not locked.
+	RecordFailure(ctx context.Context, userID string, window time.Duration) (*LoginFailures, error)
+	Lock(ctx context.Context, userID string, until time.Time) error
+	Reset(ctx context.Context, userID string) error
+}
+
+// AccessTokenRepository defines the interface for personal access token persistence.
+type AccessTokenRepository interface {
+	Create(ctx context.Context, token *AccessToken) (*AccessToken, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*AccessToken, error)
+	ListByUserID(ctx context.Context, userID string) ([]*AccessToken, error)
+	Revoke(ctx context.Context, id, userID string) error
+	TouchLastUsed(ctx context.Context, id string) error
+}
+
+// TwoFactorRepository defines the interface for two-factor authentication persistence.
+type TwoFactorRepository interface {
+	// Get returns the user's enrolment, or nil if there is none.
+	Get(ctx context.Context, userID string) (*TwoFactor, error)
+	// SavePending stores a new, unconfirmed secret, replacing any previous one.
+	SavePending(ctx context.Context, userID, secret string) (*TwoFactor, error)
+	// Enable confirms the enrolment and replaces the recovery codes in one transaction.
+	Enable(ctx context.Context, userID string, step int64, recoveryCodeHashes []string) error
+	// Disable removes the enrolment and its recovery codes.
+	Disable(ctx context.Context, userID string) error
+	// UseStep records an accepted time step; it returns false if the step was already used.
+	UseStep(ctx context.Context, userID string, step int64) (bool, error)
+	ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error
+	// UseRecoveryCode marks an unused code as used; it returns false if none matched.
+	UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error)
+	CountUnusedRecoveryCodes(ctx context.Context, userID string) (int, error)
+
+	CreateChallenge(ctx context.Context, userID string, expiresAt time.Time) (*TwoFactorChallenge, error)
+	// GetChallenge returns an unexpired challenge, or nil.
+	GetChallenge(ctx context.Context, id string) (*TwoFactorChallenge, error)
+	IncrementChallengeAttempts(ctx context.Context, id string) error
+	DeleteChallenge(ctx context.Context, id string) error
+	DeleteExpiredChallenges(ctx context.Context) error
+}
@@ -0,0 +1,411 @@
+package budgets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/blanquicet/conti/backend/internal/audit"
+	"github.com/blanquicet/conti/backend/internal/categories"
+	"github.com/blanquicet/conti/backend/internal/households"
+	"github.com/blanquicet/conti/backend/internal/notifications"
+	"github.com/blanquicet/conti/backend/internal/webhooks"
+)
+
+// DefaultAlertThresholds are used when neither the household nor the category sets thresholds
+var DefaultAlertThresholds = []int{80, 100}
+
+// ErrInvalidThresholds is returned for an empty, too long or out of range threshold list
+var ErrInvalidThresholds = errors.New("thresholds must be 1 to 5 percentages between 1 and 500")
+
+// AlertEmailSender is the part of email.Sender used for budget alerts
+type AlertEmailSender interface {
+	SendBudgetAlert(ctx context.Context, to, categoryName, month string, threshold int, spent, budget float64) error
+}
+
+// BudgetAlert is an alert sent when a category's spending crossed a threshold
+type BudgetAlert struct {
+	ID             string    `json:"id"`
+	CategoryID     string    `json:"category_id"`
+	CategoryName   string    `json:"category_name,omitempty"`
+	Month          time.Time `json:"month"`
+	Threshold      int       `json:"threshold"` // Percentage of the budget
+	Budget         float64   `json:"budget"`
+	Spent          float64   `json:"spent"`
+	RecipientCount int       `json:"recipient_count"` // Members not muting the category
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// AlertSettings is the alert configuration of a household as seen by one member
+type AlertSettings struct {
+	Thresholds []int                    `json:"thresholds"` // Household default
+	Categories []*CategoryAlertSettings `json:"categories"` // Categories with their own thresholds or muted by the member
+}
+
+// CategoryAlertSettings is the alert configuration of one category
+type CategoryAlertSettings struct {
+	CategoryID   string `json:"category_id"`
+	CategoryName string `json:"category_name"`
+	Thresholds   []int  `json:"thresholds,omitempty"` // Empty means the household default
+	Muted        bool   `json:"muted"`                // Muted by the requesting member
+}
+
+// SetAlertThresholdsInput represents input for setting alert thresholds.
+// For a category, null thresholds go back to the household default.
+type SetAlertThresholdsInput struct {
+	Thresholds []int `json:"thresholds"`
+}
+
+// Validate validates the thresholds and sorts them, dropping duplicates
+func (i *SetAlertThresholdsInput) Validate() error {
+	if len(i.Thresholds) == 0 || len(i.Thresholds) > 5 {
+		return ErrInvalidThresholds
+	}
+	seen := make(map[int]bool)
+	var thresholds []int
+	for _, t := range i.Thresholds {
+		if t < 1 || t > 500 {
+			return ErrInvalidThresholds
+		}
+		if !seen[t] {
+			seen[t] = true
+			thresholds = append(thresholds, t)
+		}
+	}
+	sort.Ints(thresholds)
+	i.Thresholds = thresholds
+	return nil
+}
+
+// crossedThresholds returns the thresholds a spending percentage has reached
+func crossedThresholds(thresholds []int, percentage float64) []int {
+	var crossed []int
+	for _, t := range thresholds {
+		if percentage >= float64(t) {
+			crossed = append(crossed, t)
+		}
+	}
+	return crossed
+}
+
+// SetAlertEmailSender sets the sender used to email budget alerts
+func (s *BudgetService) SetAlertEmailSender(sender AlertEmailSender) {
+	s.alertEmails = sender
+}
+
+// CheckAlerts re-evaluates a category's budget for the month after a movement
+// changed. Every alert threshold newly crossed is recorded and sent once per
+// month to the members not muting the category, in-app and by email. The
+// budget exceeded webhook fires when spending passes the whole budget.
+// When the month is in the past and the category rolls over, the change also
+// moves what is carried into the current month, so that month is checked too.
+func (s *BudgetService) CheckAlerts(ctx context.Context, householdID, categoryID, month string) error {
+	if s.notifier == nil && s.webhooks == nil && s.alertEmails == nil {
+		return nil
+	}
+
+	if err := s.checkMonthAlerts(ctx, householdID, categoryID, month); err != nil {
+		return err
+	}
+
+	currentMonth := time.Now().Format("2006-01")
+	if month >= currentMonth {
+		return nil
+	}
+	chain, err := s.repo.GetRolloverMonths(ctx, householdID, currentMonth)
+	if err != nil {
+		return err
+	}
+	if !inRolloverChain(chain, categoryID, month) {
+		return nil
+	}
+	return s.checkMonthAlerts(ctx, householdID, categoryID, currentMonth)
+}
+
+// inRolloverChain reports whether a category's remainder chain includes month
+func inRolloverChain(chain []*RolloverMonth, categoryID, month string) bool {
+	for _, m := range chain {
+		if m.CategoryID == categoryID && m.Month.Format("2006-01") == month {
+			return true
+		}
+	}
+	return false
+}
+
+// checkMonthAlerts sends the alerts for the thresholds a category newly crossed in a month
+func (s *BudgetService) checkMonthAlerts(ctx context.Context, householdID, categoryID, month string) error {
+	budget, err := s.repo.GetEffectiveBudget(ctx, householdID, categoryID, month)
+	if err != nil {
+		return err
+	}
+	carried, err := s.carriedIn(ctx, householdID, month)
+	if err != nil {
+		return err
+	}
+	budget += carried[categoryID]
+	if budget <= 0 {
+		return nil
+	}
+
+	spent, err := s.repo.GetSpentForCategory(ctx, householdID, categoryID, month)
+	if err != nil {
+		return err
+	}
+	percentage := (spent / budget) * 100
+
+	thresholds, err := s.repo.GetAlertThresholds(ctx, householdID, categoryID)
+	if err != nil {
+		return err
+	}
+	crossed := crossedThresholds(thresholds, percentage)
+	exceeded := CalculateBudgetStatus(percentage) == "exceeded"
+	if len(crossed) == 0 && !exceeded {
+		return nil
+	}
+
+	category, err := s.categoryRepo.GetByID(ctx, categoryID)
+	if err != nil {
+		return err
+	}
+
+	if exceeded && s.webhooks != nil {
+		dedupKey := fmt.Sprintf("budget_exceeded:%s:%s", categoryID, month)
+		s.webhooks.Dispatch(ctx, &webhooks.Event{
+			HouseholdID: householdID,
+			Type:        webhooks.EventBudgetExceeded,
+			Data: map[string]interface{}{
+				"category_id":   categoryID,
+				"category_name": category.Name,
+				"month":         month,
+				"budget":        budget,
+				"spent":         spent,
+			},
+			DedupKey: &dedupKey,
+		})
+	}
+
+	if len(crossed) == 0 {
+		return nil
+	}
+
+	recipients, err := s.alertRecipients(ctx, householdID, categoryID)
+	if err != nil {
+		return err
+	}
+
+	monthDate, _ := ParseMonth(month)
+	for _, threshold := range crossed {
+		alert := &BudgetAlert{
+			CategoryID:     categoryID,
+			CategoryName:   category.Name,
+			Month:          monthDate,
+			Threshold:      threshold,
+			Budget:         budget,
+			Spent:          spent,
+			RecipientCount: len(recipients),
+		}
+		recorded, err := s.repo.RecordAlert(ctx, alert)
+		if err != nil {
+			return err
+		}
+		if recorded {
+			s.sendAlert(ctx, householdID, month, alert, recipients)
+		}
+	}
+
+	return nil
+}
+
+// alertRecipients returns the household members who have not muted the category
+func (s *BudgetService) alertRecipients(ctx context.Context, householdID, categoryID string) ([]*households.HouseholdMember, error) {
+	members, err := s.householdRepo.GetMembers(ctx, householdID)
+	if err != nil {
+		return nil, err
+	}
+	mutedIDs, err := s.repo.GetMutedUserIDs(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	muted := make(map[string]bool, len(mutedIDs))
+	for _, id := range mutedIDs {
+		muted[id] = true
+	}
+
+	var recipients []*households.HouseholdMember
+	for _, m := range members {
+		if !muted[m.UserID] {
+			recipients = append(recipients, m)
+		}
+	}
+	return recipients, nil
+}
+
+// sendAlert delivers a recorded alert in-app and by email
+func (s *BudgetService) sendAlert(ctx context.Context, householdID, month string, alert *BudgetAlert, recipients []*households.HouseholdMember) {
+	if len(recipients) == 0 {
+		return
+	}
+
+	if s.notifier != nil {
+		userIDs := make([]string, len(recipients))
+		for i, m := range recipients {
+			userIDs[i] = m.UserID
+		}
+
+		notificationType := notifications.TypeBudgetAlert
+		title := fmt.Sprintf("Presupuesto al %d%%: %s", alert.Threshold, alert.CategoryName)
+		if alert.Threshold >= 100 {
+			notificationType = notifications.TypeBudgetExceeded
+			title = fmt.Sprintf("Presupuesto excedido: %s", alert.CategoryName)
+		}
+		dedupKey := fmt.Sprintf("budget_alert:%s:%s:%d", alert.CategoryID, month, alert.Threshold)
+
+		s.notifier.Publish(ctx, &notifications.PublishInput{
+			UserIDs:      userIDs,
+			HouseholdID:  &householdID,
+			Type:         notificationType,
+			Title:        title,
+			Body:         notifications.StringPtr(fmt.Sprintf("Gastado %.0f de %.0f en %s", alert.Spent, alert.Budget, month)),
+			ResourceType: notifications.StringPtr("category"),
+			ResourceID:   &alert.CategoryID,
+			Data: map[string]interface{}{
+				"category_id": alert.CategoryID,
+				"month":       month,
+				"threshold":   alert.Threshold,
+				"budget":      alert.Budget,
+				"spent":       alert.Spent,
+			},
+			DedupKey: &dedupKey,
+		})
+	}
+
+	if s.alertEmails != nil {
+		// Email delivery must not hold up the movement that triggered the alert
+		emailCtx := context.WithoutCancel(ctx)
+		go func() {
+			for _, m := range recipients {
+				if m.UserEmail == "" {
+					continue
+				}
+				_ = s.alertEmails.SendBudgetAlert(emailCtx, m.UserEmail, alert.CategoryName, month, alert.Threshold, alert.Spent, alert.Budget)
+			}
+		}()
+	}
+}
+
+// ListAlerts returns the alerts sent in the household for a month
+func (s *BudgetService) ListAlerts(ctx context.Context, userID, month string) ([]*BudgetAlert, error) {
+	if _, err := ParseMonth(month); err != nil {
+		return nil, ErrInvalidMonth
+	}
+
+	householdID, err := s.getUserHouseholdID(ctx, userID, households.PermView)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListAlerts(ctx, householdID, month)
+}
+
+// GetAlertSettings returns the household's alert thresholds and the categories
+// with their own thresholds or muted by the user
+func (s *BudgetService) GetAlertSettings(ctx context.Context, userID string) (*AlertSettings, error) {
+	householdID, err := s.getUserHouseholdID(ctx, userID, households.PermView)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetAlertSettings(ctx, householdID, userID)
+}
+
+// SetHouseholdAlertThresholds sets the default alert thresholds of the household
+func (s *BudgetService) SetHouseholdAlertThresholds(ctx context.Context, userID string, input *SetAlertThresholdsInput) (*AlertSettings, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	householdID, err := s.getUserHouseholdID(ctx, userID, households.PermEditBudgets)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SetHouseholdAlertThresholds(ctx, householdID, input.Thresholds); err != nil {
+		return nil, err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		Action:       audit.ActionBudgetUpdated,
+		ResourceType: "household_budget_settings",
+		ResourceID:   audit.StringPtr(householdID),
+		UserID:       audit.StringPtr(userID),
+		HouseholdID:  audit.StringPtr(householdID),
+		Success:      true,
+		NewValues:    map[string]interface{}{"alert_thresholds": input.Thresholds},
+	})
+
+	return s.repo.GetAlertSettings(ctx, householdID, userID)
+}
+
+// SetCategoryAlertThresholds sets a category's own alert thresholds, or goes
+// back to the household default when the thresholds are null
+func (s *BudgetService) SetCategoryAlertThresholds(ctx context.Context, userID, categoryID string, input *SetAlertThresholdsInput) (*AlertSettings, error) {
+	if input.Thresholds != nil {
+		if err := input.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	householdID, err := s.getUserHouseholdID(ctx, userID, households.PermEditBudgets)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkCategory(ctx, householdID, categoryID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SetCategoryAlertThresholds(ctx, categoryID, input.Thresholds); err != nil {
+		return nil, err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		Action:       audit.ActionBudgetUpdated,
+		ResourceType: "category_budget_settings",
+		ResourceID:   audit.StringPtr(categoryID),
+		UserID:       audit.StringPtr(userID),
+		HouseholdID:  audit.StringPtr(householdID),
+		Success:      true,
+		NewValues:    map[string]interface{}{"alert_thresholds": input.Thresholds},
+	})
+
+	return s.repo.GetAlertSettings(ctx, householdID, userID)
+}
+
+// SetAlertMute mutes or unmutes a category's alerts for the user
+func (s *BudgetService) SetAlertMute(ctx context.Context, userID, categoryID string, muted bool) error {
+	householdID, err := s.getUserHouseholdID(ctx, userID, households.PermView)
+	if err != nil {
+		return err
+	}
+	if err := s.checkCategory(ctx, householdID, categoryID); err != nil {
+		return err
+	}
+
+	return s.repo.SetAlertMute(ctx, userID, categoryID, muted)
+}
+
+// checkCategory verifies the category exists and belongs to the household
+func (s *BudgetService) checkCategory(ctx context.Context, householdID, categoryID string) error {
+	category, err := s.categoryRepo.GetByID(ctx, categoryID)
+	if err != nil {
+		if err == categories.ErrCategoryNotFound {
+			return ErrCategoryNotFound
+		}
+		return err
+	}
+	if category.HouseholdID != householdID {
+		return ErrNotAuthorized
+	}
+	return nil
+}
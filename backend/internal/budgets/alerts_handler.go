@@ -0,0 +1,159 @@
+package budgets
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ListAlerts handles GET /budgets/alerts?month=YYYY-MM
+func (h *Handler) ListAlerts(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserFromSession(r)
+	if err != nil {
+		h.logger.Error("failed to get user from session", "error", err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	month := r.URL.Query().Get("month")
+	alerts, err := h.service.ListAlerts(r.Context(), user.ID, month)
+	if err != nil {
+		h.logger.Error("failed to list budget alerts", "error", err, "user_id", user.ID, "month", month)
+		h.writeAlertError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"month":  month,
+		"alerts": alerts,
+	})
+}
+
+// GetAlertSettings handles GET /budgets/alerts/settings
+func (h *Handler) GetAlertSettings(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserFromSession(r)
+	if err != nil {
+		h.logger.Error("failed to get user from session", "error", err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	settings, err := h.service.GetAlertSettings(r.Context(), user.ID)
+	if err != nil {
+		h.logger.Error("failed to get budget alert settings", "error", err, "user_id", user.ID)
+		h.writeAlertError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(settings)
+}
+
+// SetHouseholdAlertThresholds handles PUT /budgets/alerts/settings
+func (h *Handler) SetHouseholdAlertThresholds(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserFromSession(r)
+	if err != nil {
+		h.logger.Error("failed to get user from session", "error", err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var input SetAlertThresholdsInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error("failed to decode request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	settings, err := h.service.SetHouseholdAlertThresholds(r.Context(), user.ID, &input)
+	if err != nil {
+		h.logger.Error("failed to set household alert thresholds", "error", err, "user_id", user.ID)
+		h.writeAlertError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(settings)
+}
+
+// SetCategoryAlertThresholds handles PUT /budgets/alerts/categories/{category_id}
+func (h *Handler) SetCategoryAlertThresholds(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserFromSession(r)
+	if err != nil {
+		h.logger.Error("failed to get user from session", "error", err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	categoryID := r.PathValue("category_id")
+	if categoryID == "" {
+		http.Error(w, "category ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var input SetAlertThresholdsInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error("failed to decode request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	settings, err := h.service.SetCategoryAlertThresholds(r.Context(), user.ID, categoryID, &input)
+	if err != nil {
+		h.logger.Error("failed to set category alert thresholds", "error", err, "user_id", user.ID, "category_id", categoryID)
+		h.writeAlertError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(settings)
+}
+
+// MuteCategoryAlerts handles PUT /budgets/alerts/categories/{category_id}/mute
+func (h *Handler) MuteCategoryAlerts(w http.ResponseWriter, r *http.Request) {
+	h.setAlertMute(w, r, true)
+}
+
+// UnmuteCategoryAlerts handles DELETE /budgets/alerts/categories/{category_id}/mute
+func (h *Handler) UnmuteCategoryAlerts(w http.ResponseWriter, r *http.Request) {
+	h.setAlertMute(w, r, false)
+}
+
+func (h *Handler) setAlertMute(w http.ResponseWriter, r *http.Request, muted bool) {
+	user, err := h.getUserFromSession(r)
+	if err != nil {
+		h.logger.Error("failed to get user from session", "error", err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	categoryID := r.PathValue("category_id")
+	if categoryID == "" {
+		http.Error(w, "category ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.SetAlertMute(r.Context(), user.ID, categoryID, muted); err != nil {
+		h.logger.Error("failed to set budget alert mute", "error", err, "user_id", user.ID, "category_id", categoryID)
+		h.writeAlertError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeAlertError maps budget alert errors to HTTP responses
+func (h *Handler) writeAlertError(w http.ResponseWriter, err error) {
+	switch err {
+	case ErrInvalidMonth, ErrInvalidThresholds:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case ErrCategoryNotFound:
+		http.Error(w, "category not found", http.StatusNotFound)
+	case ErrNoHousehold:
+		http.Error(w, "user has no household", http.StatusNotFound)
+	case ErrNotAuthorized:
+		http.Error(w, "forbidden: your role cannot edit budgets", http.StatusForbidden)
+	default:
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,181 @@
+package budgets
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetAlertThresholds returns the category's alert thresholds, falling back to
+// the household default and then to DefaultAlertThresholds
+func (r *PostgresRepository) GetAlertThresholds(ctx context.Context, householdID, categoryID string) ([]int, error) {
+	var thresholds []int
+	err := r.pool.QueryRow(ctx, `
+		SELECT COALESCE(cbs.alert_thresholds, hbs.alert_thresholds, $3::int[])
+		FROM (SELECT 1) AS dummy
+		LEFT JOIN category_budget_settings cbs ON cbs.category_id = $2
+		LEFT JOIN household_budget_settings hbs ON hbs.household_id = $1
+	`, householdID, categoryID, DefaultAlertThresholds).Scan(&thresholds)
+	if err != nil {
+		return nil, err
+	}
+	return thresholds, nil
+}
+
+// RecordAlert stores an alert unless the same threshold already fired for the
+// category and month
+func (r *PostgresRepository) RecordAlert(ctx context.Context, alert *BudgetAlert) (bool, error) {
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO budget_alerts (category_id, month, threshold, budget, spent, recipient_count)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (category_id, month, threshold) DO NOTHING
+		RETURNING id, created_at
+	`, alert.CategoryID, alert.Month, alert.Threshold, alert.Budget, alert.Spent, alert.RecipientCount).Scan(
+		&alert.ID,
+		&alert.CreatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ListAlerts returns the alerts sent in a household for a month, newest first
+func (r *PostgresRepository) ListAlerts(ctx context.Context, householdID, month string) ([]*BudgetAlert, error) {
+	monthDate, err := ParseMonth(month)
+	if err != nil {
+		return nil, ErrInvalidMonth
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT ba.id, ba.category_id, c.name, ba.month, ba.threshold, ba.budget, ba.spent, ba.recipient_count, ba.created_at
+		FROM budget_alerts ba
+		JOIN categories c ON c.id = ba.category_id
+		WHERE c.household_id = $1 AND ba.month = $2
+		ORDER BY ba.created_at DESC
+	`, householdID, monthDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	alerts := []*BudgetAlert{}
+	for rows.Next() {
+		var a BudgetAlert
+		if err := rows.Scan(
+			&a.ID,
+			&a.CategoryID,
+			&a.CategoryName,
+			&a.Month,
+			&a.Threshold,
+			&a.Budget,
+			&a.Spent,
+			&a.RecipientCount,
+			&a.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, &a)
+	}
+	return alerts, rows.Err()
+}
+
+// GetAlertSettings returns the household thresholds and the categories that
+// have their own thresholds or are muted by the user
+func (r *PostgresRepository) GetAlertSettings(ctx context.Context, householdID, userID string) (*AlertSettings, error) {
+	settings := &AlertSettings{Categories: []*CategoryAlertSettings{}}
+	err := r.pool.QueryRow(ctx, `
+		SELECT COALESCE(
+			(SELECT alert_thresholds FROM household_budget_settings WHERE household_id = $1),
+			$2::int[]
+		)
+	`, householdID, DefaultAlertThresholds).Scan(&settings.Thresholds)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT c.id, c.name, cbs.alert_thresholds, m.user_id IS NOT NULL
+		FROM categories c
+		LEFT JOIN category_budget_settings cbs ON cbs.category_id = c.id
+		LEFT JOIN budget_alert_mutes m ON m.category_id = c.id AND m.user_id = $2
+		WHERE c.household_id = $1
+			AND (cbs.alert_thresholds IS NOT NULL OR m.user_id IS NOT NULL)
+		ORDER BY c.name
+	`, householdID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c CategoryAlertSettings
+		if err := rows.Scan(&c.CategoryID, &c.CategoryName, &c.Thresholds, &c.Muted); err != nil {
+			return nil, err
+		}
+		settings.Categories = append(settings.Categories, &c)
+	}
+	return settings, rows.Err()
+}
+
+// SetHouseholdAlertThresholds sets the default alert thresholds of a household
+func (r *PostgresRepository) SetHouseholdAlertThresholds(ctx context.Context, householdID string, thresholds []int) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO household_budget_settings (household_id, alert_thresholds)
+		VALUES ($1, $2)
+		ON CONFLICT (household_id)
+		DO UPDATE SET alert_thresholds = EXCLUDED.alert_thresholds, updated_at = NOW()
+	`, householdID, thresholds)
+	return err
+}
+
+// SetCategoryAlertThresholds sets a category's alert thresholds; nil clears them
+func (r *PostgresRepository) SetCategoryAlertThresholds(ctx context.Context, categoryID string, thresholds []int) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO category_budget_settings (category_id, alert_thresholds)
+		VALUES ($1, $2)
+		ON CONFLICT (category_id)
+		DO UPDATE SET alert_thresholds = EXCLUDED.alert_thresholds, updated_at = NOW()
+	`, categoryID, thresholds)
+	return err
+}
+
+// GetMutedUserIDs returns the users who muted a category's alerts
+func (r *PostgresRepository) GetMutedUserIDs(ctx context.Context, categoryID string) ([]string, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT user_id FROM budget_alert_mutes WHERE category_id = $1
+	`, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, id)
+	}
+	return userIDs, rows.Err()
+}
+
+// SetAlertMute mutes or unmutes a category's alerts for a user
+func (r *PostgresRepository) SetAlertMute(ctx context.Context, userID, categoryID string, muted bool) error {
+	if !muted {
+		_, err := r.pool.Exec(ctx, `
+			DELETE FROM budget_alert_mutes WHERE user_id = $1 AND category_id = $2
+		`, userID, categoryID)
+		return err
+	}
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO budget_alert_mutes (user_id, category_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`, userID, categoryID)
+	return err
+}
@@ -0,0 +1,72 @@
+package budgets
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestSetAlertThresholdsInputValidate tests threshold validation and normalization
+func TestSetAlertThresholdsInputValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		thresholds []int
+		want       []int
+		wantErr    bool
+	}{
+		{"Sorted and deduplicated", []int{100, 80, 100}, []int{80, 100}, false},
+		{"Above budget", []int{120}, []int{120}, false},
+		{"Empty", []int{}, nil, true},
+		{"Zero", []int{0, 80}, nil, true},
+		{"Too high", []int{501}, nil, true},
+		{"Too many", []int{10, 20, 30, 40, 50, 60}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := &SetAlertThresholdsInput{Thresholds: tt.thresholds}
+			err := input.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(input.Thresholds, tt.want) {
+				t.Errorf("Thresholds = %v, want %v", input.Thresholds, tt.want)
+			}
+		})
+	}
+}
+
+// TestCrossedThresholds tests which thresholds a spending percentage reaches
+func TestCrossedThresholds(t *testing.T) {
+	thresholds := []int{50, 80, 100}
+
+	if got := crossedThresholds(thresholds, 49.9); len(got) != 0 {
+		t.Errorf("49.9%% crossed %v, want none", got)
+	}
+	if got := crossedThresholds(thresholds, 80); !reflect.DeepEqual(got, []int{50, 80}) {
+		t.Errorf("80%% crossed %v, want [50 80]", got)
+	}
+	if got := crossedThresholds(thresholds, 130); !reflect.DeepEqual(got, []int{50, 80, 100}) {
+		t.Errorf("130%% crossed %v, want [50 80 100]", got)
+	}
+}
+
+// TestInRolloverChain tests which past months feed the current month's carry
+func TestInRolloverChain(t *testing.T) {
+	jan := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	chain := []*RolloverMonth{
+		{CategoryID: "food", Month: jan},
+		{CategoryID: "food", Month: jan.AddDate(0, 1, 0)},
+		{CategoryID: "fun", Month: jan.AddDate(0, 1, 0)},
+	}
+
+	if !inRolloverChain(chain, "food", "2025-02") {
+		t.Error("food 2025-02 should be in the chain")
+	}
+	if inRolloverChain(chain, "fun", "2025-01") {
+		t.Error("fun only rolls over from 2025-02")
+	}
+	if inRolloverChain(chain, "rent", "2025-01") {
+		t.Error("rent does not roll over")
+	}
+}
@@ -0,0 +1,330 @@
+package budgets
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/blanquicet/conti/backend/internal/audit"
+	"github.com/blanquicet/conti/backend/internal/households"
+)
+
+// Errors for member allowances
+var (
+	ErrMemberNotInHousehold = errors.New("user is not a member of this household")
+	ErrAllowanceNotFound    = errors.New("allowance not found")
+)
+
+// MemberBudget is a member's personal allowance for a month
+type MemberBudget struct {
+	ID          string    `json:"id"`
+	HouseholdID string    `json:"household_id"`
+	UserID      string    `json:"user_id"`
+	Month       time.Time `json:"month"`
+	Amount      float64   `json:"amount"`
+	Currency    string    `json:"currency"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// MemberAllowance is a member's allowance for a month with what they spent:
+// HOUSEHOLD movements they paid or in the categories they own. Movements are
+// only filled in for the member's own allowance; others see the totals.
+type MemberAllowance struct {
+	ID         string               `json:"id"`
+	UserID     string               `json:"user_id"`
+	UserName   string               `json:"user_name"`
+	Amount     float64              `json:"amount"`
+	Currency   string               `json:"currency"`
+	Spent      float64              `json:"spent"`
+	Remaining  float64              `json:"remaining"`  // amount - spent; negative when overspent
+	Percentage float64              `json:"percentage"` // (spent / amount) * 100
+	Status     string               `json:"status"`
+	Categories []*AllowanceCategory `json:"categories"` // Categories the member owns
+	Movements  []*AllowanceMovement `json:"movements,omitempty"`
+}
+
+// AllowanceCategory is a category owned by a member
+type AllowanceCategory struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// AllowanceMovement is a movement counted against a member's allowance
+type AllowanceMovement struct {
+	ID           string    `json:"id"`
+	Description  string    `json:"description"`
+	Amount       float64   `json:"amount"`
+	MovementDate time.Time `json:"movement_date"`
+	CategoryID   *string   `json:"category_id,omitempty"`
+	CategoryName *string   `json:"category_name,omitempty"`
+	PaidByMember bool      `json:"paid_by_member"` // False when it counts only through an owned category
+}
+
+// SetAllowanceInput represents input for setting a member's allowance
+type SetAllowanceInput struct {
+	UserID string      `json:"user_id"`
+	Month  string      `json:"month"` // YYYY-MM
+	Amount float64     `json:"amount"`
+	Scope  BudgetScope `json:"scope,omitempty"` // THIS, FUTURE, ALL (default: FUTURE)
+}
+
+// Validate validates the set allowance input
+func (i *SetAllowanceInput) Validate() error {
+	if i.UserID == "" {
+		return errors.New("user_id is required")
+	}
+	if _, err := ParseMonth(i.Month); err != nil {
+		return ErrInvalidMonth
+	}
+	if i.Amount < 0 {
+		return ErrInvalidAmount
+	}
+	if i.Scope != "" && i.Scope != ScopeThis && i.Scope != ScopeFuture && i.Scope != ScopeAll {
+		return ErrInvalidScope
+	}
+	return nil
+}
+
+// SetCategoryOwnerInput represents input for giving a category to a member's allowance
+type SetCategoryOwnerInput struct {
+	UserID string `json:"user_id"`
+}
+
+// calculate fills remaining, percentage and status from amount and spent
+func (a *MemberAllowance) calculate() {
+	a.Remaining = a.Amount - a.Spent
+	a.Percentage = 0
+	if a.Amount > 0 {
+		a.Percentage = (a.Spent / a.Amount) * 100
+	} else if a.Spent > 0 {
+		a.Percentage = 100
+	}
+	a.Status = CalculateBudgetStatus(a.Percentage)
+}
+
+// checkMember returns ErrMemberNotInHousehold unless the user belongs to the household
+func (s *BudgetService) checkMember(ctx context.Context, householdID, userID string) error {
+	if _, err := s.householdRepo.GetMemberByUserID(ctx, householdID, userID); err != nil {
+		if errors.Is(err, households.ErrMemberNotFound) {
+			return ErrMemberNotInHousehold
+		}
+		return err
+	}
+	return nil
+}
+
+// ListAllowances returns the household's allowances for a month. The
+// requesting member's own allowance includes its movements.
+func (s *BudgetService) ListAllowances(ctx context.Context, userID, month string) ([]*MemberAllowance, error) {
+	if _, err := ParseMonth(month); err != nil {
+		return nil, ErrInvalidMonth
+	}
+
+	householdID, err := s.getUserHouseholdID(ctx, userID, households.PermView)
+	if err != nil {
+		return nil, err
+	}
+
+	allowances, err := s.repo.ListMemberAllowances(ctx, householdID, month)
+	if err != nil {
+		return nil, err
+	}
+	owned, err := s.repo.ListMemberCategories(ctx, householdID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range allowances {
+		a.Categories = owned[a.UserID]
+		if a.Categories == nil {
+			a.Categories = []*AllowanceCategory{}
+		}
+		a.calculate()
+		if a.UserID == userID {
+			if a.Movements, err = s.repo.ListAllowanceMovements(ctx, householdID, userID, month); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return allowances, nil
+}
+
+// SetAllowance creates or updates a member's allowance. Scopes work as for
+// category budgets.
+func (s *BudgetService) SetAllowance(ctx context.Context, userID string, input *SetAllowanceInput) (*MemberBudget, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	householdID, err := s.getUserHouseholdID(ctx, userID, households.PermEditBudgets)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkMember(ctx, householdID, input.UserID); err != nil {
+		return nil, err
+	}
+
+	scope := input.Scope
+	if scope == "" {
+		scope = ScopeFuture
+	}
+
+	// For scope=THIS, capture the old amount before the upsert so next month keeps it
+	var oldAmount float64
+	var hadBudget bool
+	if scope == ScopeThis {
+		oldAmount, hadBudget, _ = s.repo.GetEffectiveMemberBudget(ctx, householdID, input.UserID, input.Month)
+	}
+
+	budget, err := s.repo.SetMemberBudget(ctx, householdID, input)
+	if err != nil {
+		s.auditService.LogAsync(ctx, &audit.LogInput{
+			Action:       audit.ActionBudgetCreated,
+			ResourceType: "member_budget",
+			UserID:       audit.StringPtr(userID),
+			HouseholdID:  audit.StringPtr(householdID),
+			Success:      false,
+			ErrorMessage: audit.StringPtr(err.Error()),
+		})
+		return nil, err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		Action:       audit.ActionBudgetCreated,
+		ResourceType: "member_budget",
+		ResourceID:   audit.StringPtr(budget.ID),
+		UserID:       audit.StringPtr(userID),
+		HouseholdID:  audit.StringPtr(householdID),
+		Success:      true,
+		NewValues:    audit.StructToMap(budget),
+	})
+
+	switch scope {
+	case ScopeFuture:
+		s.repo.DeleteFutureMemberBudgets(ctx, householdID, input.UserID, input.Month)
+	case ScopeAll:
+		s.repo.UpdateAllMemberBudgets(ctx, householdID, input.UserID, input.Amount)
+	case ScopeThis:
+		if hadBudget && oldAmount != input.Amount {
+			s.repo.PinMemberMonthIfMissing(ctx, householdID, input.UserID, NextMonth(input.Month), oldAmount)
+		}
+	}
+
+	return budget, nil
+}
+
+// DeleteAllowance deletes a member's allowance record
+func (s *BudgetService) DeleteAllowance(ctx context.Context, userID, id string) error {
+	budget, err := s.repo.GetMemberBudget(ctx, id)
+	if err != nil {
+		return err
+	}
+	if _, err := households.Authorize(ctx, s.householdRepo, budget.HouseholdID, userID, households.PermEditBudgets); err != nil {
+		if errors.Is(err, households.ErrNotAuthorized) {
+			return ErrNotAuthorized
+		}
+		return err
+	}
+
+	if err := s.repo.DeleteMemberBudget(ctx, id); err != nil {
+		return err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		Action:       audit.ActionBudgetDeleted,
+		ResourceType: "member_budget",
+		ResourceID:   audit.StringPtr(id),
+		UserID:       audit.StringPtr(userID),
+		HouseholdID:  audit.StringPtr(budget.HouseholdID),
+		Success:      true,
+		OldValues:    audit.StructToMap(budget),
+	})
+
+	return nil
+}
+
+// SetCategoryOwner makes a member own a category, so its spending counts
+// against their allowance. A category has at most one owner.
+func (s *BudgetService) SetCategoryOwner(ctx context.Context, userID, categoryID string, input *SetCategoryOwnerInput) error {
+	if input.UserID == "" {
+		return errors.New("user_id is required")
+	}
+
+	householdID, err := s.getUserHouseholdID(ctx, userID, households.PermEditBudgets)
+	if err != nil {
+		return err
+	}
+	if err := s.checkCategory(ctx, householdID, categoryID); err != nil {
+		return err
+	}
+	if err := s.checkMember(ctx, householdID, input.UserID); err != nil {
+		return err
+	}
+
+	previous, err := s.repo.SetCategoryOwner(ctx, householdID, categoryID, input.UserID)
+	if err != nil {
+		s.auditService.LogAsync(ctx, &audit.LogInput{
+			Action:       audit.ActionBudgetUpdated,
+			ResourceType: "budget_category_owner",
+			ResourceID:   audit.StringPtr(categoryID),
+			UserID:       audit.StringPtr(userID),
+			HouseholdID:  audit.StringPtr(householdID),
+			Success:      false,
+			ErrorMessage: audit.StringPtr(err.Error()),
+			NewValues:    map[string]interface{}{"user_id": input.UserID},
+		})
+		return err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		Action:       audit.ActionBudgetUpdated,
+		ResourceType: "budget_category_owner",
+		ResourceID:   audit.StringPtr(categoryID),
+		UserID:       audit.StringPtr(userID),
+		HouseholdID:  audit.StringPtr(householdID),
+		Success:      true,
+		OldValues:    map[string]interface{}{"user_id": previous},
+		NewValues:    map[string]interface{}{"user_id": input.UserID},
+	})
+
+	return nil
+}
+
+// ClearCategoryOwner takes a category out of its owner's allowance
+func (s *BudgetService) ClearCategoryOwner(ctx context.Context, userID, categoryID string) error {
+	householdID, err := s.getUserHouseholdID(ctx, userID, households.PermEditBudgets)
+	if err != nil {
+		return err
+	}
+	if err := s.checkCategory(ctx, householdID, categoryID); err != nil {
+		return err
+	}
+
+	previous, err := s.repo.DeleteCategoryOwner(ctx, categoryID)
+	if err != nil {
+		s.auditService.LogAsync(ctx, &audit.LogInput{
+			Action:       audit.ActionBudgetUpdated,
+			ResourceType: "budget_category_owner",
+			ResourceID:   audit.StringPtr(categoryID),
+			UserID:       audit.StringPtr(userID),
+			HouseholdID:  audit.StringPtr(householdID),
+			Success:      false,
+			ErrorMessage: audit.StringPtr(err.Error()),
+		})
+		return err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		Action:       audit.ActionBudgetUpdated,
+		ResourceType: "budget_category_owner",
+		ResourceID:   audit.StringPtr(categoryID),
+		UserID:       audit.StringPtr(userID),
+		HouseholdID:  audit.StringPtr(householdID),
+		Success:      true,
+		OldValues:    map[string]interface{}{"user_id": previous},
+		NewValues:    map[string]interface{}{"user_id": nil},
+	})
+
+	return nil
+}
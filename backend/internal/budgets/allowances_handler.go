@@ -0,0 +1,156 @@
+package budgets
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// ListAllowances handles GET /budgets/allowances?month=YYYY-MM
+func (h *Handler) ListAllowances(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserFromSession(r)
+	if err != nil {
+		h.logger.Error("failed to get user from session", "error", err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	month := r.URL.Query().Get("month")
+	allowances, err := h.service.ListAllowances(r.Context(), user.ID, month)
+	if err != nil {
+		h.logger.Error("failed to list allowances", "error", err, "user_id", user.ID, "month", month)
+		h.writeAllowanceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"month":      month,
+		"allowances": allowances,
+	})
+}
+
+// SetAllowance handles PUT /budgets/allowances
+func (h *Handler) SetAllowance(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserFromSession(r)
+	if err != nil {
+		h.logger.Error("failed to get user from session", "error", err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var input SetAllowanceInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error("failed to decode request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	budget, err := h.service.SetAllowance(r.Context(), user.ID, &input)
+	if err != nil {
+		h.logger.Error("failed to set allowance", "error", err, "user_id", user.ID)
+		h.writeAllowanceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(budget)
+}
+
+// DeleteAllowance handles DELETE /budgets/allowances/{id}
+func (h *Handler) DeleteAllowance(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserFromSession(r)
+	if err != nil {
+		h.logger.Error("failed to get user from session", "error", err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id := r.PathValue("id")
+	if id == "" {
+		http.Error(w, "allowance ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteAllowance(r.Context(), user.ID, id); err != nil {
+		h.logger.Error("failed to delete allowance", "error", err, "user_id", user.ID, "allowance_id", id)
+		h.writeAllowanceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SetCategoryOwner handles PUT /budgets/allowances/categories/{category_id}
+func (h *Handler) SetCategoryOwner(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserFromSession(r)
+	if err != nil {
+		h.logger.Error("failed to get user from session", "error", err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	categoryID := r.PathValue("category_id")
+	if categoryID == "" {
+		http.Error(w, "category ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var input SetCategoryOwnerInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error("failed to decode request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.SetCategoryOwner(r.Context(), user.ID, categoryID, &input); err != nil {
+		h.logger.Error("failed to set category owner", "error", err, "user_id", user.ID, "category_id", categoryID)
+		h.writeAllowanceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ClearCategoryOwner handles DELETE /budgets/allowances/categories/{category_id}
+func (h *Handler) ClearCategoryOwner(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserFromSession(r)
+	if err != nil {
+		h.logger.Error("failed to get user from session", "error", err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	categoryID := r.PathValue("category_id")
+	if categoryID == "" {
+		http.Error(w, "category ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.ClearCategoryOwner(r.Context(), user.ID, categoryID); err != nil {
+		h.logger.Error("failed to clear category owner", "error", err, "user_id", user.ID, "category_id", categoryID)
+		h.writeAllowanceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeAllowanceError maps allowance errors to HTTP responses
+func (h *Handler) writeAllowanceError(w http.ResponseWriter, err error) {
+	switch {
+	case err == ErrInvalidMonth, err == ErrInvalidAmount, err == ErrInvalidScope,
+		err == ErrMemberNotInHousehold, strings.Contains(err.Error(), "required"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case err == ErrAllowanceNotFound:
+		http.Error(w, "allowance not found", http.StatusNotFound)
+	case err == ErrCategoryNotFound:
+		http.Error(w, "category not found", http.StatusNotFound)
+	case err == ErrNoHousehold:
+		http.Error(w, "user has no household", http.StatusNotFound)
+	case err == ErrNotAuthorized:
+		http.Error(w, "forbidden: your role cannot edit budgets", http.StatusForbidden)
+	default:
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+	}
+}
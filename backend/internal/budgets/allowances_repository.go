@@ -0,0 +1,280 @@
+package budgets
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const memberBudgetColumns = `id, household_id, user_id, month, amount, currency, created_at, updated_at`
+
+func scanMemberBudget(row pgx.Row) (*MemberBudget, error) {
+	var b MemberBudget
+	err := row.Scan(
+		&b.ID,
+		&b.HouseholdID,
+		&b.UserID,
+		&b.Month,
+		&b.Amount,
+		&b.Currency,
+		&b.CreatedAt,
+		&b.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// allowanceMovementFilter matches the HOUSEHOLD movements of month ($3)
+// counted against member $2: paid by them or in a category they own
+const allowanceMovementFilter = `
+	m.household_id = $1 AND m.type = 'HOUSEHOLD'
+	AND DATE_TRUNC('month', m.movement_date) = $3
+	AND (m.payer_user_id = $2 OR m.category_id IN (
+		SELECT category_id FROM member_budget_categories WHERE household_id = $1 AND user_id = $2
+	))`
+
+// ListMemberAllowances returns the allowance each household member inherits
+// for the month with their spent. Members without an allowance are left out.
+func (r *PostgresRepository) ListMemberAllowances(ctx context.Context, householdID, month string) ([]*MemberAllowance, error) {
+	monthDate, err := ParseMonth(month)
+	if err != nil {
+		return nil, ErrInvalidMonth
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT mb.id, u.id, u.name, mb.amount, mb.currency, COALESCE(sp.spent, 0)
+		FROM household_members hm
+		JOIN users u ON u.id = hm.user_id
+		JOIN LATERAL (
+			SELECT id, amount, currency
+			FROM member_budgets
+			WHERE household_id = $1 AND user_id = hm.user_id AND month <= $2
+			ORDER BY month DESC
+			LIMIT 1
+		) mb ON true
+		LEFT JOIN LATERAL (
+			SELECT SUM(m.amount) AS spent
+			FROM movements m
+			WHERE m.household_id = $1 AND m.type = 'HOUSEHOLD'
+				AND DATE_TRUNC('month', m.movement_date) = $2
+				AND (m.payer_user_id = hm.user_id OR m.category_id IN (
+					SELECT category_id FROM member_budget_categories WHERE household_id = $1 AND user_id = hm.user_id
+				))
+		) sp ON true
+		WHERE hm.household_id = $1
+		ORDER BY u.name
+	`, householdID, monthDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	allowances := []*MemberAllowance{}
+	for rows.Next() {
+		var a MemberAllowance
+		if err := rows.Scan(&a.ID, &a.UserID, &a.UserName, &a.Amount, &a.Currency, &a.Spent); err != nil {
+			return nil, err
+		}
+		allowances = append(allowances, &a)
+	}
+	return allowances, rows.Err()
+}
+
+// ListMemberCategories returns the categories each member of the household owns, by user ID
+func (r *PostgresRepository) ListMemberCategories(ctx context.Context, householdID string) (map[string][]*AllowanceCategory, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT mbc.user_id, c.id, c.name
+		FROM member_budget_categories mbc
+		JOIN categories c ON c.id = mbc.category_id
+		WHERE mbc.household_id = $1
+		ORDER BY c.display_order, c.name
+	`, householdID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	owned := make(map[string][]*AllowanceCategory)
+	for rows.Next() {
+		var userID string
+		var c AllowanceCategory
+		if err := rows.Scan(&userID, &c.ID, &c.Name); err != nil {
+			return nil, err
+		}
+		owned[userID] = append(owned[userID], &c)
+	}
+	return owned, rows.Err()
+}
+
+// ListAllowanceMovements returns the movements counted against a member's
+// allowance for a month. Other members' private movements in the categories
+// the member owns still count in spent but are not listed.
+func (r *PostgresRepository) ListAllowanceMovements(ctx context.Context, householdID, userID, month string) ([]*AllowanceMovement, error) {
+	monthDate, err := ParseMonth(month)
+	if err != nil {
+		return nil, ErrInvalidMonth
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT m.id, m.description, m.amount, m.movement_date, m.category_id, c.name,
+			m.payer_user_id IS NOT DISTINCT FROM $2::uuid
+		FROM movements m
+		LEFT JOIN categories c ON c.id = m.category_id
+		WHERE `+allowanceMovementFilter+`
+		  AND (m.is_shared_with_household OR m.payer_user_id = $2)
+		ORDER BY m.movement_date DESC, m.created_at DESC
+	`, householdID, userID, monthDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	movements := []*AllowanceMovement{}
+	for rows.Next() {
+		var m AllowanceMovement
+		if err := rows.Scan(&m.ID, &m.Description, &m.Amount, &m.MovementDate, &m.CategoryID, &m.CategoryName, &m.PaidByMember); err != nil {
+			return nil, err
+		}
+		movements = append(movements, &m)
+	}
+	return movements, rows.Err()
+}
+
+// GetEffectiveMemberBudget returns the allowance a member inherits for the
+// month and whether they have one
+func (r *PostgresRepository) GetEffectiveMemberBudget(ctx context.Context, householdID, userID, month string) (float64, bool, error) {
+	monthDate, err := ParseMonth(month)
+	if err != nil {
+		return 0, false, ErrInvalidMonth
+	}
+	var amount float64
+	err = r.pool.QueryRow(ctx, `
+		SELECT amount
+		FROM member_budgets
+		WHERE household_id = $1 AND user_id = $2 AND month <= $3
+		ORDER BY month DESC
+		LIMIT 1
+	`, householdID, userID, monthDate).Scan(&amount)
+	if err == pgx.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return amount, true, nil
+}
+
+// SetMemberBudget creates or updates a member's allowance for a month
+func (r *PostgresRepository) SetMemberBudget(ctx context.Context, householdID string, input *SetAllowanceInput) (*MemberBudget, error) {
+	monthDate, err := ParseMonth(input.Month)
+	if err != nil {
+		return nil, ErrInvalidMonth
+	}
+	return scanMemberBudget(r.pool.QueryRow(ctx, `
+		INSERT INTO member_budgets (household_id, user_id, month, amount, currency)
+		VALUES ($1, $2, $3, $4, 'COP')
+		ON CONFLICT (household_id, user_id, month)
+		DO UPDATE SET amount = EXCLUDED.amount, updated_at = NOW()
+		RETURNING `+memberBudgetColumns,
+		householdID, input.UserID, monthDate, input.Amount,
+	))
+}
+
+// GetMemberBudget returns an allowance record by ID
+func (r *PostgresRepository) GetMemberBudget(ctx context.Context, id string) (*MemberBudget, error) {
+	budget, err := scanMemberBudget(r.pool.QueryRow(ctx, `
+		SELECT `+memberBudgetColumns+` FROM member_budgets WHERE id = $1
+	`, id))
+	if err == pgx.ErrNoRows {
+		return nil, ErrAllowanceNotFound
+	}
+	return budget, err
+}
+
+// DeleteMemberBudget deletes an allowance record by ID
+func (r *PostgresRepository) DeleteMemberBudget(ctx context.Context, id string) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM member_budgets WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrAllowanceNotFound
+	}
+	return nil
+}
+
+// DeleteFutureMemberBudgets deletes a member's allowance records after the month
+func (r *PostgresRepository) DeleteFutureMemberBudgets(ctx context.Context, householdID, userID, afterMonth string) (int64, error) {
+	monthDate, err := ParseMonth(afterMonth)
+	if err != nil {
+		return 0, ErrInvalidMonth
+	}
+	result, err := r.pool.Exec(ctx, `
+		DELETE FROM member_budgets
+		WHERE household_id = $1 AND user_id = $2 AND month > $3
+	`, householdID, userID, monthDate)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+// UpdateAllMemberBudgets sets every allowance record of a member to the amount
+func (r *PostgresRepository) UpdateAllMemberBudgets(ctx context.Context, householdID, userID string, amount float64) (int64, error) {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE member_budgets SET amount = $3, updated_at = NOW()
+		WHERE household_id = $1 AND user_id = $2
+	`, householdID, userID, amount)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+// PinMemberMonthIfMissing inserts an allowance record for the month only if none exists yet
+func (r *PostgresRepository) PinMemberMonthIfMissing(ctx context.Context, householdID, userID, month string, amount float64) error {
+	monthDate, err := ParseMonth(month)
+	if err != nil {
+		return ErrInvalidMonth
+	}
+	_, err = r.pool.Exec(ctx, `
+		INSERT INTO member_budgets (household_id, user_id, month, amount, currency)
+		VALUES ($1, $2, $3, $4, 'COP')
+		ON CONFLICT (household_id, user_id, month) DO NOTHING
+	`, householdID, userID, monthDate, amount)
+	return err
+}
+
+// SetCategoryOwner makes a member the owner of a category, replacing any previous
+// owner. It returns the previous owner, or nil if the category had none.
+func (r *PostgresRepository) SetCategoryOwner(ctx context.Context, householdID, categoryID, userID string) (*string, error) {
+	var previous *string
+	err := r.pool.QueryRow(ctx, `
+		WITH previous AS (
+			SELECT user_id FROM member_budget_categories WHERE category_id = $1
+		)
+		INSERT INTO member_budget_categories (category_id, household_id, user_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (category_id) DO UPDATE SET user_id = EXCLUDED.user_id, created_at = NOW()
+		RETURNING (SELECT user_id FROM previous)
+	`, categoryID, householdID, userID).Scan(&previous)
+	return previous, err
+}
+
+// DeleteCategoryOwner removes a category's owner, if any, and returns it
+func (r *PostgresRepository) DeleteCategoryOwner(ctx context.Context, categoryID string) (*string, error) {
+	var previous string
+	err := r.pool.QueryRow(ctx, `
+		DELETE FROM member_budget_categories WHERE category_id = $1 RETURNING user_id
+	`, categoryID).Scan(&previous)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &previous, nil
+}
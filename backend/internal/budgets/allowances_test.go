@@ -0,0 +1,53 @@
+package budgets
+
+import "testing"
+
+// TestMemberAllowanceCalculate tests remaining, percentage and status of an allowance
+func TestMemberAllowanceCalculate(t *testing.T) {
+	tests := []struct {
+		name          string
+		amount, spent float64
+		wantRemaining float64
+		wantPct       float64
+		wantStatus    string
+	}{
+		{"under", 500000, 200000, 300000, 40, "under_budget"},
+		{"overspent", 500000, 600000, -100000, 120, "exceeded"},
+		{"no allowance left", 0, 10000, -10000, 100, "exceeded"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &MemberAllowance{Amount: tt.amount, Spent: tt.spent}
+			a.calculate()
+			if a.Remaining != tt.wantRemaining || a.Percentage != tt.wantPct || a.Status != tt.wantStatus {
+				t.Errorf("got %v, %v, %q, want %v, %v, %q", a.Remaining, a.Percentage, a.Status, tt.wantRemaining, tt.wantPct, tt.wantStatus)
+			}
+		})
+	}
+}
+
+// TestSetAllowanceInputValidate tests allowance input validation
+func TestSetAllowanceInputValidate(t *testing.T) {
+	valid := SetAllowanceInput{UserID: "u1", Month: "2026-10", Amount: 300000}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+
+	noUser := valid
+	noUser.UserID = ""
+	if err := noUser.Validate(); err == nil {
+		t.Error("Validate() without user_id = nil, want error")
+	}
+
+	negative := valid
+	negative.Amount = -1
+	if err := negative.Validate(); err != ErrInvalidAmount {
+		t.Errorf("Validate() with negative amount = %v, want ErrInvalidAmount", err)
+	}
+
+	badScope := valid
+	badScope.Scope = "SOMETIMES"
+	if err := badScope.Validate(); err != ErrInvalidScope {
+		t.Errorf("Validate() with unknown scope = %v, want ErrInvalidScope", err)
+	}
+}
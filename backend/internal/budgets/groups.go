@@ -0,0 +1,394 @@
+package budgets
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/blanquicet/conti/backend/internal/audit"
+	"github.com/blanquicet/conti/backend/internal/households"
+)
+
+// Errors for category group budgets
+var (
+	ErrGroupNotFound              = errors.New("category group not found")
+	ErrGroupBudgetNotFound        = errors.New("group budget not found")
+	ErrExceedsGroupBudget         = errors.New("category budgets in the group would exceed the group budget")
+	ErrGroupBudgetBelowCategories = errors.New("group budget cannot be less than the budgets of its categories")
+)
+
+// GroupBudget is a monthly budget for a whole category group
+type GroupBudget struct {
+	ID              string    `json:"id"`
+	HouseholdID     string    `json:"household_id"`
+	CategoryGroupID string    `json:"category_group_id"`
+	Month           time.Time `json:"month"`
+	Amount          float64   `json:"amount"`
+	Currency        string    `json:"currency"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// GroupBudgetWithSpent is a group budget for a month with the spending of all
+// its categories. Category budgets in the group count inside its amount.
+type GroupBudgetWithSpent struct {
+	ID                string             `json:"id"`
+	CategoryGroupID   string             `json:"category_group_id"`
+	CategoryGroupName string             `json:"category_group_name"`
+	CategoryGroupIcon *string            `json:"category_group_icon,omitempty"`
+	Amount            float64            `json:"amount"`
+	Currency          string             `json:"currency"`
+	Allocated         float64            `json:"allocated"`      // Sum of the category budgets in the group
+	Unallocated       float64            `json:"unallocated"`    // amount - allocated
+	OverAllocated     bool               `json:"over_allocated"` // Category budgets exceed the group budget, e.g. after budget items grew
+	Spent             float64            `json:"spent"`          // Sum over all categories in the group
+	Percentage        float64            `json:"percentage"`     // (spent / amount) * 100
+	Status            string             `json:"status"`
+	Categories        []*BudgetWithSpent `json:"categories"`
+}
+
+// SetGroupBudgetInput represents input for setting a category group's budget
+type SetGroupBudgetInput struct {
+	CategoryGroupID string      `json:"category_group_id"`
+	Month           string      `json:"month"` // YYYY-MM
+	Amount          float64     `json:"amount"`
+	Scope           BudgetScope `json:"scope,omitempty"` // THIS, FUTURE, ALL (default: FUTURE)
+}
+
+// Validate validates the set group budget input
+func (i *SetGroupBudgetInput) Validate() error {
+	if i.CategoryGroupID == "" {
+		return errors.New("category_group_id is required")
+	}
+	if _, err := ParseMonth(i.Month); err != nil {
+		return ErrInvalidMonth
+	}
+	if i.Amount < 0 {
+		return ErrInvalidAmount
+	}
+	if i.Scope != "" && i.Scope != ScopeThis && i.Scope != ScopeFuture && i.Scope != ScopeAll {
+		return ErrInvalidScope
+	}
+	return nil
+}
+
+// nestGroupBudgets fills each group with its categories and their spending
+func nestGroupBudgets(groups []*GroupBudgetWithSpent, budgets []*BudgetWithSpent) {
+	byID := make(map[string]*GroupBudgetWithSpent, len(groups))
+	for _, g := range groups {
+		g.Categories = []*BudgetWithSpent{}
+		byID[g.CategoryGroupID] = g
+	}
+	for _, b := range budgets {
+		if b.CategoryGroupID == nil {
+			continue
+		}
+		g, ok := byID[*b.CategoryGroupID]
+		if !ok {
+			continue
+		}
+		g.Categories = append(g.Categories, b)
+		g.Allocated += b.Amount
+		g.Spent += b.Spent
+	}
+
+	for _, g := range groups {
+		g.Unallocated = g.Amount - g.Allocated
+		g.OverAllocated = g.Unallocated < 0
+		g.Percentage = 0
+		if g.Amount > 0 {
+			g.Percentage = (g.Spent / g.Amount) * 100
+		} else if g.Spent > 0 {
+			g.Percentage = 100
+		}
+		g.Status = CalculateBudgetStatus(g.Percentage)
+	}
+}
+
+// budgetTotal is the month's total budget: a group's amount replaces the
+// budgets of its categories, which are already inside it
+func budgetTotal(groups []*GroupBudgetWithSpent, budgets []*BudgetWithSpent) float64 {
+	capped := make(map[string]bool, len(groups))
+	var total float64
+	for _, g := range groups {
+		capped[g.CategoryGroupID] = true
+		total += g.Amount
+	}
+	for _, b := range budgets {
+		if b.CategoryGroupID != nil && capped[*b.CategoryGroupID] {
+			continue
+		}
+		total += b.EffectiveAmount
+	}
+	return total
+}
+
+// allocatedInGroup sums the category budgets of a group for a month, leaving
+// out one category when given
+func (s *BudgetService) allocatedInGroup(ctx context.Context, householdID, groupID, month, exceptCategoryID string) (float64, error) {
+	budgets, err := s.repo.GetByMonth(ctx, householdID, month)
+	if err != nil {
+		return 0, err
+	}
+	var allocated float64
+	for _, b := range budgets {
+		if b.CategoryGroupID != nil && *b.CategoryGroupID == groupID && b.CategoryID != exceptCategoryID {
+			allocated += b.Amount
+		}
+	}
+	return allocated, nil
+}
+
+// allocatedByGroup sums category budgets per category group
+func allocatedByGroup(budgets []*BudgetWithSpent) map[string]float64 {
+	allocated := make(map[string]float64)
+	for _, b := range budgets {
+		if b.CategoryGroupID != nil {
+			allocated[*b.CategoryGroupID] += b.Amount
+		}
+	}
+	return allocated
+}
+
+// checkGroupCap returns ErrExceedsGroupBudget when giving the category amount
+// would take its group's category budgets over the group budget
+func (s *BudgetService) checkGroupCap(ctx context.Context, householdID string, groupID *string, categoryID, month string, amount float64) error {
+	if groupID == nil {
+		return nil
+	}
+	groupAmount, ok, err := s.repo.GetEffectiveGroupBudget(ctx, householdID, *groupID, month)
+	if err != nil || !ok {
+		return err
+	}
+	allocated, err := s.allocatedInGroup(ctx, householdID, *groupID, month, categoryID)
+	if err != nil {
+		return err
+	}
+	if allocated+amount > groupAmount {
+		return ErrExceedsGroupBudget
+	}
+	return nil
+}
+
+// scopeMonths returns the months a write with the given scope starting at month
+// can change within a group: month itself, for FUTURE every later month where
+// the group's budgets change, and for ALL every such month before it too
+func (s *BudgetService) scopeMonths(ctx context.Context, householdID, groupID, categoryID, month string, scope BudgetScope) ([]string, error) {
+	months := []string{month}
+	switch scope {
+	case ScopeFuture:
+		later, err := s.repo.ListGroupCapMonths(ctx, householdID, groupID, categoryID, month)
+		if err != nil {
+			return nil, err
+		}
+		months = append(months, later...)
+	case ScopeAll:
+		all, err := s.repo.ListGroupCapMonths(ctx, householdID, groupID, categoryID, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range all {
+			if m != month {
+				months = append(months, m)
+			}
+		}
+	}
+	return months, nil
+}
+
+// checkScopedGroupCap runs checkGroupCap for every month a category budget
+// write with the given scope changes. ALL only rewrites months that already
+// have a budget, so earlier months without one are skipped.
+func (s *BudgetService) checkScopedGroupCap(ctx context.Context, householdID string, groupID *string, categoryID, month string, amount float64, scope BudgetScope) error {
+	if groupID == nil {
+		return nil
+	}
+	months, err := s.scopeMonths(ctx, householdID, *groupID, categoryID, month, scope)
+	if err != nil {
+		return err
+	}
+	for _, m := range months {
+		if m < month {
+			current, err := s.repo.GetEffectiveBudget(ctx, householdID, categoryID, m)
+			if err != nil {
+				return err
+			}
+			if current == 0 {
+				continue
+			}
+		}
+		if err := s.checkGroupCap(ctx, householdID, groupID, categoryID, m, amount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkCopyGroupCaps returns ErrExceedsGroupBudget when copying fromMonth's
+// category budgets would take a group over the budget it has in toMonth
+func (s *BudgetService) checkCopyGroupCaps(ctx context.Context, householdID, fromMonth, toMonth string) error {
+	budgets, err := s.repo.GetByMonth(ctx, householdID, fromMonth)
+	if err != nil {
+		return err
+	}
+	for groupID, allocated := range allocatedByGroup(budgets) {
+		groupAmount, ok, err := s.repo.GetEffectiveGroupBudget(ctx, householdID, groupID, toMonth)
+		if err != nil {
+			return err
+		}
+		if ok && allocated > groupAmount {
+			return ErrExceedsGroupBudget
+		}
+	}
+	return nil
+}
+
+// CheckCategoryMove returns ErrExceedsGroupBudget when moving a category into
+// a group would take the group's category budgets over its group budget, in
+// the current month or any later month where one of those budgets changes.
+// The categories service calls it before changing a category's group.
+func (s *BudgetService) CheckCategoryMove(ctx context.Context, householdID, categoryID, groupID string) error {
+	month := FormatMonth(time.Now())
+	later, err := s.repo.ListGroupCapMonths(ctx, householdID, groupID, categoryID, month)
+	if err != nil {
+		return err
+	}
+	for _, m := range append([]string{month}, later...) {
+		amount, err := s.repo.GetEffectiveBudget(ctx, householdID, categoryID, m)
+		if err != nil {
+			return err
+		}
+		if err := s.checkGroupCap(ctx, householdID, &groupID, categoryID, m, amount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SyncBudgetFromItems sets a category's budget for the month to the sum of its
+// budget items. The budget always follows its items, even past the group
+// budget; the group then reports itself as over-allocated.
+func (s *BudgetService) SyncBudgetFromItems(ctx context.Context, householdID, categoryID, month string, itemsSum float64) error {
+	return s.repo.UpsertBudgetFromItems(ctx, householdID, categoryID, month, itemsSum)
+}
+
+// SetGroupBudget creates or updates a category group's budget. Scopes work as
+// for category budgets.
+func (s *BudgetService) SetGroupBudget(ctx context.Context, userID string, input *SetGroupBudgetInput) (*GroupBudget, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	householdID, err := s.getUserHouseholdID(ctx, userID, households.PermEditBudgets)
+	if err != nil {
+		return nil, err
+	}
+	groupHouseholdID, err := s.repo.GetCategoryGroupHouseholdID(ctx, input.CategoryGroupID)
+	if err != nil {
+		return nil, err
+	}
+	if groupHouseholdID != householdID {
+		return nil, ErrGroupNotFound
+	}
+
+	scope := input.Scope
+	if scope == "" {
+		scope = ScopeFuture
+	}
+
+	// The new amount must hold the category budgets of every month it applies to.
+	// ALL only rewrites months that already have a group budget.
+	months, err := s.scopeMonths(ctx, householdID, input.CategoryGroupID, "", input.Month, scope)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range months {
+		if m < input.Month {
+			if _, ok, err := s.repo.GetEffectiveGroupBudget(ctx, householdID, input.CategoryGroupID, m); err != nil {
+				return nil, err
+			} else if !ok {
+				continue
+			}
+		}
+		allocated, err := s.allocatedInGroup(ctx, householdID, input.CategoryGroupID, m, "")
+		if err != nil {
+			return nil, err
+		}
+		if input.Amount < allocated {
+			return nil, ErrGroupBudgetBelowCategories
+		}
+	}
+
+	// For scope=THIS, capture the old amount before the upsert so next month keeps it
+	var oldAmount float64
+	var hadBudget bool
+	if scope == ScopeThis {
+		oldAmount, hadBudget, _ = s.repo.GetEffectiveGroupBudget(ctx, householdID, input.CategoryGroupID, input.Month)
+	}
+
+	budget, err := s.repo.SetGroupBudget(ctx, householdID, input)
+	if err != nil {
+		s.auditService.LogAsync(ctx, &audit.LogInput{
+			Action:       audit.ActionBudgetCreated,
+			ResourceType: "group_budget",
+			UserID:       audit.StringPtr(userID),
+			HouseholdID:  audit.StringPtr(householdID),
+			Success:      false,
+			ErrorMessage: audit.StringPtr(err.Error()),
+		})
+		return nil, err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		Action:       audit.ActionBudgetCreated,
+		ResourceType: "group_budget",
+		ResourceID:   audit.StringPtr(budget.ID),
+		UserID:       audit.StringPtr(userID),
+		HouseholdID:  audit.StringPtr(householdID),
+		Success:      true,
+		NewValues:    audit.StructToMap(budget),
+	})
+
+	switch scope {
+	case ScopeFuture:
+		s.repo.DeleteFutureGroupBudgets(ctx, householdID, input.CategoryGroupID, input.Month)
+	case ScopeAll:
+		s.repo.UpdateAllGroupBudgets(ctx, householdID, input.CategoryGroupID, input.Amount)
+	case ScopeThis:
+		if hadBudget && oldAmount != input.Amount {
+			s.repo.PinGroupMonthIfMissing(ctx, householdID, input.CategoryGroupID, NextMonth(input.Month), oldAmount)
+		}
+	}
+
+	return budget, nil
+}
+
+// DeleteGroupBudget deletes a category group's budget record
+func (s *BudgetService) DeleteGroupBudget(ctx context.Context, userID, id string) error {
+	budget, err := s.repo.GetGroupBudget(ctx, id)
+	if err != nil {
+		return err
+	}
+	if _, err := households.Authorize(ctx, s.householdRepo, budget.HouseholdID, userID, households.PermEditBudgets); err != nil {
+		if errors.Is(err, households.ErrNotAuthorized) {
+			return ErrNotAuthorized
+		}
+		return err
+	}
+
+	if err := s.repo.DeleteGroupBudget(ctx, id); err != nil {
+		return err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		Action:       audit.ActionBudgetDeleted,
+		ResourceType: "group_budget",
+		ResourceID:   audit.StringPtr(id),
+		UserID:       audit.StringPtr(userID),
+		HouseholdID:  audit.StringPtr(budget.HouseholdID),
+		Success:      true,
+		OldValues:    audit.StructToMap(budget),
+	})
+
+	return nil
+}
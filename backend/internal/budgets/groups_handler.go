@@ -0,0 +1,77 @@
+package budgets
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// SetGroupBudget handles PUT /budgets/groups
+func (h *Handler) SetGroupBudget(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserFromSession(r)
+	if err != nil {
+		h.logger.Error("failed to get user from session", "error", err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var input SetGroupBudgetInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error("failed to decode request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	budget, err := h.service.SetGroupBudget(r.Context(), user.ID, &input)
+	if err != nil {
+		h.logger.Error("failed to set group budget", "error", err, "user_id", user.ID)
+		h.writeGroupError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(budget)
+}
+
+// DeleteGroupBudget handles DELETE /budgets/groups/{id}
+func (h *Handler) DeleteGroupBudget(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserFromSession(r)
+	if err != nil {
+		h.logger.Error("failed to get user from session", "error", err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id := r.PathValue("id")
+	if id == "" {
+		http.Error(w, "group budget ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteGroupBudget(r.Context(), user.ID, id); err != nil {
+		h.logger.Error("failed to delete group budget", "error", err, "user_id", user.ID, "group_budget_id", id)
+		h.writeGroupError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeGroupError maps group budget errors to HTTP responses
+func (h *Handler) writeGroupError(w http.ResponseWriter, err error) {
+	switch {
+	case err == ErrInvalidMonth, err == ErrInvalidAmount, err == ErrInvalidScope,
+		err == ErrGroupBudgetBelowCategories, strings.Contains(err.Error(), "required"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case err == ErrGroupBudgetNotFound:
+		http.Error(w, "group budget not found", http.StatusNotFound)
+	case err == ErrGroupNotFound:
+		http.Error(w, "category group not found", http.StatusNotFound)
+	case err == ErrNoHousehold:
+		http.Error(w, "user has no household", http.StatusNotFound)
+	case err == ErrNotAuthorized:
+		http.Error(w, "forbidden: your role cannot edit budgets", http.StatusForbidden)
+	default:
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+	}
+}
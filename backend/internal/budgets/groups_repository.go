@@ -0,0 +1,220 @@
+package budgets
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const groupBudgetColumns = `id, household_id, category_group_id, month, amount, currency, created_at, updated_at`
+
+func scanGroupBudget(row pgx.Row) (*GroupBudget, error) {
+	var b GroupBudget
+	err := row.Scan(
+		&b.ID,
+		&b.HouseholdID,
+		&b.CategoryGroupID,
+		&b.Month,
+		&b.Amount,
+		&b.Currency,
+		&b.CreatedAt,
+		&b.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// GetGroupBudgetsByMonth returns the budget each active category group
+// inherits for the month. Groups without a budget are left out.
+func (r *PostgresRepository) GetGroupBudgetsByMonth(ctx context.Context, householdID, month string) ([]*GroupBudgetWithSpent, error) {
+	monthDate, err := ParseMonth(month)
+	if err != nil {
+		return nil, ErrInvalidMonth
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT gb.id, cg.id, cg.name, cg.icon, gb.amount, gb.currency
+		FROM category_groups cg
+		JOIN LATERAL (
+			SELECT id, amount, currency
+			FROM monthly_group_budgets
+			WHERE household_id = $1 AND category_group_id = cg.id AND month <= $2
+			ORDER BY month DESC
+			LIMIT 1
+		) gb ON true
+		WHERE cg.household_id = $1 AND cg.is_active = true
+		ORDER BY cg.display_order, cg.name
+	`, householdID, monthDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groups []*GroupBudgetWithSpent
+	for rows.Next() {
+		var g GroupBudgetWithSpent
+		if err := rows.Scan(&g.ID, &g.CategoryGroupID, &g.CategoryGroupName, &g.CategoryGroupIcon, &g.Amount, &g.Currency); err != nil {
+			return nil, err
+		}
+		groups = append(groups, &g)
+	}
+	return groups, rows.Err()
+}
+
+// GetEffectiveGroupBudget returns the budget a category group inherits for
+// the month and whether it has one
+func (r *PostgresRepository) GetEffectiveGroupBudget(ctx context.Context, householdID, groupID, month string) (float64, bool, error) {
+	monthDate, err := ParseMonth(month)
+	if err != nil {
+		return 0, false, ErrInvalidMonth
+	}
+	var amount float64
+	err = r.pool.QueryRow(ctx, `
+		SELECT amount
+		FROM monthly_group_budgets
+		WHERE household_id = $1 AND category_group_id = $2 AND month <= $3
+		ORDER BY month DESC
+		LIMIT 1
+	`, householdID, groupID, monthDate).Scan(&amount)
+	if err == pgx.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return amount, true, nil
+}
+
+// ListGroupCapMonths returns the months after month, or all months when month
+// is empty, in which the group's budget, a budget of one of its categories or
+// of categoryID changes
+func (r *PostgresRepository) ListGroupCapMonths(ctx context.Context, householdID, groupID, categoryID, month string) ([]string, error) {
+	var after *time.Time
+	if month != "" {
+		monthDate, err := ParseMonth(month)
+		if err != nil {
+			return nil, ErrInvalidMonth
+		}
+		after = &monthDate
+	}
+	rows, err := r.pool.Query(ctx, `
+		SELECT month FROM monthly_group_budgets
+		WHERE household_id = $1 AND category_group_id = $2 AND ($4::date IS NULL OR month > $4)
+		UNION
+		SELECT mb.month FROM monthly_budgets mb
+		JOIN categories c ON c.id = mb.category_id
+		WHERE mb.household_id = $1 AND (c.category_group_id = $2 OR c.id::text = $3)
+		  AND ($4::date IS NULL OR mb.month > $4)
+		ORDER BY month
+	`, householdID, groupID, categoryID, after)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var months []string
+	for rows.Next() {
+		var m time.Time
+		if err := rows.Scan(&m); err != nil {
+			return nil, err
+		}
+		months = append(months, FormatMonth(m))
+	}
+	return months, rows.Err()
+}
+
+// GetCategoryGroupHouseholdID returns the household a category group belongs to
+func (r *PostgresRepository) GetCategoryGroupHouseholdID(ctx context.Context, groupID string) (string, error) {
+	var householdID string
+	err := r.pool.QueryRow(ctx, `
+		SELECT household_id FROM category_groups WHERE id = $1 AND is_active = true
+	`, groupID).Scan(&householdID)
+	if err == pgx.ErrNoRows {
+		return "", ErrGroupNotFound
+	}
+	return householdID, err
+}
+
+// SetGroupBudget creates or updates a category group's budget for a month
+func (r *PostgresRepository) SetGroupBudget(ctx context.Context, householdID string, input *SetGroupBudgetInput) (*GroupBudget, error) {
+	monthDate, err := ParseMonth(input.Month)
+	if err != nil {
+		return nil, ErrInvalidMonth
+	}
+	return scanGroupBudget(r.pool.QueryRow(ctx, `
+		INSERT INTO monthly_group_budgets (household_id, category_group_id, month, amount, currency)
+		VALUES ($1, $2, $3, $4, 'COP')
+		ON CONFLICT (household_id, category_group_id, month)
+		DO UPDATE SET amount = EXCLUDED.amount, updated_at = NOW()
+		RETURNING `+groupBudgetColumns,
+		householdID, input.CategoryGroupID, monthDate, input.Amount,
+	))
+}
+
+// GetGroupBudget returns a group budget record by ID
+func (r *PostgresRepository) GetGroupBudget(ctx context.Context, id string) (*GroupBudget, error) {
+	budget, err := scanGroupBudget(r.pool.QueryRow(ctx, `
+		SELECT `+groupBudgetColumns+` FROM monthly_group_budgets WHERE id = $1
+	`, id))
+	if err == pgx.ErrNoRows {
+		return nil, ErrGroupBudgetNotFound
+	}
+	return budget, err
+}
+
+// DeleteGroupBudget deletes a group budget record by ID
+func (r *PostgresRepository) DeleteGroupBudget(ctx context.Context, id string) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM monthly_group_budgets WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrGroupBudgetNotFound
+	}
+	return nil
+}
+
+// DeleteFutureGroupBudgets deletes a group's budget records after the month
+func (r *PostgresRepository) DeleteFutureGroupBudgets(ctx context.Context, householdID, groupID, afterMonth string) (int64, error) {
+	monthDate, err := ParseMonth(afterMonth)
+	if err != nil {
+		return 0, ErrInvalidMonth
+	}
+	result, err := r.pool.Exec(ctx, `
+		DELETE FROM monthly_group_budgets
+		WHERE household_id = $1 AND category_group_id = $2 AND month > $3
+	`, householdID, groupID, monthDate)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+// UpdateAllGroupBudgets sets every budget record of a group to the amount
+func (r *PostgresRepository) UpdateAllGroupBudgets(ctx context.Context, householdID, groupID string, amount float64) (int64, error) {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE monthly_group_budgets SET amount = $3, updated_at = NOW()
+		WHERE household_id = $1 AND category_group_id = $2
+	`, householdID, groupID, amount)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+// PinGroupMonthIfMissing inserts a group budget record for the month only if none exists yet
+func (r *PostgresRepository) PinGroupMonthIfMissing(ctx context.Context, householdID, groupID, month string, amount float64) error {
+	monthDate, err := ParseMonth(month)
+	if err != nil {
+		return ErrInvalidMonth
+	}
+	_, err = r.pool.Exec(ctx, `
+		INSERT INTO monthly_group_budgets (household_id, category_group_id, month, amount, currency)
+		VALUES ($1, $2, $3, $4, 'COP')
+		ON CONFLICT (household_id, category_group_id, month) DO NOTHING
+	`, householdID, groupID, monthDate, amount)
+	return err
+}
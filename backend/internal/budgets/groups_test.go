@@ -0,0 +1,130 @@
+package budgets
+
+import (
+	"context"
+	"testing"
+)
+
+// TestNestGroupBudgets tests group spent, allocation and totals with nested categories
+func TestNestGroupBudgets(t *testing.T) {
+	casa := "casa"
+	carro := "carro"
+	budgets := []*BudgetWithSpent{
+		{CategoryID: "mercado", CategoryGroupID: &casa, Amount: 600, EffectiveAmount: 600, Spent: 500},
+		{CategoryID: "aseo", CategoryGroupID: &casa, Amount: 0, EffectiveAmount: 0, Spent: 200},
+		{CategoryID: "gasolina", CategoryGroupID: &carro, Amount: 300, EffectiveAmount: 300, Spent: 100},
+		{CategoryID: "regalos", Amount: 100, EffectiveAmount: 100, Spent: 0},
+	}
+	groups := []*GroupBudgetWithSpent{{CategoryGroupID: casa, Amount: 1000}}
+
+	nestGroupBudgets(groups, budgets)
+	g := groups[0]
+	if len(g.Categories) != 2 {
+		t.Fatalf("len(Categories) = %d, want 2", len(g.Categories))
+	}
+	if g.Spent != 700 || g.Allocated != 600 || g.Unallocated != 400 {
+		t.Errorf("spent, allocated, unallocated = %v, %v, %v, want 700, 600, 400", g.Spent, g.Allocated, g.Unallocated)
+	}
+	if g.OverAllocated {
+		t.Error("OverAllocated = true, want false")
+	}
+	if g.Percentage != 70 || g.Status != "under_budget" {
+		t.Errorf("percentage, status = %v, %q, want 70, under_budget", g.Percentage, g.Status)
+	}
+
+	// Budget items can take the categories past the group budget
+	over := []*GroupBudgetWithSpent{{CategoryGroupID: casa, Amount: 500}}
+	nestGroupBudgets(over, budgets)
+	if !over[0].OverAllocated || over[0].Unallocated != -100 {
+		t.Errorf("over-allocated, unallocated = %v, %v, want true, -100", over[0].OverAllocated, over[0].Unallocated)
+	}
+
+	// The group's 1000 replaces mercado and aseo; gasolina and regalos add up on their own
+	if total := budgetTotal(groups, budgets); total != 1400 {
+		t.Errorf("budgetTotal = %v, want 1400", total)
+	}
+}
+
+// TestAllocatedByGroup tests category budgets are summed per group
+func TestAllocatedByGroup(t *testing.T) {
+	casa := "casa"
+	carro := "carro"
+	budgets := []*BudgetWithSpent{
+		{CategoryID: "mercado", CategoryGroupID: &casa, Amount: 600},
+		{CategoryID: "aseo", CategoryGroupID: &casa, Amount: 150},
+		{CategoryID: "gasolina", CategoryGroupID: &carro, Amount: 300},
+		{CategoryID: "regalos", Amount: 100},
+	}
+
+	allocated := allocatedByGroup(budgets)
+	if len(allocated) != 2 || allocated[casa] != 750 || allocated[carro] != 300 {
+		t.Errorf("allocatedByGroup = %v, want casa 750, carro 300", allocated)
+	}
+}
+
+// capRepo serves the group cap lookups from fixed data: a group budget of 1000
+// for casa in every month, aseo budgeted 700 in 2025-03 and 200 otherwise
+type capRepo struct {
+	Repository
+	mercado float64 // Effective budget of mercado before the write
+}
+
+func (r *capRepo) GetEffectiveGroupBudget(ctx context.Context, householdID, groupID, month string) (float64, bool, error) {
+	return 1000, true, nil
+}
+
+func (r *capRepo) GetByMonth(ctx context.Context, householdID, month string) ([]*BudgetWithSpent, error) {
+	casa := "casa"
+	aseo := 200.0
+	if month == "2025-03" {
+		aseo = 700
+	}
+	return []*BudgetWithSpent{
+		{CategoryID: "mercado", CategoryGroupID: &casa, Amount: r.mercado},
+		{CategoryID: "aseo", CategoryGroupID: &casa, Amount: aseo},
+	}, nil
+}
+
+func (r *capRepo) GetEffectiveBudget(ctx context.Context, householdID, categoryID, month string) (float64, error) {
+	return r.mercado, nil
+}
+
+func (r *capRepo) ListGroupCapMonths(ctx context.Context, householdID, groupID, categoryID, month string) ([]string, error) {
+	var months []string
+	for _, m := range []string{"2025-01", "2025-03"} {
+		if month == "" || m > month {
+			months = append(months, m)
+		}
+	}
+	return months, nil
+}
+
+// TestCheckScopedGroupCap tests the group cap is checked in every month a scope rewrites
+func TestCheckScopedGroupCap(t *testing.T) {
+	ctx := context.Background()
+	casa := "casa"
+	repo := &capRepo{}
+	s := &BudgetService{repo: repo}
+
+	tests := []struct {
+		name    string
+		mercado float64
+		month   string
+		scope   BudgetScope
+		wantErr error
+	}{
+		{"this month fits", 0, "2025-01", ScopeThis, nil},
+		{"future reaches an override month", 0, "2025-01", ScopeFuture, ErrExceedsGroupBudget},
+		{"all skips earlier months without a budget", 0, "2025-04", ScopeAll, nil},
+		{"all rewrites earlier budgeted months", 100, "2025-04", ScopeAll, ErrExceedsGroupBudget},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo.mercado = tt.mercado
+			err := s.checkScopedGroupCap(ctx, "household", &casa, "mercado", tt.month, 500, tt.scope)
+			if err != tt.wantErr {
+				t.Errorf("checkScopedGroupCap() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
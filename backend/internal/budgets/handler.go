@@ -44,8 +44,17 @@ func (h *Handler) GetBudgetsForMonth(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get budgets
-	response, err := h.service.GetByMonth(r.Context(), user.ID, month)
+	// Get budgets; zero-based mode also compares them with the month's real income
+	var response *GetBudgetResponse
+	switch r.URL.Query().Get("mode") {
+	case "", "standard":
+		response, err = h.service.GetByMonth(r.Context(), user.ID, month)
+	case "zero_based":
+		response, err = h.service.GetByMonthZeroBased(r.Context(), user.ID, month)
+	default:
+		http.Error(w, "invalid mode (must be standard or zero_based)", http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		h.logger.Error("failed to get budgets", "error", err, "user_id", user.ID, "month", month)
 		if err == ErrInvalidMonth {
@@ -88,6 +97,7 @@ func (h *Handler) SetBudget(w http.ResponseWriter, r *http.Request) {
 		h.logger.Error("failed to set budget", "error", err, "user_id", user.ID)
 		if err == ErrInvalidMonth || err == ErrInvalidAmount ||
 		   err == ErrBudgetBelowTemplates || err == ErrInvalidScope ||
+		   err == ErrExceedsGroupBudget ||
 		   strings.Contains(err.Error(), "required") {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
@@ -171,7 +181,7 @@ func (h *Handler) CopyBudgets(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		h.logger.Error("failed to copy budgets", "error", err, "user_id", user.ID)
 		w.Header().Set("Content-Type", "application/json")
-		if err == ErrInvalidMonth || strings.Contains(err.Error(), "must be after") {
+		if err == ErrInvalidMonth || err == ErrExceedsGroupBudget || strings.Contains(err.Error(), "must be after") {
 			w.WriteHeader(http.StatusBadRequest)
 			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 			return
@@ -186,6 +196,11 @@ func (h *Handler) CopyBudgets(w http.ResponseWriter, r *http.Request) {
 			json.NewEncoder(w).Encode(map[string]string{"error": "user has no household"})
 			return
 		}
+		if err == ErrNotAuthorized {
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(map[string]string{"error": "forbidden: your role cannot edit budgets"})
+			return
+		}
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(map[string]string{"error": "internal server error"})
 		return
@@ -198,11 +213,57 @@ func (h *Handler) CopyBudgets(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// getUserFromSession extracts the user from the session cookie
-func (h *Handler) getUserFromSession(r *http.Request) (*auth.User, error) {
-	cookie, err := r.Cookie(h.cookieName)
+// SetCategorySettings handles PUT /budgets/settings/{category_id}
+func (h *Handler) SetCategorySettings(w http.ResponseWriter, r *http.Request) {
+	// Get user from session
+	user, err := h.getUserFromSession(r)
 	if err != nil {
-		return nil, err
+		h.logger.Error("failed to get user from session", "error", err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
 	}
-	return h.authSvc.GetUserBySession(r.Context(), cookie.Value)
+
+	categoryID := r.PathValue("category_id")
+	if categoryID == "" {
+		http.Error(w, "category ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var input SetCategorySettingsInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error("failed to decode request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	settings, err := h.service.SetCategorySettings(r.Context(), user.ID, categoryID, &input)
+	if err != nil {
+		h.logger.Error("failed to set category budget settings", "error", err, "user_id", user.ID, "category_id", categoryID)
+		if err == ErrInvalidMonth {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err == ErrCategoryNotFound {
+			http.Error(w, "category not found", http.StatusNotFound)
+			return
+		}
+		if err == ErrNoHousehold {
+			http.Error(w, "user has no household", http.StatusNotFound)
+			return
+		}
+		if err == ErrNotAuthorized {
+			http.Error(w, "forbidden: your role cannot edit budgets", http.StatusForbidden)
+			return
+		}
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(settings)
+}
+
+// getUserFromSession extracts the user from the session cookie or access token
+func (h *Handler) getUserFromSession(r *http.Request) (*auth.User, error) {
+	return h.authSvc.UserFromRequest(r, h.cookieName)
 }
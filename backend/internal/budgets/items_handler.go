@@ -1,30 +1,26 @@
 package budgets
 
 import (
-	"context"
 	"encoding/json"
 	"errors"
 	"log/slog"
 	"net/http"
 
 	"github.com/blanquicet/conti/backend/internal/auth"
+	"github.com/blanquicet/conti/backend/internal/households"
 )
 
 // BudgetItemsHandler handles HTTP requests for monthly budget items
 type BudgetItemsHandler struct {
 	service    *BudgetItemsService
 	authSvc    *auth.Service
-	householdRepo interface {
-		GetUserHouseholdID(ctx context.Context, userID string) (string, error)
-	}
+	householdRepo households.SelectedMemberGetter
 	cookieName string
 	logger     *slog.Logger
 }
 
 // NewBudgetItemsHandler creates a new budget items handler
-func NewBudgetItemsHandler(service *BudgetItemsService, authSvc *auth.Service, householdRepo interface {
-	GetUserHouseholdID(ctx context.Context, userID string) (string, error)
-}, cookieName string, logger *slog.Logger) *BudgetItemsHandler {
+func NewBudgetItemsHandler(service *BudgetItemsService, authSvc *auth.Service, householdRepo households.SelectedMemberGetter, cookieName string, logger *slog.Logger) *BudgetItemsHandler {
 	return &BudgetItemsHandler{
 		service:       service,
 		authSvc:       authSvc,
@@ -49,28 +45,35 @@ func (h *BudgetItemsHandler) getScope(r *http.Request) (BudgetScope, error) {
 	return s, nil
 }
 
-func (h *BudgetItemsHandler) getUserAndHousehold(r *http.Request) (string, string, error) {
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		return "", "", err
-	}
-	user, err := h.authSvc.GetUserBySession(r.Context(), cookie.Value)
+// getUserAndHousehold authenticates the request and checks the user's role in
+// the selected household grants perm
+func (h *BudgetItemsHandler) getUserAndHousehold(r *http.Request, perm households.Permission) (string, string, error) {
+	user, err := h.authSvc.UserFromRequest(r, h.cookieName)
 	if err != nil {
 		return "", "", err
 	}
-	householdID, err := h.householdRepo.GetUserHouseholdID(r.Context(), user.ID)
+	householdID, err := households.AuthorizeSelected(r.Context(), h.householdRepo, user.ID, perm)
 	if err != nil {
 		return "", "", err
 	}
 	return user.ID, householdID, nil
 }
 
+// respondAuthError answers 403 when the user's role is not enough, 401 otherwise
+func (h *BudgetItemsHandler) respondAuthError(w http.ResponseWriter, err error) {
+	if errors.Is(err, households.ErrNotAuthorized) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	http.Error(w, "unauthorized", http.StatusUnauthorized)
+}
+
 // HandleListByMonth returns budget items for a month (with lazy copy)
 // GET /api/budget-items/{month}
 func (h *BudgetItemsHandler) HandleListByMonth(w http.ResponseWriter, r *http.Request) {
-	_, householdID, err := h.getUserAndHousehold(r)
+	_, householdID, err := h.getUserAndHousehold(r, households.PermView)
 	if err != nil {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		h.respondAuthError(w, err)
 		return
 	}
 
@@ -94,9 +97,9 @@ func (h *BudgetItemsHandler) HandleListByMonth(w http.ResponseWriter, r *http.Re
 // HandleGetByID returns a single budget item
 // GET /api/budget-items/item/{id}
 func (h *BudgetItemsHandler) HandleGetByID(w http.ResponseWriter, r *http.Request) {
-	_, householdID, err := h.getUserAndHousehold(r)
+	_, householdID, err := h.getUserAndHousehold(r, households.PermView)
 	if err != nil {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		h.respondAuthError(w, err)
 		return
 	}
 
@@ -118,9 +121,9 @@ func (h *BudgetItemsHandler) HandleGetByID(w http.ResponseWriter, r *http.Reques
 // HandleCreate creates a new budget item
 // POST /api/budget-items
 func (h *BudgetItemsHandler) HandleCreate(w http.ResponseWriter, r *http.Request) {
-	_, householdID, err := h.getUserAndHousehold(r)
+	_, householdID, err := h.getUserAndHousehold(r, households.PermEditBudgets)
 	if err != nil {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		h.respondAuthError(w, err)
 		return
 	}
 
@@ -151,9 +154,9 @@ func (h *BudgetItemsHandler) HandleCreate(w http.ResponseWriter, r *http.Request
 // HandleUpdate updates a budget item
 // PUT /api/budget-items/{id}
 func (h *BudgetItemsHandler) HandleUpdate(w http.ResponseWriter, r *http.Request) {
-	_, householdID, err := h.getUserAndHousehold(r)
+	_, householdID, err := h.getUserAndHousehold(r, households.PermEditBudgets)
 	if err != nil {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		h.respondAuthError(w, err)
 		return
 	}
 
@@ -193,9 +196,9 @@ func (h *BudgetItemsHandler) HandleUpdate(w http.ResponseWriter, r *http.Request
 // HandleDelete deletes a budget item
 // DELETE /api/budget-items/{id}
 func (h *BudgetItemsHandler) HandleDelete(w http.ResponseWriter, r *http.Request) {
-	_, householdID, err := h.getUserAndHousehold(r)
+	_, householdID, err := h.getUserAndHousehold(r, households.PermEditBudgets)
 	if err != nil {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		h.respondAuthError(w, err)
 		return
 	}
 
@@ -0,0 +1,316 @@
+package budgets
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/blanquicet/conti/backend/internal/audit"
+	"github.com/blanquicet/conti/backend/internal/households"
+)
+
+// Errors for period budget operations
+var (
+	ErrInvalidPeriod        = errors.New("invalid period (must be YEARLY or CUSTOM)")
+	ErrInvalidPeriodRange   = errors.New("end_month must not be before start_month")
+	ErrPeriodBudgetNotFound = errors.New("period budget not found")
+	ErrPeriodBudgetsOverlap = errors.New("another custom budget for this category overlaps these months")
+	ErrPocketNotInHousehold = errors.New("pocket not found in this household")
+)
+
+// BudgetPeriod is the length of a budget that spans more than one month
+type BudgetPeriod string
+
+const (
+	PeriodYearly BudgetPeriod = "YEARLY" // A calendar year, inherited by later years
+	PeriodCustom BudgetPeriod = "CUSTOM" // An explicit range of months
+)
+
+// PeriodBudget is a budget for a category over a year or a custom range of months
+type PeriodBudget struct {
+	ID          string       `json:"id"`
+	HouseholdID string       `json:"household_id"`
+	CategoryID  string       `json:"category_id"`
+	Period      BudgetPeriod `json:"period"`
+	StartMonth  time.Time    `json:"start_month"`
+	EndMonth    time.Time    `json:"end_month"` // Inclusive
+	Amount      float64      `json:"amount"`
+	Currency    string       `json:"currency"`
+	SinkingFund bool         `json:"sinking_fund"`
+	PocketID    *string      `json:"pocket_id,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// PeriodBudgetStatus is the period budget that applies to a category in a
+// month, with spending from the start of the period through that month
+type PeriodBudgetStatus struct {
+	ID             string       `json:"id"`
+	Period         BudgetPeriod `json:"period"`
+	StartMonth     string       `json:"start_month"` // YYYY-MM; January of the month's year for YEARLY
+	EndMonth       string       `json:"end_month"`   // YYYY-MM, inclusive
+	Amount         float64      `json:"amount"`
+	Spent          float64      `json:"spent"`            // Period to date, e.g. year to date
+	SpentThisMonth float64      `json:"spent_this_month"` // Also counted in spent
+	Percentage     float64      `json:"percentage"`       // (spent / amount) * 100
+	Status         string       `json:"status"`
+	SinkingFund    *SinkingFund `json:"sinking_fund,omitempty"`
+
+	// Scanned for the sinking fund calculation
+	categoryID    string
+	sinkingFund   bool
+	pocketBalance *float64
+}
+
+// SinkingFund shows how much must still be set aside each month to pay a
+// period budget by its last month
+type SinkingFund struct {
+	Saved               *float64 `json:"saved,omitempty"`      // Balance of the linked pocket
+	MonthsRemaining     int      `json:"months_remaining"`     // Including the current month
+	MonthlyContribution float64  `json:"monthly_contribution"` // Needed per month to cover what is left
+	Progress            float64  `json:"progress"`             // (saved + spent) / amount * 100
+}
+
+// SetPeriodBudgetInput represents input for setting a yearly or custom budget
+type SetPeriodBudgetInput struct {
+	CategoryID  string       `json:"category_id"`
+	Period      BudgetPeriod `json:"period"`
+	StartMonth  string       `json:"start_month"`         // YYYY-MM; any month of the year for YEARLY
+	EndMonth    string       `json:"end_month,omitempty"` // YYYY-MM; CUSTOM only
+	Amount      float64      `json:"amount"`
+	SinkingFund bool         `json:"sinking_fund"`
+	PocketID    *string      `json:"pocket_id,omitempty"`
+	Scope       BudgetScope  `json:"scope,omitempty"` // YEARLY only: THIS, FUTURE, ALL (default: FUTURE)
+}
+
+// Validate validates the input and returns the normalized first and last month
+func (i *SetPeriodBudgetInput) Validate() (time.Time, time.Time, error) {
+	if i.CategoryID == "" {
+		return time.Time{}, time.Time{}, errors.New("category_id is required")
+	}
+	if i.Amount < 0 {
+		return time.Time{}, time.Time{}, ErrInvalidAmount
+	}
+	if i.Scope != "" && i.Scope != ScopeThis && i.Scope != ScopeFuture && i.Scope != ScopeAll {
+		return time.Time{}, time.Time{}, ErrInvalidScope
+	}
+	start, err := ParseMonth(i.StartMonth)
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrInvalidMonth
+	}
+
+	switch i.Period {
+	case PeriodYearly:
+		start = time.Date(start.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 11, 0), nil
+	case PeriodCustom:
+		end, err := ParseMonth(i.EndMonth)
+		if err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidMonth
+		}
+		if end.Before(start) {
+			return time.Time{}, time.Time{}, ErrInvalidPeriodRange
+		}
+		return start, end, nil
+	}
+	return time.Time{}, time.Time{}, ErrInvalidPeriod
+}
+
+// monthsBetween returns the number of months from one month to another, inclusive
+func monthsBetween(from, to time.Time) int {
+	return (to.Year()-from.Year())*12 + int(to.Month()-from.Month()) + 1
+}
+
+// calculate fills percentage, status and, for sinking funds, the contribution
+// still needed per month from month through the end of the period
+func (p *PeriodBudgetStatus) calculate(month time.Time) {
+	if p.Amount > 0 {
+		p.Percentage = (p.Spent / p.Amount) * 100
+	}
+	p.Status = CalculateBudgetStatus(p.Percentage)
+
+	if !p.sinkingFund {
+		return
+	}
+	end, _ := ParseMonth(p.EndMonth)
+	fund := &SinkingFund{
+		Saved:           p.pocketBalance,
+		MonthsRemaining: monthsBetween(month, end),
+	}
+	covered := p.Spent
+	if p.pocketBalance != nil {
+		covered += *p.pocketBalance
+	}
+	if left := p.Amount - covered; left > 0 && fund.MonthsRemaining > 0 {
+		fund.MonthlyContribution = left / float64(fund.MonthsRemaining)
+	}
+	if p.Amount > 0 {
+		fund.Progress = (covered / p.Amount) * 100
+	}
+	p.SinkingFund = fund
+}
+
+// ListPeriodBudgets returns the household's yearly and custom budgets
+func (s *BudgetService) ListPeriodBudgets(ctx context.Context, userID string) ([]*PeriodBudget, error) {
+	householdID, err := s.getUserHouseholdID(ctx, userID, households.PermView)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListPeriodBudgets(ctx, householdID)
+}
+
+// SetPeriodBudget creates or updates a yearly or custom budget. Yearly budgets
+// follow the same scopes as monthly ones, applied to years: THIS changes only
+// that year, FUTURE also drops later overrides and ALL updates every year.
+func (s *BudgetService) SetPeriodBudget(ctx context.Context, userID string, input *SetPeriodBudgetInput) (*PeriodBudget, error) {
+	start, end, err := input.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	householdID, err := s.getUserHouseholdID(ctx, userID, households.PermEditBudgets)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkCategory(ctx, householdID, input.CategoryID); err != nil {
+		return nil, err
+	}
+	if input.PocketID != nil {
+		ok, err := s.repo.PocketInHousehold(ctx, householdID, *input.PocketID)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, ErrPocketNotInHousehold
+		}
+	}
+
+	budget := &PeriodBudget{
+		HouseholdID: householdID,
+		CategoryID:  input.CategoryID,
+		Period:      input.Period,
+		StartMonth:  start,
+		EndMonth:    end,
+		Amount:      input.Amount,
+		SinkingFund: input.SinkingFund,
+		PocketID:    input.PocketID,
+	}
+
+	scope := input.Scope
+	if scope == "" {
+		scope = ScopeFuture
+	}
+
+	var previous *PeriodBudget
+	switch input.Period {
+	case PeriodCustom:
+		overlaps, err := s.repo.HasOverlappingPeriodBudget(ctx, householdID, input.CategoryID, start, end)
+		if err != nil {
+			return nil, err
+		}
+		if overlaps {
+			return nil, ErrPeriodBudgetsOverlap
+		}
+	case PeriodYearly:
+		if scope == ScopeThis {
+			// Captured before the upsert so the next year can keep it
+			previous, err = s.repo.GetEffectiveYearlyBudget(ctx, householdID, input.CategoryID, start)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	saved, err := s.repo.SetPeriodBudget(ctx, budget)
+	if err != nil {
+		s.auditService.LogAsync(ctx, &audit.LogInput{
+			Action:       audit.ActionBudgetCreated,
+			ResourceType: "period_budget",
+			UserID:       audit.StringPtr(userID),
+			HouseholdID:  audit.StringPtr(householdID),
+			Success:      false,
+			ErrorMessage: audit.StringPtr(err.Error()),
+		})
+		return nil, err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		Action:       audit.ActionBudgetCreated,
+		ResourceType: "period_budget",
+		ResourceID:   audit.StringPtr(saved.ID),
+		UserID:       audit.StringPtr(userID),
+		HouseholdID:  audit.StringPtr(householdID),
+		Success:      true,
+		NewValues:    audit.StructToMap(saved),
+	})
+
+	if input.Period == PeriodYearly {
+		switch scope {
+		case ScopeFuture:
+			s.repo.DeleteLaterYearlyBudgets(ctx, householdID, input.CategoryID, start)
+		case ScopeAll:
+			s.repo.UpdateAllYearlyBudgets(ctx, saved)
+		case ScopeThis:
+			// Pin next year to the old budget so the change doesn't carry over
+			if previous != nil {
+				next := *previous
+				next.StartMonth = start.AddDate(1, 0, 0)
+				next.EndMonth = end.AddDate(1, 0, 0)
+				s.repo.PinYearlyBudgetIfMissing(ctx, &next)
+			}
+		}
+	}
+
+	return saved, nil
+}
+
+// DeletePeriodBudget deletes a yearly or custom budget
+func (s *BudgetService) DeletePeriodBudget(ctx context.Context, userID, id string) error {
+	budget, err := s.repo.GetPeriodBudget(ctx, id)
+	if err != nil {
+		return err
+	}
+	if _, err := households.Authorize(ctx, s.householdRepo, budget.HouseholdID, userID, households.PermEditBudgets); err != nil {
+		if errors.Is(err, households.ErrNotAuthorized) {
+			return ErrNotAuthorized
+		}
+		return err
+	}
+
+	if err := s.repo.DeletePeriodBudget(ctx, id); err != nil {
+		return err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		Action:       audit.ActionBudgetDeleted,
+		ResourceType: "period_budget",
+		ResourceID:   audit.StringPtr(id),
+		UserID:       audit.StringPtr(userID),
+		HouseholdID:  audit.StringPtr(budget.HouseholdID),
+		Success:      true,
+		OldValues:    audit.StructToMap(budget),
+	})
+
+	return nil
+}
+
+// attachPeriodBudgets adds to each category row the period budget that
+// applies to the month, if any
+func (s *BudgetService) attachPeriodBudgets(ctx context.Context, householdID, month string, budgets []*BudgetWithSpent) error {
+	statuses, err := s.repo.GetPeriodBudgetStatuses(ctx, householdID, month)
+	if err != nil {
+		return err
+	}
+	if len(statuses) == 0 {
+		return nil
+	}
+
+	byCategory := make(map[string]*PeriodBudgetStatus, len(statuses))
+	for _, st := range statuses {
+		byCategory[st.categoryID] = st
+	}
+	for _, b := range budgets {
+		b.PeriodBudget = byCategory[b.CategoryID]
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package budgets
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// ListPeriodBudgets handles GET /budgets/periods
+func (h *Handler) ListPeriodBudgets(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserFromSession(r)
+	if err != nil {
+		h.logger.Error("failed to get user from session", "error", err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	budgets, err := h.service.ListPeriodBudgets(r.Context(), user.ID)
+	if err != nil {
+		h.logger.Error("failed to list period budgets", "error", err, "user_id", user.ID)
+		h.writePeriodError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"period_budgets": budgets,
+	})
+}
+
+// SetPeriodBudget handles PUT /budgets/periods
+func (h *Handler) SetPeriodBudget(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserFromSession(r)
+	if err != nil {
+		h.logger.Error("failed to get user from session", "error", err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var input SetPeriodBudgetInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error("failed to decode request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	budget, err := h.service.SetPeriodBudget(r.Context(), user.ID, &input)
+	if err != nil {
+		h.logger.Error("failed to set period budget", "error", err, "user_id", user.ID)
+		h.writePeriodError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(budget)
+}
+
+// DeletePeriodBudget handles DELETE /budgets/periods/{id}
+func (h *Handler) DeletePeriodBudget(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserFromSession(r)
+	if err != nil {
+		h.logger.Error("failed to get user from session", "error", err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id := r.PathValue("id")
+	if id == "" {
+		http.Error(w, "period budget ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeletePeriodBudget(r.Context(), user.ID, id); err != nil {
+		h.logger.Error("failed to delete period budget", "error", err, "user_id", user.ID, "period_budget_id", id)
+		h.writePeriodError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writePeriodError maps period budget errors to HTTP responses
+func (h *Handler) writePeriodError(w http.ResponseWriter, err error) {
+	switch {
+	case err == ErrInvalidMonth, err == ErrInvalidAmount, err == ErrInvalidScope,
+		err == ErrInvalidPeriod, err == ErrInvalidPeriodRange, err == ErrPocketNotInHousehold,
+		strings.Contains(err.Error(), "required"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case err == ErrPeriodBudgetsOverlap:
+		http.Error(w, err.Error(), http.StatusConflict)
+	case err == ErrPeriodBudgetNotFound:
+		http.Error(w, "period budget not found", http.StatusNotFound)
+	case err == ErrCategoryNotFound:
+		http.Error(w, "category not found", http.StatusNotFound)
+	case err == ErrNoHousehold:
+		http.Error(w, "user has no household", http.StatusNotFound)
+	case err == ErrNotAuthorized:
+		http.Error(w, "forbidden: your role cannot edit budgets", http.StatusForbidden)
+	default:
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,253 @@
+package budgets
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const periodBudgetColumns = `id, household_id, category_id, period, start_month, end_month, amount, currency, sinking_fund, pocket_id, created_at, updated_at`
+
+func scanPeriodBudget(row pgx.Row) (*PeriodBudget, error) {
+	var b PeriodBudget
+	err := row.Scan(
+		&b.ID,
+		&b.HouseholdID,
+		&b.CategoryID,
+		&b.Period,
+		&b.StartMonth,
+		&b.EndMonth,
+		&b.Amount,
+		&b.Currency,
+		&b.SinkingFund,
+		&b.PocketID,
+		&b.CreatedAt,
+		&b.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// SetPeriodBudget creates or updates a period budget keyed by category, period and start month
+func (r *PostgresRepository) SetPeriodBudget(ctx context.Context, budget *PeriodBudget) (*PeriodBudget, error) {
+	return scanPeriodBudget(r.pool.QueryRow(ctx, `
+		INSERT INTO period_budgets (household_id, category_id, period, start_month, end_month, amount, currency, sinking_fund, pocket_id)
+		VALUES ($1, $2, $3, $4, $5, $6, 'COP', $7, $8)
+		ON CONFLICT (household_id, category_id, period, start_month)
+		DO UPDATE SET end_month = EXCLUDED.end_month, amount = EXCLUDED.amount,
+			sinking_fund = EXCLUDED.sinking_fund, pocket_id = EXCLUDED.pocket_id, updated_at = NOW()
+		RETURNING `+periodBudgetColumns,
+		budget.HouseholdID, budget.CategoryID, budget.Period, budget.StartMonth, budget.EndMonth,
+		budget.Amount, budget.SinkingFund, budget.PocketID,
+	))
+}
+
+// GetPeriodBudget returns a period budget by ID
+func (r *PostgresRepository) GetPeriodBudget(ctx context.Context, id string) (*PeriodBudget, error) {
+	budget, err := scanPeriodBudget(r.pool.QueryRow(ctx, `
+		SELECT `+periodBudgetColumns+` FROM period_budgets WHERE id = $1
+	`, id))
+	if err == pgx.ErrNoRows {
+		return nil, ErrPeriodBudgetNotFound
+	}
+	return budget, err
+}
+
+// ListPeriodBudgets returns all period budgets of a household
+func (r *PostgresRepository) ListPeriodBudgets(ctx context.Context, householdID string) ([]*PeriodBudget, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+periodBudgetColumns+`
+		FROM period_budgets
+		WHERE household_id = $1
+		ORDER BY category_id, period, start_month
+	`, householdID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	budgets := []*PeriodBudget{}
+	for rows.Next() {
+		budget, err := scanPeriodBudget(rows)
+		if err != nil {
+			return nil, err
+		}
+		budgets = append(budgets, budget)
+	}
+	return budgets, rows.Err()
+}
+
+// DeletePeriodBudget deletes a period budget by ID
+func (r *PostgresRepository) DeletePeriodBudget(ctx context.Context, id string) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM period_budgets WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrPeriodBudgetNotFound
+	}
+	return nil
+}
+
+// HasOverlappingPeriodBudget reports whether another custom budget of the
+// category covers any month in the range. The budget starting at the same
+// month is the one being updated and is not counted.
+func (r *PostgresRepository) HasOverlappingPeriodBudget(ctx context.Context, householdID, categoryID string, start, end time.Time) (bool, error) {
+	var overlaps bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM period_budgets
+			WHERE household_id = $1 AND category_id = $2 AND period = 'CUSTOM'
+				AND start_month <> $3 AND start_month <= $4 AND end_month >= $3
+		)
+	`, householdID, categoryID, start, end).Scan(&overlaps)
+	return overlaps, err
+}
+
+// GetEffectiveYearlyBudget returns the yearly budget inherited by the year
+// starting at yearStart, or nil when there is none
+func (r *PostgresRepository) GetEffectiveYearlyBudget(ctx context.Context, householdID, categoryID string, yearStart time.Time) (*PeriodBudget, error) {
+	budget, err := scanPeriodBudget(r.pool.QueryRow(ctx, `
+		SELECT `+periodBudgetColumns+`
+		FROM period_budgets
+		WHERE household_id = $1 AND category_id = $2 AND period = 'YEARLY' AND start_month <= $3
+		ORDER BY start_month DESC
+		LIMIT 1
+	`, householdID, categoryID, yearStart))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return budget, err
+}
+
+// DeleteLaterYearlyBudgets deletes the yearly budgets of a category for years after yearStart
+func (r *PostgresRepository) DeleteLaterYearlyBudgets(ctx context.Context, householdID, categoryID string, yearStart time.Time) (int64, error) {
+	result, err := r.pool.Exec(ctx, `
+		DELETE FROM period_budgets
+		WHERE household_id = $1 AND category_id = $2 AND period = 'YEARLY' AND start_month > $3
+	`, householdID, categoryID, yearStart)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+// UpdateAllYearlyBudgets copies amount and sinking fund settings to every yearly budget of the category
+func (r *PostgresRepository) UpdateAllYearlyBudgets(ctx context.Context, budget *PeriodBudget) (int64, error) {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE period_budgets
+		SET amount = $3, sinking_fund = $4, pocket_id = $5, updated_at = NOW()
+		WHERE household_id = $1 AND category_id = $2 AND period = 'YEARLY'
+	`, budget.HouseholdID, budget.CategoryID, budget.Amount, budget.SinkingFund, budget.PocketID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+// PinYearlyBudgetIfMissing inserts a yearly budget only if none exists for that year
+func (r *PostgresRepository) PinYearlyBudgetIfMissing(ctx context.Context, budget *PeriodBudget) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO period_budgets (household_id, category_id, period, start_month, end_month, amount, currency, sinking_fund, pocket_id)
+		VALUES ($1, $2, 'YEARLY', $3, $4, $5, 'COP', $6, $7)
+		ON CONFLICT (household_id, category_id, period, start_month) DO NOTHING
+	`, budget.HouseholdID, budget.CategoryID, budget.StartMonth, budget.EndMonth, budget.Amount, budget.SinkingFund, budget.PocketID)
+	return err
+}
+
+// PocketInHousehold reports whether a pocket belongs to the household
+func (r *PostgresRepository) PocketInHousehold(ctx context.Context, householdID, pocketID string) (bool, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM pockets WHERE id = $1 AND household_id = $2)
+	`, pocketID, householdID).Scan(&exists)
+	return exists, err
+}
+
+// GetPeriodBudgetStatuses returns, per category, the period budget covering
+// the month: a custom range containing it, otherwise the yearly budget
+// inherited by its year. Spent runs from the start of the period (January for
+// yearly budgets) through the end of the month.
+func (r *PostgresRepository) GetPeriodBudgetStatuses(ctx context.Context, householdID, month string) ([]*PeriodBudgetStatus, error) {
+	monthDate, err := ParseMonth(month)
+	if err != nil {
+		return nil, ErrInvalidMonth
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		WITH applicable AS (
+			SELECT DISTINCT ON (pb.category_id)
+				pb.id,
+				pb.category_id,
+				pb.period,
+				pb.amount,
+				pb.sinking_fund,
+				pb.pocket_id,
+				CASE WHEN pb.period = 'YEARLY' THEN DATE_TRUNC('year', $2::date)::date ELSE pb.start_month END as window_start,
+				CASE WHEN pb.period = 'YEARLY' THEN (DATE_TRUNC('year', $2::date) + INTERVAL '11 months')::date ELSE pb.end_month END as window_end
+			FROM period_budgets pb
+			JOIN categories c ON c.id = pb.category_id AND c.is_active = true
+			WHERE pb.household_id = $1
+				AND ((pb.period = 'CUSTOM' AND pb.start_month <= $2 AND pb.end_month >= $2)
+					OR (pb.period = 'YEARLY' AND pb.start_month <= $2))
+			ORDER BY pb.category_id, pb.period = 'CUSTOM' DESC, pb.start_month DESC
+		)
+		SELECT
+			a.id,
+			a.category_id,
+			a.period,
+			a.window_start,
+			a.window_end,
+			a.amount,
+			a.sinking_fund,
+			COALESCE(sp.spent, 0),
+			COALESCE(sp.spent_this_month, 0),
+			CASE WHEN a.pocket_id IS NULL THEN NULL ELSE (
+				SELECT COALESCE(SUM(CASE WHEN type = 'DEPOSIT' THEN amount ELSE -amount END), 0)
+				FROM pocket_transactions
+				WHERE pocket_id = a.pocket_id
+			) END as pocket_balance
+		FROM applicable a
+		LEFT JOIN LATERAL (
+			SELECT
+				SUM(amount) as spent,
+				SUM(amount) FILTER (WHERE DATE_TRUNC('month', movement_date) = $2) as spent_this_month
+			FROM movements
+			WHERE household_id = $1 AND category_id = a.category_id
+				AND movement_date >= a.window_start
+				AND movement_date < $2::date + INTERVAL '1 month'
+		) sp ON true
+	`, householdID, monthDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var statuses []*PeriodBudgetStatus
+	for rows.Next() {
+		var st PeriodBudgetStatus
+		var start, end time.Time
+		if err := rows.Scan(
+			&st.ID,
+			&st.categoryID,
+			&st.Period,
+			&start,
+			&end,
+			&st.Amount,
+			&st.sinkingFund,
+			&st.Spent,
+			&st.SpentThisMonth,
+			&st.pocketBalance,
+		); err != nil {
+			return nil, err
+		}
+		st.StartMonth = FormatMonth(start)
+		st.EndMonth = FormatMonth(end)
+		st.calculate(monthDate)
+		statuses = append(statuses, &st)
+	}
+	return statuses, rows.Err()
+}
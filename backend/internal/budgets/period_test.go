@@ -0,0 +1,96 @@
+package budgets
+
+import (
+	"testing"
+)
+
+// TestSetPeriodBudgetInputValidate tests period validation and month normalization
+func TestSetPeriodBudgetInputValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     SetPeriodBudgetInput
+		wantStart string
+		wantEnd   string
+		wantErr   error
+	}{
+		{
+			name:      "Yearly normalizes to the calendar year",
+			input:     SetPeriodBudgetInput{CategoryID: "soat", Period: PeriodYearly, StartMonth: "2026-07", Amount: 900000},
+			wantStart: "2026-01",
+			wantEnd:   "2026-12",
+		},
+		{
+			name:      "Custom range",
+			input:     SetPeriodBudgetInput{CategoryID: "regalos", Period: PeriodCustom, StartMonth: "2026-02", EndMonth: "2026-12", Amount: 1200000},
+			wantStart: "2026-02",
+			wantEnd:   "2026-12",
+		},
+		{
+			name:    "Custom range backwards",
+			input:   SetPeriodBudgetInput{CategoryID: "regalos", Period: PeriodCustom, StartMonth: "2026-12", EndMonth: "2026-02"},
+			wantErr: ErrInvalidPeriodRange,
+		},
+		{
+			name:    "Unknown period",
+			input:   SetPeriodBudgetInput{CategoryID: "soat", Period: "WEEKLY", StartMonth: "2026-01"},
+			wantErr: ErrInvalidPeriod,
+		},
+		{
+			name:    "Invalid scope",
+			input:   SetPeriodBudgetInput{CategoryID: "soat", Period: PeriodYearly, StartMonth: "2026-01", Scope: "NEXT"},
+			wantErr: ErrInvalidScope,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := tt.input.Validate()
+			if err != tt.wantErr {
+				t.Fatalf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if FormatMonth(start) != tt.wantStart || FormatMonth(end) != tt.wantEnd {
+				t.Errorf("range = %s..%s, want %s..%s", FormatMonth(start), FormatMonth(end), tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+// TestPeriodBudgetStatusSinkingFund tests the monthly contribution of a sinking fund
+func TestPeriodBudgetStatusSinkingFund(t *testing.T) {
+	march, _ := ParseMonth("2026-03")
+
+	// Christmas gifts: 1.200.000 by December, nothing spent, 300.000 saved in a pocket
+	saved := 300000.0
+	st := &PeriodBudgetStatus{
+		Period:        PeriodYearly,
+		StartMonth:    "2026-01",
+		EndMonth:      "2026-12",
+		Amount:        1200000,
+		sinkingFund:   true,
+		pocketBalance: &saved,
+	}
+	st.calculate(march)
+
+	if st.SinkingFund == nil {
+		t.Fatal("expected sinking fund status")
+	}
+	if st.SinkingFund.MonthsRemaining != 10 {
+		t.Errorf("MonthsRemaining = %d, want 10", st.SinkingFund.MonthsRemaining)
+	}
+	if st.SinkingFund.MonthlyContribution != 90000 {
+		t.Errorf("MonthlyContribution = %v, want 90000", st.SinkingFund.MonthlyContribution)
+	}
+	if st.SinkingFund.Progress != 25 {
+		t.Errorf("Progress = %v, want 25", st.SinkingFund.Progress)
+	}
+
+	// A plain yearly budget reports year-to-date usage only
+	plain := &PeriodBudgetStatus{Period: PeriodYearly, StartMonth: "2026-01", EndMonth: "2026-12", Amount: 900000, Spent: 900000}
+	plain.calculate(march)
+	if plain.SinkingFund != nil || plain.Status != "exceeded" {
+		t.Errorf("plain yearly budget: sinking fund %v, status %q", plain.SinkingFund, plain.Status)
+	}
+}
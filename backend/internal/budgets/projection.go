@@ -0,0 +1,161 @@
+package budgets
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/blanquicet/conti/backend/internal/audit"
+	"github.com/blanquicet/conti/backend/internal/households"
+)
+
+// ErrInvalidProjection is returned for an unknown projection method or month count
+var ErrInvalidProjection = errors.New("invalid projection (method must be PACE, PLANNED or NONE and months between 1 and 12)")
+
+// ProjectionMethod selects how a category's month-end spending is projected
+type ProjectionMethod string
+
+const (
+	ProjectionPace    ProjectionMethod = "PACE"    // Pending recurring spending plus the usual discretionary spending for the rest of the month
+	ProjectionPlanned ProjectionMethod = "PLANNED" // Pending recurring spending only, for fixed-cost categories
+	ProjectionNone    ProjectionMethod = "NONE"    // No projection
+)
+
+// DefaultProjectionMonths is how many past months the PACE estimate averages by default
+const DefaultProjectionMonths = 3
+
+// ProjectionBasis is what a category's projection adds to the month's spent
+type ProjectionBasis struct {
+	CategoryID string
+	Method     ProjectionMethod
+	Months     int
+	// Recurring templates and budget items of the month with no movement yet
+	Pending float64
+	// Average monthly spending not generated from a template over the last Months months
+	DiscretionaryAvg float64
+}
+
+// SetCategoryProjectionInput represents input for choosing a category's projection method
+type SetCategoryProjectionInput struct {
+	Method ProjectionMethod `json:"method"`
+	Months int              `json:"months,omitempty"` // PACE lookback; defaults to 3
+}
+
+// Validate validates the set category projection input
+func (i *SetCategoryProjectionInput) Validate() error {
+	if i.Method != ProjectionPace && i.Method != ProjectionPlanned && i.Method != ProjectionNone {
+		return ErrInvalidProjection
+	}
+	if i.Months == 0 {
+		i.Months = DefaultProjectionMonths
+	}
+	if i.Months < 1 || i.Months > 12 {
+		return ErrInvalidProjection
+	}
+	return nil
+}
+
+// remainingFraction returns the share of month still ahead of now: 0 for past
+// months, 1 for future months and the days left after today otherwise
+func remainingFraction(month, now time.Time) float64 {
+	current := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	switch {
+	case month.Before(current):
+		return 0
+	case month.After(current):
+		return 1
+	}
+	days := current.AddDate(0, 1, -1).Day()
+	return float64(days-now.Day()) / float64(days)
+}
+
+// applyProjection sets the projected spent and status of a category for a
+// month with the given share still ahead. A closed month projects what was spent.
+func (b *BudgetWithSpent) applyProjection(basis *ProjectionBasis, remaining float64) {
+	b.ProjectionMethod = basis.Method
+	if basis.Method == ProjectionNone {
+		return
+	}
+
+	projected := b.Spent
+	if remaining > 0 {
+		projected += basis.Pending
+		if basis.Method == ProjectionPace {
+			projected += basis.DiscretionaryAvg * remaining
+		}
+	}
+	b.ProjectedSpent = &projected
+
+	var percentage float64
+	if b.EffectiveAmount > 0 {
+		percentage = (projected / b.EffectiveAmount) * 100
+	} else if projected > 0 {
+		percentage = 100
+	}
+	b.ProjectedStatus = CalculateBudgetStatus(percentage)
+}
+
+// attachProjections projects each category's month-end spending as of now
+func (s *BudgetService) attachProjections(ctx context.Context, householdID, month string, budgets []*BudgetWithSpent, now time.Time) error {
+	bases, err := s.repo.GetProjectionBases(ctx, householdID, month)
+	if err != nil {
+		return err
+	}
+
+	byCategory := make(map[string]*ProjectionBasis, len(bases))
+	for _, basis := range bases {
+		byCategory[basis.CategoryID] = basis
+	}
+
+	monthDate, _ := ParseMonth(month)
+	remaining := remainingFraction(monthDate, now)
+	for _, b := range budgets {
+		basis, ok := byCategory[b.CategoryID]
+		if !ok {
+			basis = &ProjectionBasis{CategoryID: b.CategoryID, Method: ProjectionPace, Months: DefaultProjectionMonths}
+		}
+		b.applyProjection(basis, remaining)
+	}
+	return nil
+}
+
+// SetCategoryProjection sets how a category's month-end spending is projected
+func (s *BudgetService) SetCategoryProjection(ctx context.Context, userID, categoryID string, input *SetCategoryProjectionInput) (*CategoryBudgetSettings, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	householdID, err := s.getUserHouseholdID(ctx, userID, households.PermEditBudgets)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkCategory(ctx, householdID, categoryID); err != nil {
+		return nil, err
+	}
+
+	settings, err := s.repo.SetCategoryProjection(ctx, categoryID, input.Method, input.Months)
+	if err != nil {
+		s.auditService.LogAsync(ctx, &audit.LogInput{
+			Action:       audit.ActionBudgetUpdated,
+			ResourceType: "category_budget_settings",
+			ResourceID:   audit.StringPtr(categoryID),
+			UserID:       audit.StringPtr(userID),
+			HouseholdID:  audit.StringPtr(householdID),
+			Success:      false,
+			ErrorMessage: audit.StringPtr(err.Error()),
+		})
+		return nil, err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		Action:       audit.ActionBudgetUpdated,
+		ResourceType: "category_budget_settings",
+		ResourceID:   audit.StringPtr(categoryID),
+		UserID:       audit.StringPtr(userID),
+		HouseholdID:  audit.StringPtr(householdID),
+		Success:      true,
+		NewValues:    audit.StructToMap(settings),
+	})
+
+	return settings, nil
+}
@@ -0,0 +1,50 @@
+package budgets
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// SetCategoryProjection handles PUT /budgets/settings/{category_id}/projection
+func (h *Handler) SetCategoryProjection(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserFromSession(r)
+	if err != nil {
+		h.logger.Error("failed to get user from session", "error", err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	categoryID := r.PathValue("category_id")
+	if categoryID == "" {
+		http.Error(w, "category ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var input SetCategoryProjectionInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error("failed to decode request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	settings, err := h.service.SetCategoryProjection(r.Context(), user.ID, categoryID, &input)
+	if err != nil {
+		h.logger.Error("failed to set category projection", "error", err, "user_id", user.ID, "category_id", categoryID)
+		switch err {
+		case ErrInvalidProjection:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case ErrCategoryNotFound:
+			http.Error(w, "category not found", http.StatusNotFound)
+		case ErrNoHousehold:
+			http.Error(w, "user has no household", http.StatusNotFound)
+		case ErrNotAuthorized:
+			http.Error(w, "forbidden: your role cannot edit budgets", http.StatusForbidden)
+		default:
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(settings)
+}
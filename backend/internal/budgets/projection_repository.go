@@ -0,0 +1,103 @@
+package budgets
+
+import (
+	"context"
+)
+
+// SetCategoryProjection creates or updates the projection settings of a category
+func (r *PostgresRepository) SetCategoryProjection(ctx context.Context, categoryID string, method ProjectionMethod, months int) (*CategoryBudgetSettings, error) {
+	var settings CategoryBudgetSettings
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO category_budget_settings (category_id, projection_method, projection_months)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (category_id)
+		DO UPDATE SET projection_method = EXCLUDED.projection_method, projection_months = EXCLUDED.projection_months, updated_at = NOW()
+		RETURNING category_id, rollover, rollover_since, projection_method, projection_months, updated_at
+	`, categoryID, method, months).Scan(
+		&settings.CategoryID,
+		&settings.Rollover,
+		&settings.RolloverSince,
+		&settings.ProjectionMethod,
+		&settings.ProjectionMonths,
+		&settings.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// GetProjectionBases returns, per active category, its projection settings,
+// the month's budget items and recurring templates that have no movement yet,
+// and the average monthly spending not generated from a template over the
+// category's lookback months before month. Templates already snapshotted as a
+// budget item of the month are counted once, through the item.
+func (r *PostgresRepository) GetProjectionBases(ctx context.Context, householdID, month string) ([]*ProjectionBasis, error) {
+	monthDate, err := ParseMonth(month)
+	if err != nil {
+		return nil, ErrInvalidMonth
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		WITH used_templates AS (
+			SELECT DISTINCT generated_from_template_id AS template_id
+			FROM movements
+			WHERE household_id = $1
+				AND generated_from_template_id IS NOT NULL
+				AND DATE_TRUNC('month', movement_date) = $2
+		)
+		SELECT
+			c.id,
+			COALESCE(cbs.projection_method, 'PACE'),
+			COALESCE(cbs.projection_months, 3),
+			COALESCE(pi.amount, 0) + COALESCE(pt.amount, 0),
+			COALESCE(h.spent, 0) / COALESCE(cbs.projection_months, 3)
+		FROM categories c
+		LEFT JOIN category_budget_settings cbs ON cbs.category_id = c.id
+		LEFT JOIN LATERAL (
+			SELECT SUM(i.amount) AS amount
+			FROM monthly_budget_items i
+			WHERE i.household_id = $1 AND i.category_id = c.id AND i.month = $2
+				AND (i.source_template_id IS NULL
+					OR i.source_template_id NOT IN (SELECT template_id FROM used_templates))
+		) pi ON true
+		LEFT JOIN LATERAL (
+			SELECT SUM(t.amount) AS amount
+			FROM recurring_movement_templates t
+			WHERE t.household_id = $1 AND t.category_id = c.id AND t.is_active = true
+				AND t.id NOT IN (SELECT template_id FROM used_templates)
+				AND NOT EXISTS (
+					SELECT 1 FROM monthly_budget_items i
+					WHERE i.household_id = $1 AND i.month = $2 AND i.source_template_id = t.id
+				)
+				AND (t.start_date IS NULL OR t.start_date < $2::date + INTERVAL '1 month')
+				AND (t.recurrence_pattern IS NULL
+					OR t.recurrence_pattern = 'MONTHLY'
+					OR (t.recurrence_pattern = 'YEARLY' AND t.month_of_year = EXTRACT(MONTH FROM $2::date))
+					OR (t.recurrence_pattern = 'ONE_TIME' AND DATE_TRUNC('month', t.start_date) = $2))
+		) pt ON true
+		LEFT JOIN LATERAL (
+			SELECT SUM(m.amount) AS spent
+			FROM movements m
+			WHERE m.household_id = $1 AND m.category_id = c.id
+				AND m.generated_from_template_id IS NULL
+				AND m.movement_date >= $2::date - MAKE_INTERVAL(months => COALESCE(cbs.projection_months, 3))
+				AND m.movement_date < $2
+		) h ON true
+		WHERE c.household_id = $1 AND c.is_active = true
+	`, householdID, monthDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bases []*ProjectionBasis
+	for rows.Next() {
+		var b ProjectionBasis
+		if err := rows.Scan(&b.CategoryID, &b.Method, &b.Months, &b.Pending, &b.DiscretionaryAvg); err != nil {
+			return nil, err
+		}
+		bases = append(bases, &b)
+	}
+	return bases, rows.Err()
+}
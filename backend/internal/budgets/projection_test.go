@@ -0,0 +1,56 @@
+package budgets
+
+import (
+	"testing"
+	"time"
+)
+
+// TestRemainingFraction tests the share of a month still ahead
+func TestRemainingFraction(t *testing.T) {
+	now := time.Date(2026, time.April, 10, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		month string
+		want  float64
+	}{
+		{"2026-03", 0},
+		{"2026-04", 20.0 / 30.0},
+		{"2026-05", 1},
+	}
+	for _, tt := range tests {
+		month, _ := ParseMonth(tt.month)
+		if got := remainingFraction(month, now); got != tt.want {
+			t.Errorf("remainingFraction(%s) = %v, want %v", tt.month, got, tt.want)
+		}
+	}
+}
+
+// TestApplyProjection tests projected spent and status per method
+func TestApplyProjection(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     ProjectionMethod
+		remaining  float64
+		wantSpent  *float64
+		wantStatus string
+	}{
+		{"pace", ProjectionPace, 0.5, floatPtr(1000), "exceeded"},
+		{"planned", ProjectionPlanned, 0.5, floatPtr(700), "on_track"},
+		{"closed month", ProjectionPace, 0, floatPtr(400), "under_budget"},
+		{"none", ProjectionNone, 0.5, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &BudgetWithSpent{Amount: 800, EffectiveAmount: 800, Spent: 400}
+			b.applyProjection(&ProjectionBasis{Method: tt.method, Pending: 300, DiscretionaryAvg: 600}, tt.remaining)
+			if (b.ProjectedSpent == nil) != (tt.wantSpent == nil) ||
+				(b.ProjectedSpent != nil && *b.ProjectedSpent != *tt.wantSpent) {
+				t.Errorf("ProjectedSpent = %v, want %v", b.ProjectedSpent, tt.wantSpent)
+			}
+			if b.ProjectedStatus != tt.wantStatus {
+				t.Errorf("ProjectedStatus = %q, want %q", b.ProjectedStatus, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func floatPtr(f float64) *float64 { return &f }
@@ -2,6 +2,7 @@ package budgets
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -45,6 +46,7 @@ func (r *PostgresRepository) GetByMonth(ctx context.Context, householdID, month
 				ELSE GREATEST(COALESCE(ib.amount, 0), COALESCE(mb.amount, 0))
 			END as amount,
 			COALESCE(mb.currency, 'COP') as currency,
+			COALESCE(cbs.rollover, false) as rollover,
 			COALESCE(SUM(m.amount), 0) as spent,
 			mb.created_at,
 			mb.updated_at
@@ -60,12 +62,13 @@ func (r *PostgresRepository) GetByMonth(ctx context.Context, householdID, month
 			LIMIT 1
 		) mb ON true
 		LEFT JOIN items_budget ib ON ib.category_id = c.id
+		LEFT JOIN category_budget_settings cbs ON cbs.category_id = c.id
 		LEFT JOIN movements m ON m.category_id = c.id
 			AND m.household_id = $1
 			AND DATE_TRUNC('month', m.movement_date) = $2
 		WHERE c.household_id = $1
 			AND c.is_active = true
-		GROUP BY mb.id, mb.month, c.id, c.name, cg.id, cg.name, cg.icon, cg.display_order, c.display_order, mb.amount, mb.currency, mb.created_at, mb.updated_at, ib.amount
+		GROUP BY mb.id, mb.month, c.id, c.name, cg.id, cg.name, cg.icon, cg.display_order, c.display_order, mb.amount, mb.currency, mb.created_at, mb.updated_at, ib.amount, cbs.rollover
 		ORDER BY cg.display_order NULLS LAST, c.display_order ASC, c.name ASC
 	`
 
@@ -88,6 +91,7 @@ func (r *PostgresRepository) GetByMonth(ctx context.Context, householdID, month
 			&budget.GroupDisplayOrder,
 			&budget.Amount,
 			&budget.Currency,
+			&budget.Rollover,
 			&budget.Spent,
 			&budget.CreatedAt,
 			&budget.UpdatedAt,
@@ -96,13 +100,8 @@ func (r *PostgresRepository) GetByMonth(ctx context.Context, householdID, month
 			return nil, err
 		}
 
-		// Calculate percentage and status
-		if budget.Amount > 0 {
-			budget.Percentage = (budget.Spent / budget.Amount) * 100
-		} else {
-			budget.Percentage = 0
-		}
-		budget.Status = CalculateBudgetStatus(budget.Percentage)
+		// Calculate percentage and status; the service adds rollover carries
+		budget.applyCarry(0)
 
 		budgets = append(budgets, &budget)
 	}
@@ -212,7 +211,8 @@ func (r *PostgresRepository) CopyBudgets(ctx context.Context, householdID, fromM
 		return 0, ErrBudgetsExist
 	}
 
-	// Copy budgets
+	// Copy budgets. Only base amounts are copied: a rollover carry is derived
+	// from the remainder chain, so copying it would count it twice.
 	result, err := r.pool.Exec(ctx, `
 		INSERT INTO monthly_budgets (household_id, category_id, month, amount, currency)
 		SELECT household_id, category_id, $2, amount, currency
@@ -264,16 +264,8 @@ func (r *PostgresRepository) DeleteFutureRecords(ctx context.Context, householdI
 	return result.RowsAffected(), nil
 }
 
-// GetEffectiveBudget returns the effective displayed budget amount for a category at a given month.
-// This matches the GetByMonth LATERAL JOIN + CASE logic: considers both monthly_budgets inheritance
-// and monthly_budget_items sum.
-func (r *PostgresRepository) GetEffectiveBudget(ctx context.Context, householdID, categoryID, month string) (float64, error) {
-	monthDate, err := ParseMonth(month)
-	if err != nil {
-		return 0, ErrInvalidMonth
-	}
-	var amount float64
-	err = r.pool.QueryRow(ctx, `
+// effectiveBudgetQuery matches the GetByMonth LATERAL JOIN + CASE logic for one category
+const effectiveBudgetQuery = `
 		WITH items_budget AS (
 			SELECT COALESCE(SUM(amount), 0) as amount
 			FROM monthly_budget_items
@@ -292,13 +284,33 @@ func (r *PostgresRepository) GetEffectiveBudget(ctx context.Context, householdID
 			ORDER BY month DESC LIMIT 1
 		) mb ON true
 		CROSS JOIN items_budget ib
-	`, householdID, categoryID, monthDate).Scan(&amount)
-	if err != nil {
+	`
+
+// queryRower is satisfied by both the pool and a transaction
+type queryRower interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// effectiveBudget returns the budget amount a category shows for a month
+func effectiveBudget(ctx context.Context, q queryRower, householdID, categoryID string, month time.Time) (float64, error) {
+	var amount float64
+	if err := q.QueryRow(ctx, effectiveBudgetQuery, householdID, categoryID, month).Scan(&amount); err != nil {
 		return 0, err
 	}
 	return amount, nil
 }
 
+// GetEffectiveBudget returns the effective displayed budget amount for a category at a given month.
+// This matches the GetByMonth LATERAL JOIN + CASE logic: considers both monthly_budgets inheritance
+// and monthly_budget_items sum.
+func (r *PostgresRepository) GetEffectiveBudget(ctx context.Context, householdID, categoryID, month string) (float64, error) {
+	monthDate, err := ParseMonth(month)
+	if err != nil {
+		return 0, ErrInvalidMonth
+	}
+	return effectiveBudget(ctx, r.pool, householdID, categoryID, monthDate)
+}
+
 // PinMonthIfMissing inserts a budget record for the given month only if none exists yet
 func (r *PostgresRepository) PinMonthIfMissing(ctx context.Context, householdID, categoryID, month string, amount float64) error {
 	monthDate, err := ParseMonth(month)
@@ -340,3 +352,89 @@ func (r *PostgresRepository) UpdateAllRecords(ctx context.Context, householdID,
 	}
 	return result.RowsAffected(), nil
 }
+
+// GetRolloverMonths returns the budget and spent of every month in the remainder
+// chain of each rollover category, from rollover_since up to (not including) month.
+// Amounts use the same inheritance and items logic as GetByMonth.
+func (r *PostgresRepository) GetRolloverMonths(ctx context.Context, householdID, month string) ([]*RolloverMonth, error) {
+	monthDate, err := ParseMonth(month)
+	if err != nil {
+		return nil, ErrInvalidMonth
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		WITH chain AS (
+			SELECT cbs.category_id, gs::date AS month
+			FROM category_budget_settings cbs
+			JOIN categories c ON c.id = cbs.category_id
+			CROSS JOIN LATERAL generate_series(
+				cbs.rollover_since, $2::date - INTERVAL '1 month', INTERVAL '1 month'
+			) gs
+			WHERE c.household_id = $1 AND cbs.rollover = true
+		)
+		SELECT
+			ch.category_id,
+			ch.month,
+			CASE
+				WHEN mb.month = ch.month THEN COALESCE(mb.amount, 0)
+				ELSE GREATEST(COALESCE(ib.amount, 0), COALESCE(mb.amount, 0))
+			END as amount,
+			COALESCE(sp.spent, 0) as spent
+		FROM chain ch
+		LEFT JOIN LATERAL (
+			SELECT month, amount
+			FROM monthly_budgets
+			WHERE household_id = $1 AND category_id = ch.category_id AND month <= ch.month
+			ORDER BY month DESC LIMIT 1
+		) mb ON true
+		LEFT JOIN LATERAL (
+			SELECT SUM(amount) as amount
+			FROM monthly_budget_items
+			WHERE household_id = $1 AND category_id = ch.category_id AND month = ch.month
+		) ib ON true
+		LEFT JOIN LATERAL (
+			SELECT SUM(amount) as spent
+			FROM movements
+			WHERE household_id = $1 AND category_id = ch.category_id
+				AND DATE_TRUNC('month', movement_date) = ch.month
+		) sp ON true
+		ORDER BY ch.category_id, ch.month
+	`, householdID, monthDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var months []*RolloverMonth
+	for rows.Next() {
+		var m RolloverMonth
+		if err := rows.Scan(&m.CategoryID, &m.Month, &m.Amount, &m.Spent); err != nil {
+			return nil, err
+		}
+		months = append(months, &m)
+	}
+	return months, rows.Err()
+}
+
+// SetCategorySettings creates or updates the budget settings of a category
+func (r *PostgresRepository) SetCategorySettings(ctx context.Context, categoryID string, rollover bool, rolloverSince *time.Time) (*CategoryBudgetSettings, error) {
+	var settings CategoryBudgetSettings
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO category_budget_settings (category_id, rollover, rollover_since)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (category_id)
+		DO UPDATE SET rollover = EXCLUDED.rollover, rollover_since = EXCLUDED.rollover_since, updated_at = NOW()
+		RETURNING category_id, rollover, rollover_since, projection_method, projection_months, updated_at
+	`, categoryID, rollover, rolloverSince).Scan(
+		&settings.CategoryID,
+		&settings.Rollover,
+		&settings.RolloverSince,
+		&settings.ProjectionMethod,
+		&settings.ProjectionMonths,
+		&settings.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
@@ -2,10 +2,14 @@ package budgets
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/blanquicet/conti/backend/internal/audit"
 	"github.com/blanquicet/conti/backend/internal/categories"
 	"github.com/blanquicet/conti/backend/internal/households"
+	"github.com/blanquicet/conti/backend/internal/notifications"
+	"github.com/blanquicet/conti/backend/internal/webhooks"
 )
 
 // BudgetService implements Service
@@ -15,6 +19,9 @@ type BudgetService struct {
 	householdRepo     households.HouseholdRepository
 	auditService      audit.Service
 	templatesCalculator TemplatesSumCalculator // For validating budgets >= templates sum
+	notifier          notifications.Publisher // Optional, for budget exceeded notifications
+	webhooks          webhooks.Dispatcher     // Optional, for budget exceeded webhooks
+	alertEmails       AlertEmailSender        // Optional, for budget alert emails
 }
 
 // NewService creates a new budget service
@@ -40,6 +47,16 @@ func (s *BudgetService) SetTemplatesCalculator(calculator TemplatesSumCalculator
 	s.templatesCalculator = calculator
 }
 
+// SetNotifier sets the publisher used for budget exceeded notifications
+func (s *BudgetService) SetNotifier(notifier notifications.Publisher) {
+	s.notifier = notifier
+}
+
+// SetWebhookDispatcher sets the dispatcher used for budget exceeded webhooks
+func (s *BudgetService) SetWebhookDispatcher(dispatcher webhooks.Dispatcher) {
+	s.webhooks = dispatcher
+}
+
 // GetByMonth returns budgets for a month with status indicators
 func (s *BudgetService) GetByMonth(ctx context.Context, userID, month string) (*GetBudgetResponse, error) {
 	// Validate month format
@@ -49,7 +66,7 @@ func (s *BudgetService) GetByMonth(ctx context.Context, userID, month string) (*
 	}
 
 	// Get user's household
-	householdID, err := s.getUserHouseholdID(ctx, userID)
+	householdID, err := s.getUserHouseholdID(ctx, userID, households.PermView)
 	if err != nil {
 		return nil, err
 	}
@@ -60,10 +77,41 @@ func (s *BudgetService) GetByMonth(ctx context.Context, userID, month string) (*
 		return nil, err
 	}
 
+	// Add the remainder carried into the month by rollover categories
+	carried, err := s.carriedIn(ctx, householdID, month)
+	if err != nil {
+		return nil, err
+	}
+	for _, budget := range budgets {
+		if budget.Rollover {
+			budget.applyCarry(carried[budget.CategoryID])
+		}
+	}
+
+	// Show yearly and custom budgets next to the monthly ones
+	if err := s.attachPeriodBudgets(ctx, householdID, month, budgets); err != nil {
+		return nil, err
+	}
+
+	// Project where each category will end the month
+	if err := s.attachProjections(ctx, householdID, month, budgets, time.Now()); err != nil {
+		return nil, err
+	}
+
+	// Group budgets, with their categories nested
+	groups, err := s.repo.GetGroupBudgetsByMonth(ctx, householdID, month)
+	if err != nil {
+		return nil, err
+	}
+	if groups == nil {
+		groups = []*GroupBudgetWithSpent{}
+	}
+	nestGroupBudgets(groups, budgets)
+
 	// Calculate totals
-	var totalBudget, totalSpent float64
+	totalBudget := budgetTotal(groups, budgets)
+	var totalSpent float64
 	for _, budget := range budgets {
-		totalBudget += budget.Amount
 		totalSpent += budget.Spent
 	}
 
@@ -75,6 +123,7 @@ func (s *BudgetService) GetByMonth(ctx context.Context, userID, month string) (*
 	return &GetBudgetResponse{
 		Month:   month,
 		Budgets: budgets,
+		Groups:  groups,
 		Totals: &BudgetTotals{
 			TotalBudget: totalBudget,
 			TotalSpent:  totalSpent,
@@ -91,7 +140,7 @@ func (s *BudgetService) Set(ctx context.Context, userID string, input *SetBudget
 	}
 
 	// Get user's household
-	householdID, err := s.getUserHouseholdID(ctx, userID)
+	householdID, err := s.getUserHouseholdID(ctx, userID, households.PermEditBudgets)
 	if err != nil {
 		return nil, err
 	}
@@ -125,6 +174,11 @@ func (s *BudgetService) Set(ctx context.Context, userID string, input *SetBudget
 		scope = ScopeFuture // Default: this month + delete future overrides
 	}
 
+	// Category budgets count inside their group's budget, in every month the scope changes
+	if err := s.checkScopedGroupCap(ctx, householdID, category.CategoryGroupID, input.CategoryID, input.Month, input.Amount, scope); err != nil {
+		return nil, err
+	}
+
 	// For scope=THIS, capture old budget value before upsert so we can pin the next month
 	var oldAmount float64
 	if scope == ScopeThis {
@@ -230,24 +284,104 @@ func (s *BudgetService) CopyBudgets(ctx context.Context, userID string, input *C
 	}
 
 	// Get user's household
-	householdID, err := s.getUserHouseholdID(ctx, userID)
+	householdID, err := s.getUserHouseholdID(ctx, userID, households.PermEditBudgets)
 	if err != nil {
 		return 0, err
 	}
 
+	// Copied budgets must still fit their groups' budgets in the target month
+	if err := s.checkCopyGroupCaps(ctx, householdID, input.FromMonth, input.ToMonth); err != nil {
+		return 0, err
+	}
+
 	// Copy budgets
-	return s.repo.CopyBudgets(ctx, householdID, input.FromMonth, input.ToMonth)
+	copied, err := s.repo.CopyBudgets(ctx, householdID, input.FromMonth, input.ToMonth)
+	if err != nil {
+		return 0, err
+	}
+
+	return copied, nil
+}
+
+// SetCategorySettings updates the budget settings (rollover) of a category.
+// Turning rollover on starts a new remainder chain at rollover_since, which
+// defaults to the current month; turning it off drops the chain.
+func (s *BudgetService) SetCategorySettings(ctx context.Context, userID, categoryID string, input *SetCategorySettingsInput) (*CategoryBudgetSettings, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	householdID, err := s.getUserHouseholdID(ctx, userID, households.PermEditBudgets)
+	if err != nil {
+		return nil, err
+	}
+
+	category, err := s.categoryRepo.GetByID(ctx, categoryID)
+	if err != nil {
+		if err == categories.ErrCategoryNotFound {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, err
+	}
+	if category.HouseholdID != householdID {
+		return nil, ErrNotAuthorized
+	}
+
+	var since *time.Time
+	if input.Rollover {
+		sinceMonth := input.RolloverSince
+		if sinceMonth == "" {
+			sinceMonth = FormatMonth(time.Now())
+		}
+		sinceDate, _ := ParseMonth(sinceMonth)
+		since = &sinceDate
+	}
+
+	settings, err := s.repo.SetCategorySettings(ctx, categoryID, input.Rollover, since)
+	if err != nil {
+		s.auditService.LogAsync(ctx, &audit.LogInput{
+			Action:       audit.ActionBudgetUpdated,
+			ResourceType: "category_budget_settings",
+			ResourceID:   audit.StringPtr(categoryID),
+			UserID:       audit.StringPtr(userID),
+			HouseholdID:  audit.StringPtr(householdID),
+			Success:      false,
+			ErrorMessage: audit.StringPtr(err.Error()),
+		})
+		return nil, err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		Action:       audit.ActionBudgetUpdated,
+		ResourceType: "category_budget_settings",
+		ResourceID:   audit.StringPtr(categoryID),
+		UserID:       audit.StringPtr(userID),
+		HouseholdID:  audit.StringPtr(householdID),
+		Success:      true,
+		NewValues:    audit.StructToMap(settings),
+	})
+
+	return settings, nil
 }
 
-// getUserHouseholdID gets the household ID for a user
-func (s *BudgetService) getUserHouseholdID(ctx context.Context, userID string) (string, error) {
-	households, err := s.householdRepo.ListByUser(ctx, userID)
+// carriedIn returns the remainder each rollover category carries into month
+func (s *BudgetService) carriedIn(ctx context.Context, householdID, month string) (map[string]float64, error) {
+	months, err := s.repo.GetRolloverMonths(ctx, householdID, month)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	if len(households) == 0 {
+	return CarriedIn(months), nil
+}
+
+// getUserHouseholdID gets the household the user's request acts on and checks
+// their role there grants perm
+func (s *BudgetService) getUserHouseholdID(ctx context.Context, userID string, perm households.Permission) (string, error) {
+	householdID, err := households.AuthorizeSelected(ctx, s.householdRepo, userID, perm)
+	switch {
+	case errors.Is(err, households.ErrNoHousehold):
 		return "", ErrNoHousehold
+	case errors.Is(err, households.ErrNotAuthorized):
+		return "", ErrNotAuthorized
 	}
-	// User should only have one household (for now)
-	return households[0].ID, nil
+	return householdID, err
 }
@@ -0,0 +1,155 @@
+package budgets
+
+import (
+	"context"
+	"errors"
+
+	"github.com/blanquicet/conti/backend/internal/households"
+)
+
+// MaxTrendMonths is the longest range a budget trend report may cover
+const MaxTrendMonths = 36
+
+// ErrInvalidTrendRange is returned when from is after to or the range is too long
+var ErrInvalidTrendRange = errors.New("from must not be after to and the range must cover at most 36 months")
+
+// BudgetTrendReport compares budget and spending month by month for every
+// category, every category group and the whole household
+type BudgetTrendReport struct {
+	From   string         `json:"from"` // YYYY-MM
+	To     string         `json:"to"`   // YYYY-MM
+	Months []string       `json:"months"`
+	Groups []*TrendSeries `json:"groups"` // Each with its categories nested
+	Total  *TrendSeries   `json:"total"`
+}
+
+// TrendSeries is the month by month budget and spending of a category, a
+// category group or the household
+type TrendSeries struct {
+	ID         *string        `json:"id,omitempty"` // Category or group ID; empty for ungrouped categories and the total
+	Name       string         `json:"name"`
+	Points     []*TrendPoint  `json:"points"` // One per month, in order
+	Stats      TrendStats     `json:"stats"`
+	Categories []*TrendSeries `json:"categories,omitempty"` // Groups only
+}
+
+// TrendPoint is one month of a trend series
+type TrendPoint struct {
+	Month      string   `json:"month"` // YYYY-MM
+	Budget     float64  `json:"budget"`
+	Spent      float64  `json:"spent"`
+	Variance   float64  `json:"variance"`             // budget - spent; negative when overspent
+	Percentage *float64 `json:"percentage,omitempty"` // (spent / budget) * 100; absent without a budget
+}
+
+// TrendStats summarizes a trend series over the whole range
+type TrendStats struct {
+	AvgBudget      float64  `json:"avg_budget"`
+	AvgSpent       float64  `json:"avg_spent"`
+	AvgVariance    float64  `json:"avg_variance"`
+	AvgPercentage  *float64 `json:"avg_percentage,omitempty"` // Over the months with a budget
+	StddevSpent    float64  `json:"stddev_spent"`
+	StddevVariance float64  `json:"stddev_variance"`
+}
+
+// TrendRow is one month of one series as computed by the repository
+type TrendRow struct {
+	Level        string // "category", "group" or "total"
+	GroupID      *string
+	GroupName    *string
+	CategoryID   *string
+	CategoryName *string
+	Point        TrendPoint
+	Stats        TrendStats
+}
+
+// GetBudgetTrend returns budget against spending for every month in the range
+func (s *BudgetService) GetBudgetTrend(ctx context.Context, userID, from, to string) (*BudgetTrendReport, error) {
+	fromDate, err := ParseMonth(from)
+	if err != nil {
+		return nil, ErrInvalidMonth
+	}
+	toDate, err := ParseMonth(to)
+	if err != nil {
+		return nil, ErrInvalidMonth
+	}
+	if toDate.Before(fromDate) || monthsBetween(fromDate, toDate) > MaxTrendMonths {
+		return nil, ErrInvalidTrendRange
+	}
+
+	householdID, err := s.getUserHouseholdID(ctx, userID, households.PermView)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.GetBudgetTrend(ctx, householdID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	report := buildTrendReport(rows)
+	report.From = from
+	report.To = to
+	for m := fromDate; !m.After(toDate); m = m.AddDate(0, 1, 0) {
+		report.Months = append(report.Months, FormatMonth(m))
+	}
+	return report, nil
+}
+
+// buildTrendReport nests the repository rows into group and category series.
+// Rows must be ordered by series and month.
+func buildTrendReport(rows []*TrendRow) *BudgetTrendReport {
+	report := &BudgetTrendReport{
+		Groups: []*TrendSeries{},
+		Total:  &TrendSeries{Name: "Total", Points: []*TrendPoint{}},
+	}
+
+	groups := make(map[string]*TrendSeries)
+	categories := make(map[string]*TrendSeries)
+	groupFor := func(row *TrendRow) *TrendSeries {
+		key := ""
+		if row.GroupID != nil {
+			key = *row.GroupID
+		}
+		g, ok := groups[key]
+		if !ok {
+			g = &TrendSeries{ID: row.GroupID, Name: "Sin grupo", Points: []*TrendPoint{}}
+			if row.GroupName != nil {
+				g.Name = *row.GroupName
+			}
+			groups[key] = g
+			report.Groups = append(report.Groups, g)
+		}
+		return g
+	}
+
+	for _, row := range rows {
+		point := row.Point
+		var series *TrendSeries
+		switch row.Level {
+		case "total":
+			series = report.Total
+		case "group":
+			series = groupFor(row)
+		default:
+			if row.CategoryID == nil {
+				continue
+			}
+			c, ok := categories[*row.CategoryID]
+			if !ok {
+				c = &TrendSeries{ID: row.CategoryID, Points: []*TrendPoint{}}
+				if row.CategoryName != nil {
+					c.Name = *row.CategoryName
+				}
+				categories[*row.CategoryID] = c
+				g := groupFor(row)
+				g.Categories = append(g.Categories, c)
+			}
+			series = c
+		}
+		series.Points = append(series.Points, &point)
+		series.Stats = row.Stats
+	}
+
+	return report
+}
@@ -0,0 +1,37 @@
+package budgets
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// GetBudgetTrend handles GET /reports/budget-trend?from=YYYY-MM&to=YYYY-MM
+func (h *Handler) GetBudgetTrend(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserFromSession(r)
+	if err != nil {
+		h.logger.Error("failed to get user from session", "error", err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	from := r.URL.Query().Get("from")
+	to := r.URL.Query().Get("to")
+	report, err := h.service.GetBudgetTrend(r.Context(), user.ID, from, to)
+	if err != nil {
+		h.logger.Error("failed to get budget trend", "error", err, "user_id", user.ID, "from", from, "to", to)
+		switch err {
+		case ErrInvalidMonth, ErrInvalidTrendRange:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case ErrNoHousehold:
+			http.Error(w, "user has no household", http.StatusNotFound)
+		case ErrNotAuthorized:
+			http.Error(w, "forbidden: user is not a member of this household", http.StatusForbidden)
+		default:
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,174 @@
+package budgets
+
+import (
+	"context"
+	"time"
+)
+
+// GetBudgetTrend returns budget and spent for every month from..to per
+// category, per category group and for the household, with each series'
+// averages and standard deviations. Budgets use the same inheritance and
+// items logic as GetByMonth; rollover carries are not included. As in
+// GetByMonth, a group with a group budget is budgeted that amount instead of
+// the sum of its categories, in its group row and in the total. Rows are
+// ordered by group, then category (group and total rows first), then month.
+func (r *PostgresRepository) GetBudgetTrend(ctx context.Context, householdID, from, to string) ([]*TrendRow, error) {
+	fromDate, err := ParseMonth(from)
+	if err != nil {
+		return nil, ErrInvalidMonth
+	}
+	toDate, err := ParseMonth(to)
+	if err != nil {
+		return nil, ErrInvalidMonth
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		WITH months AS (
+			SELECT gs::date AS month
+			FROM generate_series($2::date, $3::date, INTERVAL '1 month') gs
+		),
+		spent AS (
+			SELECT category_id, DATE_TRUNC('month', movement_date)::date AS month, SUM(amount) AS spent
+			FROM movements
+			WHERE household_id = $1 AND category_id IS NOT NULL
+				AND movement_date >= $2 AND movement_date < $3::date + INTERVAL '1 month'
+			GROUP BY 1, 2
+		),
+		cells AS (
+			SELECT
+				c.id AS category_id,
+				c.name AS category_name,
+				c.display_order AS category_order,
+				cg.id AS group_id,
+				cg.name AS group_name,
+				cg.display_order AS group_order,
+				mo.month,
+				CASE
+					WHEN mb.month = mo.month THEN COALESCE(mb.amount, 0)
+					ELSE GREATEST(COALESCE(ib.amount, 0), COALESCE(mb.amount, 0))
+				END AS budget,
+				COALESCE(sp.spent, 0) AS spent
+			FROM categories c
+			LEFT JOIN category_groups cg ON cg.id = c.category_group_id
+			CROSS JOIN months mo
+			LEFT JOIN LATERAL (
+				SELECT month, amount
+				FROM monthly_budgets
+				WHERE household_id = $1 AND category_id = c.id AND month <= mo.month
+				ORDER BY month DESC LIMIT 1
+			) mb ON true
+			LEFT JOIN LATERAL (
+				SELECT SUM(amount) AS amount
+				FROM monthly_budget_items
+				WHERE household_id = $1 AND category_id = c.id AND month = mo.month
+			) ib ON true
+			LEFT JOIN spent sp ON sp.category_id = c.id AND sp.month = mo.month
+			WHERE c.household_id = $1 AND c.is_active = true
+		),
+		series AS (
+			SELECT
+				CASE
+					WHEN GROUPING(group_id) = 1 THEN 'total'
+					WHEN GROUPING(category_id) = 1 THEN 'group'
+					ELSE 'category'
+				END AS level,
+				group_id, group_name, group_order,
+				category_id, category_name, category_order,
+				month,
+				SUM(budget) AS budget,
+				SUM(spent) AS spent
+			FROM cells
+			GROUP BY GROUPING SETS (
+				(group_id, group_name, group_order, category_id, category_name, category_order, month),
+				(group_id, group_name, group_order, month),
+				(month)
+			)
+		),
+		group_budgets AS (
+			SELECT cg.id AS group_id, mo.month, gb.amount
+			FROM category_groups cg
+			CROSS JOIN months mo
+			JOIN LATERAL (
+				SELECT amount
+				FROM monthly_group_budgets
+				WHERE household_id = $1 AND category_group_id = cg.id AND month <= mo.month
+				ORDER BY month DESC LIMIT 1
+			) gb ON true
+			WHERE cg.household_id = $1 AND cg.is_active = true
+		),
+		-- A group budget replaces the budgets of its categories in the total
+		group_delta AS (
+			SELECT gb.month, SUM(gb.amount - COALESCE(s.budget, 0)) AS delta
+			FROM group_budgets gb
+			LEFT JOIN series s ON s.level = 'group' AND s.group_id = gb.group_id AND s.month = gb.month
+			GROUP BY gb.month
+		),
+		budgeted AS (
+			SELECT
+				s.level, s.group_id, s.group_name, s.group_order,
+				s.category_id, s.category_name, s.category_order,
+				s.month,
+				CASE
+					WHEN s.level = 'group' THEN COALESCE(gb.amount, s.budget)
+					WHEN s.level = 'total' THEN s.budget + COALESCE(gd.delta, 0)
+					ELSE s.budget
+				END AS budget,
+				s.spent
+			FROM series s
+			LEFT JOIN group_budgets gb ON s.level = 'group' AND gb.group_id = s.group_id AND gb.month = s.month
+			LEFT JOIN group_delta gd ON s.level = 'total' AND gd.month = s.month
+		),
+		points AS (
+			SELECT *,
+				budget - spent AS variance,
+				CASE WHEN budget > 0 THEN spent / budget * 100 END AS percentage
+			FROM budgeted
+		)
+		SELECT
+			level, group_id, group_name, category_id, category_name,
+			month, budget, spent, variance, percentage,
+			AVG(budget) OVER w,
+			AVG(spent) OVER w,
+			AVG(variance) OVER w,
+			AVG(percentage) OVER w,
+			COALESCE(STDDEV_POP(spent) OVER w, 0),
+			COALESCE(STDDEV_POP(variance) OVER w, 0)
+		FROM points
+		WINDOW w AS (PARTITION BY level, group_id, category_id)
+		ORDER BY level = 'total', group_order NULLS LAST, group_id NULLS LAST,
+			level = 'category', category_order, category_name, category_id, month
+	`, householdID, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var trend []*TrendRow
+	for rows.Next() {
+		var row TrendRow
+		var month time.Time
+		if err := rows.Scan(
+			&row.Level,
+			&row.GroupID,
+			&row.GroupName,
+			&row.CategoryID,
+			&row.CategoryName,
+			&month,
+			&row.Point.Budget,
+			&row.Point.Spent,
+			&row.Point.Variance,
+			&row.Point.Percentage,
+			&row.Stats.AvgBudget,
+			&row.Stats.AvgSpent,
+			&row.Stats.AvgVariance,
+			&row.Stats.AvgPercentage,
+			&row.Stats.StddevSpent,
+			&row.Stats.StddevVariance,
+		); err != nil {
+			return nil, err
+		}
+		row.Point.Month = FormatMonth(month)
+		trend = append(trend, &row)
+	}
+	return trend, rows.Err()
+}
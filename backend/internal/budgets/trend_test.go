@@ -0,0 +1,38 @@
+package budgets
+
+import "testing"
+
+func strPtr(s string) *string { return &s }
+
+// TestBuildTrendReport tests nesting repository rows into group and category series
+func TestBuildTrendReport(t *testing.T) {
+	pct := 50.0
+	hogar := strPtr("g1")
+	rows := []*TrendRow{
+		{Level: "group", GroupID: hogar, GroupName: strPtr("Hogar"), Point: TrendPoint{Month: "2026-01", Budget: 200, Spent: 100}},
+		{Level: "group", GroupID: hogar, GroupName: strPtr("Hogar"), Point: TrendPoint{Month: "2026-02", Budget: 200, Spent: 300}, Stats: TrendStats{AvgSpent: 200}},
+		{Level: "category", GroupID: hogar, GroupName: strPtr("Hogar"), CategoryID: strPtr("c1"), CategoryName: strPtr("Mercado"), Point: TrendPoint{Month: "2026-01", Budget: 200, Spent: 100, Percentage: &pct}},
+		{Level: "category", GroupID: hogar, GroupName: strPtr("Hogar"), CategoryID: strPtr("c1"), CategoryName: strPtr("Mercado"), Point: TrendPoint{Month: "2026-02", Budget: 200, Spent: 300}},
+		{Level: "group", Point: TrendPoint{Month: "2026-01"}},
+		{Level: "category", CategoryID: strPtr("c2"), CategoryName: strPtr("Regalos"), Point: TrendPoint{Month: "2026-01"}},
+		{Level: "total", Point: TrendPoint{Month: "2026-01", Budget: 200, Spent: 100}},
+	}
+
+	report := buildTrendReport(rows)
+	if len(report.Groups) != 2 {
+		t.Fatalf("len(Groups) = %d, want 2", len(report.Groups))
+	}
+	g := report.Groups[0]
+	if g.Name != "Hogar" || len(g.Points) != 2 || g.Stats.AvgSpent != 200 {
+		t.Errorf("group = %+v, want Hogar with 2 points and the last row's stats", g)
+	}
+	if len(g.Categories) != 1 || g.Categories[0].Name != "Mercado" || len(g.Categories[0].Points) != 2 {
+		t.Errorf("group categories = %+v, want Mercado with 2 points", g.Categories)
+	}
+	if ungrouped := report.Groups[1]; ungrouped.ID != nil || ungrouped.Name != "Sin grupo" || len(ungrouped.Categories) != 1 {
+		t.Errorf("ungrouped = %+v, want Sin grupo with 1 category", ungrouped)
+	}
+	if len(report.Total.Points) != 1 || report.Total.Points[0].Spent != 100 {
+		t.Errorf("total points = %+v, want 1 point with spent 100", report.Total.Points)
+	}
+}
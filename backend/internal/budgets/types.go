@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"time"
+
+	"github.com/blanquicet/conti/backend/internal/income"
 )
 
 // Errors for budget operations
@@ -39,20 +41,95 @@ type MonthlyBudget struct {
 
 // BudgetWithSpent represents a budget with calculated spent amount
 type BudgetWithSpent struct {
-	ID                 *string    `json:"id,omitempty"`
-	CategoryID         string     `json:"category_id"`
-	CategoryName       string     `json:"category_name"`
-	CategoryGroupID    *string    `json:"category_group_id,omitempty"`
-	CategoryGroupName  *string    `json:"category_group_name,omitempty"`
-	CategoryGroupIcon  *string    `json:"category_group_icon,omitempty"`
-	GroupDisplayOrder  *int       `json:"group_display_order,omitempty"`
-	Amount             float64    `json:"amount"`
-	Currency           string     `json:"currency"`
-	Spent              float64    `json:"spent"`
-	Percentage         float64    `json:"percentage"` // (spent / amount) * 100
-	Status             string     `json:"status"`     // "under_budget" | "on_track" | "exceeded"
-	CreatedAt          *time.Time `json:"created_at,omitempty"`
-	UpdatedAt          *time.Time `json:"updated_at,omitempty"`
+	ID                *string             `json:"id,omitempty"`
+	CategoryID        string              `json:"category_id"`
+	CategoryName      string              `json:"category_name"`
+	CategoryGroupID   *string             `json:"category_group_id,omitempty"`
+	CategoryGroupName *string             `json:"category_group_name,omitempty"`
+	CategoryGroupIcon *string             `json:"category_group_icon,omitempty"`
+	GroupDisplayOrder *int                `json:"group_display_order,omitempty"`
+	Amount            float64             `json:"amount"`
+	Currency          string              `json:"currency"`
+	Rollover          bool                `json:"rollover"`
+	CarriedIn         float64             `json:"carried_in"`       // Remainder carried from last month (negative when overspent)
+	EffectiveAmount   float64             `json:"effective_amount"` // amount + carried_in
+	CarriedOut        float64             `json:"carried_out"`      // effective_amount - spent; only for rollover categories
+	Spent             float64             `json:"spent"`
+	Percentage        float64             `json:"percentage"`              // (spent / effective_amount) * 100
+	Status            string              `json:"status"`                  // "under_budget" | "on_track" | "exceeded"
+	PeriodBudget      *PeriodBudgetStatus `json:"period_budget,omitempty"` // Yearly or custom budget covering the month
+	ProjectionMethod  ProjectionMethod    `json:"projection_method,omitempty"`
+	ProjectedSpent    *float64            `json:"projected_spent,omitempty"`  // Expected spent by the end of the month
+	ProjectedStatus   string              `json:"projected_status,omitempty"` // Status projected_spent would have
+	CreatedAt         *time.Time          `json:"created_at,omitempty"`
+	UpdatedAt         *time.Time          `json:"updated_at,omitempty"`
+}
+
+// applyCarry sets the rollover figures from the remainder carried into the
+// month and recalculates percentage and status against the effective amount
+func (b *BudgetWithSpent) applyCarry(carriedIn float64) {
+	b.CarriedIn = carriedIn
+	b.EffectiveAmount = b.Amount + carriedIn
+	b.CarriedOut = 0
+	if b.Rollover {
+		b.CarriedOut = b.EffectiveAmount - b.Spent
+	}
+
+	if b.EffectiveAmount > 0 {
+		b.Percentage = (b.Spent / b.EffectiveAmount) * 100
+	} else if b.Spent > 0 {
+		// Nothing left to spend (an overspent carry can eat the whole budget)
+		b.Percentage = 100
+	} else {
+		b.Percentage = 0
+	}
+	b.Status = CalculateBudgetStatus(b.Percentage)
+}
+
+// CategoryBudgetSettings holds the budget behaviour of a category
+type CategoryBudgetSettings struct {
+	CategoryID       string           `json:"category_id"`
+	Rollover         bool             `json:"rollover"`
+	RolloverSince    *time.Time       `json:"rollover_since,omitempty"` // First month of the remainder chain
+	ProjectionMethod ProjectionMethod `json:"projection_method"`
+	ProjectionMonths int              `json:"projection_months"` // Past months the PACE estimate averages
+	UpdatedAt        time.Time        `json:"updated_at"`
+}
+
+// SetCategorySettingsInput represents input for updating a category's budget settings
+type SetCategorySettingsInput struct {
+	Rollover      bool   `json:"rollover"`
+	RolloverSince string `json:"rollover_since,omitempty"` // YYYY-MM; defaults to the current month
+}
+
+// Validate validates the set category settings input
+func (i *SetCategorySettingsInput) Validate() error {
+	if i.RolloverSince != "" {
+		if _, err := ParseMonth(i.RolloverSince); err != nil {
+			return ErrInvalidMonth
+		}
+	}
+	return nil
+}
+
+// RolloverMonth is one past month of a rollover category's remainder chain
+type RolloverMonth struct {
+	CategoryID string
+	Month      time.Time
+	Amount     float64 // Budget amount as shown for that month
+	Spent      float64
+}
+
+// CarriedIn folds each category's remainder chain and returns the amount
+// carried into the month after the last row. Rows must be ordered by category
+// and month. The chain is rebuilt from movements on every read, so editing a
+// past movement changes every later carry.
+func CarriedIn(months []*RolloverMonth) map[string]float64 {
+	carried := make(map[string]float64)
+	for _, m := range months {
+		carried[m.CategoryID] += m.Amount - m.Spent
+	}
+	return carried
 }
 
 // BudgetTotals represents total budget and spent for a month
@@ -64,9 +141,11 @@ type BudgetTotals struct {
 
 // GetBudgetResponse represents the response for getting budgets for a month
 type GetBudgetResponse struct {
-	Month   string              `json:"month"` // YYYY-MM format
-	Budgets []*BudgetWithSpent  `json:"budgets"`
-	Totals  *BudgetTotals       `json:"totals"`
+	Month     string                  `json:"month"` // YYYY-MM format
+	Budgets   []*BudgetWithSpent      `json:"budgets"`
+	Groups    []*GroupBudgetWithSpent `json:"groups"` // Group budgets with their categories nested
+	Totals    *BudgetTotals           `json:"totals"`
+	ZeroBased *ZeroBasedSummary       `json:"zero_based,omitempty"` // Only with ?mode=zero_based
 }
 
 // SetBudgetInput represents input for setting/updating a budget
@@ -164,6 +243,161 @@ type Repository interface {
 
 	// UpsertBudgetFromItems creates or updates budget to match items sum (preserves user buffer)
 	UpsertBudgetFromItems(ctx context.Context, householdID, categoryID, month string, itemsSum float64) error
+
+	// GetRolloverMonths returns, for every rollover category of the household,
+	// the budget and spent of each month from rollover_since up to (not including) month
+	GetRolloverMonths(ctx context.Context, householdID, month string) ([]*RolloverMonth, error)
+
+	// SetCategorySettings creates or updates the budget settings of a category
+	SetCategorySettings(ctx context.Context, categoryID string, rollover bool, rolloverSince *time.Time) (*CategoryBudgetSettings, error)
+
+	// SetCategoryProjection sets how a category's month-end spending is projected
+	SetCategoryProjection(ctx context.Context, categoryID string, method ProjectionMethod, months int) (*CategoryBudgetSettings, error)
+
+	// GetProjectionBases returns, per active category, what the month-end projection needs besides spent
+	GetProjectionBases(ctx context.Context, householdID, month string) ([]*ProjectionBasis, error)
+
+	// GetAlertThresholds returns the category's alert thresholds, falling back to the household default
+	GetAlertThresholds(ctx context.Context, householdID, categoryID string) ([]int, error)
+
+	// RecordAlert stores an alert unless the same threshold already fired for the category and month.
+	// It reports whether the alert was recorded.
+	RecordAlert(ctx context.Context, alert *BudgetAlert) (bool, error)
+
+	// ListAlerts returns the alerts sent in a household for a month, newest first
+	ListAlerts(ctx context.Context, householdID, month string) ([]*BudgetAlert, error)
+
+	// GetAlertSettings returns the household thresholds and the category overrides and mutes of a user
+	GetAlertSettings(ctx context.Context, householdID, userID string) (*AlertSettings, error)
+
+	// SetHouseholdAlertThresholds sets the default alert thresholds of a household
+	SetHouseholdAlertThresholds(ctx context.Context, householdID string, thresholds []int) error
+
+	// SetCategoryAlertThresholds sets a category's alert thresholds; nil clears them
+	SetCategoryAlertThresholds(ctx context.Context, categoryID string, thresholds []int) error
+
+	// GetMutedUserIDs returns the users who muted a category's alerts
+	GetMutedUserIDs(ctx context.Context, categoryID string) ([]string, error)
+
+	// SetAlertMute mutes or unmutes a category's alerts for a user
+	SetAlertMute(ctx context.Context, userID, categoryID string, muted bool) error
+
+	// SetPeriodBudget creates or updates a period budget keyed by category, period and start month
+	SetPeriodBudget(ctx context.Context, budget *PeriodBudget) (*PeriodBudget, error)
+
+	// GetPeriodBudget returns a period budget by ID
+	GetPeriodBudget(ctx context.Context, id string) (*PeriodBudget, error)
+
+	// ListPeriodBudgets returns all period budgets of a household
+	ListPeriodBudgets(ctx context.Context, householdID string) ([]*PeriodBudget, error)
+
+	// DeletePeriodBudget deletes a period budget by ID
+	DeletePeriodBudget(ctx context.Context, id string) error
+
+	// HasOverlappingPeriodBudget reports whether another custom budget of the category
+	// covers any month in the range
+	HasOverlappingPeriodBudget(ctx context.Context, householdID, categoryID string, start, end time.Time) (bool, error)
+
+	// GetEffectiveYearlyBudget returns the yearly budget inherited by the year starting at yearStart, or nil
+	GetEffectiveYearlyBudget(ctx context.Context, householdID, categoryID string, yearStart time.Time) (*PeriodBudget, error)
+
+	// DeleteLaterYearlyBudgets deletes the yearly budgets of a category for years after yearStart
+	DeleteLaterYearlyBudgets(ctx context.Context, householdID, categoryID string, yearStart time.Time) (int64, error)
+
+	// UpdateAllYearlyBudgets copies amount and sinking fund settings to every yearly budget of the category
+	UpdateAllYearlyBudgets(ctx context.Context, budget *PeriodBudget) (int64, error)
+
+	// PinYearlyBudgetIfMissing inserts a yearly budget only if none exists for that year
+	PinYearlyBudgetIfMissing(ctx context.Context, budget *PeriodBudget) error
+
+	// PocketInHousehold reports whether a pocket belongs to the household
+	PocketInHousehold(ctx context.Context, householdID, pocketID string) (bool, error)
+
+	// GetPeriodBudgetStatuses returns, per category, the period budget covering the month
+	// with its spending from the start of the period through the month
+	GetPeriodBudgetStatuses(ctx context.Context, householdID, month string) ([]*PeriodBudgetStatus, error)
+
+	// GetIncomeByType returns the household's income for a month summed per type
+	GetIncomeByType(ctx context.Context, householdID, month string) (map[income.IncomeType]float64, error)
+
+	// GetPocketDepositsByCategory returns the household's pocket deposits for a month summed per category
+	GetPocketDepositsByCategory(ctx context.Context, householdID, month string) (map[string]float64, error)
+
+	// ReassignBudget moves an amount between two categories' budgets for one month and records it
+	ReassignBudget(ctx context.Context, reassignment *BudgetReassignment) error
+
+	// ListReassignments returns the budget reassignments of a household for a month
+	ListReassignments(ctx context.Context, householdID, month string) ([]*BudgetReassignment, error)
+
+	// GetBudgetTrend returns budget against spent per month for categories, groups and the household
+	GetBudgetTrend(ctx context.Context, householdID, from, to string) ([]*TrendRow, error)
+
+	// GetGroupBudgetsByMonth returns the budget each category group inherits for a month
+	GetGroupBudgetsByMonth(ctx context.Context, householdID, month string) ([]*GroupBudgetWithSpent, error)
+
+	// GetEffectiveGroupBudget returns the budget a category group inherits for a month and whether it has one
+	GetEffectiveGroupBudget(ctx context.Context, householdID, groupID, month string) (float64, bool, error)
+
+	// ListGroupCapMonths returns the months after month (all months when empty) in which the
+	// group's budget, a budget of one of its categories or of categoryID changes
+	ListGroupCapMonths(ctx context.Context, householdID, groupID, categoryID, month string) ([]string, error)
+
+	// GetCategoryGroupHouseholdID returns the household a category group belongs to
+	GetCategoryGroupHouseholdID(ctx context.Context, groupID string) (string, error)
+
+	// SetGroupBudget creates or updates a category group's budget for a month
+	SetGroupBudget(ctx context.Context, householdID string, input *SetGroupBudgetInput) (*GroupBudget, error)
+
+	// GetGroupBudget returns a group budget record by ID
+	GetGroupBudget(ctx context.Context, id string) (*GroupBudget, error)
+
+	// DeleteGroupBudget deletes a group budget record by ID
+	DeleteGroupBudget(ctx context.Context, id string) error
+
+	// DeleteFutureGroupBudgets deletes a group's budget records after a month
+	DeleteFutureGroupBudgets(ctx context.Context, householdID, groupID, afterMonth string) (int64, error)
+
+	// UpdateAllGroupBudgets sets every budget record of a group to an amount
+	UpdateAllGroupBudgets(ctx context.Context, householdID, groupID string, amount float64) (int64, error)
+
+	// PinGroupMonthIfMissing inserts a group budget record for a month only if none exists yet
+	PinGroupMonthIfMissing(ctx context.Context, householdID, groupID, month string, amount float64) error
+
+	// ListMemberAllowances returns the allowance each member inherits for a month with their spent
+	ListMemberAllowances(ctx context.Context, householdID, month string) ([]*MemberAllowance, error)
+
+	// ListMemberCategories returns the categories each member of a household owns, by user ID
+	ListMemberCategories(ctx context.Context, householdID string) (map[string][]*AllowanceCategory, error)
+
+	// ListAllowanceMovements returns the movements counted against a member's allowance for a month
+	ListAllowanceMovements(ctx context.Context, householdID, userID, month string) ([]*AllowanceMovement, error)
+
+	// GetEffectiveMemberBudget returns the allowance a member inherits for a month and whether they have one
+	GetEffectiveMemberBudget(ctx context.Context, householdID, userID, month string) (float64, bool, error)
+
+	// SetMemberBudget creates or updates a member's allowance for a month
+	SetMemberBudget(ctx context.Context, householdID string, input *SetAllowanceInput) (*MemberBudget, error)
+
+	// GetMemberBudget returns an allowance record by ID
+	GetMemberBudget(ctx context.Context, id string) (*MemberBudget, error)
+
+	// DeleteMemberBudget deletes an allowance record by ID
+	DeleteMemberBudget(ctx context.Context, id string) error
+
+	// DeleteFutureMemberBudgets deletes a member's allowance records after a month
+	DeleteFutureMemberBudgets(ctx context.Context, householdID, userID, afterMonth string) (int64, error)
+
+	// UpdateAllMemberBudgets sets every allowance record of a member to an amount
+	UpdateAllMemberBudgets(ctx context.Context, householdID, userID string, amount float64) (int64, error)
+
+	// PinMemberMonthIfMissing inserts an allowance record for a month only if none exists yet
+	PinMemberMonthIfMissing(ctx context.Context, householdID, userID, month string, amount float64) error
+
+	// SetCategoryOwner makes a member the owner of a category and returns the previous owner
+	SetCategoryOwner(ctx context.Context, householdID, categoryID, userID string) (*string, error)
+
+	// DeleteCategoryOwner removes a category's owner, if any, and returns it
+	DeleteCategoryOwner(ctx context.Context, categoryID string) (*string, error)
 }
 
 // Service defines the interface for budget business logic
@@ -179,6 +413,69 @@ type Service interface {
 	
 	// CopyBudgets copies budgets from one month to another
 	CopyBudgets(ctx context.Context, userID string, input *CopyBudgetsInput) (int, error)
+
+	// SetCategorySettings updates the budget settings (rollover) of a category
+	SetCategorySettings(ctx context.Context, userID, categoryID string, input *SetCategorySettingsInput) (*CategoryBudgetSettings, error)
+
+	// SetCategoryProjection sets how a category's month-end spending is projected
+	SetCategoryProjection(ctx context.Context, userID, categoryID string, input *SetCategoryProjectionInput) (*CategoryBudgetSettings, error)
+
+	// ListAlerts returns the budget alerts sent in the household for a month
+	ListAlerts(ctx context.Context, userID, month string) ([]*BudgetAlert, error)
+
+	// GetAlertSettings returns the alert thresholds and the user's muted categories
+	GetAlertSettings(ctx context.Context, userID string) (*AlertSettings, error)
+
+	// SetHouseholdAlertThresholds sets the household's default alert thresholds
+	SetHouseholdAlertThresholds(ctx context.Context, userID string, input *SetAlertThresholdsInput) (*AlertSettings, error)
+
+	// SetCategoryAlertThresholds sets or clears a category's alert thresholds
+	SetCategoryAlertThresholds(ctx context.Context, userID, categoryID string, input *SetAlertThresholdsInput) (*AlertSettings, error)
+
+	// SetAlertMute mutes or unmutes a category's alerts for the user
+	SetAlertMute(ctx context.Context, userID, categoryID string, muted bool) error
+
+	// ListPeriodBudgets returns the household's yearly and custom budgets
+	ListPeriodBudgets(ctx context.Context, userID string) ([]*PeriodBudget, error)
+
+	// SetPeriodBudget creates or updates a yearly or custom budget
+	SetPeriodBudget(ctx context.Context, userID string, input *SetPeriodBudgetInput) (*PeriodBudget, error)
+
+	// DeletePeriodBudget deletes a yearly or custom budget
+	DeletePeriodBudget(ctx context.Context, userID, id string) error
+
+	// GetByMonthZeroBased returns the month's budgets with the unassigned share of real income
+	GetByMonthZeroBased(ctx context.Context, userID, month string) (*GetBudgetResponse, error)
+
+	// ReassignBudget moves budget from one category to another within a month
+	ReassignBudget(ctx context.Context, userID string, input *ReassignBudgetInput) (*BudgetReassignment, error)
+
+	// ListReassignments returns the budget reassignments made in a month
+	ListReassignments(ctx context.Context, userID, month string) ([]*BudgetReassignment, error)
+
+	// GetBudgetTrend returns budget against spent per month for categories, groups and the household
+	GetBudgetTrend(ctx context.Context, userID, from, to string) (*BudgetTrendReport, error)
+
+	// SetGroupBudget creates or updates a category group's budget
+	SetGroupBudget(ctx context.Context, userID string, input *SetGroupBudgetInput) (*GroupBudget, error)
+
+	// DeleteGroupBudget deletes a category group's budget record
+	DeleteGroupBudget(ctx context.Context, userID, id string) error
+
+	// ListAllowances returns the household's member allowances for a month
+	ListAllowances(ctx context.Context, userID, month string) ([]*MemberAllowance, error)
+
+	// SetAllowance creates or updates a member's allowance
+	SetAllowance(ctx context.Context, userID string, input *SetAllowanceInput) (*MemberBudget, error)
+
+	// DeleteAllowance deletes a member's allowance record
+	DeleteAllowance(ctx context.Context, userID, id string) error
+
+	// SetCategoryOwner makes a member own a category for their allowance
+	SetCategoryOwner(ctx context.Context, userID, categoryID string, input *SetCategoryOwnerInput) error
+
+	// ClearCategoryOwner takes a category out of its owner's allowance
+	ClearCategoryOwner(ctx context.Context, userID, categoryID string) error
 }
 
 // CalculateBudgetStatus determines the status based on percentage
@@ -0,0 +1,68 @@
+package budgets
+
+import (
+	"testing"
+	"time"
+)
+
+// TestCarriedIn tests folding the remainder chain of rollover categories
+func TestCarriedIn(t *testing.T) {
+	month := func(s string) time.Time {
+		m, _ := ParseMonth(s)
+		return m
+	}
+	months := []*RolloverMonth{
+		{CategoryID: "ropa", Month: month("2026-01"), Amount: 200000, Spent: 150000},
+		{CategoryID: "ropa", Month: month("2026-02"), Amount: 200000, Spent: 300000},
+		{CategoryID: "ropa", Month: month("2026-03"), Amount: 200000, Spent: 0},
+		{CategoryID: "mercado", Month: month("2026-03"), Amount: 800000, Spent: 900000},
+	}
+
+	carried := CarriedIn(months)
+	// Ropa: +50.000, -100.000, +200.000
+	if carried["ropa"] != 150000 {
+		t.Errorf("ropa carried = %v, want 150000", carried["ropa"])
+	}
+	if carried["mercado"] != -100000 {
+		t.Errorf("mercado carried = %v, want -100000", carried["mercado"])
+	}
+
+	// Editing a past movement changes every later carry
+	months[0].Spent = 250000
+	if got := CarriedIn(months)["ropa"]; got != 50000 {
+		t.Errorf("ropa carried after edit = %v, want 50000", got)
+	}
+}
+
+// TestApplyCarry tests effective amount, carried out and status with a carry
+func TestApplyCarry(t *testing.T) {
+	tests := []struct {
+		name           string
+		budget         BudgetWithSpent
+		carriedIn      float64
+		wantEffective  float64
+		wantCarriedOut float64
+		wantStatus     string
+	}{
+		{"No rollover", BudgetWithSpent{Amount: 100, Spent: 50}, 0, 100, 0, "under_budget"},
+		{"Positive carry", BudgetWithSpent{Amount: 100, Spent: 120, Rollover: true}, 50, 150, 30, "on_track"},
+		{"Negative carry", BudgetWithSpent{Amount: 100, Spent: 80, Rollover: true}, -40, 60, -20, "exceeded"},
+		{"Carry eats the budget", BudgetWithSpent{Amount: 100, Spent: 10, Rollover: true}, -150, -50, -60, "exceeded"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := tt.budget
+			b.applyCarry(tt.carriedIn)
+			if b.EffectiveAmount != tt.wantEffective {
+				t.Errorf("EffectiveAmount = %v, want %v", b.EffectiveAmount, tt.wantEffective)
+			}
+			if b.CarriedOut != tt.wantCarriedOut {
+				t.Errorf("CarriedOut = %v, want %v", b.CarriedOut, tt.wantCarriedOut)
+			}
+			if b.Status != tt.wantStatus {
+				t.Errorf("Status = %q, want %q", b.Status, tt.wantStatus)
+			}
+		})
+	}
+}
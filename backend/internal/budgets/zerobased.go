@@ -0,0 +1,240 @@
+package budgets
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/blanquicet/conti/backend/internal/audit"
+	"github.com/blanquicet/conti/backend/internal/households"
+	"github.com/blanquicet/conti/backend/internal/income"
+)
+
+// Errors for zero-based budgeting
+var (
+	ErrSameCategory       = errors.New("from and to categories must be different")
+	ErrInsufficientBudget = errors.New("amount exceeds the budget assigned to the source category")
+)
+
+// ZeroBasedSummary compares a month's real income with what has been assigned
+// to budgets and pockets, so every peso can be given a job
+type ZeroBasedSummary struct {
+	RealIncome        float64 `json:"real_income"`
+	AssignedToBudgets float64 `json:"assigned_to_budgets"` // This month's budget amounts; rollover carries are not new money
+	AssignedToPockets float64 `json:"assigned_to_pockets"` // Pocket deposits into categories without a budget this month
+	TotalAssigned     float64 `json:"total_assigned"`
+	Unassigned        float64 `json:"unassigned"` // Negative when more was assigned than earned
+}
+
+// BudgetReassignment records an amount moved between two categories' budgets in a month
+type BudgetReassignment struct {
+	ID               string    `json:"id"`
+	HouseholdID      string    `json:"household_id"`
+	Month            time.Time `json:"month"`
+	FromCategoryID   string    `json:"from_category_id"`
+	FromCategoryName string    `json:"from_category_name,omitempty"`
+	ToCategoryID     string    `json:"to_category_id"`
+	ToCategoryName   string    `json:"to_category_name,omitempty"`
+	Amount           float64   `json:"amount"`
+	Note             *string   `json:"note,omitempty"`
+	CreatedByUserID  *string   `json:"created_by_user_id,omitempty"`
+	CreatedByName    *string   `json:"created_by_name,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// ReassignBudgetInput represents input for moving budget between categories
+type ReassignBudgetInput struct {
+	Month          string  `json:"month"` // YYYY-MM
+	FromCategoryID string  `json:"from_category_id"`
+	ToCategoryID   string  `json:"to_category_id"`
+	Amount         float64 `json:"amount"`
+	Note           *string `json:"note,omitempty"`
+}
+
+// Validate validates the reassign budget input
+func (i *ReassignBudgetInput) Validate() error {
+	if _, err := ParseMonth(i.Month); err != nil {
+		return ErrInvalidMonth
+	}
+	if i.FromCategoryID == "" || i.ToCategoryID == "" {
+		return errors.New("from_category_id and to_category_id are required")
+	}
+	if i.FromCategoryID == i.ToCategoryID {
+		return ErrSameCategory
+	}
+	if i.Amount <= 0 {
+		return errors.New("amount must be positive")
+	}
+	if i.Note != nil && len(*i.Note) > 255 {
+		return errors.New("note must be at most 255 characters")
+	}
+	return nil
+}
+
+// zeroBasedSummary builds the summary from income per type, the month's
+// category and group budgets and pocket deposits per category. A group budget
+// replaces the budgets of its categories. Deposits into a budgeted category
+// are already part of that budget and are not counted twice.
+func zeroBasedSummary(incomeByType map[income.IncomeType]float64, budgets []*BudgetWithSpent, groups []*GroupBudgetWithSpent, depositsByCategory map[string]float64) *ZeroBasedSummary {
+	summary := &ZeroBasedSummary{}
+	for t, amount := range incomeByType {
+		if t.IsRealIncome() {
+			summary.RealIncome += amount
+		}
+	}
+
+	capped := make(map[string]bool, len(groups))
+	for _, g := range groups {
+		summary.AssignedToBudgets += g.Amount
+		capped[g.CategoryGroupID] = g.Amount > 0
+	}
+
+	budgeted := make(map[string]bool, len(budgets))
+	for _, b := range budgets {
+		if b.CategoryGroupID != nil {
+			if groupBudgeted, ok := capped[*b.CategoryGroupID]; ok {
+				budgeted[b.CategoryID] = groupBudgeted
+				continue
+			}
+		}
+		summary.AssignedToBudgets += b.Amount
+		if b.Amount > 0 {
+			budgeted[b.CategoryID] = true
+		}
+	}
+	for categoryID, amount := range depositsByCategory {
+		if !budgeted[categoryID] {
+			summary.AssignedToPockets += amount
+		}
+	}
+
+	summary.TotalAssigned = summary.AssignedToBudgets + summary.AssignedToPockets
+	summary.Unassigned = summary.RealIncome - summary.TotalAssigned
+	return summary
+}
+
+// GetByMonthZeroBased returns the month's budgets along with how much of the
+// month's real income is still unassigned
+func (s *BudgetService) GetByMonthZeroBased(ctx context.Context, userID, month string) (*GetBudgetResponse, error) {
+	response, err := s.GetByMonth(ctx, userID, month)
+	if err != nil {
+		return nil, err
+	}
+
+	householdID, err := s.getUserHouseholdID(ctx, userID, households.PermView)
+	if err != nil {
+		return nil, err
+	}
+
+	incomeByType, err := s.repo.GetIncomeByType(ctx, householdID, month)
+	if err != nil {
+		return nil, err
+	}
+	deposits, err := s.repo.GetPocketDepositsByCategory(ctx, householdID, month)
+	if err != nil {
+		return nil, err
+	}
+
+	response.ZeroBased = zeroBasedSummary(incomeByType, response.Budgets, response.Groups, deposits)
+	return response, nil
+}
+
+// ReassignBudget moves an amount from one category's budget to another's for a
+// single month, leaving later months unchanged, and records the move
+func (s *BudgetService) ReassignBudget(ctx context.Context, userID string, input *ReassignBudgetInput) (*BudgetReassignment, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	householdID, err := s.getUserHouseholdID(ctx, userID, households.PermEditBudgets)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkCategory(ctx, householdID, input.FromCategoryID); err != nil {
+		return nil, err
+	}
+	if err := s.checkCategory(ctx, householdID, input.ToCategoryID); err != nil {
+		return nil, err
+	}
+
+	// Moving budget into another group must stay within that group's budget
+	from, err := s.categoryRepo.GetByID(ctx, input.FromCategoryID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.categoryRepo.GetByID(ctx, input.ToCategoryID)
+	if err != nil {
+		return nil, err
+	}
+	if to.CategoryGroupID != nil && (from.CategoryGroupID == nil || *from.CategoryGroupID != *to.CategoryGroupID) {
+		current, err := s.repo.GetEffectiveBudget(ctx, householdID, input.ToCategoryID, input.Month)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.checkGroupCap(ctx, householdID, to.CategoryGroupID, input.ToCategoryID, input.Month, current+input.Amount); err != nil {
+			return nil, err
+		}
+	}
+
+	// The source budget must still cover its templates
+	if s.templatesCalculator != nil {
+		templatesSum, err := s.templatesCalculator.CalculateTemplatesSum(ctx, userID, input.FromCategoryID)
+		if err == nil {
+			current, err := s.repo.GetEffectiveBudget(ctx, householdID, input.FromCategoryID, input.Month)
+			if err != nil {
+				return nil, err
+			}
+			if current-input.Amount < templatesSum {
+				return nil, ErrBudgetBelowTemplates
+			}
+		}
+	}
+
+	monthDate, _ := ParseMonth(input.Month)
+	reassignment := &BudgetReassignment{
+		HouseholdID:     householdID,
+		Month:           monthDate,
+		FromCategoryID:  input.FromCategoryID,
+		ToCategoryID:    input.ToCategoryID,
+		Amount:          input.Amount,
+		Note:            input.Note,
+		CreatedByUserID: &userID,
+	}
+	if err := s.repo.ReassignBudget(ctx, reassignment); err != nil {
+		s.auditService.LogAsync(ctx, &audit.LogInput{
+			Action:       audit.ActionBudgetUpdated,
+			ResourceType: "budget_reassignment",
+			UserID:       audit.StringPtr(userID),
+			HouseholdID:  audit.StringPtr(householdID),
+			Success:      false,
+			ErrorMessage: audit.StringPtr(err.Error()),
+		})
+		return nil, err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		Action:       audit.ActionBudgetUpdated,
+		ResourceType: "budget_reassignment",
+		ResourceID:   audit.StringPtr(reassignment.ID),
+		UserID:       audit.StringPtr(userID),
+		HouseholdID:  audit.StringPtr(householdID),
+		Success:      true,
+		NewValues:    audit.StructToMap(reassignment),
+	})
+
+	return reassignment, nil
+}
+
+// ListReassignments returns the budget reassignments made in a month
+func (s *BudgetService) ListReassignments(ctx context.Context, userID, month string) ([]*BudgetReassignment, error) {
+	if _, err := ParseMonth(month); err != nil {
+		return nil, ErrInvalidMonth
+	}
+
+	householdID, err := s.getUserHouseholdID(ctx, userID, households.PermView)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListReassignments(ctx, householdID, month)
+}
@@ -0,0 +1,82 @@
+package budgets
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// ReassignBudget handles POST /budgets/reassign
+func (h *Handler) ReassignBudget(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserFromSession(r)
+	if err != nil {
+		h.logger.Error("failed to get user from session", "error", err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var input ReassignBudgetInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error("failed to decode request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	reassignment, err := h.service.ReassignBudget(r.Context(), user.ID, &input)
+	if err != nil {
+		h.logger.Error("failed to reassign budget", "error", err, "user_id", user.ID)
+		switch {
+		case err == ErrInvalidMonth, err == ErrSameCategory, err == ErrBudgetBelowTemplates,
+			strings.Contains(err.Error(), "required"), strings.Contains(err.Error(), "must be"):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case err == ErrInsufficientBudget, err == ErrExceedsGroupBudget:
+			http.Error(w, err.Error(), http.StatusConflict)
+		case err == ErrCategoryNotFound:
+			http.Error(w, "category not found", http.StatusNotFound)
+		case err == ErrNoHousehold:
+			http.Error(w, "user has no household", http.StatusNotFound)
+		case err == ErrNotAuthorized:
+			http.Error(w, "forbidden: your role cannot edit budgets", http.StatusForbidden)
+		default:
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(reassignment)
+}
+
+// ListReassignments handles GET /budgets/reassignments?month=YYYY-MM
+func (h *Handler) ListReassignments(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserFromSession(r)
+	if err != nil {
+		h.logger.Error("failed to get user from session", "error", err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	month := r.URL.Query().Get("month")
+	reassignments, err := h.service.ListReassignments(r.Context(), user.ID, month)
+	if err != nil {
+		h.logger.Error("failed to list budget reassignments", "error", err, "user_id", user.ID, "month", month)
+		switch err {
+		case ErrInvalidMonth:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case ErrNoHousehold:
+			http.Error(w, "user has no household", http.StatusNotFound)
+		case ErrNotAuthorized:
+			http.Error(w, "forbidden: user is not a member of this household", http.StatusForbidden)
+		default:
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"month":         month,
+		"reassignments": reassignments,
+	})
+}
@@ -0,0 +1,184 @@
+package budgets
+
+import (
+	"context"
+
+	"github.com/blanquicet/conti/backend/internal/income"
+)
+
+// GetIncomeByType returns the household's income for a month summed per type
+func (r *PostgresRepository) GetIncomeByType(ctx context.Context, householdID, month string) (map[income.IncomeType]float64, error) {
+	monthDate, err := ParseMonth(month)
+	if err != nil {
+		return nil, ErrInvalidMonth
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT type, COALESCE(SUM(amount), 0)
+		FROM income
+		WHERE household_id = $1 AND DATE_TRUNC('month', income_date) = $2
+		GROUP BY type
+	`, householdID, monthDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byType := make(map[income.IncomeType]float64)
+	for rows.Next() {
+		var t income.IncomeType
+		var amount float64
+		if err := rows.Scan(&t, &amount); err != nil {
+			return nil, err
+		}
+		byType[t] = amount
+	}
+	return byType, rows.Err()
+}
+
+// GetPocketDepositsByCategory returns the household's pocket deposits for a month summed per category
+func (r *PostgresRepository) GetPocketDepositsByCategory(ctx context.Context, householdID, month string) (map[string]float64, error) {
+	monthDate, err := ParseMonth(month)
+	if err != nil {
+		return nil, ErrInvalidMonth
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT category_id, COALESCE(SUM(amount), 0)
+		FROM pocket_transactions
+		WHERE household_id = $1 AND type = 'DEPOSIT'
+			AND category_id IS NOT NULL
+			AND DATE_TRUNC('month', transaction_date) = $2
+		GROUP BY category_id
+	`, householdID, monthDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deposits := make(map[string]float64)
+	for rows.Next() {
+		var categoryID string
+		var amount float64
+		if err := rows.Scan(&categoryID, &amount); err != nil {
+			return nil, err
+		}
+		deposits[categoryID] = amount
+	}
+	return deposits, rows.Err()
+}
+
+// ReassignBudget moves an amount between two categories' budgets for one month
+// and records it. The next month of each category is pinned to its previous
+// amount, so the move does not carry over.
+func (r *PostgresRepository) ReassignBudget(ctx context.Context, reassignment *BudgetReassignment) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Serialize reassignments in the household so both amounts are read consistently
+	if _, err := tx.Exec(ctx, `SELECT 1 FROM households WHERE id = $1 FOR UPDATE`, reassignment.HouseholdID); err != nil {
+		return err
+	}
+
+	month := reassignment.Month
+	fromAmount, err := effectiveBudget(ctx, tx, reassignment.HouseholdID, reassignment.FromCategoryID, month)
+	if err != nil {
+		return err
+	}
+	if reassignment.Amount > fromAmount {
+		return ErrInsufficientBudget
+	}
+	toAmount, err := effectiveBudget(ctx, tx, reassignment.HouseholdID, reassignment.ToCategoryID, month)
+	if err != nil {
+		return err
+	}
+
+	nextMonth := month.AddDate(0, 1, 0)
+	for _, b := range []struct {
+		categoryID string
+		oldAmount  float64
+		newAmount  float64
+	}{
+		{reassignment.FromCategoryID, fromAmount, fromAmount - reassignment.Amount},
+		{reassignment.ToCategoryID, toAmount, toAmount + reassignment.Amount},
+	} {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO monthly_budgets (household_id, category_id, month, amount, currency)
+			VALUES ($1, $2, $3, $4, 'COP')
+			ON CONFLICT (household_id, category_id, month) DO NOTHING
+		`, reassignment.HouseholdID, b.categoryID, nextMonth, b.oldAmount); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO monthly_budgets (household_id, category_id, month, amount, currency)
+			VALUES ($1, $2, $3, $4, 'COP')
+			ON CONFLICT (household_id, category_id, month)
+			DO UPDATE SET amount = EXCLUDED.amount, updated_at = NOW()
+		`, reassignment.HouseholdID, b.categoryID, month, b.newAmount); err != nil {
+			return err
+		}
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO budget_reassignments (household_id, month, from_category_id, to_category_id, amount, note, created_by_user_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`, reassignment.HouseholdID, month, reassignment.FromCategoryID, reassignment.ToCategoryID,
+		reassignment.Amount, reassignment.Note, reassignment.CreatedByUserID,
+	).Scan(&reassignment.ID, &reassignment.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ListReassignments returns the budget reassignments of a household for a month, newest first
+func (r *PostgresRepository) ListReassignments(ctx context.Context, householdID, month string) ([]*BudgetReassignment, error) {
+	monthDate, err := ParseMonth(month)
+	if err != nil {
+		return nil, ErrInvalidMonth
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT br.id, br.household_id, br.month,
+			br.from_category_id, fc.name, br.to_category_id, tc.name,
+			br.amount, br.note, br.created_by_user_id, u.name, br.created_at
+		FROM budget_reassignments br
+		JOIN categories fc ON fc.id = br.from_category_id
+		JOIN categories tc ON tc.id = br.to_category_id
+		LEFT JOIN users u ON u.id = br.created_by_user_id
+		WHERE br.household_id = $1 AND br.month = $2
+		ORDER BY br.created_at DESC
+	`, householdID, monthDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reassignments := []*BudgetReassignment{}
+	for rows.Next() {
+		var ra BudgetReassignment
+		if err := rows.Scan(
+			&ra.ID,
+			&ra.HouseholdID,
+			&ra.Month,
+			&ra.FromCategoryID,
+			&ra.FromCategoryName,
+			&ra.ToCategoryID,
+			&ra.ToCategoryName,
+			&ra.Amount,
+			&ra.Note,
+			&ra.CreatedByUserID,
+			&ra.CreatedByName,
+			&ra.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		reassignments = append(reassignments, &ra)
+	}
+	return reassignments, rows.Err()
+}
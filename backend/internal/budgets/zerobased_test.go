@@ -0,0 +1,62 @@
+package budgets
+
+import (
+	"testing"
+
+	"github.com/blanquicet/conti/backend/internal/income"
+)
+
+// TestZeroBasedSummary tests real income against budgets and pocket deposits
+func TestZeroBasedSummary(t *testing.T) {
+	incomeByType := map[income.IncomeType]float64{
+		income.TypeSalary:            5000000,
+		income.TypeBonus:             500000,
+		income.TypeSavingsWithdrawal: 1000000, // Internal movement, not real income
+	}
+	budgets := []*BudgetWithSpent{
+		{CategoryID: "mercado", Amount: 1500000},
+		{CategoryID: "arriendo", Amount: 2000000},
+		{CategoryID: "ahorro", Amount: 500000},
+		{CategoryID: "viajes", Amount: 0},
+	}
+	deposits := map[string]float64{
+		"ahorro": 500000, // Already assigned through its budget
+		"viajes": 300000,
+	}
+
+	summary := zeroBasedSummary(incomeByType, budgets, nil, deposits)
+	if summary.RealIncome != 5500000 {
+		t.Errorf("RealIncome = %v, want 5500000", summary.RealIncome)
+	}
+	if summary.AssignedToBudgets != 4000000 {
+		t.Errorf("AssignedToBudgets = %v, want 4000000", summary.AssignedToBudgets)
+	}
+	if summary.AssignedToPockets != 300000 {
+		t.Errorf("AssignedToPockets = %v, want 300000", summary.AssignedToPockets)
+	}
+	if summary.Unassigned != 1200000 {
+		t.Errorf("Unassigned = %v, want 1200000", summary.Unassigned)
+	}
+}
+
+// TestReassignBudgetInputValidate tests reassignment validation
+func TestReassignBudgetInputValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   ReassignBudgetInput
+		wantErr bool
+	}{
+		{"Valid", ReassignBudgetInput{Month: "2026-03", FromCategoryID: "ropa", ToCategoryID: "mercado", Amount: 50000}, false},
+		{"Same category", ReassignBudgetInput{Month: "2026-03", FromCategoryID: "ropa", ToCategoryID: "ropa", Amount: 50000}, true},
+		{"Zero amount", ReassignBudgetInput{Month: "2026-03", FromCategoryID: "ropa", ToCategoryID: "mercado"}, true},
+		{"Bad month", ReassignBudgetInput{Month: "03-2026", FromCategoryID: "ropa", ToCategoryID: "mercado", Amount: 1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.input.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
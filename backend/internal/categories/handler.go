@@ -91,6 +91,10 @@ func (h *Handler) CreateCategory(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "user has no household", http.StatusNotFound)
 			return
 		}
+		if err == ErrNotAuthorized {
+			http.Error(w, "forbidden: your role cannot edit categories", http.StatusForbidden)
+			return
+		}
 		http.Error(w, "internal server error", http.StatusInternalServerError)
 		return
 	}
@@ -138,6 +142,10 @@ func (h *Handler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "category with this name already exists in household", http.StatusConflict)
 			return
 		}
+		if err == ErrExceedsGroupBudget {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		if err == ErrCategoryNotFound {
 			http.Error(w, "category not found", http.StatusNotFound)
 			return
@@ -236,11 +244,7 @@ func (h *Handler) ReorderCategories(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(map[string]string{"message": "categories reordered successfully"})
 }
 
-// getUserFromSession extracts the user from the session cookie
+// getUserFromSession extracts the user from the session cookie or access token
 func (h *Handler) getUserFromSession(r *http.Request) (*auth.User, error) {
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		return nil, err
-	}
-	return h.authSvc.GetUserBySession(r.Context(), cookie.Value)
+	return h.authSvc.UserFromRequest(r, h.cookieName)
 }
@@ -2,6 +2,7 @@ package categories
 
 import (
 	"context"
+	"errors"
 
 	"github.com/blanquicet/conti/backend/internal/audit"
 	"github.com/blanquicet/conti/backend/internal/households"
@@ -12,6 +13,7 @@ type CategoryService struct {
 	repo          Repository
 	householdRepo households.HouseholdRepository
 	auditService  audit.Service
+	groupCapFn    func(ctx context.Context, householdID, categoryID, groupID string) error
 }
 
 // NewService creates a new category service
@@ -23,6 +25,12 @@ func NewService(repo Repository, householdRepo households.HouseholdRepository, a
 	}
 }
 
+// SetGroupCapFn sets the function that checks a category's budgets still fit
+// a group's budget before the category moves into that group
+func (s *CategoryService) SetGroupCapFn(fn func(ctx context.Context, householdID, categoryID, groupID string) error) {
+	s.groupCapFn = fn
+}
+
 // Create creates a new category
 func (s *CategoryService) Create(ctx context.Context, userID string, input *CreateCategoryInput) (*Category, error) {
 	// Validate input
@@ -31,7 +39,7 @@ func (s *CategoryService) Create(ctx context.Context, userID string, input *Crea
 	}
 
 	// Get user's household
-	householdID, err := s.getUserHouseholdID(ctx, userID)
+	householdID, err := s.getUserHouseholdID(ctx, userID, households.PermEditBudgets)
 	if err != nil {
 		return nil, err
 	}
@@ -71,10 +79,10 @@ func (s *CategoryService) GetByID(ctx context.Context, userID, id string) (*Cate
 		return nil, err
 	}
 
-	// Verify user is member of category's household
-	_, err = s.householdRepo.GetMemberByUserID(ctx, category.HouseholdID, userID)
+	// Verify user may edit categories in the category's household
+	_, err = households.Authorize(ctx, s.householdRepo, category.HouseholdID, userID, households.PermEditBudgets)
 	if err != nil {
-		if err == households.ErrMemberNotFound {
+		if errors.Is(err, households.ErrNotAuthorized) {
 			return nil, ErrNotAuthorized
 		}
 		return nil, err
@@ -86,7 +94,7 @@ func (s *CategoryService) GetByID(ctx context.Context, userID, id string) (*Cate
 // ListByHousehold lists all categories for user's household
 func (s *CategoryService) ListByHousehold(ctx context.Context, userID string, includeInactive bool) (*ListCategoriesResponse, error) {
 	// Get user's household
-	householdID, err := s.getUserHouseholdID(ctx, userID)
+	householdID, err := s.getUserHouseholdID(ctx, userID, households.PermView)
 	if err != nil {
 		return nil, err
 	}
@@ -118,15 +126,24 @@ func (s *CategoryService) Update(ctx context.Context, userID, id string, input *
 		return nil, err
 	}
 
-	// Verify user is member of category's household
-	_, err = s.householdRepo.GetMemberByUserID(ctx, category.HouseholdID, userID)
+	// Verify user may edit categories in the category's household
+	_, err = households.Authorize(ctx, s.householdRepo, category.HouseholdID, userID, households.PermEditBudgets)
 	if err != nil {
-		if err == households.ErrMemberNotFound {
+		if errors.Is(err, households.ErrNotAuthorized) {
 			return nil, ErrNotAuthorized
 		}
 		return nil, err
 	}
 
+	// A category moving into another group must fit that group's budget
+	movesGroup := input.CategoryGroupID != nil &&
+		(category.CategoryGroupID == nil || *category.CategoryGroupID != *input.CategoryGroupID)
+	if movesGroup && s.groupCapFn != nil {
+		if err := s.groupCapFn(ctx, category.HouseholdID, id, *input.CategoryGroupID); err != nil {
+			return nil, err
+		}
+	}
+
 	// Store old values for audit
 	oldValues := audit.StructToMap(category)
 
@@ -167,10 +184,10 @@ func (s *CategoryService) Delete(ctx context.Context, userID, id string) error {
 		return err
 	}
 
-	// Verify user is member of category's household
-	_, err = s.householdRepo.GetMemberByUserID(ctx, category.HouseholdID, userID)
+	// Verify user may edit categories in the category's household
+	_, err = households.Authorize(ctx, s.householdRepo, category.HouseholdID, userID, households.PermEditBudgets)
 	if err != nil {
-		if err == households.ErrMemberNotFound {
+		if errors.Is(err, households.ErrNotAuthorized) {
 			return ErrNotAuthorized
 		}
 		return err
@@ -215,7 +232,7 @@ func (s *CategoryService) Reorder(ctx context.Context, userID string, input *Reo
 	}
 
 	// Get user's household
-	householdID, err := s.getUserHouseholdID(ctx, userID)
+	householdID, err := s.getUserHouseholdID(ctx, userID, households.PermEditBudgets)
 	if err != nil {
 		return err
 	}
@@ -235,15 +252,15 @@ func (s *CategoryService) Reorder(ctx context.Context, userID string, input *Reo
 	return s.repo.Reorder(ctx, householdID, input.CategoryIDs)
 }
 
-// getUserHouseholdID gets the household ID for a user
-func (s *CategoryService) getUserHouseholdID(ctx context.Context, userID string) (string, error) {
-	households, err := s.householdRepo.ListByUser(ctx, userID)
-	if err != nil {
-		return "", err
-	}
-	if len(households) == 0 {
+// getUserHouseholdID gets the household the user's request acts on and checks
+// their role there grants perm
+func (s *CategoryService) getUserHouseholdID(ctx context.Context, userID string, perm households.Permission) (string, error) {
+	householdID, err := households.AuthorizeSelected(ctx, s.householdRepo, userID, perm)
+	switch {
+	case errors.Is(err, households.ErrNoHousehold):
 		return "", ErrNoHousehold
+	case errors.Is(err, households.ErrNotAuthorized):
+		return "", ErrNotAuthorized
 	}
-	// User should only have one household (for now)
-	return households[0].ID, nil
+	return householdID, err
 }
@@ -169,6 +169,17 @@ return hID, nil
 return "", households.ErrHouseholdNotFound
 }
 
+func (m *MockHouseholdRepository) ResolveHouseholdID(ctx context.Context, userID string) (string, error) {
+for hID, members := range m.members {
+if _, ok := members[userID]; ok {
+return hID, nil
+}
+}
+return "", households.ErrNoHousehold
+}
+
+func (m *MockHouseholdRepository) ListMemberships(ctx context.Context, userID string) ([]*households.Membership, error) { return nil, nil }
+
 // Stub implementations for interface compliance
 func (m *MockHouseholdRepository) Create(ctx context.Context, name, createdBy string) (*households.Household, error) { return nil, nil }
 func (m *MockHouseholdRepository) GetByID(ctx context.Context, id string) (*households.Household, error) { return nil, nil }
@@ -187,15 +198,21 @@ func (m *MockHouseholdRepository) RemoveMember(ctx context.Context, householdID,
 func (m *MockHouseholdRepository) UpdateMemberRole(ctx context.Context, householdID, userID string, role households.HouseholdRole) (*households.HouseholdMember, error) { return nil, nil }
 func (m *MockHouseholdRepository) GetMembers(ctx context.Context, householdID string) ([]*households.HouseholdMember, error) { return nil, nil }
 func (m *MockHouseholdRepository) CountOwners(ctx context.Context, householdID string) (int, error) { return 0, nil }
+func (m *MockHouseholdRepository) TransferOwnership(ctx context.Context, householdID, fromUserID, toUserID string) error { return nil }
+func (m *MockHouseholdRepository) MergeHouseholds(ctx context.Context, sourceID, targetID string, apply bool) (*households.MergeReport, error) { return nil, nil }
 func (m *MockHouseholdRepository) CreateContact(ctx context.Context, contact *households.Contact) (*households.Contact, error) { return nil, nil }
 func (m *MockHouseholdRepository) GetContact(ctx context.Context, id string) (*households.Contact, error) { return nil, nil }
 func (m *MockHouseholdRepository) UpdateContact(ctx context.Context, contact *households.Contact, isActive *bool) (*households.Contact, error) { return nil, nil }
 func (m *MockHouseholdRepository) DeleteContact(ctx context.Context, id string) error { return nil }
 func (m *MockHouseholdRepository) ListContacts(ctx context.Context, householdID string) ([]*households.Contact, error) { return nil, nil }
 func (m *MockHouseholdRepository) FindContactByEmail(ctx context.Context, householdID, email string) (*households.Contact, error) { return nil, nil }
-func (m *MockHouseholdRepository) CreateInvitation(ctx context.Context, householdID, email, token, invitedBy string) (*households.HouseholdInvitation, error) { return nil, nil }
+func (m *MockHouseholdRepository) CreateInvitation(ctx context.Context, householdID, email, token, invitedBy string, expiresAt time.Time) (*households.HouseholdInvitation, error) { return nil, nil }
+func (m *MockHouseholdRepository) GetInvitationByID(ctx context.Context, id string) (*households.HouseholdInvitation, error) { return nil, nil }
 func (m *MockHouseholdRepository) GetInvitationByToken(ctx context.Context, token string) (*households.HouseholdInvitation, error) { return nil, nil }
+func (m *MockHouseholdRepository) FindOpenInvitationByEmail(ctx context.Context, householdID, email string) (*households.HouseholdInvitation, error) { return nil, nil }
 func (m *MockHouseholdRepository) AcceptInvitation(ctx context.Context, id string) error { return nil }
+func (m *MockHouseholdRepository) RotateInvitationToken(ctx context.Context, id, token string, expiresAt time.Time) (*households.HouseholdInvitation, error) { return nil, nil }
+func (m *MockHouseholdRepository) RevokeInvitation(ctx context.Context, id string) error { return nil }
 func (m *MockHouseholdRepository) ListPendingInvitations(ctx context.Context, householdID string) ([]*households.HouseholdInvitation, error) { return nil, nil }
 func (m *MockHouseholdRepository) IsUserMember(ctx context.Context, householdID, userID string) (bool, error) {
 if members, ok := m.members[householdID]; ok {
@@ -207,6 +224,9 @@ return false, nil
 func (m *MockHouseholdRepository) FindLinkedContactsByHousehold(ctx context.Context, householdID string) ([]households.LinkedContact, error) {
 return nil, nil
 }
+func (m *MockHouseholdRepository) MergeContacts(ctx context.Context, sourceID, targetID string, carryLink bool) error {
+return nil
+}
 func (m *MockHouseholdRepository) ListPendingLinkRequests(ctx context.Context, userID string) ([]households.LinkRequest, error) {
 return nil, nil
 }
@@ -231,6 +251,21 @@ return nil
 func (m *MockHouseholdRepository) FindContactByLinkedUserID(ctx context.Context, householdID string, linkedUserID string) (*households.Contact, error) {
 return nil, households.ErrContactNotFound
 }
+func (m *MockHouseholdRepository) SuggestLinksForUser(ctx context.Context, userID, email string) ([]*households.Contact, error) {
+return nil, nil
+}
+
+func (m *MockHouseholdRepository) HasPendingLinkSuggestion(ctx context.Context, contactID, userID string) (bool, error) {
+return false, nil
+}
+
+func (m *MockHouseholdRepository) AcceptLinkSuggestion(ctx context.Context, contactID, userID string) error {
+return nil
+}
+
+func (m *MockHouseholdRepository) RejectLinkSuggestion(ctx context.Context, contactID, userID string) error {
+return nil
+}
 
 func generateID(n int) string {
 return "cat-" + string(rune('0'+n))
@@ -321,3 +356,51 @@ if len(response.Categories) != 2 {
 t.Errorf("ListByHousehold() returned %d categories, want 2", len(response.Categories))
 }
 }
+
+func TestUpdateCategoryMoveChecksGroupCap(t *testing.T) {
+repo := NewMockRepository()
+householdRepo := NewMockHouseholdRepository()
+householdRepo.AddTestMember("household1", "user1", households.RoleOwner)
+auditSvc := &MockAuditService{}
+
+cat, _ := repo.Create(context.Background(), "household1", &CreateCategoryInput{
+Name:            "Groceries",
+CategoryGroupID: strPtr("group1"),
+})
+
+svc := NewService(repo, householdRepo, auditSvc)
+var checked []string
+svc.SetGroupCapFn(func(ctx context.Context, householdID, categoryID, groupID string) error {
+checked = append(checked, groupID)
+if groupID == "capped" {
+return ErrExceedsGroupBudget
+}
+return nil
+})
+
+// Renaming or keeping the same group does not check the cap
+if _, err := svc.Update(context.Background(), "user1", cat.ID, &UpdateCategoryInput{
+Name:            strPtr("Supermarket"),
+CategoryGroupID: strPtr("group1"),
+}); err != nil {
+t.Fatalf("Update() error = %v", err)
+}
+if len(checked) != 0 {
+t.Errorf("group cap checked for %v, want no check", checked)
+}
+
+if _, err := svc.Update(context.Background(), "user1", cat.ID, &UpdateCategoryInput{
+CategoryGroupID: strPtr("capped"),
+}); err != ErrExceedsGroupBudget {
+t.Errorf("Update() error = %v, want %v", err, ErrExceedsGroupBudget)
+}
+if got, _ := repo.GetByID(context.Background(), cat.ID); got.CategoryGroupID == nil || *got.CategoryGroupID != "group1" {
+t.Errorf("category moved despite exceeding the group budget: %v", got.CategoryGroupID)
+}
+
+if _, err := svc.Update(context.Background(), "user1", cat.ID, &UpdateCategoryInput{
+CategoryGroupID: strPtr("group2"),
+}); err != nil {
+t.Errorf("Update() error = %v", err)
+}
+}
@@ -16,6 +16,7 @@ var (
 	ErrCategoryInUse         = errors.New("No se puede eliminar la categoría porque se usa en movimientos")
 	ErrNoHousehold           = errors.New("El usuario no pertenece a un hogar")
 	ErrInvalidDisplayOrder   = errors.New("Orden de visualización inválido")
+	ErrExceedsGroupBudget    = errors.New("Los presupuestos de la categoría superan el presupuesto del grupo")
 )
 
 // Category represents an expense category
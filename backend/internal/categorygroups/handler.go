@@ -75,6 +75,8 @@ func (h *Handler) CreateCategoryGroup(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, err.Error(), http.StatusConflict)
 		case ErrNoHousehold:
 			http.Error(w, "user has no household", http.StatusNotFound)
+		case ErrNotAuthorized:
+			http.Error(w, "forbidden", http.StatusForbidden)
 		default:
 			http.Error(w, "internal server error", http.StatusInternalServerError)
 		}
@@ -161,11 +163,7 @@ func (h *Handler) DeleteCategoryGroup(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// getUserFromSession is a helper to extract user from session cookie
+// getUserFromSession is a helper to extract user from session cookie or access token
 func (h *Handler) getUserFromSession(r *http.Request) (*auth.User, error) {
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		return nil, err
-	}
-	return h.authSvc.GetUserBySession(r.Context(), cookie.Value)
+	return h.authSvc.UserFromRequest(r, h.cookieName)
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/blanquicet/conti/backend/internal/audit"
+	"github.com/blanquicet/conti/backend/internal/households"
 )
 
 var (
@@ -13,7 +14,7 @@ var (
 
 // UserFetcher defines interface for fetching user household info
 type UserFetcher interface {
-	GetUserHouseholdID(ctx context.Context, userID string) (string, error)
+	households.SelectedMemberGetter
 }
 
 // service implements Service
@@ -34,13 +35,10 @@ func NewService(repo Repository, userFetcher UserFetcher, auditService audit.Ser
 
 // ListByHousehold returns all category groups with their categories for the current user's household
 func (s *service) ListByHousehold(ctx context.Context, userID string, includeInactive bool) ([]*CategoryGroup, error) {
-	householdID, err := s.userFetcher.GetUserHouseholdID(ctx, userID)
+	householdID, err := s.authorize(ctx, userID, households.PermView)
 	if err != nil {
 		return nil, err
 	}
-	if householdID == "" {
-		return nil, ErrNoHousehold
-	}
 
 	return s.repo.ListByHousehold(ctx, householdID, includeInactive)
 }
@@ -51,13 +49,10 @@ func (s *service) Create(ctx context.Context, userID string, input *CreateCatego
 		return nil, err
 	}
 
-	householdID, err := s.userFetcher.GetUserHouseholdID(ctx, userID)
+	householdID, err := s.authorize(ctx, userID, households.PermEditBudgets)
 	if err != nil {
 		return nil, err
 	}
-	if householdID == "" {
-		return nil, ErrNoHousehold
-	}
 
 	group, err := s.repo.Create(ctx, householdID, input)
 	if err != nil {
@@ -179,15 +174,24 @@ func (s *service) Delete(ctx context.Context, userID, id string) error {
 	return nil
 }
 
-// verifyAccess checks if user belongs to the same household
+// authorize resolves the user's household and checks their role there grants perm
+func (s *service) authorize(ctx context.Context, userID string, perm households.Permission) (string, error) {
+	householdID, err := households.AuthorizeSelected(ctx, s.userFetcher, userID, perm)
+	switch {
+	case errors.Is(err, households.ErrNoHousehold):
+		return "", ErrNoHousehold
+	case errors.Is(err, households.ErrNotAuthorized):
+		return "", ErrNotAuthorized
+	}
+	return householdID, err
+}
+
+// verifyAccess checks if user belongs to the same household and may edit its groups
 func (s *service) verifyAccess(ctx context.Context, userID, groupHouseholdID string) (string, error) {
-	householdID, err := s.userFetcher.GetUserHouseholdID(ctx, userID)
+	householdID, err := s.authorize(ctx, userID, households.PermEditBudgets)
 	if err != nil {
 		return "", err
 	}
-	if householdID == "" {
-		return "", ErrNoHousehold
-	}
 	if householdID != groupHouseholdID {
 		return "", ErrNotAuthorized
 	}
@@ -26,6 +26,14 @@ type Config struct {
 
 	// Rate limiting configuration
 	RateLimitEnabled bool
+	RateLimitStore   string // "postgres" (shared by replicas) or "memory"
+
+	// Passwordless login by emailed one-time links (opt-in)
+	MagicLinkEnabled bool
+
+	// Household invitations
+	InvitationTTL       time.Duration
+	InvitationRateLimit int // invitation emails per household per hour
 
 	// Email configuration
 	EmailProvider    string
@@ -125,6 +133,34 @@ func Load() (*Config, error) {
 
 	// Rate limiting - disabled only if explicitly set to "false", enabled by default
 	rateLimitEnabled := os.Getenv("RATE_LIMIT_ENABLED") != "false"
+	rateLimitStore := os.Getenv("RATE_LIMIT_STORE")
+	if rateLimitStore == "" {
+		rateLimitStore = "postgres"
+	}
+	if rateLimitStore != "postgres" && rateLimitStore != "memory" {
+		return nil, errors.New("RATE_LIMIT_STORE must be \"postgres\" or \"memory\"")
+	}
+
+	// Magic-link login - enabled only if explicitly set to "true"
+	magicLinkEnabled := os.Getenv("MAGIC_LINK_ENABLED") == "true"
+
+	// Household invitations: validity (default 7 days) and emails per household per hour
+	invitationTTL := 7 * 24 * time.Hour
+	if hoursStr := os.Getenv("INVITATION_TTL_HOURS"); hoursStr != "" {
+		hours, err := strconv.Atoi(hoursStr)
+		if err != nil || hours <= 0 {
+			return nil, errors.New("INVITATION_TTL_HOURS must be a positive integer")
+		}
+		invitationTTL = time.Duration(hours) * time.Hour
+	}
+	invitationRateLimit := 10
+	if limitStr := os.Getenv("INVITATION_RATE_LIMIT"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			return nil, errors.New("INVITATION_RATE_LIMIT must be a positive integer")
+		}
+		invitationRateLimit = limit
+	}
 
 	// Azure OpenAI (optional — chat feature disabled if endpoint not set)
 	// Auth via Managed Identity (DefaultAzureCredential), no API key needed
@@ -154,6 +190,10 @@ func Load() (*Config, error) {
 		SessionCookieSecure:   sessionCookieSecure,
 		AllowedOrigins:        allowedOrigins,
 		RateLimitEnabled:      rateLimitEnabled,
+		RateLimitStore:        rateLimitStore,
+		MagicLinkEnabled:      magicLinkEnabled,
+		InvitationTTL:         invitationTTL,
+		InvitationRateLimit:   invitationRateLimit,
 		EmailProvider:         emailProvider,
 		EmailFromAddress:      emailFromAddress,
 		EmailFromName:         emailFromName,
@@ -37,13 +37,9 @@ type CreateRequest struct {
 	SourceAccountID string  `json:"source_account_id"`
 }
 
-// getUserFromSession extracts user from session cookie
+// getUserFromSession extracts user from session cookie or access token
 func (h *Handler) getUserFromSession(r *http.Request) (*auth.User, error) {
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		return nil, err
-	}
-	return h.authSvc.GetUserBySession(r.Context(), cookie.Value)
+	return h.authSvc.UserFromRequest(r, h.cookieName)
 }
 
 // HandleCreate handles POST /credit-card-payments
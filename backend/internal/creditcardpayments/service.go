@@ -2,6 +2,7 @@ package creditcardpayments
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 
 	"github.com/blanquicet/conti/backend/internal/accounts"
@@ -39,6 +40,15 @@ func NewService(
 	}
 }
 
+// authorizeEdit resolves the user's household and checks their role may record card payments
+func (s *service) authorizeEdit(ctx context.Context, userID string) (string, error) {
+	householdID, err := households.AuthorizeSelected(ctx, s.householdRepo, userID, households.PermEditMovements)
+	if errors.Is(err, households.ErrNotAuthorized) {
+		return "", ErrNotAuthorized
+	}
+	return householdID, err
+}
+
 // Create creates a new credit card payment
 func (s *service) Create(ctx context.Context, userID string, input *CreateInput) (*CreditCardPayment, error) {
 	// Validate input
@@ -47,7 +57,7 @@ func (s *service) Create(ctx context.Context, userID string, input *CreateInput)
 	}
 
 	// Get user's household
-	householdID, err := s.householdRepo.GetUserHouseholdID(ctx, userID)
+	householdID, err := s.authorizeEdit(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -127,7 +137,7 @@ func (s *service) Create(ctx context.Context, userID string, input *CreateInput)
 // GetByID retrieves a credit card payment by ID
 func (s *service) GetByID(ctx context.Context, userID, id string) (*CreditCardPayment, error) {
 	// Get user's household
-	householdID, err := s.householdRepo.GetUserHouseholdID(ctx, userID)
+	householdID, err := s.householdRepo.ResolveHouseholdID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -148,7 +158,7 @@ func (s *service) GetByID(ctx context.Context, userID, id string) (*CreditCardPa
 // Delete deletes a credit card payment
 func (s *service) Delete(ctx context.Context, userID, id string) error {
 	// Get user's household
-	householdID, err := s.householdRepo.GetUserHouseholdID(ctx, userID)
+	householdID, err := s.authorizeEdit(ctx, userID)
 	if err != nil {
 		return err
 	}
@@ -195,7 +205,7 @@ func (s *service) Delete(ctx context.Context, userID, id string) error {
 // List lists credit card payments for the user's household
 func (s *service) List(ctx context.Context, userID string, filter *ListFilter) (*ListResponse, error) {
 	// Get user's household
-	householdID, err := s.householdRepo.GetUserHouseholdID(ctx, userID)
+	householdID, err := s.householdRepo.ResolveHouseholdID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
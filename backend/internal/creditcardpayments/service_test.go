@@ -98,6 +98,14 @@ func (m *MockHouseholdRepository) GetUserHouseholdID(ctx context.Context, userID
 	return "", households.ErrHouseholdNotFound
 }
 
+func (m *MockHouseholdRepository) ResolveHouseholdID(ctx context.Context, userID string) (string, error) {
+	return m.GetUserHouseholdID(ctx, userID)
+}
+
+func (m *MockHouseholdRepository) ListMemberships(ctx context.Context, userID string) ([]*households.Membership, error) {
+	return nil, nil
+}
+
 // Stub implementations for interface compliance
 func (m *MockHouseholdRepository) Create(ctx context.Context, name, createdBy string) (*households.Household, error) {
 	return nil, nil
@@ -125,11 +133,21 @@ func (m *MockHouseholdRepository) GetMembers(ctx context.Context, householdID st
 	return nil, nil
 }
 func (m *MockHouseholdRepository) GetMemberByUserID(ctx context.Context, householdID, userID string) (*households.HouseholdMember, error) {
-	return nil, nil
+	role, ok := m.members[householdID][userID]
+	if !ok {
+		return nil, households.ErrMemberNotFound
+	}
+	return &households.HouseholdMember{HouseholdID: householdID, UserID: userID, Role: role}, nil
 }
 func (m *MockHouseholdRepository) CountOwners(ctx context.Context, householdID string) (int, error) {
 	return 0, nil
 }
+func (m *MockHouseholdRepository) TransferOwnership(ctx context.Context, householdID, fromUserID, toUserID string) error {
+	return nil
+}
+func (m *MockHouseholdRepository) MergeHouseholds(ctx context.Context, sourceID, targetID string, apply bool) (*households.MergeReport, error) {
+	return nil, nil
+}
 func (m *MockHouseholdRepository) CreateContact(ctx context.Context, contact *households.Contact) (*households.Contact, error) {
 	return nil, nil
 }
@@ -146,13 +164,23 @@ func (m *MockHouseholdRepository) ListContacts(ctx context.Context, householdID
 func (m *MockHouseholdRepository) FindContactByEmail(ctx context.Context, householdID, email string) (*households.Contact, error) {
 	return nil, nil
 }
-func (m *MockHouseholdRepository) CreateInvitation(ctx context.Context, householdID, email, token, invitedBy string) (*households.HouseholdInvitation, error) {
+func (m *MockHouseholdRepository) CreateInvitation(ctx context.Context, householdID, email, token, invitedBy string, expiresAt time.Time) (*households.HouseholdInvitation, error) {
+	return nil, nil
+}
+func (m *MockHouseholdRepository) GetInvitationByID(ctx context.Context, id string) (*households.HouseholdInvitation, error) {
 	return nil, nil
 }
 func (m *MockHouseholdRepository) GetInvitationByToken(ctx context.Context, token string) (*households.HouseholdInvitation, error) {
 	return nil, nil
 }
+func (m *MockHouseholdRepository) FindOpenInvitationByEmail(ctx context.Context, householdID, email string) (*households.HouseholdInvitation, error) {
+	return nil, nil
+}
 func (m *MockHouseholdRepository) AcceptInvitation(ctx context.Context, id string) error { return nil }
+func (m *MockHouseholdRepository) RotateInvitationToken(ctx context.Context, id, token string, expiresAt time.Time) (*households.HouseholdInvitation, error) {
+	return nil, nil
+}
+func (m *MockHouseholdRepository) RevokeInvitation(ctx context.Context, id string) error { return nil }
 func (m *MockHouseholdRepository) ListPendingInvitations(ctx context.Context, householdID string) ([]*households.HouseholdInvitation, error) {
 	return nil, nil
 }
@@ -162,6 +190,9 @@ func (m *MockHouseholdRepository) IsUserMember(ctx context.Context, householdID,
 func (m *MockHouseholdRepository) FindLinkedContactsByHousehold(ctx context.Context, householdID string) ([]households.LinkedContact, error) {
 	return nil, nil
 }
+func (m *MockHouseholdRepository) MergeContacts(ctx context.Context, sourceID, targetID string, carryLink bool) error {
+	return nil
+}
 func (m *MockHouseholdRepository) ListPendingLinkRequests(ctx context.Context, userID string) ([]households.LinkRequest, error) {
 	return nil, nil
 }
@@ -186,6 +217,21 @@ func (m *MockHouseholdRepository) DismissUnlinkBanner(ctx context.Context, conta
 func (m *MockHouseholdRepository) FindContactByLinkedUserID(ctx context.Context, householdID string, linkedUserID string) (*households.Contact, error) {
 	return nil, households.ErrContactNotFound
 }
+func (m *MockHouseholdRepository) SuggestLinksForUser(ctx context.Context, userID, email string) ([]*households.Contact, error) {
+	return nil, nil
+}
+
+func (m *MockHouseholdRepository) HasPendingLinkSuggestion(ctx context.Context, contactID, userID string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockHouseholdRepository) AcceptLinkSuggestion(ctx context.Context, contactID, userID string) error {
+	return nil
+}
+
+func (m *MockHouseholdRepository) RejectLinkSuggestion(ctx context.Context, contactID, userID string) error {
+	return nil
+}
 
 // MockPaymentMethodsRepository for testing
 type MockPaymentMethodsRepository struct {
@@ -34,14 +34,8 @@ func NewHandler(service Service, authSvc *auth.Service, cookieName string) *Hand
 func (h *Handler) HandleGetSummary(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	// Get user from session cookie
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
-		return
-	}
-
-	user, err := h.authSvc.GetUserBySession(ctx, cookie.Value)
+	// Get user from session cookie or access token
+	user, err := h.authSvc.UserFromRequest(r, h.cookieName)
 	if err != nil {
 		http.Error(w, "unauthorized", http.StatusUnauthorized)
 		return
@@ -93,14 +87,8 @@ func (h *Handler) HandleGetSummary(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) HandleGetCardMovements(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	// Get user from session cookie
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
-		return
-	}
-
-	user, err := h.authSvc.GetUserBySession(ctx, cookie.Value)
+	// Get user from session cookie or access token
+	user, err := h.authSvc.UserFromRequest(r, h.cookieName)
 	if err != nil {
 		http.Error(w, "unauthorized", http.StatusUnauthorized)
 		return
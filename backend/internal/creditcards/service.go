@@ -47,7 +47,7 @@ func NewService(
 // GetSummary returns the credit cards summary for a billing cycle
 func (s *service) GetSummary(ctx context.Context, userID string, cycleDate time.Time, filter *SummaryFilter) (*SummaryResponse, error) {
 	// Get household ID for authorization
-	householdID, err := s.householdsRepo.GetUserHouseholdID(ctx, userID)
+	householdID, err := s.householdsRepo.ResolveHouseholdID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("get household: %w", err)
 	}
@@ -129,7 +129,7 @@ func (s *service) GetSummary(ctx context.Context, userID string, cycleDate time.
 // GetCardMovements returns detailed movements and payments for a single card
 func (s *service) GetCardMovements(ctx context.Context, userID string, cardID string, cycleDate time.Time) (*CardMovementsResponse, error) {
 	// Get household ID for authorization
-	householdID, err := s.householdsRepo.GetUserHouseholdID(ctx, userID)
+	householdID, err := s.householdsRepo.ResolveHouseholdID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("get household: %w", err)
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/resend/resend-go/v2"
 )
@@ -152,3 +153,200 @@ func (s *ResendSender) SendLinkRequest(ctx context.Context, to, requesterName, h
 	)
 	return nil
 }
+
+// SendNewDeviceLogin warns the user about a login from a new device via Resend.
+func (s *ResendSender) SendNewDeviceLogin(ctx context.Context, to, userAgent, ipAddress string, at time.Time) error {
+	subject := "Nuevo inicio de sesión en tu cuenta - Conti"
+	htmlContent := formatNewDeviceLoginEmail(to, userAgent, ipAddress, at)
+
+	client := resend.NewClient(s.apiKey)
+
+	from := s.from
+	if s.fromName != "" {
+		from = fmt.Sprintf("%s <%s>", s.fromName, s.from)
+	}
+
+	s.logger.Info("sending new device login email via Resend", "to", to)
+
+	params := &resend.SendEmailRequest{
+		From:    from,
+		To:      []string{to},
+		Subject: subject,
+		Html:    htmlContent,
+	}
+
+	sent, err := client.Emails.SendWithContext(ctx, params)
+	if err != nil {
+		s.logger.Error("failed to send email via Resend",
+			"error", err,
+			"to", to,
+		)
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	s.logger.Info("new device login email sent successfully",
+		"to", to,
+		"email_id", sent.Id,
+	)
+	return nil
+}
+
+// SendEmailVerification sends the email address verification link via Resend.
+func (s *ResendSender) SendEmailVerification(ctx context.Context, to, token string) error {
+	link := fmt.Sprintf("%s/verify-email?token=%s", s.baseURL, token)
+
+	subject := "Confirma tu email - Conti"
+	htmlContent := formatEmailVerificationEmail(to, link)
+
+	client := resend.NewClient(s.apiKey)
+
+	from := s.from
+	if s.fromName != "" {
+		from = fmt.Sprintf("%s <%s>", s.fromName, s.from)
+	}
+
+	s.logger.Info("sending email verification email via Resend", "to", to)
+
+	params := &resend.SendEmailRequest{
+		From:    from,
+		To:      []string{to},
+		Subject: subject,
+		Html:    htmlContent,
+	}
+
+	sent, err := client.Emails.SendWithContext(ctx, params)
+	if err != nil {
+		s.logger.Error("failed to send email via Resend",
+			"error", err,
+			"to", to,
+		)
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	s.logger.Info("email verification email sent successfully",
+		"to", to,
+		"email_id", sent.Id,
+	)
+	return nil
+}
+
+// SendMagicLink sends a one-time sign-in link via Resend.
+func (s *ResendSender) SendMagicLink(ctx context.Context, to, token string) error {
+	link := fmt.Sprintf("%s/magic-login?token=%s", s.baseURL, token)
+
+	subject := "Tu enlace para iniciar sesión - Conti"
+	htmlContent := formatMagicLinkEmail(to, link)
+
+	client := resend.NewClient(s.apiKey)
+
+	from := s.from
+	if s.fromName != "" {
+		from = fmt.Sprintf("%s <%s>", s.fromName, s.from)
+	}
+
+	s.logger.Info("sending magic link email via Resend", "to", to)
+
+	params := &resend.SendEmailRequest{
+		From:    from,
+		To:      []string{to},
+		Subject: subject,
+		Html:    htmlContent,
+	}
+
+	sent, err := client.Emails.SendWithContext(ctx, params)
+	if err != nil {
+		s.logger.Error("failed to send email via Resend",
+			"error", err,
+			"to", to,
+		)
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	s.logger.Info("magic link email sent successfully",
+		"to", to,
+		"email_id", sent.Id,
+	)
+	return nil
+}
+
+// SendAccountLocked tells the user their account was locked and sends an unlock link via Resend.
+func (s *ResendSender) SendAccountLocked(ctx context.Context, to, token string, lockedUntil time.Time) error {
+	link := fmt.Sprintf("%s/unlock-account?token=%s", s.baseURL, token)
+
+	subject := "Tu cuenta fue bloqueada temporalmente - Conti"
+	htmlContent := formatAccountLockedEmail(to, link, lockedUntil)
+
+	client := resend.NewClient(s.apiKey)
+
+	from := s.from
+	if s.fromName != "" {
+		from = fmt.Sprintf("%s <%s>", s.fromName, s.from)
+	}
+
+	s.logger.Info("sending account locked email via Resend", "to", to)
+
+	params := &resend.SendEmailRequest{
+		From:    from,
+		To:      []string{to},
+		Subject: subject,
+		Html:    htmlContent,
+	}
+
+	sent, err := client.Emails.SendWithContext(ctx, params)
+	if err != nil {
+		s.logger.Error("failed to send email via Resend",
+			"error", err,
+			"to", to,
+		)
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	s.logger.Info("account locked email sent successfully",
+		"to", to,
+		"email_id", sent.Id,
+	)
+	return nil
+}
+
+// SendBudgetAlert tells a household member a category budget crossed an alert threshold via Resend.
+func (s *ResendSender) SendBudgetAlert(ctx context.Context, to, categoryName, month string, threshold int, spent, budget float64) error {
+	link := fmt.Sprintf("%s/?tab=presupuesto", s.baseURL)
+
+	subject := budgetAlertSubject(categoryName, threshold)
+	htmlContent := formatBudgetAlertEmail(to, link, categoryName, month, threshold, spent, budget)
+
+	client := resend.NewClient(s.apiKey)
+
+	from := s.from
+	if s.fromName != "" {
+		from = fmt.Sprintf("%s <%s>", s.fromName, s.from)
+	}
+
+	s.logger.Info("sending budget alert email via Resend",
+		"to", to,
+		"category", categoryName,
+		"threshold", threshold,
+	)
+
+	params := &resend.SendEmailRequest{
+		From:    from,
+		To:      []string{to},
+		Subject: subject,
+		Html:    htmlContent,
+	}
+
+	sent, err := client.Emails.SendWithContext(ctx, params)
+	if err != nil {
+		s.logger.Error("failed to send email via Resend",
+			"error", err,
+			"to", to,
+		)
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	s.logger.Info("budget alert email sent successfully",
+		"to", to,
+		"email_id", sent.Id,
+	)
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 )
 
 // Sender defines the interface for sending emails.
@@ -11,6 +12,11 @@ type Sender interface {
 	SendPasswordReset(ctx context.Context, to, token string) error
 	SendHouseholdInvitation(ctx context.Context, to, token, householdName, inviterName string) error
 	SendLinkRequest(ctx context.Context, to, requesterName, householdName, appURL string) error
+	SendNewDeviceLogin(ctx context.Context, to, userAgent, ipAddress string, at time.Time) error
+	SendEmailVerification(ctx context.Context, to, token string) error
+	SendMagicLink(ctx context.Context, to, token string) error
+	SendAccountLocked(ctx context.Context, to, token string, lockedUntil time.Time) error
+	SendBudgetAlert(ctx context.Context, to, categoryName, month string, threshold int, spent, budget float64) error
 }
 
 // NoOpSender is a no-op email sender for development.
@@ -56,6 +62,60 @@ func (s *NoOpSender) SendLinkRequest(ctx context.Context, to, requesterName, hou
 	return nil
 }
 
+// SendNewDeviceLogin logs the new device login email instead of sending.
+func (s *NoOpSender) SendNewDeviceLogin(ctx context.Context, to, userAgent, ipAddress string, at time.Time) error {
+	s.logger.Info("new device login email (no-op)",
+		"to", to,
+		"user_agent", userAgent,
+		"ip_address", ipAddress,
+	)
+	fmt.Printf("\n=== NEW DEVICE LOGIN EMAIL ===\nTo: %s\nDevice: %s\nIP: %s\nAt: %s\n==============================\n\n", to, userAgent, ipAddress, at.Format(time.RFC3339))
+	return nil
+}
+
+// SendEmailVerification logs the email verification email instead of sending.
+func (s *NoOpSender) SendEmailVerification(ctx context.Context, to, token string) error {
+	s.logger.Info("email verification email (no-op)",
+		"to", to,
+		"token", token,
+	)
+	fmt.Printf("\n=== EMAIL VERIFICATION EMAIL ===\nTo: %s\nToken: %s\n================================\n\n", to, token)
+	return nil
+}
+
+// SendMagicLink logs the magic link email instead of sending.
+func (s *NoOpSender) SendMagicLink(ctx context.Context, to, token string) error {
+	s.logger.Info("magic link email (no-op)",
+		"to", to,
+		"token", token,
+	)
+	fmt.Printf("\n=== MAGIC LINK EMAIL ===\nTo: %s\nToken: %s\n========================\n\n", to, token)
+	return nil
+}
+
+// SendAccountLocked logs the account locked email instead of sending.
+func (s *NoOpSender) SendAccountLocked(ctx context.Context, to, token string, lockedUntil time.Time) error {
+	s.logger.Info("account locked email (no-op)",
+		"to", to,
+		"token", token,
+		"locked_until", lockedUntil,
+	)
+	fmt.Printf("\n=== ACCOUNT LOCKED EMAIL ===\nTo: %s\nLocked until: %s\nUnlock token: %s\n============================\n\n", to, lockedUntil.Format(time.RFC3339), token)
+	return nil
+}
+
+// SendBudgetAlert logs the budget alert email instead of sending.
+func (s *NoOpSender) SendBudgetAlert(ctx context.Context, to, categoryName, month string, threshold int, spent, budget float64) error {
+	s.logger.Info("budget alert email (no-op)",
+		"to", to,
+		"category", categoryName,
+		"month", month,
+		"threshold", threshold,
+	)
+	fmt.Printf("\n=== BUDGET ALERT EMAIL ===\nTo: %s\nCategory: %s\nMonth: %s\nThreshold: %d%%\nSpent: %.0f of %.0f\n==========================\n\n", to, categoryName, month, threshold, spent, budget)
+	return nil
+}
+
 // Config holds email service configuration.
 type Config struct {
 	// Provider: "noop", "smtp", or "resend"
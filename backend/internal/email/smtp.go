@@ -3,8 +3,10 @@ package email
 import (
 	"context"
 	"fmt"
+	"html"
 	"log/slog"
 	"net/smtp"
+	"time"
 )
 
 // SMTPSender sends emails via SMTP (for local development and testing).
@@ -120,6 +122,150 @@ func (s *SMTPSender) SendLinkRequest(ctx context.Context, to, requesterName, hou
 	return nil
 }
 
+// SendNewDeviceLogin warns the user about a login from a new device via SMTP.
+func (s *SMTPSender) SendNewDeviceLogin(ctx context.Context, to, userAgent, ipAddress string, at time.Time) error {
+	subject := "Nuevo inicio de sesión en tu cuenta - Conti"
+	body := formatNewDeviceLoginEmail(to, userAgent, ipAddress, at)
+
+	msg := formatEmailMessage(s.from, s.fromName, to, subject, body)
+
+	auth := smtp.PlainAuth("", s.username, s.password, s.host)
+	addr := fmt.Sprintf("%s:%d", s.host, s.port)
+
+	s.logger.Info("sending new device login email via SMTP",
+		"to", to,
+		"smtp_host", s.host,
+	)
+
+	if err := smtp.SendMail(addr, auth, s.from, []string{to}, []byte(msg)); err != nil {
+		s.logger.Error("failed to send email via SMTP",
+			"error", err,
+			"to", to,
+		)
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	s.logger.Info("new device login email sent successfully", "to", to)
+	return nil
+}
+
+// SendEmailVerification sends the email address verification link via SMTP.
+func (s *SMTPSender) SendEmailVerification(ctx context.Context, to, token string) error {
+	link := fmt.Sprintf("%s/verify-email?token=%s", s.baseURL, token)
+
+	subject := "Confirma tu email - Conti"
+	body := formatEmailVerificationEmail(to, link)
+
+	msg := formatEmailMessage(s.from, s.fromName, to, subject, body)
+
+	auth := smtp.PlainAuth("", s.username, s.password, s.host)
+	addr := fmt.Sprintf("%s:%d", s.host, s.port)
+
+	s.logger.Info("sending email verification email via SMTP",
+		"to", to,
+		"smtp_host", s.host,
+	)
+
+	if err := smtp.SendMail(addr, auth, s.from, []string{to}, []byte(msg)); err != nil {
+		s.logger.Error("failed to send email via SMTP",
+			"error", err,
+			"to", to,
+		)
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	s.logger.Info("email verification email sent successfully", "to", to)
+	return nil
+}
+
+// SendMagicLink sends a one-time sign-in link via SMTP.
+func (s *SMTPSender) SendMagicLink(ctx context.Context, to, token string) error {
+	link := fmt.Sprintf("%s/magic-login?token=%s", s.baseURL, token)
+
+	subject := "Tu enlace para iniciar sesión - Conti"
+	body := formatMagicLinkEmail(to, link)
+
+	msg := formatEmailMessage(s.from, s.fromName, to, subject, body)
+
+	auth := smtp.PlainAuth("", s.username, s.password, s.host)
+	addr := fmt.Sprintf("%s:%d", s.host, s.port)
+
+	s.logger.Info("sending magic link email via SMTP",
+		"to", to,
+		"smtp_host", s.host,
+	)
+
+	if err := smtp.SendMail(addr, auth, s.from, []string{to}, []byte(msg)); err != nil {
+		s.logger.Error("failed to send email via SMTP",
+			"error", err,
+			"to", to,
+		)
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	s.logger.Info("magic link email sent successfully", "to", to)
+	return nil
+}
+
+// SendAccountLocked tells the user their account was locked and sends an unlock link via SMTP.
+func (s *SMTPSender) SendAccountLocked(ctx context.Context, to, token string, lockedUntil time.Time) error {
+	link := fmt.Sprintf("%s/unlock-account?token=%s", s.baseURL, token)
+
+	subject := "Tu cuenta fue bloqueada temporalmente - Conti"
+	body := formatAccountLockedEmail(to, link, lockedUntil)
+
+	msg := formatEmailMessage(s.from, s.fromName, to, subject, body)
+
+	auth := smtp.PlainAuth("", s.username, s.password, s.host)
+	addr := fmt.Sprintf("%s:%d", s.host, s.port)
+
+	s.logger.Info("sending account locked email via SMTP",
+		"to", to,
+		"smtp_host", s.host,
+	)
+
+	if err := smtp.SendMail(addr, auth, s.from, []string{to}, []byte(msg)); err != nil {
+		s.logger.Error("failed to send email via SMTP",
+			"error", err,
+			"to", to,
+		)
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	s.logger.Info("account locked email sent successfully", "to", to)
+	return nil
+}
+
+// SendBudgetAlert tells a household member a category budget crossed an alert threshold via SMTP.
+func (s *SMTPSender) SendBudgetAlert(ctx context.Context, to, categoryName, month string, threshold int, spent, budget float64) error {
+	link := fmt.Sprintf("%s/?tab=presupuesto", s.baseURL)
+
+	subject := budgetAlertSubject(categoryName, threshold)
+	body := formatBudgetAlertEmail(to, link, categoryName, month, threshold, spent, budget)
+
+	msg := formatEmailMessage(s.from, s.fromName, to, subject, body)
+
+	auth := smtp.PlainAuth("", s.username, s.password, s.host)
+	addr := fmt.Sprintf("%s:%d", s.host, s.port)
+
+	s.logger.Info("sending budget alert email via SMTP",
+		"to", to,
+		"category", categoryName,
+		"smtp_host", s.host,
+	)
+
+	if err := smtp.SendMail(addr, auth, s.from, []string{to}, []byte(msg)); err != nil {
+		s.logger.Error("failed to send email via SMTP",
+			"error", err,
+			"to", to,
+		)
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	s.logger.Info("budget alert email sent successfully", "to", to)
+	return nil
+}
+
 // formatEmailMessage formats an email message with headers.
 func formatEmailMessage(from, fromName, to, subject, htmlBody string) string {
 	fromHeader := from
@@ -267,3 +413,223 @@ func formatLinkRequestEmail(to, requesterName, householdName, appURL string) str
 </body>
 </html>`, requesterName, householdName, appURL, to)
 }
+
+// formatNewDeviceLoginEmail creates the HTML body for the new device login email.
+func formatNewDeviceLoginEmail(to, userAgent, ipAddress string, at time.Time) string {
+	if userAgent == "" {
+		userAgent = "Desconocido"
+	}
+	if ipAddress == "" {
+		ipAddress = "Desconocida"
+	}
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html lang="es">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Nuevo inicio de sesión</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333; max-width: 600px; margin: 0 auto; padding: 20px;">
+    <div style="background-color: #f8f9fa; border-radius: 10px; padding: 30px; margin: 20px 0;">
+        <h1 style="color: #2c3e50; margin-top: 0;">🔐 Nuevo inicio de sesión</h1>
+        
+        <p>Hola,</p>
+        
+        <p>Detectamos un inicio de sesión en tu cuenta de <strong>Conti</strong> desde un dispositivo que no habíamos visto antes.</p>
+        
+        <ul>
+            <li><strong>Dispositivo:</strong> %s</li>
+            <li><strong>Dirección IP:</strong> %s</li>
+            <li><strong>Fecha:</strong> %s (UTC)</li>
+        </ul>
+        
+        <p>Si fuiste tú, no necesitas hacer nada.</p>
+        
+        <p>Si no reconoces este acceso, cambia tu contraseña y cierra las demás sesiones desde tu perfil.</p>
+        
+        <hr style="border: none; border-top: 1px solid #ddd; margin: 30px 0;">
+        
+        <p style="font-size: 12px; color: #7f8c8d;">
+            <em>Este correo fue enviado a: %s</em>
+        </p>
+    </div>
+</body>
+</html>`, html.EscapeString(userAgent), html.EscapeString(ipAddress), at.UTC().Format("2006-01-02 15:04"), to)
+}
+
+// formatEmailVerificationEmail creates the HTML body for the email verification email.
+func formatEmailVerificationEmail(to, verifyLink string) string {
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html lang="es">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Confirma tu email</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333; max-width: 600px; margin: 0 auto; padding: 20px;">
+    <div style="background-color: #f8f9fa; border-radius: 10px; padding: 30px; margin: 20px 0;">
+        <h1 style="color: #2c3e50; margin-top: 0;">✉️ Confirma tu email</h1>
+        
+        <p>Hola,</p>
+        
+        <p>Gracias por registrarte en <strong>Conti</strong>. Confirma que este email es tuyo para poder vincular contactos y recibir invitaciones de otros hogares.</p>
+        
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="%s" 
+               style="background-color: #27ae60; color: white; padding: 12px 30px; text-decoration: none; border-radius: 5px; display: inline-block; font-weight: bold;">
+                Confirmar Email
+            </a>
+        </div>
+        
+        <p>O copia y pega este enlace en tu navegador:</p>
+        <p style="background-color: #ecf0f1; padding: 10px; border-radius: 5px; word-break: break-all;">
+            <code>%s</code>
+        </p>
+        
+        <p style="color: #e74c3c; font-weight: bold;">⚠️ Este enlace expirará en 24 horas.</p>
+        
+        <p>Si no creaste una cuenta, puedes ignorar este correo.</p>
+        
+        <hr style="border: none; border-top: 1px solid #ddd; margin: 30px 0;">
+        
+        <p style="font-size: 12px; color: #7f8c8d;">
+            <em>Este correo fue enviado a: %s</em>
+        </p>
+    </div>
+</body>
+</html>`, verifyLink, verifyLink, to)
+}
+
+// formatMagicLinkEmail creates the HTML body for the magic link email.
+func formatMagicLinkEmail(to, loginLink string) string {
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html lang="es">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Iniciar sesión</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333; max-width: 600px; margin: 0 auto; padding: 20px;">
+    <div style="background-color: #f8f9fa; border-radius: 10px; padding: 30px; margin: 20px 0;">
+        <h1 style="color: #2c3e50; margin-top: 0;">🔑 Iniciar sesión en Conti</h1>
+        
+        <p>Hola,</p>
+        
+        <p>Recibimos una solicitud para iniciar sesión en tu cuenta de <strong>Conti</strong> sin contraseña.</p>
+        
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="%s" 
+               style="background-color: #3498db; color: white; padding: 12px 30px; text-decoration: none; border-radius: 5px; display: inline-block; font-weight: bold;">
+                Iniciar Sesión
+            </a>
+        </div>
+        
+        <p>O copia y pega este enlace en tu navegador:</p>
+        <p style="background-color: #ecf0f1; padding: 10px; border-radius: 5px; word-break: break-all;">
+            <code>%s</code>
+        </p>
+        
+        <p style="color: #e74c3c; font-weight: bold;">⚠️ Este enlace expirará en 15 minutos y solo puede usarse una vez.</p>
+        
+        <p>Si no lo solicitaste, puedes ignorar este correo: nadie podrá entrar sin acceso a tu email.</p>
+        
+        <hr style="border: none; border-top: 1px solid #ddd; margin: 30px 0;">
+        
+        <p style="font-size: 12px; color: #7f8c8d;">
+            <em>Este correo fue enviado a: %s</em>
+        </p>
+    </div>
+</body>
+</html>`, loginLink, loginLink, to)
+}
+
+// formatAccountLockedEmail creates the HTML body for the account locked email.
+func formatAccountLockedEmail(to, unlockLink string, lockedUntil time.Time) string {
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html lang="es">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Cuenta bloqueada</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333; max-width: 600px; margin: 0 auto; padding: 20px;">
+    <div style="background-color: #f8f9fa; border-radius: 10px; padding: 30px; margin: 20px 0;">
+        <h1 style="color: #2c3e50; margin-top: 0;">🔒 Cuenta bloqueada temporalmente</h1>
+        
+        <p>Hola,</p>
+        
+        <p>Hubo demasiados intentos fallidos de iniciar sesión en tu cuenta de <strong>Conti</strong>, así que la bloqueamos hasta las <strong>%s (UTC)</strong>.</p>
+        
+        <p>Si fuiste tú, puedes desbloquearla ahora mismo:</p>
+        
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="%s" 
+               style="background-color: #3498db; color: white; padding: 12px 30px; text-decoration: none; border-radius: 5px; display: inline-block; font-weight: bold;">
+                Desbloquear Cuenta
+            </a>
+        </div>
+        
+        <p>O copia y pega este enlace en tu navegador:</p>
+        <p style="background-color: #ecf0f1; padding: 10px; border-radius: 5px; word-break: break-all;">
+            <code>%s</code>
+        </p>
+        
+        <p style="color: #e74c3c; font-weight: bold;">⚠️ Si no fuiste tú, alguien está intentando adivinar tu contraseña. Te recomendamos cambiarla y activar la verificación en dos pasos.</p>
+        
+        <hr style="border: none; border-top: 1px solid #ddd; margin: 30px 0;">
+        
+        <p style="font-size: 12px; color: #7f8c8d;">
+            <em>Este correo fue enviado a: %s</em>
+        </p>
+    </div>
+</body>
+</html>`, lockedUntil.UTC().Format("2006-01-02 15:04"), unlockLink, unlockLink, to)
+}
+
+// budgetAlertSubject returns the subject line for a budget alert email.
+func budgetAlertSubject(categoryName string, threshold int) string {
+	if threshold >= 100 {
+		return fmt.Sprintf("Presupuesto excedido: %s - Conti", categoryName)
+	}
+	return fmt.Sprintf("Llevas el %d%% del presupuesto de %s - Conti", threshold, categoryName)
+}
+
+// formatBudgetAlertEmail creates the HTML body for the budget alert email.
+func formatBudgetAlertEmail(to, budgetLink, categoryName, month string, threshold int, spent, budget float64) string {
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html lang="es">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Alerta de presupuesto</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333; max-width: 600px; margin: 0 auto; padding: 20px;">
+    <div style="background-color: #f8f9fa; border-radius: 10px; padding: 30px; margin: 20px 0;">
+        <h1 style="color: #2c3e50; margin-top: 0;">📊 Alerta de presupuesto</h1>
+        
+        <p>Hola,</p>
+        
+        <p>El gasto en <strong>%s</strong> llegó al <strong>%d%%</strong> del presupuesto de <strong>%s</strong>.</p>
+        
+        <p style="background-color: #ecf0f1; padding: 10px; border-radius: 5px;">
+            Gastado: <strong>$%.0f</strong> de <strong>$%.0f</strong>
+        </p>
+        
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="%s" 
+               style="background-color: #3498db; color: white; padding: 12px 30px; text-decoration: none; border-radius: 5px; display: inline-block; font-weight: bold;">
+                Ver Presupuesto
+            </a>
+        </div>
+        
+        <p>Puedes silenciar las alertas de esta categoría desde el presupuesto en Conti.</p>
+        
+        <hr style="border: none; border-top: 1px solid #ddd; margin: 30px 0;">
+        
+        <p style="font-size: 12px; color: #7f8c8d;">
+            <em>Este correo fue enviado a: %s</em>
+        </p>
+    </div>
+</body>
+</html>`, categoryName, threshold, month, spent, budget, budgetLink, to)
+}
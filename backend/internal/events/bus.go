@@ -0,0 +1,136 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// pgChannel is the Postgres NOTIFY channel shared by all API replicas. The
+// notify_household_change trigger (migration 074) publishes on it whenever a
+// household's movements, income, budgets, pockets or templates are written,
+// so services do not publish changes themselves.
+const pgChannel = "household_events"
+
+// subscriberBuffer is how many events a slow subscriber can lag behind
+// before events are dropped for it
+const subscriberBuffer = 32
+
+// Bus fans out change events to subscribers of a household. Events arrive
+// through Postgres NOTIFY, so every replica receives them; without a pool the
+// bus has no source of events.
+type Bus struct {
+	pool   *pgxpool.Pool
+	logger *slog.Logger
+
+	mu          sync.RWMutex
+	subscribers map[string]map[chan *Event]struct{} // household_id -> subscriber channels
+}
+
+// NewBus creates a new event bus. Call Run to start receiving events from Postgres.
+func NewBus(pool *pgxpool.Pool, logger *slog.Logger) *Bus {
+	return &Bus{
+		pool:        pool,
+		logger:      logger,
+		subscribers: make(map[string]map[chan *Event]struct{}),
+	}
+}
+
+// Subscribe registers a subscriber for a household's events. The returned
+// function must be called to unsubscribe; it closes the channel.
+func (b *Bus) Subscribe(householdID string) (<-chan *Event, func()) {
+	ch := make(chan *Event, subscriberBuffer)
+
+	b.mu.Lock()
+	if b.subscribers[householdID] == nil {
+		b.subscribers[householdID] = make(map[chan *Event]struct{})
+	}
+	b.subscribers[householdID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers[householdID], ch)
+			if len(b.subscribers[householdID]) == 0 {
+				delete(b.subscribers, householdID)
+			}
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+	return ch, unsubscribe
+}
+
+// dispatch delivers an event to local subscribers without blocking
+func (b *Bus) dispatch(event *Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subscribers[event.HouseholdID] {
+		select {
+		case ch <- event:
+		default:
+			b.logger.Warn("event subscriber is lagging, dropping event",
+				"household_id", event.HouseholdID,
+				"resource_type", event.ResourceType,
+			)
+		}
+	}
+}
+
+// Run listens for events from Postgres until ctx is cancelled, reconnecting on errors
+func (b *Bus) Run(ctx context.Context) {
+	if b.pool == nil {
+		return
+	}
+
+	for {
+		err := b.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		b.logger.Warn("event listener disconnected, retrying", "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+// listen holds a dedicated connection on LISTEN and dispatches notifications
+func (b *Bus) listen(ctx context.Context) error {
+	conn, err := b.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// The connection stays in LISTEN mode, so take it out of the pool for good
+	pgConn := conn.Hijack()
+	defer pgConn.Close(context.Background())
+
+	if _, err := pgConn.Exec(ctx, "LISTEN "+pgChannel); err != nil {
+		return err
+	}
+	b.logger.Info("event listener started", "channel", pgChannel)
+
+	for {
+		notification, err := pgConn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var event Event
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			b.logger.Warn("invalid event payload", "error", err)
+			continue
+		}
+		b.dispatch(&event)
+	}
+}
@@ -0,0 +1,75 @@
+package events
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func newTestBus() *Bus {
+	return NewBus(nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestBus_DeliversOnlyToSameHousehold(t *testing.T) {
+	bus := newTestBus()
+
+	mine, unsubMine := bus.Subscribe("household-1")
+	defer unsubMine()
+	other, unsubOther := bus.Subscribe("household-2")
+	defer unsubOther()
+
+	bus.dispatch(&Event{
+		HouseholdID:  "household-1",
+		ResourceType: ResourceMovement,
+		ResourceID:   "mov-1",
+		Action:       ActionCreated,
+	})
+
+	select {
+	case event := <-mine:
+		if event.ResourceID != "mov-1" || event.Action != ActionCreated {
+			t.Errorf("got %+v, want mov-1 created", event)
+		}
+	default:
+		t.Fatal("expected event for household-1 subscriber")
+	}
+
+	select {
+	case event := <-other:
+		t.Errorf("household-2 subscriber received %+v", event)
+	default:
+	}
+}
+
+func TestBus_UnsubscribeClosesChannel(t *testing.T) {
+	bus := newTestBus()
+
+	ch, unsubscribe := bus.Subscribe("household-1")
+	unsubscribe()
+	unsubscribe() // safe to call twice
+
+	if _, ok := <-ch; ok {
+		t.Error("expected channel to be closed")
+	}
+	if len(bus.subscribers) != 0 {
+		t.Errorf("subscribers = %d, want 0", len(bus.subscribers))
+	}
+
+	// Dispatching after unsubscribe must not panic
+	bus.dispatch(&Event{HouseholdID: "household-1"})
+}
+
+func TestBus_DropsWhenSubscriberLags(t *testing.T) {
+	bus := newTestBus()
+
+	ch, unsubscribe := bus.Subscribe("household-1")
+	defer unsubscribe()
+
+	for i := 0; i < subscriberBuffer+5; i++ {
+		bus.dispatch(&Event{HouseholdID: "household-1"})
+	}
+
+	if len(ch) != subscriberBuffer {
+		t.Errorf("buffered events = %d, want %d", len(ch), subscriberBuffer)
+	}
+}
@@ -0,0 +1,99 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/blanquicet/conti/backend/internal/auth"
+	"github.com/blanquicet/conti/backend/internal/households"
+)
+
+// heartbeatInterval keeps idle connections open through proxies
+const heartbeatInterval = 25 * time.Second
+
+// Handler streams household change events over Server-Sent Events
+type Handler struct {
+	bus           *Bus
+	authSvc       *auth.Service
+	householdRepo households.HouseholdRepository
+	cookieName    string
+	logger        *slog.Logger
+}
+
+// NewHandler creates a new events stream handler
+func NewHandler(bus *Bus, authSvc *auth.Service, householdRepo households.HouseholdRepository, cookieName string, logger *slog.Logger) *Handler {
+	return &Handler{
+		bus:           bus,
+		authSvc:       authSvc,
+		householdRepo: householdRepo,
+		cookieName:    cookieName,
+		logger:        logger,
+	}
+}
+
+// HandleStream pushes change events for the user's household until the client disconnects
+// GET /events/stream
+func (h *Handler) HandleStream(w http.ResponseWriter, r *http.Request) {
+	user, err := h.authSvc.UserFromRequest(r, h.cookieName)
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	householdID, err := h.householdRepo.ResolveHouseholdID(r.Context(), user.ID)
+	if err != nil {
+		h.logger.Warn("no household for event stream", "error", err, "user_id", user.ID)
+		http.Error(w, "household not found", http.StatusNotFound)
+		return
+	}
+
+	// The stream outlives the server's write timeout
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.Warn("failed to clear write deadline for event stream", "error", err)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	events, unsubscribe := h.bus.Subscribe(householdID)
+	defer unsubscribe()
+
+	// Tell the browser how long to wait before reconnecting
+	fmt.Fprint(w, "retry: 5000\n\n")
+	if err := rc.Flush(); err != nil {
+		h.logger.Error("event stream does not support flushing", "error", err)
+		return
+	}
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				h.logger.Error("failed to encode event", "error", err)
+				continue
+			}
+			fmt.Fprintf(w, "event: change\ndata: %s\n\n", data)
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
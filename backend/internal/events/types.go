@@ -0,0 +1,33 @@
+package events
+
+import "time"
+
+// ResourceType identifies the kind of resource that changed
+type ResourceType string
+
+const (
+	ResourceMovement ResourceType = "movement"
+	ResourceIncome   ResourceType = "income"
+	ResourceBudget   ResourceType = "budget"
+	ResourcePocket   ResourceType = "pocket"
+	ResourceTemplate ResourceType = "template"
+)
+
+// Action identifies what happened to the resource
+type Action string
+
+const (
+	ActionCreated Action = "created"
+	ActionUpdated Action = "updated"
+	ActionDeleted Action = "deleted"
+)
+
+// Event is a change notification scoped to a household. It only carries
+// identifiers; clients refetch the resource to see the new state.
+type Event struct {
+	HouseholdID  string       `json:"household_id"`
+	ResourceType ResourceType `json:"resource_type"`
+	ResourceID   string       `json:"resource_id,omitempty"` // Empty for bulk changes (e.g. copying budgets)
+	Action       Action       `json:"action"`
+	OccurredAt   time.Time    `json:"occurred_at"`
+}
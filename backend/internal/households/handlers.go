@@ -88,12 +88,7 @@ type ErrorResponse struct {
 // Helper methods
 
 func (h *Handler) getUserFromRequest(r *http.Request) (*auth.User, error) {
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		return nil, errors.New("no session cookie")
-	}
-	
-	user, err := h.authSvc.GetUserBySession(r.Context(), cookie.Value)
+	user, err := h.authSvc.UserFromRequest(r, h.cookieName)
 	if err != nil {
 		return nil, err
 	}
@@ -137,10 +132,32 @@ func (h *Handler) handleServiceError(w http.ResponseWriter, err error) {
 		h.respondError(w, "el contacto no tiene correo electrónico", http.StatusBadRequest)
 	case errors.Is(err, ErrEmailNotRegistered):
 		h.respondError(w, "el correo no está registrado en la app", http.StatusNotFound)
+	case errors.Is(err, ErrEmailNotVerified):
+		h.respondError(w, "debes verificar tu correo antes de vincular contactos", http.StatusForbidden)
 	case errors.Is(err, ErrLinkRequestNotPending):
 		h.respondError(w, "la solicitud de vinculación no está pendiente", http.StatusBadRequest)
 	case errors.Is(err, ErrInvalidRole):
 		h.respondError(w, "rol inválido", http.StatusBadRequest)
+	case errors.Is(err, ErrInvitationExpired):
+		h.respondError(w, "la invitación ha expirado", http.StatusGone)
+	case errors.Is(err, ErrInvitationRevoked):
+		h.respondError(w, "la invitación fue revocada", http.StatusGone)
+	case errors.Is(err, ErrInvitationAccepted):
+		h.respondError(w, "esta invitación ya fue aceptada", http.StatusConflict)
+	case errors.Is(err, ErrMergeSameHousehold):
+		h.respondError(w, "no se puede fusionar un hogar consigo mismo", http.StatusBadRequest)
+	case errors.Is(err, ErrCurrencyMismatch):
+		h.respondError(w, "los hogares usan monedas diferentes", http.StatusBadRequest)
+	case errors.Is(err, ErrMergeConflict):
+		h.respondError(w, "no se pudo fusionar: hay nombres en conflicto entre los hogares", http.StatusConflict)
+	case errors.Is(err, ErrContactMergeSame):
+		h.respondError(w, "no se puede fusionar un contacto consigo mismo", http.StatusBadRequest)
+	case errors.Is(err, ErrContactLinkConflict):
+		h.respondError(w, "los contactos están vinculados a usuarios diferentes", http.StatusConflict)
+	case errors.Is(err, ErrTransferToSelf):
+		h.respondError(w, "no puedes transferirte la propiedad a ti mismo", http.StatusBadRequest)
+	case errors.Is(err, ErrInvitationRateLimited):
+		h.respondError(w, "se han enviado demasiadas invitaciones, intenta más tarde", http.StatusTooManyRequests)
 	case err.Error() == "user not found":
 		h.respondError(w, "usuario no encontrado", http.StatusNotFound)
 	case err.Error() == "user not found with that email":
@@ -450,6 +467,94 @@ func (h *Handler) LeaveHousehold(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// TransferOwnershipRequest is the body of POST /households/{id}/transfer-ownership
+type TransferOwnershipRequest struct {
+	UserID string `json:"user_id"`
+}
+
+// TransferOwnership handles POST /households/{id}/transfer-ownership
+func (h *Handler) TransferOwnership(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserFromRequest(r)
+	if err != nil {
+		h.respondError(w, "no autorizado", http.StatusUnauthorized)
+		return
+	}
+
+	householdID := r.PathValue("id")
+	if householdID == "" {
+		h.respondError(w, "ID de hogar requerido", http.StatusBadRequest)
+		return
+	}
+
+	var req TransferOwnershipRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, "cuerpo de solicitud inválido", http.StatusBadRequest)
+		return
+	}
+	if req.UserID == "" {
+		h.respondError(w, "user_id es requerido", http.StatusBadRequest)
+		return
+	}
+
+	member, err := h.service.TransferOwnership(r.Context(), &TransferOwnershipInput{
+		HouseholdID: householdID,
+		NewOwnerID:  req.UserID,
+		UserID:      user.ID,
+	})
+	if err != nil {
+		h.handleServiceError(w, err)
+		return
+	}
+
+	h.respondJSON(w, member, http.StatusOK)
+}
+
+// MergeHouseholdRequest is the body of POST /households/{id}/merge
+type MergeHouseholdRequest struct {
+	SourceHouseholdID string `json:"source_household_id"`
+	DryRun            *bool  `json:"dry_run"` // Defaults to true; send false to apply the merge
+}
+
+// MergeHousehold handles POST /households/{id}/merge
+// Merges the source household into {id}. Without "dry_run": false it only
+// returns the report of what would be moved, remapped and renamed.
+func (h *Handler) MergeHousehold(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserFromRequest(r)
+	if err != nil {
+		h.respondError(w, "no autorizado", http.StatusUnauthorized)
+		return
+	}
+
+	householdID := r.PathValue("id")
+	if householdID == "" {
+		h.respondError(w, "ID de hogar requerido", http.StatusBadRequest)
+		return
+	}
+
+	var req MergeHouseholdRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, "cuerpo de solicitud inválido", http.StatusBadRequest)
+		return
+	}
+	if req.SourceHouseholdID == "" {
+		h.respondError(w, "source_household_id es requerido", http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.service.MergeHouseholds(r.Context(), &MergeHouseholdsInput{
+		SourceHouseholdID: req.SourceHouseholdID,
+		TargetHouseholdID: householdID,
+		UserID:            user.ID,
+		DryRun:            req.DryRun == nil || *req.DryRun,
+	})
+	if err != nil {
+		h.handleServiceError(w, err)
+		return
+	}
+
+	h.respondJSON(w, report, http.StatusOK)
+}
+
 // Contact endpoints
 
 // ListContacts handles GET /households/{id}/contacts
@@ -576,6 +681,52 @@ func (h *Handler) DeleteContact(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// MergeContactRequest is the body of POST /households/{household_id}/contacts/{contact_id}/merge
+type MergeContactRequest struct {
+	IntoContactID string `json:"into_contact_id"`
+}
+
+// MergeContact handles POST /households/{household_id}/contacts/{contact_id}/merge
+// The contact in the path is folded into into_contact_id and deleted.
+func (h *Handler) MergeContact(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserFromRequest(r)
+	if err != nil {
+		h.respondError(w, "no autorizado", http.StatusUnauthorized)
+		return
+	}
+
+	householdID := r.PathValue("household_id")
+	contactID := r.PathValue("contact_id")
+
+	if householdID == "" || contactID == "" {
+		h.respondError(w, "IDs requeridos", http.StatusBadRequest)
+		return
+	}
+
+	var req MergeContactRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, "cuerpo de solicitud inválido", http.StatusBadRequest)
+		return
+	}
+	if req.IntoContactID == "" {
+		h.respondError(w, "into_contact_id es requerido", http.StatusBadRequest)
+		return
+	}
+
+	contact, err := h.service.MergeContacts(r.Context(), &MergeContactsInput{
+		HouseholdID:     householdID,
+		SourceContactID: contactID,
+		TargetContactID: req.IntoContactID,
+		UserID:          user.ID,
+	})
+	if err != nil {
+		h.handleServiceError(w, err)
+		return
+	}
+
+	h.respondJSON(w, contact, http.StatusOK)
+}
+
 // PromoteContact handles POST /households/{household_id}/contacts/{contact_id}/promote
 func (h *Handler) PromoteContact(w http.ResponseWriter, r *http.Request) {
 	user, err := h.getUserFromRequest(r)
@@ -614,6 +765,7 @@ type InvitationInfoResponse struct {
 	Email         string `json:"email"`
 	IsExpired     bool   `json:"is_expired"`
 	IsAccepted    bool   `json:"is_accepted"`
+	IsRevoked     bool   `json:"is_revoked"`
 }
 
 // GetInvitationInfo handles GET /invitations/{token}
@@ -642,6 +794,7 @@ func (h *Handler) GetInvitationInfo(w http.ResponseWriter, r *http.Request) {
 		Email:         invitation.Email,
 		IsExpired:     invitation.IsExpired(),
 		IsAccepted:    invitation.IsAccepted(),
+		IsRevoked:     invitation.IsRevoked(),
 	}
 
 	h.respondJSON(w, response, http.StatusOK)
@@ -682,9 +835,11 @@ func (h *Handler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
 			h.respondError(w, "tu correo no coincide con la invitación", http.StatusForbidden)
 		case errors.Is(err, ErrUserAlreadyMember):
 			h.respondError(w, "ya eres miembro de este hogar", http.StatusConflict)
-		case err.Error() == "invitation has already been accepted":
+		case errors.Is(err, ErrInvitationAccepted):
 			h.respondError(w, "esta invitación ya fue aceptada", http.StatusConflict)
-		case err.Error() == "invitation has expired":
+		case errors.Is(err, ErrInvitationRevoked):
+			h.respondError(w, "la invitación fue revocada", http.StatusGone)
+		case errors.Is(err, ErrInvitationExpired):
 			h.respondError(w, "la invitación ha expirado", http.StatusGone)
 		default:
 			h.logger.Error("failed to accept invitation", "error", err)
@@ -729,6 +884,76 @@ func (h *Handler) CreateInvitation(w http.ResponseWriter, r *http.Request) {
 	h.respondJSON(w, invitation, http.StatusCreated)
 }
 
+// ListInvitations handles GET /households/{id}/invitations
+func (h *Handler) ListInvitations(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserFromRequest(r)
+	if err != nil {
+		h.respondError(w, "no autorizado", http.StatusUnauthorized)
+		return
+	}
+
+	householdID := r.PathValue("id")
+	if householdID == "" {
+		h.respondError(w, "ID de hogar requerido", http.StatusBadRequest)
+		return
+	}
+
+	invitations, err := h.service.ListInvitations(r.Context(), householdID, user.ID)
+	if err != nil {
+		h.handleServiceError(w, err)
+		return
+	}
+
+	h.respondJSON(w, invitations, http.StatusOK)
+}
+
+// ResendInvitation handles POST /households/{household_id}/invitations/{invitation_id}/resend
+func (h *Handler) ResendInvitation(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserFromRequest(r)
+	if err != nil {
+		h.respondError(w, "no autorizado", http.StatusUnauthorized)
+		return
+	}
+
+	householdID := r.PathValue("household_id")
+	invitationID := r.PathValue("invitation_id")
+	if householdID == "" || invitationID == "" {
+		h.respondError(w, "IDs requeridos", http.StatusBadRequest)
+		return
+	}
+
+	invitation, err := h.service.ResendInvitation(r.Context(), householdID, invitationID, user.ID)
+	if err != nil {
+		h.handleServiceError(w, err)
+		return
+	}
+
+	h.respondJSON(w, invitation, http.StatusOK)
+}
+
+// RevokeInvitation handles DELETE /households/{household_id}/invitations/{invitation_id}
+func (h *Handler) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserFromRequest(r)
+	if err != nil {
+		h.respondError(w, "no autorizado", http.StatusUnauthorized)
+		return
+	}
+
+	householdID := r.PathValue("household_id")
+	invitationID := r.PathValue("invitation_id")
+	if householdID == "" || invitationID == "" {
+		h.respondError(w, "IDs requeridos", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.RevokeInvitation(r.Context(), householdID, invitationID, user.ID); err != nil {
+		h.handleServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // Link request endpoints
 
 // ListLinkRequests handles GET /link-requests
@@ -823,7 +1048,7 @@ func (h *Handler) RejectLinkRequest(w http.ResponseWriter, r *http.Request) {
 
 // CheckEmail handles GET /contacts/check-email?email=X
 func (h *Handler) CheckEmail(w http.ResponseWriter, r *http.Request) {
-	_, err := h.getUserFromRequest(r)
+	user, err := h.getUserFromRequest(r)
 	if err != nil {
 		h.respondError(w, "no autorizado", http.StatusUnauthorized)
 		return
@@ -835,7 +1060,11 @@ func (h *Handler) CheckEmail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := h.service.CheckEmail(r.Context(), email)
+	result, err := h.service.CheckEmail(r.Context(), user.ID, email)
+	if errors.Is(err, ErrEmailNotVerified) {
+		h.handleServiceError(w, err)
+		return
+	}
 	if err != nil {
 		h.respondJSON(w, map[string]interface{}{"is_registered": false}, http.StatusOK)
 		return
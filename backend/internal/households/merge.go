@@ -0,0 +1,143 @@
+package households
+
+import (
+	"context"
+	"errors"
+
+	"github.com/blanquicet/conti/backend/internal/audit"
+)
+
+// MergeReport describes what merging one household into another moves.
+// A dry run returns the same report without changing anything.
+type MergeReport struct {
+	SourceHouseholdID string         `json:"source_household_id"`
+	TargetHouseholdID string         `json:"target_household_id"`
+	DryRun            bool           `json:"dry_run"`
+	Counts            MergeCounts    `json:"counts"`
+	Remapped          []MergeRemap   `json:"remapped"`  // Duplicates folded into an existing target row
+	Renamed           []MergeRename  `json:"renamed"`   // Name clashes resolved by suffixing the source household name
+	Discarded         []MergeDiscard `json:"discarded"` // Source rows dropped in favor of the target's own
+}
+
+// MergeCounts is the number of rows moved into the target household per kind
+type MergeCounts struct {
+	MembersAdded       int `json:"members_added"`
+	Contacts           int `json:"contacts"`
+	CategoryGroups     int `json:"category_groups"`
+	Categories         int `json:"categories"`
+	Accounts           int `json:"accounts"`
+	PaymentMethods     int `json:"payment_methods"`
+	Pockets            int `json:"pockets"`
+	PocketTransactions int `json:"pocket_transactions"`
+	Movements          int `json:"movements"`
+	Income             int `json:"income"`
+	CreditCardPayments int `json:"credit_card_payments"`
+	Budgets            int `json:"budgets"`
+	BudgetsCombined    int `json:"budgets_combined"` // Same category and month in both; amounts added up
+	PeriodBudgets      int `json:"period_budgets"`
+	GroupBudgets       int `json:"group_budgets"`
+	Allowances         int `json:"allowances"`
+	BudgetItems        int `json:"budget_items"`
+	Templates          int `json:"templates"`
+	WebhookEndpoints   int `json:"webhook_endpoints"`
+	Invitations        int `json:"invitations"`
+	Notifications      int `json:"notifications"`
+	AuditLogs          int `json:"audit_logs"` // History of the source household, kept under the target
+}
+
+// MergeRemap is a source row replaced by a same-named row of the target
+// household, or a contact replaced by the member it is linked to
+type MergeRemap struct {
+	Kind     string `json:"kind"` // contact, category_group, category or member (TargetID is then the user ID)
+	Name     string `json:"name"`
+	SourceID string `json:"source_id"`
+	TargetID string `json:"target_id"`
+}
+
+// MergeRename is a source row renamed because its name was taken in the target household
+type MergeRename struct {
+	Kind     string `json:"kind"` // account, payment_method, pocket, budget_item or template
+	SourceID string `json:"source_id"`
+	OldName  string `json:"old_name"`
+	NewName  string `json:"new_name"`
+}
+
+// MergeDiscard is a source row dropped because the target household already
+// has its own
+type MergeDiscard struct {
+	Kind     string `json:"kind"` // budget_settings, category_budget_settings or invitation
+	SourceID string `json:"source_id"`
+	Name     string `json:"name,omitempty"` // Category name or invited email
+}
+
+// MergeHouseholdsInput contains the data needed to merge two households
+type MergeHouseholdsInput struct {
+	SourceHouseholdID string // Household that is emptied and deleted
+	TargetHouseholdID string // Household that receives everything
+	UserID            string // User making the request
+	DryRun            bool
+}
+
+// MergeHouseholds moves members, contacts, categories, accounts, payment
+// methods, pockets, movements, income, budgets, budget settings, templates,
+// webhook endpoints, invitations, notifications and audit history from the
+// source household into the target and deletes the source. The user must own both.
+func (s *Service) MergeHouseholds(ctx context.Context, input *MergeHouseholdsInput) (*MergeReport, error) {
+	if input.SourceHouseholdID == "" || input.TargetHouseholdID == "" || input.UserID == "" {
+		return nil, errors.New("source household ID, target household ID, and user ID are required")
+	}
+	if input.SourceHouseholdID == input.TargetHouseholdID {
+		return nil, ErrMergeSameHousehold
+	}
+
+	// Merging deletes the source household, so the user must own both
+	if _, err := Authorize(ctx, s.repo, input.SourceHouseholdID, input.UserID, PermManageHousehold); err != nil {
+		return nil, err
+	}
+	if _, err := Authorize(ctx, s.repo, input.TargetHouseholdID, input.UserID, PermManageHousehold); err != nil {
+		return nil, err
+	}
+
+	source, err := s.repo.GetByID(ctx, input.SourceHouseholdID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.repo.GetByID(ctx, input.TargetHouseholdID)
+	if err != nil {
+		return nil, err
+	}
+	if source.Currency != target.Currency {
+		return nil, ErrCurrencyMismatch
+	}
+
+	report, err := s.repo.MergeHouseholds(ctx, input.SourceHouseholdID, input.TargetHouseholdID, !input.DryRun)
+	if err != nil {
+		if !input.DryRun {
+			s.auditService.LogAsync(ctx, &audit.LogInput{
+				Action:       audit.ActionHouseholdMerged,
+				ResourceType: "household",
+				ResourceID:   audit.StringPtr(input.TargetHouseholdID),
+				UserID:       audit.StringPtr(input.UserID),
+				HouseholdID:  audit.StringPtr(input.TargetHouseholdID),
+				Success:      false,
+				ErrorMessage: audit.StringPtr(err.Error()),
+			})
+		}
+		return nil, err
+	}
+
+	if !input.DryRun {
+		s.auditService.LogAsync(ctx, &audit.LogInput{
+			Action:       audit.ActionHouseholdMerged,
+			ResourceType: "household",
+			ResourceID:   audit.StringPtr(input.TargetHouseholdID),
+			UserID:       audit.StringPtr(input.UserID),
+			HouseholdID:  audit.StringPtr(input.TargetHouseholdID),
+			Success:      true,
+			OldValues:    audit.StructToMap(source),
+			NewValues:    audit.StructToMap(report),
+		})
+	}
+
+	return report, nil
+}
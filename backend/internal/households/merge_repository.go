@@ -0,0 +1,823 @@
+package households
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// MergeHouseholds moves everything in the source household into the target
+// household and deletes the source, all in one transaction. Categories,
+// category groups and contacts whose names already exist in the target are
+// remapped onto the target rows; other name clashes are resolved by suffixing
+// the source household name. When apply is false the transaction is rolled
+// back, so the report is an exact dry run.
+func (r *Repository) MergeHouseholds(ctx context.Context, sourceID, targetID string, apply bool) (*MergeReport, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	report := &MergeReport{
+		SourceHouseholdID: sourceID,
+		TargetHouseholdID: targetID,
+		DryRun:            !apply,
+		Remapped:          []MergeRemap{},
+		Renamed:           []MergeRename{},
+		Discarded:         []MergeDiscard{},
+	}
+
+	// Lock both households for the duration of the merge
+	var sourceName string
+	err = tx.QueryRow(ctx, `SELECT name FROM households WHERE id = $1 FOR UPDATE`, sourceID).Scan(&sourceName)
+	if err == pgx.ErrNoRows {
+		return nil, ErrHouseholdNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var targetName string
+	err = tx.QueryRow(ctx, `SELECT name FROM households WHERE id = $1 FOR UPDATE`, targetID).Scan(&targetName)
+	if err == pgx.ErrNoRows {
+		return nil, ErrHouseholdNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	suffix := mergeNameSuffix(sourceName)
+
+	m := &householdMerger{tx: tx, sourceID: sourceID, targetID: targetID, suffix: suffix, report: report}
+	steps := []func(context.Context) error{
+		m.mergeMembers,
+		m.mergeContacts,
+		m.mergeCategoryGroups,
+		m.mergeCategories,
+		m.mergeAccounts,
+		m.mergeBudgets,
+		m.mergeTemplates,
+		m.moveRemaining,
+		m.mergeSettings,
+		m.replaceMemberContacts,
+		m.moveHistory,
+	}
+	for _, step := range steps {
+		if err := step(ctx); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				return nil, fmt.Errorf("%w: %s", ErrMergeConflict, pgErr.ConstraintName)
+			}
+			return nil, err
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM households WHERE id = $1`, sourceID); err != nil {
+		return nil, err
+	}
+
+	if !apply {
+		return report, nil
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// mergeNameSuffix builds the " (Source name)" suffix used to resolve name clashes
+func mergeNameSuffix(sourceName string) string {
+	name := []rune(sourceName)
+	if len(name) > 40 {
+		name = name[:40]
+	}
+	return " (" + string(name) + ")"
+}
+
+// householdMerger holds the state shared by the steps of a household merge
+type householdMerger struct {
+	tx       pgx.Tx
+	sourceID string
+	targetID string
+	suffix   string
+	report   *MergeReport
+}
+
+func (m *householdMerger) mergeMembers(ctx context.Context) error {
+	// Members keep their role; users already in the target keep their target role
+	result, err := m.tx.Exec(ctx, `
+		INSERT INTO household_members (household_id, user_id, role)
+		SELECT $2, user_id, role FROM household_members WHERE household_id = $1
+		ON CONFLICT (household_id, user_id) DO NOTHING
+	`, m.sourceID, m.targetID)
+	if err != nil {
+		return err
+	}
+	m.report.Counts.MembersAdded = int(result.RowsAffected())
+	return nil
+}
+
+// duplicatePairs returns (source id, target id, name) for rows of the source
+// household whose name matches a target row according to the given query
+func (m *householdMerger) duplicatePairs(ctx context.Context, query string) ([][3]string, error) {
+	rows, err := m.tx.Query(ctx, query, m.sourceID, m.targetID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pairs [][3]string
+	for rows.Next() {
+		var p [3]string
+		if err := rows.Scan(&p[0], &p[1], &p[2]); err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, p)
+	}
+	return pairs, rows.Err()
+}
+
+func (m *householdMerger) mergeContacts(ctx context.Context) error {
+	pairs, err := m.duplicatePairs(ctx, `
+		SELECT s.id, t.id, s.name
+		FROM contacts s
+		CROSS JOIN LATERAL (
+			SELECT id FROM contacts
+			WHERE household_id = $2 AND LOWER(name) = LOWER(s.name)
+			ORDER BY created_at
+			LIMIT 1
+		) t
+		WHERE s.household_id = $1
+	`)
+	if err != nil {
+		return err
+	}
+	for _, p := range pairs {
+		if err := remapContactReferences(ctx, m.tx, p[0], p[1]); err != nil {
+			return err
+		}
+		if _, err := m.tx.Exec(ctx, `DELETE FROM contacts WHERE id = $1`, p[0]); err != nil {
+			return err
+		}
+		m.report.Remapped = append(m.report.Remapped, MergeRemap{Kind: "contact", Name: p[2], SourceID: p[0], TargetID: p[1]})
+	}
+
+	result, err := m.tx.Exec(ctx, `UPDATE contacts SET household_id = $2 WHERE household_id = $1`, m.sourceID, m.targetID)
+	if err != nil {
+		return err
+	}
+	m.report.Counts.Contacts = int(result.RowsAffected())
+	return nil
+}
+
+// remapContactReferences points every movement, template and budget item that
+// references fromID at toID instead. Participant rows that would duplicate an
+// existing participant are folded into it.
+func remapContactReferences(ctx context.Context, tx pgx.Tx, fromID, toID string) error {
+	statements := []string{
+		`UPDATE movements SET payer_contact_id = $2 WHERE payer_contact_id = $1`,
+		`UPDATE movements SET counterparty_contact_id = $2 WHERE counterparty_contact_id = $1`,
+		`UPDATE recurring_movement_templates SET payer_contact_id = $2 WHERE payer_contact_id = $1`,
+		`UPDATE recurring_movement_templates SET counterparty_contact_id = $2 WHERE counterparty_contact_id = $1`,
+		`UPDATE monthly_budget_items SET payer_contact_id = $2 WHERE payer_contact_id = $1`,
+		`UPDATE monthly_budget_items SET counterparty_contact_id = $2 WHERE counterparty_contact_id = $1`,
+		`UPDATE contact_link_suggestions s SET contact_id = $2
+		 WHERE s.contact_id = $1
+		   AND NOT EXISTS (SELECT 1 FROM contact_link_suggestions t WHERE t.contact_id = $2 AND t.user_id = s.user_id)`,
+	}
+	participantTables := []struct{ table, parent string }{
+		{"movement_participants", "movement_id"},
+		{"recurring_movement_participants", "template_id"},
+		{"monthly_budget_item_participants", "budget_item_id"},
+	}
+	for _, pt := range participantTables {
+		amount := ""
+		if pt.table == "movement_participants" {
+			amount = `, amount = CASE WHEN t.amount IS NULL AND s.amount IS NULL THEN NULL
+			                  ELSE COALESCE(t.amount, 0) + COALESCE(s.amount, 0) END`
+		}
+		statements = append(statements,
+			`UPDATE `+pt.table+` t
+			 SET percentage = LEAST(1, t.percentage + s.percentage)`+amount+`
+			 FROM `+pt.table+` s
+			 WHERE s.participant_contact_id = $1 AND t.participant_contact_id = $2
+			   AND s.`+pt.parent+` = t.`+pt.parent,
+			`DELETE FROM `+pt.table+` s
+			 USING `+pt.table+` t
+			 WHERE s.participant_contact_id = $1 AND t.participant_contact_id = $2
+			   AND s.`+pt.parent+` = t.`+pt.parent,
+			`UPDATE `+pt.table+` SET participant_contact_id = $2 WHERE participant_contact_id = $1`,
+		)
+	}
+	for _, stmt := range statements {
+		if _, err := tx.Exec(ctx, stmt, fromID, toID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MergeContacts folds the source contact into the target in one transaction:
+// every reference is re-pointed at the target, missing email and phone are
+// filled in from the source, and when carryLink is set the source's link to a
+// user replaces the target's. The source contact is then deleted.
+func (r *Repository) MergeContacts(ctx context.Context, sourceID, targetID string, carryLink bool) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := remapContactReferences(ctx, tx, sourceID, targetID); err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(ctx, `
+		UPDATE contacts t
+		SET email = COALESCE(t.email, s.email),
+		    phone = COALESCE(t.phone, s.phone),
+		    linked_user_id = CASE WHEN $3 THEN s.linked_user_id ELSE t.linked_user_id END,
+		    link_status = CASE WHEN $3 THEN s.link_status ELSE t.link_status END,
+		    link_requested_at = CASE WHEN $3 THEN s.link_requested_at ELSE t.link_requested_at END,
+		    link_responded_at = CASE WHEN $3 THEN s.link_responded_at ELSE t.link_responded_at END,
+		    link_requested_by_user_id = CASE WHEN $3 THEN s.link_requested_by_user_id ELSE t.link_requested_by_user_id END,
+		    was_unlinked_at = CASE WHEN $3 THEN s.was_unlinked_at ELSE t.was_unlinked_at END,
+		    updated_at = NOW()
+		FROM contacts s
+		WHERE t.id = $2 AND s.id = $1
+	`, sourceID, targetID, carryLink)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrContactNotFound
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM contacts WHERE id = $1`, sourceID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (m *householdMerger) mergeCategoryGroups(ctx context.Context) error {
+	pairs, err := m.duplicatePairs(ctx, `
+		SELECT s.id, t.id, s.name
+		FROM category_groups s
+		CROSS JOIN LATERAL (
+			SELECT id FROM category_groups
+			WHERE household_id = $2 AND LOWER(name) = LOWER(s.name)
+			ORDER BY display_order, name
+			LIMIT 1
+		) t
+		WHERE s.household_id = $1
+	`)
+	if err != nil {
+		return err
+	}
+	for _, p := range pairs {
+		if _, err := m.tx.Exec(ctx, `UPDATE categories SET category_group_id = $2 WHERE category_group_id = $1`, p[0], p[1]); err != nil {
+			return err
+		}
+		if err := m.remapGroupBudgets(ctx, p[0], p[1]); err != nil {
+			return err
+		}
+		if _, err := m.tx.Exec(ctx, `DELETE FROM category_groups WHERE id = $1`, p[0]); err != nil {
+			return err
+		}
+		m.report.Remapped = append(m.report.Remapped, MergeRemap{Kind: "category_group", Name: p[2], SourceID: p[0], TargetID: p[1]})
+	}
+
+	result, err := m.tx.Exec(ctx, `UPDATE category_groups SET household_id = $2 WHERE household_id = $1`, m.sourceID, m.targetID)
+	if err != nil {
+		return err
+	}
+	m.report.Counts.CategoryGroups = int(result.RowsAffected())
+	return nil
+}
+
+// remapGroupBudgets points a duplicate group's budgets at the target group
+// before the duplicate is deleted. A month budgeted in both keeps one budget
+// with the combined amount.
+func (m *householdMerger) remapGroupBudgets(ctx context.Context, sourceGroupID, targetGroupID string) error {
+	result, err := m.tx.Exec(ctx, `
+		UPDATE monthly_group_budgets t
+		SET amount = t.amount + s.amount, updated_at = NOW()
+		FROM monthly_group_budgets s
+		WHERE s.category_group_id = $1 AND t.category_group_id = $2 AND s.month = t.month
+	`, sourceGroupID, targetGroupID)
+	if err != nil {
+		return err
+	}
+	m.report.Counts.BudgetsCombined += int(result.RowsAffected())
+	if _, err := m.tx.Exec(ctx, `
+		DELETE FROM monthly_group_budgets s
+		USING monthly_group_budgets t
+		WHERE s.category_group_id = $1 AND t.category_group_id = $2 AND s.month = t.month
+	`, sourceGroupID, targetGroupID); err != nil {
+		return err
+	}
+	_, err = m.tx.Exec(ctx, `UPDATE monthly_group_budgets SET category_group_id = $2 WHERE category_group_id = $1`, sourceGroupID, targetGroupID)
+	return err
+}
+
+// remapCategoryBudgets moves a duplicate category's budgets in table to the kept
+// category. The rows still belong to the source household, so when two source
+// categories fold into the same target their budgets for the same key columns
+// are combined here; mergeBudgets later combines them with the target's.
+func (m *householdMerger) remapCategoryBudgets(ctx context.Context, table, sourceCategoryID, targetCategoryID string, keyColumns ...string) error {
+	match := "s.household_id = t.household_id"
+	for _, col := range keyColumns {
+		match += " AND s." + col + " = t." + col
+	}
+	result, err := m.tx.Exec(ctx, `
+		UPDATE `+table+` t
+		SET amount = t.amount + s.amount, updated_at = NOW()
+		FROM `+table+` s
+		WHERE s.category_id = $1 AND t.category_id = $2 AND `+match,
+		sourceCategoryID, targetCategoryID)
+	if err != nil {
+		return err
+	}
+	m.report.Counts.BudgetsCombined += int(result.RowsAffected())
+	if _, err := m.tx.Exec(ctx, `
+		DELETE FROM `+table+` s
+		USING `+table+` t
+		WHERE s.category_id = $1 AND t.category_id = $2 AND `+match,
+		sourceCategoryID, targetCategoryID); err != nil {
+		return err
+	}
+	_, err = m.tx.Exec(ctx, `UPDATE `+table+` SET category_id = $2 WHERE category_id = $1`, sourceCategoryID, targetCategoryID)
+	return err
+}
+
+// remapCategorySettings moves a duplicate category's budget settings, alert
+// history and alert mutes to the kept category. The target's own settings win;
+// the duplicate's are then reported as discarded. Alerts and mutes the target
+// already has for the same month and threshold, or user, are equivalent and
+// simply dropped with the duplicate.
+func (m *householdMerger) remapCategorySettings(ctx context.Context, pair [3]string) error {
+	if _, err := m.tx.Exec(ctx, `
+		UPDATE category_budget_settings SET category_id = $2
+		WHERE category_id = $1 AND NOT EXISTS (SELECT 1 FROM category_budget_settings WHERE category_id = $2)
+	`, pair[0], pair[1]); err != nil {
+		return err
+	}
+	result, err := m.tx.Exec(ctx, `DELETE FROM category_budget_settings WHERE category_id = $1`, pair[0])
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() > 0 {
+		m.report.Discarded = append(m.report.Discarded, MergeDiscard{Kind: "category_budget_settings", SourceID: pair[0], Name: pair[2]})
+	}
+
+	if _, err := m.tx.Exec(ctx, `
+		UPDATE budget_alerts s SET category_id = $2
+		WHERE s.category_id = $1 AND NOT EXISTS (
+			SELECT 1 FROM budget_alerts t
+			WHERE t.category_id = $2 AND t.month = s.month AND t.threshold = s.threshold
+		)
+	`, pair[0], pair[1]); err != nil {
+		return err
+	}
+	_, err = m.tx.Exec(ctx, `
+		UPDATE budget_alert_mutes s SET category_id = $2
+		WHERE s.category_id = $1 AND NOT EXISTS (
+			SELECT 1 FROM budget_alert_mutes t WHERE t.category_id = $2 AND t.user_id = s.user_id
+		)
+	`, pair[0], pair[1])
+	return err
+}
+
+func (m *householdMerger) mergeCategories(ctx context.Context) error {
+	// Groups are already remapped, so a duplicate is a same-named category in the same group
+	pairs, err := m.duplicatePairs(ctx, `
+		SELECT s.id, t.id, s.name
+		FROM categories s
+		CROSS JOIN LATERAL (
+			SELECT id FROM categories
+			WHERE household_id = $2
+			  AND category_group_id IS NOT DISTINCT FROM s.category_group_id
+			  AND LOWER(name) = LOWER(s.name)
+			ORDER BY created_at
+			LIMIT 1
+		) t
+		WHERE s.household_id = $1
+	`)
+	if err != nil {
+		return err
+	}
+	for _, p := range pairs {
+		if err := m.remapCategoryBudgets(ctx, "monthly_budgets", p[0], p[1], "month"); err != nil {
+			return err
+		}
+		if err := m.remapCategoryBudgets(ctx, "period_budgets", p[0], p[1], "period", "start_month"); err != nil {
+			return err
+		}
+		for _, table := range []string{
+			"movements", "monthly_budget_items",
+			"recurring_movement_templates", "pockets", "pocket_transactions",
+		} {
+			if _, err := m.tx.Exec(ctx, `UPDATE `+table+` SET category_id = $2 WHERE category_id = $1`, p[0], p[1]); err != nil {
+				return err
+			}
+		}
+		// The target category keeps its own owner, if it has one
+		if _, err := m.tx.Exec(ctx, `
+			UPDATE member_budget_categories SET category_id = $2
+			WHERE category_id = $1 AND NOT EXISTS (SELECT 1 FROM member_budget_categories WHERE category_id = $2)
+		`, p[0], p[1]); err != nil {
+			return err
+		}
+		if err := m.remapCategorySettings(ctx, p); err != nil {
+			return err
+		}
+		// A move between the two categories becomes a move to itself; drop it
+		if _, err := m.tx.Exec(ctx, `
+			DELETE FROM budget_reassignments
+			WHERE (from_category_id = $1 AND to_category_id = $2) OR (from_category_id = $2 AND to_category_id = $1)
+		`, p[0], p[1]); err != nil {
+			return err
+		}
+		for _, column := range []string{"from_category_id", "to_category_id"} {
+			if _, err := m.tx.Exec(ctx, `UPDATE budget_reassignments SET `+column+` = $2 WHERE `+column+` = $1`, p[0], p[1]); err != nil {
+				return err
+			}
+		}
+		if _, err := m.tx.Exec(ctx, `DELETE FROM categories WHERE id = $1`, p[0]); err != nil {
+			return err
+		}
+		m.report.Remapped = append(m.report.Remapped, MergeRemap{Kind: "category", Name: p[2], SourceID: p[0], TargetID: p[1]})
+	}
+
+	result, err := m.tx.Exec(ctx, `UPDATE categories SET household_id = $2 WHERE household_id = $1`, m.sourceID, m.targetID)
+	if err != nil {
+		return err
+	}
+	m.report.Counts.Categories = int(result.RowsAffected())
+	return nil
+}
+
+// renameClashes suffixes source rows of table whose name clashes with a target
+// row on the same key columns, and records each rename in the report
+func (m *householdMerger) renameClashes(ctx context.Context, kind, table string, maxLen int, keyColumns ...string) error {
+	match := "t.name = s.name"
+	for _, col := range keyColumns {
+		match += " AND t." + col + " = s." + col
+	}
+	rows, err := m.tx.Query(ctx, `
+		UPDATE `+table+` s
+		SET name = LEFT(s.name, $4::int - LENGTH($3::text)) || $3::text
+		FROM `+table+` t
+		WHERE s.household_id = $1 AND t.household_id = $2 AND `+match+`
+		RETURNING s.id, t.name, s.name
+	`, m.sourceID, m.targetID, m.suffix, maxLen)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		rename := MergeRename{Kind: kind}
+		if err := rows.Scan(&rename.SourceID, &rename.OldName, &rename.NewName); err != nil {
+			return err
+		}
+		m.report.Renamed = append(m.report.Renamed, rename)
+	}
+	return rows.Err()
+}
+
+// moveTable reassigns every row of table from the source to the target household
+func (m *householdMerger) moveTable(ctx context.Context, table string) (int, error) {
+	result, err := m.tx.Exec(ctx, `UPDATE `+table+` SET household_id = $2 WHERE household_id = $1`, m.sourceID, m.targetID)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.RowsAffected()), nil
+}
+
+func (m *householdMerger) mergeAccounts(ctx context.Context) error {
+	var err error
+	if err = m.renameClashes(ctx, "account", "accounts", 100); err != nil {
+		return err
+	}
+	if m.report.Counts.Accounts, err = m.moveTable(ctx, "accounts"); err != nil {
+		return err
+	}
+	if err = m.renameClashes(ctx, "payment_method", "payment_methods", 100); err != nil {
+		return err
+	}
+	if m.report.Counts.PaymentMethods, err = m.moveTable(ctx, "payment_methods"); err != nil {
+		return err
+	}
+	if err = m.renameClashes(ctx, "pocket", "pockets", 100); err != nil {
+		return err
+	}
+	m.report.Counts.Pockets, err = m.moveTable(ctx, "pockets")
+	return err
+}
+
+func (m *householdMerger) mergeBudgets(ctx context.Context) error {
+	// A category budgeted in both households for the same month keeps one
+	// budget with the combined amount
+	result, err := m.tx.Exec(ctx, `
+		UPDATE monthly_budgets t
+		SET amount = t.amount + s.amount, updated_at = NOW()
+		FROM monthly_budgets s
+		WHERE s.household_id = $1 AND t.household_id = $2
+		  AND s.category_id = t.category_id AND s.month = t.month
+	`, m.sourceID, m.targetID)
+	if err != nil {
+		return err
+	}
+	m.report.Counts.BudgetsCombined = int(result.RowsAffected())
+	if _, err := m.tx.Exec(ctx, `
+		DELETE FROM monthly_budgets s
+		USING monthly_budgets t
+		WHERE s.household_id = $1 AND t.household_id = $2
+		  AND s.category_id = t.category_id AND s.month = t.month
+	`, m.sourceID, m.targetID); err != nil {
+		return err
+	}
+	if m.report.Counts.Budgets, err = m.moveTable(ctx, "monthly_budgets"); err != nil {
+		return err
+	}
+
+	// Yearly and custom budgets starting the same month are combined the same
+	// way; a custom range keeps the target's last month
+	result, err = m.tx.Exec(ctx, `
+		UPDATE period_budgets t
+		SET amount = t.amount + s.amount, updated_at = NOW()
+		FROM period_budgets s
+		WHERE s.household_id = $1 AND t.household_id = $2
+		  AND s.category_id = t.category_id AND s.period = t.period AND s.start_month = t.start_month
+	`, m.sourceID, m.targetID)
+	if err != nil {
+		return err
+	}
+	m.report.Counts.BudgetsCombined += int(result.RowsAffected())
+	if _, err := m.tx.Exec(ctx, `
+		DELETE FROM period_budgets s
+		USING period_budgets t
+		WHERE s.household_id = $1 AND t.household_id = $2
+		  AND s.category_id = t.category_id AND s.period = t.period AND s.start_month = t.start_month
+	`, m.sourceID, m.targetID); err != nil {
+		return err
+	}
+	if m.report.Counts.PeriodBudgets, err = m.moveTable(ctx, "period_budgets"); err != nil {
+		return err
+	}
+	if m.report.Counts.GroupBudgets, err = m.moveTable(ctx, "monthly_group_budgets"); err != nil {
+		return err
+	}
+
+	// A member with an allowance in both households keeps one per month with
+	// the combined amount
+	result, err = m.tx.Exec(ctx, `
+		UPDATE member_budgets t
+		SET amount = t.amount + s.amount, updated_at = NOW()
+		FROM member_budgets s
+		WHERE s.household_id = $1 AND t.household_id = $2
+		  AND s.user_id = t.user_id AND s.month = t.month
+	`, m.sourceID, m.targetID)
+	if err != nil {
+		return err
+	}
+	m.report.Counts.BudgetsCombined += int(result.RowsAffected())
+	if _, err := m.tx.Exec(ctx, `
+		DELETE FROM member_budgets s
+		USING member_budgets t
+		WHERE s.household_id = $1 AND t.household_id = $2
+		  AND s.user_id = t.user_id AND s.month = t.month
+	`, m.sourceID, m.targetID); err != nil {
+		return err
+	}
+	if m.report.Counts.Allowances, err = m.moveTable(ctx, "member_budgets"); err != nil {
+		return err
+	}
+	if _, err = m.moveTable(ctx, "member_budget_categories"); err != nil {
+		return err
+	}
+	if _, err = m.moveTable(ctx, "budget_reassignments"); err != nil {
+		return err
+	}
+
+	if err = m.renameClashes(ctx, "budget_item", "monthly_budget_items", 200, "category_id", "month"); err != nil {
+		return err
+	}
+	m.report.Counts.BudgetItems, err = m.moveTable(ctx, "monthly_budget_items")
+	return err
+}
+
+func (m *householdMerger) mergeTemplates(ctx context.Context) error {
+	if err := m.renameClashes(ctx, "template", "recurring_movement_templates", 200, "category_id"); err != nil {
+		return err
+	}
+	var err error
+	m.report.Counts.Templates, err = m.moveTable(ctx, "recurring_movement_templates")
+	return err
+}
+
+func (m *householdMerger) moveRemaining(ctx context.Context) error {
+	var err error
+	if m.report.Counts.Movements, err = m.moveTable(ctx, "movements"); err != nil {
+		return err
+	}
+	if m.report.Counts.Income, err = m.moveTable(ctx, "income"); err != nil {
+		return err
+	}
+	if m.report.Counts.PocketTransactions, err = m.moveTable(ctx, "pocket_transactions"); err != nil {
+		return err
+	}
+	m.report.Counts.CreditCardPayments, err = m.moveTable(ctx, "credit_card_payments")
+	return err
+}
+
+// moveHistory keeps the source household's notifications and audit log under
+// the target, since deleting the source would lose them
+func (m *householdMerger) moveHistory(ctx context.Context) error {
+	var err error
+	if m.report.Counts.Notifications, err = m.moveTable(ctx, "notifications"); err != nil {
+		return err
+	}
+	m.report.Counts.AuditLogs, err = m.moveTable(ctx, "audit_logs")
+	return err
+}
+
+func (m *householdMerger) mergeSettings(ctx context.Context) error {
+	// The target keeps its own alert thresholds if it has them
+	if _, err := m.tx.Exec(ctx, `
+		UPDATE household_budget_settings SET household_id = $2
+		WHERE household_id = $1 AND NOT EXISTS (SELECT 1 FROM household_budget_settings WHERE household_id = $2)
+	`, m.sourceID, m.targetID); err != nil {
+		return err
+	}
+	result, err := m.tx.Exec(ctx, `DELETE FROM household_budget_settings WHERE household_id = $1`, m.sourceID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() > 0 {
+		m.report.Discarded = append(m.report.Discarded, MergeDiscard{Kind: "budget_settings", SourceID: m.sourceID})
+	}
+
+	// Deliveries follow their endpoint
+	if m.report.Counts.WebhookEndpoints, err = m.moveTable(ctx, "webhook_endpoints"); err != nil {
+		return err
+	}
+
+	// Open invitations for someone who is already a member of the target, or
+	// already invited to it, are dropped
+	rows, err := m.tx.Query(ctx, `
+		DELETE FROM household_invitations s
+		WHERE s.household_id = $1 AND s.accepted_at IS NULL AND s.revoked_at IS NULL
+		  AND (
+		    EXISTS (
+		      SELECT 1 FROM household_invitations t
+		      WHERE t.household_id = $2 AND LOWER(t.email) = LOWER(s.email)
+		        AND t.accepted_at IS NULL AND t.revoked_at IS NULL
+		    )
+		    OR EXISTS (
+		      SELECT 1 FROM household_members hm
+		      JOIN users u ON u.id = hm.user_id
+		      WHERE hm.household_id = $2 AND LOWER(u.email) = LOWER(s.email)
+		    )
+		  )
+		RETURNING s.id, s.email
+	`, m.sourceID, m.targetID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		discard := MergeDiscard{Kind: "invitation"}
+		if err := rows.Scan(&discard.SourceID, &discard.Name); err != nil {
+			return err
+		}
+		m.report.Discarded = append(m.report.Discarded, discard)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	m.report.Counts.Invitations, err = m.moveTable(ctx, "household_invitations")
+	return err
+}
+
+// replaceMemberContacts replaces the target's contacts that are linked to a
+// user who is now a member with that member, then deletes the contacts. After
+// a merge one household may have kept a contact for someone who was a member
+// of the other.
+func (m *householdMerger) replaceMemberContacts(ctx context.Context) error {
+	rows, err := m.tx.Query(ctx, `
+		SELECT c.id, c.linked_user_id, c.name
+		FROM contacts c
+		JOIN household_members hm ON hm.household_id = c.household_id AND hm.user_id = c.linked_user_id
+		WHERE c.household_id = $1
+	`, m.targetID)
+	if err != nil {
+		return err
+	}
+	var pairs [][3]string
+	for rows.Next() {
+		var p [3]string
+		if err := rows.Scan(&p[0], &p[1], &p[2]); err != nil {
+			rows.Close()
+			return err
+		}
+		pairs = append(pairs, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, p := range pairs {
+		if err := replaceContactWithUser(ctx, m.tx, p[0], p[1]); err != nil {
+			return err
+		}
+		if _, err := m.tx.Exec(ctx, `DELETE FROM contacts WHERE id = $1`, p[0]); err != nil {
+			return err
+		}
+		m.report.Remapped = append(m.report.Remapped, MergeRemap{Kind: "member", Name: p[2], SourceID: p[0], TargetID: p[1]})
+	}
+	return nil
+}
+
+// replaceContactWithUser points every movement, template and budget item that
+// references the contact at the user instead. Participant rows that would
+// duplicate the user's own participation are folded into it.
+func replaceContactWithUser(ctx context.Context, tx pgx.Tx, contactID, userID string) error {
+	var statements []string
+	for _, table := range []string{"movements", "recurring_movement_templates", "monthly_budget_items"} {
+		for _, role := range []string{"payer", "counterparty"} {
+			statements = append(statements,
+				`UPDATE `+table+` SET `+role+`_user_id = $2, `+role+`_contact_id = NULL WHERE `+role+`_contact_id = $1`)
+		}
+	}
+	participantTables := []struct{ table, parent string }{
+		{"movement_participants", "movement_id"},
+		{"recurring_movement_participants", "template_id"},
+		{"monthly_budget_item_participants", "budget_item_id"},
+	}
+	for _, pt := range participantTables {
+		amount := ""
+		if pt.table == "movement_participants" {
+			amount = `, amount = CASE WHEN t.amount IS NULL AND s.amount IS NULL THEN NULL
+			                  ELSE COALESCE(t.amount, 0) + COALESCE(s.amount, 0) END`
+		}
+		statements = append(statements,
+			`UPDATE `+pt.table+` t
+			 SET percentage = LEAST(1, t.percentage + s.percentage)`+amount+`
+			 FROM `+pt.table+` s
+			 WHERE s.participant_contact_id = $1 AND t.participant_user_id = $2
+			   AND s.`+pt.parent+` = t.`+pt.parent,
+			`DELETE FROM `+pt.table+` s
+			 USING `+pt.table+` t
+			 WHERE s.participant_contact_id = $1 AND t.participant_user_id = $2
+			   AND s.`+pt.parent+` = t.`+pt.parent,
+			`UPDATE `+pt.table+` SET participant_user_id = $2, participant_contact_id = NULL WHERE participant_contact_id = $1`,
+		)
+	}
+	for _, stmt := range statements {
+		if _, err := tx.Exec(ctx, stmt, contactID, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TransferOwnership makes toUserID an owner and fromUserID a regular member in one transaction
+func (r *Repository) TransferOwnership(ctx context.Context, householdID, fromUserID, toUserID string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE household_members SET role = $3
+		WHERE household_id = $1 AND user_id = $2
+	`, householdID, toUserID, RoleOwner)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrMemberNotFound
+	}
+
+	result, err = tx.Exec(ctx, `
+		UPDATE household_members SET role = $3
+		WHERE household_id = $1 AND user_id = $2
+	`, householdID, fromUserID, RoleMember)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrMemberNotFound
+	}
+
+	return tx.Commit(ctx)
+}
@@ -2,7 +2,10 @@ package households
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/blanquicet/conti/backend/internal/audit"
@@ -22,6 +25,7 @@ type MockHouseholdRepository struct {
 	members     map[string][]*HouseholdMember
 	contacts    map[string][]*Contact
 	invitations map[string]*HouseholdInvitation
+	suggestions map[[2]string]string // (contact, user) -> status
 }
 
 // NewMockRepository creates a new mock repository
@@ -31,6 +35,7 @@ func NewMockRepository() *MockHouseholdRepository {
 		members:     make(map[string][]*HouseholdMember),
 		contacts:    make(map[string][]*Contact),
 		invitations: make(map[string]*HouseholdInvitation),
+		suggestions: make(map[[2]string]string),
 	}
 }
 
@@ -165,6 +170,28 @@ func (m *MockHouseholdRepository) CountOwners(ctx context.Context, householdID s
 	return count, nil
 }
 
+func (m *MockHouseholdRepository) TransferOwnership(ctx context.Context, householdID, fromUserID, toUserID string) error {
+	var from, to *HouseholdMember
+	for _, member := range m.members[householdID] {
+		switch member.UserID {
+		case fromUserID:
+			from = member
+		case toUserID:
+			to = member
+		}
+	}
+	if from == nil || to == nil {
+		return ErrMemberNotFound
+	}
+	to.Role = RoleOwner
+	from.Role = RoleMember
+	return nil
+}
+
+func (m *MockHouseholdRepository) MergeHouseholds(ctx context.Context, sourceID, targetID string, apply bool) (*MergeReport, error) {
+	return &MergeReport{SourceHouseholdID: sourceID, TargetHouseholdID: targetID, DryRun: !apply}, nil
+}
+
 func (m *MockHouseholdRepository) CreateContact(ctx context.Context, contact *Contact) (*Contact, error) {
 	c := &Contact{
 		ID:           "contact-new",
@@ -242,19 +269,57 @@ func (m *MockHouseholdRepository) FindLinkedContactsByHousehold(ctx context.Cont
 	return nil, nil
 }
 
-func (m *MockHouseholdRepository) CreateInvitation(ctx context.Context, householdID, email, token, invitedBy string) (*HouseholdInvitation, error) {
+func (m *MockHouseholdRepository) MergeContacts(ctx context.Context, sourceID, targetID string, carryLink bool) error {
+	source, err := m.GetContact(ctx, sourceID)
+	if err != nil {
+		return err
+	}
+	target, err := m.GetContact(ctx, targetID)
+	if err != nil {
+		return err
+	}
+	if target.Email == nil {
+		target.Email = source.Email
+	}
+	if target.Phone == nil {
+		target.Phone = source.Phone
+	}
+	if carryLink {
+		target.LinkedUserID = source.LinkedUserID
+		target.LinkStatus = source.LinkStatus
+		target.LinkRequestedAt = source.LinkRequestedAt
+		target.LinkRespondedAt = source.LinkRespondedAt
+		target.LinkRequestedByUserID = source.LinkRequestedByUserID
+		target.WasUnlinkedAt = source.WasUnlinkedAt
+	}
+	return m.DeleteContact(ctx, sourceID)
+}
+
+func (m *MockHouseholdRepository) CreateInvitation(ctx context.Context, householdID, email, token, invitedBy string, expiresAt time.Time) (*HouseholdInvitation, error) {
 	inv := &HouseholdInvitation{
-		ID:          "invitation-new",
+		ID:          fmt.Sprintf("invitation-%d", len(m.invitations)+1),
 		HouseholdID: householdID,
 		Email:       email,
 		Token:       token,
 		InvitedBy:   invitedBy,
+		ExpiresAt:   &expiresAt,
+		LastSentAt:  time.Now(),
+		SendCount:   1,
 		CreatedAt:   time.Now(),
 	}
 	m.invitations[token] = inv
 	return inv, nil
 }
 
+func (m *MockHouseholdRepository) GetInvitationByID(ctx context.Context, id string) (*HouseholdInvitation, error) {
+	for _, inv := range m.invitations {
+		if inv.ID == id {
+			return inv, nil
+		}
+	}
+	return nil, ErrInvitationNotFound
+}
+
 func (m *MockHouseholdRepository) GetInvitationByToken(ctx context.Context, token string) (*HouseholdInvitation, error) {
 	inv, ok := m.invitations[token]
 	if !ok {
@@ -263,6 +328,15 @@ func (m *MockHouseholdRepository) GetInvitationByToken(ctx context.Context, toke
 	return inv, nil
 }
 
+func (m *MockHouseholdRepository) FindOpenInvitationByEmail(ctx context.Context, householdID, email string) (*HouseholdInvitation, error) {
+	for _, inv := range m.invitations {
+		if inv.HouseholdID == householdID && inv.Email == email && inv.AcceptedAt == nil && inv.RevokedAt == nil {
+			return inv, nil
+		}
+	}
+	return nil, nil
+}
+
 func (m *MockHouseholdRepository) AcceptInvitation(ctx context.Context, id string) error {
 	for _, inv := range m.invitations {
 		if inv.ID == id {
@@ -274,10 +348,36 @@ func (m *MockHouseholdRepository) AcceptInvitation(ctx context.Context, id strin
 	return ErrInvitationNotFound
 }
 
+func (m *MockHouseholdRepository) RotateInvitationToken(ctx context.Context, id, token string, expiresAt time.Time) (*HouseholdInvitation, error) {
+	for oldToken, inv := range m.invitations {
+		if inv.ID == id {
+			delete(m.invitations, oldToken)
+			inv.Token = token
+			inv.ExpiresAt = &expiresAt
+			inv.LastSentAt = time.Now()
+			inv.SendCount++
+			m.invitations[token] = inv
+			return inv, nil
+		}
+	}
+	return nil, ErrInvitationNotFound
+}
+
+func (m *MockHouseholdRepository) RevokeInvitation(ctx context.Context, id string) error {
+	for _, inv := range m.invitations {
+		if inv.ID == id && inv.AcceptedAt == nil && inv.RevokedAt == nil {
+			now := time.Now()
+			inv.RevokedAt = &now
+			return nil
+		}
+	}
+	return ErrInvitationNotFound
+}
+
 func (m *MockHouseholdRepository) ListPendingInvitations(ctx context.Context, householdID string) ([]*HouseholdInvitation, error) {
 	var result []*HouseholdInvitation
 	for _, inv := range m.invitations {
-		if inv.HouseholdID == householdID && inv.AcceptedAt == nil {
+		if inv.HouseholdID == householdID && inv.AcceptedAt == nil && inv.RevokedAt == nil {
 			result = append(result, inv)
 		}
 	}
@@ -293,7 +393,50 @@ func (m *MockHouseholdRepository) GetUserHouseholdID(ctx context.Context, userID
 			}
 		}
 	}
-	return "", ErrHouseholdNotFound
+	return "", ErrNoHousehold
+}
+
+// ResolveHouseholdID returns the selected household if the user belongs to it,
+// otherwise the household the user joined first
+func (m *MockHouseholdRepository) ResolveHouseholdID(ctx context.Context, userID string) (string, error) {
+	if selected := SelectedHouseholdID(ctx); selected != "" {
+		for _, member := range m.members[selected] {
+			if member.UserID == userID {
+				return selected, nil
+			}
+		}
+		return "", ErrNotAuthorized
+	}
+
+	memberships, _ := m.ListMemberships(ctx, userID)
+	if len(memberships) == 0 {
+		return "", ErrNoHousehold
+	}
+	return memberships[0].HouseholdID, nil
+}
+
+// ListMemberships returns the user's households, oldest membership first
+func (m *MockHouseholdRepository) ListMemberships(ctx context.Context, userID string) ([]*Membership, error) {
+	var result []*Membership
+	for hID, members := range m.members {
+		for _, member := range members {
+			if member.UserID != userID {
+				continue
+			}
+			membership := &Membership{HouseholdID: hID, Role: member.Role, JoinedAt: member.JoinedAt}
+			if h, ok := m.households[hID]; ok {
+				membership.HouseholdName = h.Name
+			}
+			result = append(result, membership)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].JoinedAt.Equal(result[j].JoinedAt) {
+			return result[i].JoinedAt.Before(result[j].JoinedAt)
+		}
+		return result[i].HouseholdID < result[j].HouseholdID
+	})
+	return result, nil
 }
 
 // IsUserMember checks if a user is a member of a household
@@ -315,6 +458,11 @@ func (m *MockHouseholdRepository) CountPendingLinkRequests(ctx context.Context,
 }
 
 func (m *MockHouseholdRepository) UpdateContactLinkStatus(ctx context.Context, contactID string, status string) error {
+	contact, err := m.GetContact(ctx, contactID)
+	if err != nil {
+		return err
+	}
+	contact.LinkStatus = status
 	return nil
 }
 
@@ -338,6 +486,56 @@ func (m *MockHouseholdRepository) FindContactByLinkedUserID(ctx context.Context,
 	return nil, ErrContactNotFound
 }
 
+func (m *MockHouseholdRepository) SuggestLinksForUser(ctx context.Context, userID, email string) ([]*Contact, error) {
+	var suggested []*Contact
+	for householdID, contacts := range m.contacts {
+		if _, err := m.GetMemberByUserID(ctx, householdID, userID); err == nil {
+			continue
+		}
+		for _, c := range contacts {
+			if c.LinkedUserID != nil || c.LinkStatus != "NONE" || !c.IsActive {
+				continue
+			}
+			if c.Email == nil || !strings.EqualFold(*c.Email, email) {
+				continue
+			}
+			key := [2]string{c.ID, userID}
+			if _, exists := m.suggestions[key]; exists {
+				continue
+			}
+			m.suggestions[key] = "PENDING"
+			suggested = append(suggested, c)
+		}
+	}
+	return suggested, nil
+}
+
+func (m *MockHouseholdRepository) HasPendingLinkSuggestion(ctx context.Context, contactID, userID string) (bool, error) {
+	return m.suggestions[[2]string{contactID, userID}] == "PENDING", nil
+}
+
+func (m *MockHouseholdRepository) AcceptLinkSuggestion(ctx context.Context, contactID, userID string) error {
+	c, err := m.GetContact(ctx, contactID)
+	if err != nil {
+		return err
+	}
+	if c.LinkedUserID != nil || c.LinkStatus != "NONE" {
+		return ErrLinkRequestNotPending
+	}
+	c.LinkedUserID = &userID
+	c.LinkStatus = "ACCEPTED"
+	m.suggestions[[2]string{contactID, userID}] = "ACCEPTED"
+	return nil
+}
+
+func (m *MockHouseholdRepository) RejectLinkSuggestion(ctx context.Context, contactID, userID string) error {
+	key := [2]string{contactID, userID}
+	if m.suggestions[key] != "PENDING" {
+		return ErrLinkRequestNotPending
+	}
+	m.suggestions[key] = "REJECTED"
+	return nil
+}
 
 // MockUserRepository is a mock implementation for testing
 type MockUserRepository struct {
@@ -408,15 +606,27 @@ func (m *MockUserRepository) CompleteOnboarding(ctx context.Context, id string)
 	return nil
 }
 
-// AddTestUser is a helper to add users for testing
+func (m *MockUserRepository) MarkEmailVerified(ctx context.Context, id string) error {
+	user, ok := m.users[id]
+	if !ok {
+		return auth.ErrUserNotFound
+	}
+	now := time.Now()
+	user.EmailVerifiedAt = &now
+	return nil
+}
+
+// AddTestUser is a helper to add users for testing. Test users have a verified email.
 func (m *MockUserRepository) AddTestUser(id, email, name string) *auth.User {
+	now := time.Now()
 	user := &auth.User{
-		ID:           id,
-		Email:        email,
-		Name:         name,
-		PasswordHash: "hash",
-		CreatedAt:    time.Now(),
-		UpdatedAt:    time.Now(),
+		ID:              id,
+		Email:           email,
+		Name:            name,
+		PasswordHash:    "hash",
+		EmailVerifiedAt: &now,
+		CreatedAt:       now,
+		UpdatedAt:       now,
 	}
 	m.users[id] = user
 	return user
@@ -437,3 +647,8 @@ type MockEmailSender struct{}
 func (m *MockEmailSender) SendPasswordReset(ctx context.Context, to, token string) error { return nil }
 func (m *MockEmailSender) SendHouseholdInvitation(ctx context.Context, to, token, householdName, inviterName string) error { return nil }
 func (m *MockEmailSender) SendLinkRequest(ctx context.Context, to, requesterName, householdName, appURL string) error { return nil }
+func (m *MockEmailSender) SendNewDeviceLogin(ctx context.Context, to, userAgent, ipAddress string, at time.Time) error { return nil }
+func (m *MockEmailSender) SendEmailVerification(ctx context.Context, to, token string) error { return nil }
+func (m *MockEmailSender) SendMagicLink(ctx context.Context, to, token string) error { return nil }
+func (m *MockEmailSender) SendAccountLocked(ctx context.Context, to, token string, lockedUntil time.Time) error { return nil }
+func (m *MockEmailSender) SendBudgetAlert(ctx context.Context, to, categoryName, month string, threshold int, spent, budget float64) error { return nil }
@@ -0,0 +1,102 @@
+package households
+
+import (
+	"context"
+	"errors"
+)
+
+// Permission is something a household role may do.
+type Permission string
+
+const (
+	// PermView reads everything in the household.
+	PermView Permission = "view"
+	// PermEditMovements covers movements, income, recurring templates, card payments and comments.
+	PermEditMovements Permission = "movements:edit"
+	// PermEditBudgets covers budgets, budget items, categories and category groups.
+	PermEditBudgets Permission = "budgets:edit"
+	// PermEditAccounts covers accounts, payment methods, credit cards and pockets.
+	PermEditAccounts Permission = "accounts:edit"
+	// PermManageContacts covers creating, editing, linking and deleting contacts.
+	PermManageContacts Permission = "contacts:manage"
+	// PermEditHousehold covers renaming the household and adding registered users to it.
+	PermEditHousehold Permission = "household:edit"
+	// PermManageMembers covers invitations, removing members and changing roles.
+	PermManageMembers Permission = "members:manage"
+	// PermManageHousehold covers deleting the household and its webhooks.
+	PermManageHousehold Permission = "household:manage"
+)
+
+// rolePermissions is the permissions matrix.
+var rolePermissions = map[HouseholdRole][]Permission{
+	RoleOwner: {
+		PermView, PermEditMovements, PermEditBudgets, PermEditAccounts,
+		PermManageContacts, PermEditHousehold, PermManageMembers, PermManageHousehold,
+	},
+	RoleMember: {
+		PermView, PermEditMovements, PermEditBudgets, PermEditAccounts,
+		PermManageContacts, PermEditHousehold,
+	},
+	RoleBookkeeper: {
+		PermView, PermEditMovements,
+	},
+	RoleViewer: {
+		PermView,
+	},
+}
+
+// Permissions returns everything the role may do.
+func (r HouseholdRole) Permissions() []Permission {
+	return rolePermissions[r]
+}
+
+// Can reports whether the role grants p.
+func (r HouseholdRole) Can(p Permission) bool {
+	for _, granted := range rolePermissions[r] {
+		if granted == p {
+			return true
+		}
+	}
+	return false
+}
+
+// MemberGetter looks up a user's membership in a household.
+type MemberGetter interface {
+	GetMemberByUserID(ctx context.Context, householdID, userID string) (*HouseholdMember, error)
+}
+
+// SelectedMemberGetter also resolves the household a request acts on.
+type SelectedMemberGetter interface {
+	MemberGetter
+	ResolveHouseholdID(ctx context.Context, userID string) (string, error)
+}
+
+// Authorize is the central authorization check: it returns the user's
+// membership when their role in householdID grants perm, and ErrNotAuthorized
+// when they are not a member or their role does not allow it.
+func Authorize(ctx context.Context, repo MemberGetter, householdID, userID string, perm Permission) (*HouseholdMember, error) {
+	member, err := repo.GetMemberByUserID(ctx, householdID, userID)
+	if err != nil {
+		if errors.Is(err, ErrMemberNotFound) {
+			return nil, ErrNotAuthorized
+		}
+		return nil, err
+	}
+	if !member.Role.Can(perm) {
+		return nil, ErrNotAuthorized
+	}
+	return member, nil
+}
+
+// AuthorizeSelected resolves the household the request acts on (see
+// ResolveHouseholdID) and checks the user's role there grants perm.
+func AuthorizeSelected(ctx context.Context, repo SelectedMemberGetter, userID string, perm Permission) (string, error) {
+	householdID, err := repo.ResolveHouseholdID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if _, err := Authorize(ctx, repo, householdID, userID, perm); err != nil {
+		return "", err
+	}
+	return householdID, nil
+}
@@ -0,0 +1,57 @@
+package households
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRolePermissions(t *testing.T) {
+	tests := []struct {
+		role HouseholdRole
+		perm Permission
+		want bool
+	}{
+		{RoleOwner, PermManageHousehold, true},
+		{RoleOwner, PermManageMembers, true},
+		{RoleMember, PermEditAccounts, true},
+		{RoleMember, PermManageMembers, false},
+		{RoleBookkeeper, PermEditMovements, true},
+		{RoleBookkeeper, PermEditAccounts, false},
+		{RoleBookkeeper, PermManageMembers, false},
+		{RoleViewer, PermView, true},
+		{RoleViewer, PermEditMovements, false},
+		{HouseholdRole("unknown"), PermView, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.role.Can(tt.perm); got != tt.want {
+			t.Errorf("%s.Can(%s) = %v, want %v", tt.role, tt.perm, got, tt.want)
+		}
+	}
+}
+
+func TestViewerCannotManageContacts(t *testing.T) {
+	repo := NewMockRepository()
+	userRepo := NewMockUserRepository()
+	svc := NewService(repo, userRepo, &MockCategoriesRepo{}, &MockAuditService{}, &MockEmailSender{})
+	ctx := context.Background()
+
+	owner := userRepo.AddTestUser("user-1", "owner@example.com", "Owner")
+	viewer := userRepo.AddTestUser("user-2", "viewer@example.com", "Viewer")
+
+	household, err := svc.CreateHousehold(ctx, &CreateHouseholdInput{Name: "Casa", UserID: owner.ID})
+	if err != nil {
+		t.Fatalf("CreateHousehold: %v", err)
+	}
+	repo.AddMember(ctx, household.ID, viewer.ID, RoleViewer)
+
+	_, err = svc.CreateContact(ctx, &CreateContactInput{HouseholdID: household.ID, Name: "Ana", UserID: viewer.ID})
+	if !errors.Is(err, ErrNotAuthorized) {
+		t.Errorf("CreateContact as viewer error = %v, want ErrNotAuthorized", err)
+	}
+
+	if _, err := svc.ListContacts(ctx, household.ID, viewer.ID); err != nil {
+		t.Errorf("ListContacts as viewer: %v", err)
+	}
+}
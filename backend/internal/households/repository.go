@@ -3,6 +3,7 @@ package households
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -527,7 +528,7 @@ func (r *Repository) FindContactByEmail(ctx context.Context, householdID, email
 // Caro (Jose's household member) also sees cross-household movements.
 func (r *Repository) FindLinkedContactsByHousehold(ctx context.Context, householdID string) ([]LinkedContact, error) {
 	query := `
-		SELECT DISTINCT c.id, c.household_id, h.name, c.name
+		SELECT DISTINCT c.id, c.household_id, h.name, c.name, c.linked_user_id
 		FROM contacts c
 		JOIN households h ON c.household_id = h.id
 		JOIN household_members hm ON hm.household_id = $1
@@ -545,7 +546,7 @@ func (r *Repository) FindLinkedContactsByHousehold(ctx context.Context, househol
 	var contacts []LinkedContact
 	for rows.Next() {
 		var lc LinkedContact
-		err := rows.Scan(&lc.ContactID, &lc.HouseholdID, &lc.HouseholdName, &lc.ContactName)
+		err := rows.Scan(&lc.ContactID, &lc.HouseholdID, &lc.HouseholdName, &lc.ContactName, &lc.LinkedUserID)
 		if err != nil {
 			return nil, err
 		}
@@ -554,16 +555,26 @@ func (r *Repository) FindLinkedContactsByHousehold(ctx context.Context, househol
 	return contacts, rows.Err()
 }
 
-// ListPendingLinkRequests lists all pending link requests for a user
+// ListPendingLinkRequests lists all pending link requests for a user, including
+// suggestions for contacts kept under the user's verified email
 func (r *Repository) ListPendingLinkRequests(ctx context.Context, userID string) ([]LinkRequest, error) {
 	rows, err := r.pool.Query(ctx, `
-		SELECT c.id, c.name, u.name, h.name, c.household_id, c.link_requested_at
+		SELECT c.id, c.name, u.name, h.name, c.household_id, c.link_requested_at, NULL
 		FROM contacts c
 		JOIN households h ON c.household_id = h.id
 		JOIN users u ON u.id = c.link_requested_by_user_id
 		WHERE c.linked_user_id = $1
 		  AND c.link_status = 'PENDING'
-		ORDER BY c.link_requested_at DESC
+		UNION ALL
+		SELECT c.id, c.name, '', h.name, c.household_id, s.created_at, 'email'
+		FROM contact_link_suggestions s
+		JOIN contacts c ON c.id = s.contact_id
+		JOIN households h ON c.household_id = h.id
+		WHERE s.user_id = $1
+		  AND s.status = 'PENDING'
+		  AND c.linked_user_id IS NULL
+		  AND c.is_active = true
+		ORDER BY 6 DESC
 	`, userID)
 	if err != nil {
 		return nil, err
@@ -573,7 +584,7 @@ func (r *Repository) ListPendingLinkRequests(ctx context.Context, userID string)
 	var requests []LinkRequest
 	for rows.Next() {
 		var lr LinkRequest
-		err := rows.Scan(&lr.ContactID, &lr.ContactName, &lr.RequesterName, &lr.HouseholdName, &lr.HouseholdID, &lr.RequestedAt)
+		err := rows.Scan(&lr.ContactID, &lr.ContactName, &lr.RequesterName, &lr.HouseholdName, &lr.HouseholdID, &lr.RequestedAt, &lr.MatchedBy)
 		if err != nil {
 			return nil, err
 		}
@@ -589,6 +600,11 @@ func (r *Repository) CountPendingLinkRequests(ctx context.Context, userID string
 		SELECT (
 			SELECT COUNT(*) FROM contacts
 			WHERE linked_user_id = $1 AND link_status = 'PENDING'
+		) + (
+			SELECT COUNT(*) FROM contact_link_suggestions s
+			JOIN contacts c ON c.id = s.contact_id
+			WHERE s.user_id = $1 AND s.status = 'PENDING'
+			  AND c.linked_user_id IS NULL AND c.is_active = true
 		) + (
 			SELECT COUNT(*) FROM contacts c
 			JOIN household_members hm ON hm.household_id = c.household_id
@@ -650,6 +666,110 @@ func (r *Repository) UnlinkContact(ctx context.Context, contactID string) error
 	return nil
 }
 
+// SuggestLinksForUser records a link suggestion for every unlinked contact in
+// households the user does not belong to whose email matches. The contacts
+// themselves are left untouched. Returns the contacts suggested for the first
+// time; earlier suggestions, answered or not, are not repeated.
+func (r *Repository) SuggestLinksForUser(ctx context.Context, userID, email string) ([]*Contact, error) {
+	rows, err := r.pool.Query(ctx, `
+		WITH suggested AS (
+			INSERT INTO contact_link_suggestions (contact_id, user_id)
+			SELECT c.id, $1
+			FROM contacts c
+			WHERE c.linked_user_id IS NULL
+			  AND c.link_status = 'NONE'
+			  AND c.is_active = true
+			  AND LOWER(c.email) = $2
+			  AND NOT EXISTS (
+			    SELECT 1 FROM household_members hm
+			    WHERE hm.household_id = c.household_id AND hm.user_id = $1
+			  )
+			ON CONFLICT (contact_id, user_id) DO NOTHING
+			RETURNING contact_id
+		)
+		SELECT c.id, c.household_id, c.name
+		FROM suggested s
+		JOIN contacts c ON c.id = s.contact_id
+	`, userID, strings.ToLower(email))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var contacts []*Contact
+	for rows.Next() {
+		c := &Contact{LinkStatus: "NONE"}
+		if err := rows.Scan(&c.ID, &c.HouseholdID, &c.Name); err != nil {
+			return nil, err
+		}
+		contacts = append(contacts, c)
+	}
+	return contacts, rows.Err()
+}
+
+// HasPendingLinkSuggestion reports whether the user has an unanswered
+// suggestion for the contact
+func (r *Repository) HasPendingLinkSuggestion(ctx context.Context, contactID, userID string) (bool, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM contact_link_suggestions
+			WHERE contact_id = $1 AND user_id = $2 AND status = 'PENDING'
+		)
+	`, contactID, userID).Scan(&exists)
+	return exists, err
+}
+
+// AcceptLinkSuggestion links the suggested contact to the user and marks the
+// suggestion accepted. Fails with ErrLinkRequestNotPending when the contact
+// was linked to someone else in the meantime.
+func (r *Repository) AcceptLinkSuggestion(ctx context.Context, contactID, userID string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE contacts
+		SET linked_user_id = $2, link_requested_by_user_id = NULL, link_status = 'ACCEPTED',
+		    link_requested_at = NOW(), link_responded_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND linked_user_id IS NULL AND link_status = 'NONE'
+	`, contactID, userID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrLinkRequestNotPending
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE contact_link_suggestions
+		SET status = 'ACCEPTED', responded_at = NOW()
+		WHERE contact_id = $1 AND user_id = $2
+	`, contactID, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// RejectLinkSuggestion marks a pending suggestion rejected so it is not offered again
+func (r *Repository) RejectLinkSuggestion(ctx context.Context, contactID, userID string) error {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE contact_link_suggestions
+		SET status = 'REJECTED', responded_at = NOW()
+		WHERE contact_id = $1 AND user_id = $2 AND status = 'PENDING'
+	`, contactID, userID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrLinkRequestNotPending
+	}
+	return nil
+}
+
 // SetWasUnlinkedAt sets was_unlinked_at on a contact (notification for the other side)
 func (r *Repository) SetWasUnlinkedAt(ctx context.Context, contactID string) error {
 	_, err := r.pool.Exec(ctx, `
@@ -703,13 +823,14 @@ func (r *Repository) FindContactByLinkedUserID(ctx context.Context, householdID,
 }
 
 // CreateInvitation creates a new household invitation
-func (r *Repository) CreateInvitation(ctx context.Context, householdID, email, token, invitedBy string) (*HouseholdInvitation, error) {
+func (r *Repository) CreateInvitation(ctx context.Context, householdID, email, token, invitedBy string, expiresAt time.Time) (*HouseholdInvitation, error) {
 	var inv HouseholdInvitation
 	err := r.pool.QueryRow(ctx, `
-		INSERT INTO household_invitations (household_id, email, token, invited_by)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id, household_id, email, token, invited_by, expires_at, accepted_at, created_at
-	`, householdID, email, token, invitedBy).Scan(
+		INSERT INTO household_invitations (household_id, email, token, invited_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, household_id, email, token, invited_by, expires_at, accepted_at,
+		          revoked_at, last_sent_at, send_count, created_at
+	`, householdID, email, token, invitedBy, expiresAt).Scan(
 		&inv.ID,
 		&inv.HouseholdID,
 		&inv.Email,
@@ -717,10 +838,13 @@ func (r *Repository) CreateInvitation(ctx context.Context, householdID, email, t
 		&inv.InvitedBy,
 		&inv.ExpiresAt,
 		&inv.AcceptedAt,
+		&inv.RevokedAt,
+		&inv.LastSentAt,
+		&inv.SendCount,
 		&inv.CreatedAt,
 	)
 	if err != nil {
-		// Check for unique constraint violation (duplicate invitation)
+		// Check for unique constraint violation (duplicate open invitation)
 		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" {
 			return nil, errors.New("invitation already exists for this email")
 		}
@@ -729,19 +853,20 @@ func (r *Repository) CreateInvitation(ctx context.Context, householdID, email, t
 	return &inv, nil
 }
 
-// GetInvitationByToken retrieves an invitation by token with household info
-func (r *Repository) GetInvitationByToken(ctx context.Context, token string) (*HouseholdInvitation, error) {
+// getInvitation retrieves a single invitation with household info, matching column = value
+func (r *Repository) getInvitation(ctx context.Context, column, value string) (*HouseholdInvitation, error) {
 	var inv HouseholdInvitation
 	err := r.pool.QueryRow(ctx, `
 		SELECT 
 			i.id, i.household_id, i.email, i.token, i.invited_by, 
-			i.expires_at, i.accepted_at, i.created_at,
+			i.expires_at, i.accepted_at, i.revoked_at, i.last_sent_at, i.send_count,
+			i.created_at,
 			h.name, u.name
 		FROM household_invitations i
 		INNER JOIN households h ON i.household_id = h.id
 		INNER JOIN users u ON i.invited_by = u.id
-		WHERE i.token = $1
-	`, token).Scan(
+		WHERE i.`+column+` = $1
+	`, value).Scan(
 		&inv.ID,
 		&inv.HouseholdID,
 		&inv.Email,
@@ -749,6 +874,9 @@ func (r *Repository) GetInvitationByToken(ctx context.Context, token string) (*H
 		&inv.InvitedBy,
 		&inv.ExpiresAt,
 		&inv.AcceptedAt,
+		&inv.RevokedAt,
+		&inv.LastSentAt,
+		&inv.SendCount,
 		&inv.CreatedAt,
 		&inv.HouseholdName,
 		&inv.InviterName,
@@ -762,12 +890,41 @@ func (r *Repository) GetInvitationByToken(ctx context.Context, token string) (*H
 	return &inv, nil
 }
 
+// GetInvitationByID retrieves an invitation by ID with household info
+func (r *Repository) GetInvitationByID(ctx context.Context, id string) (*HouseholdInvitation, error) {
+	return r.getInvitation(ctx, "id", id)
+}
+
+// GetInvitationByToken retrieves an invitation by token with household info
+func (r *Repository) GetInvitationByToken(ctx context.Context, token string) (*HouseholdInvitation, error) {
+	return r.getInvitation(ctx, "token", token)
+}
+
+// FindOpenInvitationByEmail finds the invitation for email that is neither
+// accepted nor revoked (it may have expired). Returns nil if there is none.
+func (r *Repository) FindOpenInvitationByEmail(ctx context.Context, householdID, email string) (*HouseholdInvitation, error) {
+	var id string
+	err := r.pool.QueryRow(ctx, `
+		SELECT id
+		FROM household_invitations
+		WHERE household_id = $1 AND email = $2
+		  AND accepted_at IS NULL AND revoked_at IS NULL
+	`, householdID, email).Scan(&id)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.GetInvitationByID(ctx, id)
+}
+
 // AcceptInvitation marks an invitation as accepted
 func (r *Repository) AcceptInvitation(ctx context.Context, id string) error {
 	result, err := r.pool.Exec(ctx, `
 		UPDATE household_invitations
 		SET accepted_at = NOW()
-		WHERE id = $1 AND accepted_at IS NULL
+		WHERE id = $1 AND accepted_at IS NULL AND revoked_at IS NULL
 	`, id)
 	if err != nil {
 		return err
@@ -778,16 +935,51 @@ func (r *Repository) AcceptInvitation(ctx context.Context, id string) error {
 	return nil
 }
 
-// ListPendingInvitations retrieves all pending invitations for a household
+// RotateInvitationToken replaces the token of an open invitation, so the
+// previous link stops working, and restarts its expiry
+func (r *Repository) RotateInvitationToken(ctx context.Context, id, token string, expiresAt time.Time) (*HouseholdInvitation, error) {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE household_invitations
+		SET token = $2, expires_at = $3, last_sent_at = NOW(), send_count = send_count + 1
+		WHERE id = $1 AND accepted_at IS NULL AND revoked_at IS NULL
+	`, id, token, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	if result.RowsAffected() == 0 {
+		return nil, ErrInvitationNotFound
+	}
+	return r.GetInvitationByID(ctx, id)
+}
+
+// RevokeInvitation marks an open invitation as revoked
+func (r *Repository) RevokeInvitation(ctx context.Context, id string) error {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE household_invitations
+		SET revoked_at = NOW()
+		WHERE id = $1 AND accepted_at IS NULL AND revoked_at IS NULL
+	`, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrInvitationNotFound
+	}
+	return nil
+}
+
+// ListPendingInvitations retrieves the open (not accepted, not revoked)
+// invitations for a household, including expired ones
 func (r *Repository) ListPendingInvitations(ctx context.Context, householdID string) ([]*HouseholdInvitation, error) {
 	rows, err := r.pool.Query(ctx, `
 		SELECT 
 			i.id, i.household_id, i.email, i.token, i.invited_by, 
-			i.expires_at, i.accepted_at, i.created_at,
+			i.expires_at, i.accepted_at, i.revoked_at, i.last_sent_at, i.send_count,
+			i.created_at,
 			u.name
 		FROM household_invitations i
 		INNER JOIN users u ON i.invited_by = u.id
-		WHERE i.household_id = $1 AND i.accepted_at IS NULL
+		WHERE i.household_id = $1 AND i.accepted_at IS NULL AND i.revoked_at IS NULL
 		ORDER BY i.created_at DESC
 	`, householdID)
 	if err != nil {
@@ -795,7 +987,7 @@ func (r *Repository) ListPendingInvitations(ctx context.Context, householdID str
 	}
 	defer rows.Close()
 
-	var invitations []*HouseholdInvitation
+	invitations := make([]*HouseholdInvitation, 0)
 	for rows.Next() {
 		var inv HouseholdInvitation
 		err := rows.Scan(
@@ -806,6 +998,9 @@ func (r *Repository) ListPendingInvitations(ctx context.Context, householdID str
 			&inv.InvitedBy,
 			&inv.ExpiresAt,
 			&inv.AcceptedAt,
+			&inv.RevokedAt,
+			&inv.LastSentAt,
+			&inv.SendCount,
 			&inv.CreatedAt,
 			&inv.InviterName,
 		)
@@ -822,19 +1017,57 @@ func (r *Repository) ListPendingInvitations(ctx context.Context, householdID str
 	return invitations, nil
 }
 
-// GetUserHouseholdID gets the household ID for a user (first household they belong to)
+// GetUserHouseholdID gets the default household ID for a user (the first one
+// they joined). It ignores the request's selection, so it is meant for looking
+// up other users; use ResolveHouseholdID for the caller's own household.
 func (r *Repository) GetUserHouseholdID(ctx context.Context, userID string) (string, error) {
 	var householdID string
 	err := r.pool.QueryRow(ctx, `
 		SELECT household_id 
 		FROM household_members 
 		WHERE user_id = $1
+		ORDER BY joined_at, household_id
 		LIMIT 1
 	`, userID).Scan(&householdID)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return "", errors.New("user has no household")
+			return "", ErrNoHousehold
+		}
+		return "", err
+	}
+
+	return householdID, nil
+}
+
+// ResolveHouseholdID returns the household the request acts on: the one
+// selected through the X-Household-ID header or path when the user belongs to
+// it, otherwise the household the user joined first.
+func (r *Repository) ResolveHouseholdID(ctx context.Context, userID string) (string, error) {
+	var selected *string
+	if id := SelectedHouseholdID(ctx); id != "" {
+		if !IsValidHouseholdID(id) {
+			return "", ErrNotAuthorized
+		}
+		selected = &id
+	}
+
+	var householdID string
+	err := r.pool.QueryRow(ctx, `
+		SELECT household_id
+		FROM household_members
+		WHERE user_id = $1
+		  AND ($2::uuid IS NULL OR household_id = $2::uuid)
+		ORDER BY joined_at, household_id
+		LIMIT 1
+	`, userID, selected).Scan(&householdID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			if selected != nil {
+				return "", ErrNotAuthorized
+			}
+			return "", ErrNoHousehold
 		}
 		return "", err
 	}
@@ -842,6 +1075,33 @@ func (r *Repository) GetUserHouseholdID(ctx context.Context, userID string) (str
 	return householdID, nil
 }
 
+// ListMemberships returns every household the user belongs to with their role,
+// oldest membership first.
+func (r *Repository) ListMemberships(ctx context.Context, userID string) ([]*Membership, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT h.id, h.name, hm.role, hm.joined_at
+		FROM household_members hm
+		INNER JOIN households h ON h.id = hm.household_id
+		WHERE hm.user_id = $1
+		ORDER BY hm.joined_at, h.id
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var memberships []*Membership
+	for rows.Next() {
+		var m Membership
+		if err := rows.Scan(&m.HouseholdID, &m.HouseholdName, &m.Role, &m.JoinedAt); err != nil {
+			return nil, err
+		}
+		memberships = append(memberships, &m)
+	}
+
+	return memberships, rows.Err()
+}
+
 // IsUserMember checks if a user is a member of a household
 func (r *Repository) IsUserMember(ctx context.Context, householdID, userID string) (bool, error) {
 	var exists bool
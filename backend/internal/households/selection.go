@@ -0,0 +1,58 @@
+package households
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// HouseholdIDHeader lets clients choose which of their households a request acts on.
+const HouseholdIDHeader = "X-Household-ID"
+
+type selectionContextKey struct{}
+
+// WithSelectedHousehold stores the household a request acts on.
+func WithSelectedHousehold(ctx context.Context, householdID string) context.Context {
+	return context.WithValue(ctx, selectionContextKey{}, householdID)
+}
+
+// SelectedHouseholdID returns the household chosen for the request, or "" when
+// the client did not choose one.
+func SelectedHouseholdID(ctx context.Context) string {
+	id, _ := ctx.Value(selectionContextKey{}).(string)
+	return id
+}
+
+// SelectionFromRequest returns the household a request targets. Routes under
+// /households/{id} select it through the path; any other route may send the
+// X-Household-ID header. fromHeader tells which of the two was used.
+func SelectionFromRequest(r *http.Request) (householdID string, fromHeader bool) {
+	if rest, ok := strings.CutPrefix(r.URL.Path, "/households/"); ok {
+		id, _, _ := strings.Cut(rest, "/")
+		if IsValidHouseholdID(id) {
+			return id, false
+		}
+	}
+	householdID = strings.TrimSpace(r.Header.Get(HouseholdIDHeader))
+	return householdID, householdID != ""
+}
+
+// IsValidHouseholdID reports whether s has the canonical 8-4-4-4-12 hex UUID form.
+func IsValidHouseholdID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i, c := range s {
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F') {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,38 @@
+package households
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSelectionFromRequest(t *testing.T) {
+	const pathID = "6f1c2a3b-4d5e-4f60-8a7b-9c0d1e2f3a4b"
+	const headerID = "0a1b2c3d-4e5f-4a6b-8c7d-8e9f0a1b2c3d"
+
+	tests := []struct {
+		name           string
+		path           string
+		header         string
+		wantID         string
+		wantFromHeader bool
+	}{
+		{name: "no selection", path: "/movements"},
+		{name: "header", path: "/movements", header: headerID, wantID: headerID, wantFromHeader: true},
+		{name: "path", path: "/households/" + pathID + "/contacts", wantID: pathID},
+		{name: "path wins over header", path: "/households/" + pathID, header: headerID, wantID: pathID},
+		{name: "non-id path segment falls back to header", path: "/households/invitations/accept", header: headerID, wantID: headerID, wantFromHeader: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.path, nil)
+			if tt.header != "" {
+				r.Header.Set(HouseholdIDHeader, tt.header)
+			}
+			id, fromHeader := SelectionFromRequest(r)
+			if id != tt.wantID || fromHeader != tt.wantFromHeader {
+				t.Errorf("SelectionFromRequest() = (%q, %v), want (%q, %v)", id, fromHeader, tt.wantID, tt.wantFromHeader)
+			}
+		})
+	}
+}
@@ -5,12 +5,14 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
 	"github.com/blanquicet/conti/backend/internal/audit"
 	"github.com/blanquicet/conti/backend/internal/auth"
 	"github.com/blanquicet/conti/backend/internal/email"
+	"github.com/blanquicet/conti/backend/internal/notifications"
 )
 
 // DefaultCategoriesCreator creates default categories for a new household
@@ -25,6 +27,15 @@ type Service struct {
 	categoriesRepo DefaultCategoriesCreator
 	auditService   audit.Service
 	emailSender    email.Sender
+	notifier       notifications.Publisher
+
+	invitationTTL     time.Duration
+	invitationLimiter InvitationLimiter
+}
+
+// InvitationLimiter throttles invitation emails per household
+type InvitationLimiter interface {
+	Allow(key string) bool
 }
 
 // NewService creates a new household service
@@ -35,7 +46,72 @@ func NewService(repo HouseholdRepository, userRepo auth.UserRepository, categori
 		categoriesRepo: categoriesRepo,
 		auditService:   auditService,
 		emailSender:    emailSender,
+		invitationTTL:  DefaultInvitationTTL,
+	}
+}
+
+// SetInvitationTTL sets how long new and resent invitations stay valid
+func (s *Service) SetInvitationTTL(ttl time.Duration) {
+	if ttl > 0 {
+		s.invitationTTL = ttl
+	}
+}
+
+// SetInvitationLimiter sets the limiter applied to invitation emails, keyed by household
+func (s *Service) SetInvitationLimiter(limiter InvitationLimiter) {
+	s.invitationLimiter = limiter
+}
+
+// allowInvitationEmail reports whether another invitation email may be sent for the household
+func (s *Service) allowInvitationEmail(householdID string) bool {
+	return s.invitationLimiter == nil || s.invitationLimiter.Allow(householdID)
+}
+
+// sendInvitationEmail emails an invitation link; failures are not fatal since
+// the token is still valid and can be shared manually
+func (s *Service) sendInvitationEmail(ctx context.Context, invitation *HouseholdInvitation, householdName, inviterUserID string) {
+	if s.emailSender == nil {
+		return
+	}
+	inviterName := ""
+	if inviter, err := s.userRepo.GetByID(ctx, inviterUserID); err == nil {
+		inviterName = inviter.Name
+		if inviterName == "" {
+			inviterName = inviter.Email
+		}
+	}
+	_ = s.emailSender.SendHouseholdInvitation(ctx, invitation.Email, invitation.Token, householdName, inviterName)
+}
+
+// SetNotifier sets the publisher used for in-app link request notifications
+func (s *Service) SetNotifier(notifier notifications.Publisher) {
+	s.notifier = notifier
+}
+
+// notifyLink publishes a link notification to a single user about a contact
+func (s *Service) notifyLink(ctx context.Context, recipientUserID string, notificationType notifications.Type, title string, contact *Contact) {
+	if s.notifier == nil || recipientUserID == "" {
+		return
+	}
+	s.notifier.Publish(ctx, &notifications.PublishInput{
+		UserIDs:      []string{recipientUserID},
+		Type:         notificationType,
+		Title:        title,
+		ResourceType: notifications.StringPtr("contact"),
+		ResourceID:   &contact.ID,
+		Data: map[string]interface{}{
+			"contact_household_id": contact.HouseholdID,
+		},
+	})
+}
+
+// userName returns the user's name, or an empty string if not found
+func (s *Service) userName(ctx context.Context, userID string) string {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return ""
 	}
+	return user.Name
 }
 
 // CreateHouseholdInput contains the data needed to create a household
@@ -115,11 +191,8 @@ func (s *Service) CreateHousehold(ctx context.Context, input *CreateHouseholdInp
 // GetHousehold retrieves a household if the user is a member
 func (s *Service) GetHousehold(ctx context.Context, householdID, userID string) (*Household, error) {
 	// Check user is a member
-	_, err := s.repo.GetMemberByUserID(ctx, householdID, userID)
+	_, err := Authorize(ctx, s.repo, householdID, userID, PermView)
 	if err != nil {
-		if errors.Is(err, ErrMemberNotFound) {
-			return nil, ErrNotAuthorized
-		}
 		return nil, err
 	}
 
@@ -157,12 +230,9 @@ func (s *Service) UpdateHousehold(ctx context.Context, input *UpdateHouseholdInp
 		return nil, err
 	}
 
-	// Check user is a member
-	_, err := s.repo.GetMemberByUserID(ctx, input.HouseholdID, input.UserID)
+	// Check user may edit the household
+	_, err := Authorize(ctx, s.repo, input.HouseholdID, input.UserID, PermEditHousehold)
 	if err != nil {
-		if errors.Is(err, ErrMemberNotFound) {
-			return nil, ErrNotAuthorized
-		}
 		return nil, err
 	}
 
@@ -203,17 +273,11 @@ func (s *Service) UpdateHousehold(ctx context.Context, input *UpdateHouseholdInp
 
 // DeleteHousehold deletes a household (owner only)
 func (s *Service) DeleteHousehold(ctx context.Context, householdID, userID string) error {
-	// Check user is an owner
-	member, err := s.repo.GetMemberByUserID(ctx, householdID, userID)
+	// Check user may delete the household (owners only)
+	_, err := Authorize(ctx, s.repo, householdID, userID, PermManageHousehold)
 	if err != nil {
-		if errors.Is(err, ErrMemberNotFound) {
-			return ErrNotAuthorized
-		}
 		return err
 	}
-	if member.Role != RoleOwner {
-		return ErrNotAuthorized
-	}
 
 	// Get household for audit log before deletion
 	household, err := s.repo.GetByID(ctx, householdID)
@@ -284,12 +348,9 @@ func (s *Service) AddMember(ctx context.Context, input *AddMemberInput) (*Househ
 		return nil, err
 	}
 
-	// Check requester is a member
-	_, err := s.repo.GetMemberByUserID(ctx, input.HouseholdID, input.UserID)
+	// Check user may edit the household
+	_, err := Authorize(ctx, s.repo, input.HouseholdID, input.UserID, PermEditHousehold)
 	if err != nil {
-		if errors.Is(err, ErrMemberNotFound) {
-			return nil, ErrNotAuthorized
-		}
 		return nil, err
 	}
 
@@ -346,12 +407,9 @@ func (s *Service) RemoveMember(ctx context.Context, input *RemoveMemberInput) er
 		return errors.New("household ID, member ID, and user ID are required")
 	}
 
-	// Get requester's membership
-	requester, err := s.repo.GetMemberByUserID(ctx, input.HouseholdID, input.UserID)
+	// Check user is a member
+	requester, err := Authorize(ctx, s.repo, input.HouseholdID, input.UserID, PermView)
 	if err != nil {
-		if errors.Is(err, ErrMemberNotFound) {
-			return ErrNotAuthorized
-		}
 		return err
 	}
 
@@ -362,9 +420,9 @@ func (s *Service) RemoveMember(ctx context.Context, input *RemoveMemberInput) er
 	}
 
 	// Check authorization:
-	// - Owners can remove anyone
-	// - Members can only remove themselves
-	if requester.Role != RoleOwner && input.MemberID != input.UserID {
+	// - Roles that manage members (owners) can remove anyone
+	// - Everyone else can only remove themselves
+	if !requester.Role.Can(PermManageMembers) && input.MemberID != input.UserID {
 		return ErrNotAuthorized
 	}
 
@@ -426,17 +484,11 @@ func (s *Service) UpdateMemberRole(ctx context.Context, input *UpdateMemberRoleI
 		return nil, err
 	}
 
-	// Check requester is an owner
-	requester, err := s.repo.GetMemberByUserID(ctx, input.HouseholdID, input.UserID)
+	// Check user may manage members (owners only)
+	_, err := Authorize(ctx, s.repo, input.HouseholdID, input.UserID, PermManageMembers)
 	if err != nil {
-		if errors.Is(err, ErrMemberNotFound) {
-			return nil, ErrNotAuthorized
-		}
 		return nil, err
 	}
-	if requester.Role != RoleOwner {
-		return nil, ErrNotAuthorized
-	}
 
 	// Get current member info
 	member, err := s.repo.GetMemberByUserID(ctx, input.HouseholdID, input.MemberID)
@@ -444,8 +496,8 @@ func (s *Service) UpdateMemberRole(ctx context.Context, input *UpdateMemberRoleI
 		return nil, err
 	}
 
-	// If demoting yourself to member, ensure you're not the last owner
-	if input.MemberID == input.UserID && member.Role == RoleOwner && input.Role == RoleMember {
+	// If demoting yourself, ensure you're not the last owner
+	if input.MemberID == input.UserID && member.Role == RoleOwner && input.Role != RoleOwner {
 		count, err := s.repo.CountOwners(ctx, input.HouseholdID)
 		if err != nil {
 			return nil, err
@@ -458,14 +510,75 @@ func (s *Service) UpdateMemberRole(ctx context.Context, input *UpdateMemberRoleI
 	return s.repo.UpdateMemberRole(ctx, input.HouseholdID, input.MemberID, input.Role)
 }
 
+// TransferOwnershipInput contains the data needed to hand a household to another member
+type TransferOwnershipInput struct {
+	HouseholdID string
+	NewOwnerID  string // Member who becomes owner
+	UserID      string // Owner making the request
+}
+
+// TransferOwnership makes another member owner and the requesting owner a
+// regular member, so the previous owner can leave without deleting the household
+func (s *Service) TransferOwnership(ctx context.Context, input *TransferOwnershipInput) (*HouseholdMember, error) {
+	if input.HouseholdID == "" || input.NewOwnerID == "" || input.UserID == "" {
+		return nil, errors.New("household ID, new owner ID, and user ID are required")
+	}
+	if input.NewOwnerID == input.UserID {
+		return nil, ErrTransferToSelf
+	}
+
+	// Check user may hand over the household (owners only)
+	requester, err := Authorize(ctx, s.repo, input.HouseholdID, input.UserID, PermManageHousehold)
+	if err != nil {
+		return nil, err
+	}
+
+	newOwner, err := s.repo.GetMemberByUserID(ctx, input.HouseholdID, input.NewOwnerID)
+	if err != nil {
+		return nil, err
+	}
+	oldValues := map[string]interface{}{
+		"previous_owner_id":   input.UserID,
+		"new_owner_old_role": newOwner.Role,
+	}
+
+	if err := s.repo.TransferOwnership(ctx, input.HouseholdID, requester.UserID, input.NewOwnerID); err != nil {
+		s.auditService.LogAsync(ctx, &audit.LogInput{
+			Action:       audit.ActionHouseholdOwnershipTransferred,
+			ResourceType: "household",
+			ResourceID:   audit.StringPtr(input.HouseholdID),
+			UserID:       audit.StringPtr(input.UserID),
+			HouseholdID:  audit.StringPtr(input.HouseholdID),
+			Success:      false,
+			ErrorMessage: audit.StringPtr(err.Error()),
+		})
+		return nil, err
+	}
+
+	member, err := s.repo.GetMemberByUserID(ctx, input.HouseholdID, input.NewOwnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		Action:       audit.ActionHouseholdOwnershipTransferred,
+		ResourceType: "household",
+		ResourceID:   audit.StringPtr(input.HouseholdID),
+		UserID:       audit.StringPtr(input.UserID),
+		HouseholdID:  audit.StringPtr(input.HouseholdID),
+		Success:      true,
+		OldValues:    oldValues,
+		NewValues:    audit.StructToMap(member),
+	})
+
+	return member, nil
+}
+
 // GetMembers retrieves all members of a household
 func (s *Service) GetMembers(ctx context.Context, householdID, userID string) ([]*HouseholdMember, error) {
 	// Check user is a member
-	_, err := s.repo.GetMemberByUserID(ctx, householdID, userID)
+	_, err := Authorize(ctx, s.repo, householdID, userID, PermView)
 	if err != nil {
-		if errors.Is(err, ErrMemberNotFound) {
-			return nil, ErrNotAuthorized
-		}
 		return nil, err
 	}
 
@@ -518,11 +631,8 @@ func (i *CreateContactInput) Validate() error {
 // ListContacts lists all contacts for a household
 func (s *Service) ListContacts(ctx context.Context, householdID string, userID string) ([]*Contact, error) {
 	// Check user is a member
-	_, err := s.repo.GetMemberByUserID(ctx, householdID, userID)
+	_, err := Authorize(ctx, s.repo, householdID, userID, PermView)
 	if err != nil {
-		if errors.Is(err, ErrMemberNotFound) {
-			return nil, ErrNotAuthorized
-		}
 		return nil, err
 	}
 
@@ -535,12 +645,9 @@ func (s *Service) CreateContact(ctx context.Context, input *CreateContactInput)
 		return nil, err
 	}
 
-	// Check user is a member
-	_, err := s.repo.GetMemberByUserID(ctx, input.HouseholdID, input.UserID)
+	// Check user may manage contacts
+	_, err := Authorize(ctx, s.repo, input.HouseholdID, input.UserID, PermManageContacts)
 	if err != nil {
-		if errors.Is(err, ErrMemberNotFound) {
-			return nil, ErrNotAuthorized
-		}
 		return nil, err
 	}
 
@@ -554,9 +661,9 @@ func (s *Service) CreateContact(ctx context.Context, input *CreateContactInput)
 		LinkStatus:  "NONE",
 	}
 
-	// Only link if explicitly requested
-	if input.RequestLink && input.Email != nil && *input.Email != "" {
-		user, err := s.userRepo.GetByEmail(ctx, *input.Email)
+	// Only link if explicitly requested, and only between verified emails
+	if input.RequestLink && input.Email != nil && *input.Email != "" && s.requireVerifiedEmail(ctx, input.UserID) == nil {
+		user, err := s.getVerifiedUserByEmail(ctx, *input.Email)
 		if err == nil {
 			contact.LinkedUserID = &user.ID
 			contact.LinkStatus = "PENDING"
@@ -638,12 +745,9 @@ func (s *Service) UpdateContact(ctx context.Context, input *UpdateContactInput)
 		return nil, err
 	}
 
-	// Check user is a member
-	_, err := s.repo.GetMemberByUserID(ctx, input.HouseholdID, input.UserID)
+	// Check user may manage contacts
+	_, err := Authorize(ctx, s.repo, input.HouseholdID, input.UserID, PermManageContacts)
 	if err != nil {
-		if errors.Is(err, ErrMemberNotFound) {
-			return nil, ErrNotAuthorized
-		}
 		return nil, err
 	}
 
@@ -676,12 +780,9 @@ func (s *Service) UpdateContact(ctx context.Context, input *UpdateContactInput)
 
 // DeleteContact deletes a contact (member or owner)
 func (s *Service) DeleteContact(ctx context.Context, contactID, householdID, userID string) error {
-	// Check user is a member
-	_, err := s.repo.GetMemberByUserID(ctx, householdID, userID)
+	// Check user may manage contacts
+	_, err := Authorize(ctx, s.repo, householdID, userID, PermManageContacts)
 	if err != nil {
-		if errors.Is(err, ErrMemberNotFound) {
-			return ErrNotAuthorized
-		}
 		return err
 	}
 
@@ -697,15 +798,137 @@ func (s *Service) DeleteContact(ctx context.Context, contactID, householdID, use
 	return s.repo.DeleteContact(ctx, contactID)
 }
 
+// MergeContactsInput contains the data needed to fold one contact into another
+type MergeContactsInput struct {
+	HouseholdID     string
+	SourceContactID string // Duplicate that is removed
+	TargetContactID string // Contact that is kept
+	UserID          string // User making the request
+}
+
+// linkRank orders link statuses from weakest to strongest
+func linkRank(c *Contact) int {
+	if c.LinkedUserID == nil {
+		return 0
+	}
+	switch c.LinkStatus {
+	case "ACCEPTED":
+		return 3
+	case "PENDING":
+		return 2
+	default:
+		return 1
+	}
+}
+
+// MergeContacts re-points every movement, participant, template and budget
+// item of the source contact at the target contact and deletes the source.
+// The kept contact ends up with the strongest link of the two.
+func (s *Service) MergeContacts(ctx context.Context, input *MergeContactsInput) (*Contact, error) {
+	if input.SourceContactID == input.TargetContactID {
+		return nil, ErrContactMergeSame
+	}
+
+	// Check user may manage contacts
+	if _, err := Authorize(ctx, s.repo, input.HouseholdID, input.UserID, PermManageContacts); err != nil {
+		return nil, err
+	}
+
+	// Both contacts must belong to the household
+	source, err := s.repo.GetContact(ctx, input.SourceContactID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.repo.GetContact(ctx, input.TargetContactID)
+	if err != nil {
+		return nil, err
+	}
+	if source.HouseholdID != input.HouseholdID || target.HouseholdID != input.HouseholdID {
+		return nil, ErrNotAuthorized
+	}
+
+	// A contact can only stand for one user
+	if source.LinkedUserID != nil && target.LinkedUserID != nil && *source.LinkedUserID != *target.LinkedUserID {
+		return nil, ErrContactLinkConflict
+	}
+	carryLink := linkRank(source) > linkRank(target)
+
+	if err := s.repo.MergeContacts(ctx, source.ID, target.ID, carryLink); err != nil {
+		s.auditService.LogAsync(ctx, &audit.LogInput{
+			Action:       audit.ActionContactMerged,
+			ResourceType: "contact",
+			ResourceID:   audit.StringPtr(target.ID),
+			UserID:       audit.StringPtr(input.UserID),
+			HouseholdID:  audit.StringPtr(input.HouseholdID),
+			Success:      false,
+			ErrorMessage: audit.StringPtr(err.Error()),
+		})
+		return nil, err
+	}
+
+	merged, err := s.repo.GetContact(ctx, target.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		Action:       audit.ActionContactMerged,
+		ResourceType: "contact",
+		ResourceID:   audit.StringPtr(target.ID),
+		UserID:       audit.StringPtr(input.UserID),
+		HouseholdID:  audit.StringPtr(input.HouseholdID),
+		Success:      true,
+		OldValues: map[string]interface{}{
+			"source": audit.StructToMap(source),
+			"target": audit.StructToMap(target),
+		},
+		NewValues: audit.StructToMap(merged),
+	})
+
+	return merged, nil
+}
+
 // CheckEmailResult contains the result of checking an email
 type CheckEmailResult struct {
 	IsRegistered bool   `json:"is_registered"`
 	DisplayName  string `json:"display_name,omitempty"`
 }
 
-// CheckEmail checks if an email belongs to a registered user
-func (s *Service) CheckEmail(ctx context.Context, email string) (*CheckEmailResult, error) {
+// requireVerifiedEmail returns ErrEmailNotVerified unless the user verified
+// their email. Linking trusts emails, so unverified accounts cannot start it.
+func (s *Service) requireVerifiedEmail(ctx context.Context, userID string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.IsEmailVerified() {
+		return ErrEmailNotVerified
+	}
+	return nil
+}
+
+// getVerifiedUserByEmail finds the user owning an email. Accounts that have
+// not verified the address are treated as not registered, so nobody can be
+// linked to an email they merely typed in at sign-up.
+func (s *Service) getVerifiedUserByEmail(ctx context.Context, email string) (*auth.User, error) {
 	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsEmailVerified() {
+		return nil, auth.ErrUserNotFound
+	}
+	return user, nil
+}
+
+// CheckEmail checks if an email belongs to a registered user.
+// The requesting user must have a verified email.
+func (s *Service) CheckEmail(ctx context.Context, userID, email string) (*CheckEmailResult, error) {
+	if err := s.requireVerifiedEmail(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.getVerifiedUserByEmail(ctx, email)
 	if err != nil {
 		return &CheckEmailResult{IsRegistered: false}, nil
 	}
@@ -723,12 +946,13 @@ func (s *Service) RequestLink(ctx context.Context, userID, contactID string) err
 		return err
 	}
 
-	// Check user is a member of the contact's household
-	_, err = s.repo.GetMemberByUserID(ctx, contact.HouseholdID, userID)
+	// Check user may manage contacts
+	_, err = Authorize(ctx, s.repo, contact.HouseholdID, userID, PermManageContacts)
 	if err != nil {
-		if errors.Is(err, ErrMemberNotFound) {
-			return ErrNotAuthorized
-		}
+		return err
+	}
+
+	if err := s.requireVerifiedEmail(ctx, userID); err != nil {
 		return err
 	}
 
@@ -742,8 +966,8 @@ func (s *Service) RequestLink(ctx context.Context, userID, contactID string) err
 		return ErrContactAlreadyLinked
 	}
 
-	// Check email is registered
-	user, err := s.userRepo.GetByEmail(ctx, *contact.Email)
+	// Check email is registered and verified
+	user, err := s.getVerifiedUserByEmail(ctx, *contact.Email)
 	if err != nil {
 		return ErrEmailNotRegistered
 	}
@@ -761,6 +985,8 @@ func (s *Service) RequestLink(ctx context.Context, userID, contactID string) err
 		requester, rErr := s.userRepo.GetByID(ctx, userID)
 		if rErr == nil {
 			_ = s.emailSender.SendLinkRequest(ctx, *contact.Email, requester.Name, household.Name, "")
+			s.notifyLink(ctx, user.ID, notifications.TypeLinkRequested,
+				fmt.Sprintf("%s (%s) quiere vincularse contigo", requester.Name, household.Name), contact)
 		}
 	}
 
@@ -782,12 +1008,9 @@ func (s *Service) UnlinkContact(ctx context.Context, userID, contactID string) e
 		return err
 	}
 
-	// Check user is a member of the contact's household
-	_, err = s.repo.GetMemberByUserID(ctx, contact.HouseholdID, userID)
+	// Check user may manage contacts
+	_, err = Authorize(ctx, s.repo, contact.HouseholdID, userID, PermManageContacts)
 	if err != nil {
-		if errors.Is(err, ErrMemberNotFound) {
-			return ErrNotAuthorized
-		}
 		return err
 	}
 
@@ -830,12 +1053,9 @@ func (s *Service) DismissUnlinkBanner(ctx context.Context, userID, contactID str
 		return err
 	}
 
-	// Check user is a member of the contact's household
-	_, err = s.repo.GetMemberByUserID(ctx, contact.HouseholdID, userID)
+	// Check user is a member
+	_, err = Authorize(ctx, s.repo, contact.HouseholdID, userID, PermView)
 	if err != nil {
-		if errors.Is(err, ErrMemberNotFound) {
-			return ErrNotAuthorized
-		}
 		return err
 	}
 
@@ -848,17 +1068,11 @@ func (s *Service) PromoteContactToMember(ctx context.Context, input *PromoteCont
 		return nil, errors.New("contact ID, household ID, and user ID are required")
 	}
 
-	// Check requester is an owner
-	requester, err := s.repo.GetMemberByUserID(ctx, input.HouseholdID, input.UserID)
+	// Check user may manage members (owners only)
+	_, err := Authorize(ctx, s.repo, input.HouseholdID, input.UserID, PermManageMembers)
 	if err != nil {
-		if errors.Is(err, ErrMemberNotFound) {
-			return nil, ErrNotAuthorized
-		}
 		return nil, err
 	}
-	if requester.Role != RoleOwner {
-		return nil, ErrNotAuthorized
-	}
 
 	// Get contact
 	contact, err := s.repo.GetContact(ctx, input.ContactID)
@@ -929,17 +1143,11 @@ func (s *Service) CreateInvitation(ctx context.Context, input *CreateInvitationI
 		return nil, err
 	}
 
-	// Check requester is an owner
-	requester, err := s.repo.GetMemberByUserID(ctx, input.HouseholdID, input.UserID)
+	// Check user may manage members (owners only)
+	_, err := Authorize(ctx, s.repo, input.HouseholdID, input.UserID, PermManageMembers)
 	if err != nil {
-		if errors.Is(err, ErrMemberNotFound) {
-			return nil, ErrNotAuthorized
-		}
 		return nil, err
 	}
-	if requester.Role != RoleOwner {
-		return nil, ErrNotAuthorized
-	}
 
 	// Get household for name (needed for email)
 	household, err := s.repo.GetByID(ctx, input.HouseholdID)
@@ -947,12 +1155,6 @@ func (s *Service) CreateInvitation(ctx context.Context, input *CreateInvitationI
 		return nil, err
 	}
 
-	// Get inviter name for email
-	inviter, err := s.userRepo.GetByID(ctx, input.UserID)
-	if err != nil {
-		return nil, err
-	}
-
 	// Check if user is already a member of this household
 	invitedUser, _ := s.userRepo.GetByEmail(ctx, input.Email)
 	if invitedUser != nil {
@@ -964,6 +1166,26 @@ func (s *Service) CreateInvitation(ctx context.Context, input *CreateInvitationI
 		}
 	}
 
+	// An expired invitation for the same email is replaced by a new one;
+	// a still-valid one must be resent instead
+	existing, err := s.repo.FindOpenInvitationByEmail(ctx, input.HouseholdID, input.Email)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && !existing.IsExpired() {
+		return nil, errors.New("invitation already exists for this email")
+	}
+
+	if !s.allowInvitationEmail(input.HouseholdID) {
+		return nil, ErrInvitationRateLimited
+	}
+
+	if existing != nil {
+		if err := s.repo.RevokeInvitation(ctx, existing.ID); err != nil && !errors.Is(err, ErrInvitationNotFound) {
+			return nil, err
+		}
+	}
+
 	// Always create invitation and send email (whether user exists or not)
 	// Generate token
 	token, err := GenerateInvitationToken()
@@ -971,28 +1193,143 @@ func (s *Service) CreateInvitation(ctx context.Context, input *CreateInvitationI
 		return nil, err
 	}
 
-	invitation, err := s.repo.CreateInvitation(ctx, input.HouseholdID, input.Email, token, input.UserID)
+	invitation, err := s.repo.CreateInvitation(ctx, input.HouseholdID, input.Email, token, input.UserID, time.Now().Add(s.invitationTTL))
 	if err != nil {
 		return nil, err
 	}
+	invitation.Status = invitation.CurrentStatus()
 
-	// Send invitation email
-	if s.emailSender != nil {
-		inviterName := inviter.Name
-		if inviterName == "" {
-			inviterName = inviter.Email
-		}
-		if err := s.emailSender.SendHouseholdInvitation(ctx, input.Email, token, household.Name, inviterName); err != nil {
-			// Log the error but don't fail the invitation creation
-			// The token is still valid and can be shared manually
-			// TODO: Add proper logging here
-			_ = err
-		}
+	s.sendInvitationEmail(ctx, invitation, household.Name, input.UserID)
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		UserID:       audit.StringPtr(input.UserID),
+		Action:       audit.ActionHouseholdInvitationSent,
+		ResourceType: "household_invitation",
+		ResourceID:   audit.StringPtr(invitation.ID),
+		HouseholdID:  audit.StringPtr(input.HouseholdID),
+		Success:      true,
+		Metadata: map[string]interface{}{
+			"email":      invitation.Email,
+			"expires_at": invitation.ExpiresAt,
+		},
+	})
+
+	return invitation, nil
+}
+
+// ListInvitations returns the open invitations of a household with their
+// current status (pending or expired)
+func (s *Service) ListInvitations(ctx context.Context, householdID, userID string) ([]*HouseholdInvitation, error) {
+	if _, err := Authorize(ctx, s.repo, householdID, userID, PermManageMembers); err != nil {
+		return nil, err
+	}
+
+	invitations, err := s.repo.ListPendingInvitations(ctx, householdID)
+	if err != nil {
+		return nil, err
+	}
+	for _, inv := range invitations {
+		inv.Status = inv.CurrentStatus()
+	}
+	return invitations, nil
+}
+
+// getManagedInvitation loads an invitation that belongs to the household,
+// checking the user may manage its members
+func (s *Service) getManagedInvitation(ctx context.Context, householdID, invitationID, userID string) (*HouseholdInvitation, error) {
+	if _, err := Authorize(ctx, s.repo, householdID, userID, PermManageMembers); err != nil {
+		return nil, err
 	}
 
+	invitation, err := s.repo.GetInvitationByID(ctx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	if invitation.HouseholdID != householdID {
+		return nil, ErrInvitationNotFound
+	}
+	if invitation.IsAccepted() {
+		return nil, ErrInvitationAccepted
+	}
+	if invitation.IsRevoked() {
+		return nil, ErrInvitationRevoked
+	}
 	return invitation, nil
 }
 
+// ResendInvitation rotates the token of an open invitation, restarts its
+// expiry and emails the new link. The previous link stops working.
+func (s *Service) ResendInvitation(ctx context.Context, householdID, invitationID, userID string) (*HouseholdInvitation, error) {
+	invitation, err := s.getManagedInvitation(ctx, householdID, invitationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.allowInvitationEmail(householdID) {
+		return nil, ErrInvitationRateLimited
+	}
+
+	household, err := s.repo.GetByID(ctx, householdID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := GenerateInvitationToken()
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.RotateInvitationToken(ctx, invitation.ID, token, time.Now().Add(s.invitationTTL))
+	if err != nil {
+		return nil, err
+	}
+	updated.Status = updated.CurrentStatus()
+
+	s.sendInvitationEmail(ctx, updated, household.Name, userID)
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		UserID:       audit.StringPtr(userID),
+		Action:       audit.ActionHouseholdInvitationResent,
+		ResourceType: "household_invitation",
+		ResourceID:   audit.StringPtr(updated.ID),
+		HouseholdID:  audit.StringPtr(householdID),
+		Success:      true,
+		Metadata: map[string]interface{}{
+			"email":      updated.Email,
+			"send_count": updated.SendCount,
+			"expires_at": updated.ExpiresAt,
+		},
+	})
+
+	return updated, nil
+}
+
+// RevokeInvitation cancels an open invitation so its link can no longer be used
+func (s *Service) RevokeInvitation(ctx context.Context, householdID, invitationID, userID string) error {
+	invitation, err := s.getManagedInvitation(ctx, householdID, invitationID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.RevokeInvitation(ctx, invitation.ID); err != nil {
+		return err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		UserID:       audit.StringPtr(userID),
+		Action:       audit.ActionHouseholdInvitationRevoked,
+		ResourceType: "household_invitation",
+		ResourceID:   audit.StringPtr(invitation.ID),
+		HouseholdID:  audit.StringPtr(householdID),
+		Success:      true,
+		Metadata: map[string]interface{}{
+			"email": invitation.Email,
+		},
+	})
+
+	return nil
+}
+
 // AcceptInvitationByTokenInput contains the data needed to accept an invitation by token
 type AcceptInvitationByTokenInput struct {
 	Token  string
@@ -1026,12 +1363,15 @@ func (s *Service) AcceptInvitationByToken(ctx context.Context, input *AcceptInvi
 		return nil, err
 	}
 
-	// Check if invitation is still valid (not expired, not accepted)
+	// Check if invitation is still valid (not accepted, revoked or expired)
 	if invitation.IsAccepted() {
-		return nil, errors.New("invitation has already been accepted")
+		return nil, ErrInvitationAccepted
+	}
+	if invitation.IsRevoked() {
+		return nil, ErrInvitationRevoked
 	}
 	if invitation.IsExpired() {
-		return nil, errors.New("invitation has expired")
+		return nil, ErrInvitationExpired
 	}
 
 	// Get user to verify email matches
@@ -1071,6 +1411,48 @@ func (s *Service) AcceptInvitationByToken(ctx context.Context, input *AcceptInvi
 	return member, nil
 }
 
+// SuggestLinksForVerifiedUser offers a user whose email was just verified
+// links to the contacts other households keep with that email. Accepting one
+// makes that household's SPLIT and DEBT_PAYMENT movements with the contact
+// show up in the user's cross-household debts.
+func (s *Service) SuggestLinksForVerifiedUser(ctx context.Context, userID, email string) error {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return nil
+	}
+	contacts, err := s.repo.SuggestLinksForUser(ctx, userID, email)
+	if err != nil {
+		return err
+	}
+
+	for _, contact := range contacts {
+		householdName := ""
+		if household, err := s.repo.GetByID(ctx, contact.HouseholdID); err == nil {
+			householdName = household.Name
+		}
+		s.notifyLink(ctx, userID, notifications.TypeLinkRequested,
+			fmt.Sprintf("%s te tiene como contacto. ¿Quieres vincularte?", householdName), contact)
+	}
+
+	return nil
+}
+
+// checkLinkSuggestion reports whether the user may answer a suggestion for an
+// unlinked contact
+func (s *Service) checkLinkSuggestion(ctx context.Context, userID string, contact *Contact) error {
+	suggested, err := s.repo.HasPendingLinkSuggestion(ctx, contact.ID, userID)
+	if err != nil {
+		return err
+	}
+	if !suggested {
+		return ErrNotAuthorized
+	}
+	if contact.LinkStatus != "NONE" {
+		return ErrLinkRequestNotPending
+	}
+	return nil
+}
+
 // ListLinkRequests returns all pending link requests for a user
 func (s *Service) ListLinkRequests(ctx context.Context, userID string) ([]LinkRequest, error) {
 	return s.repo.ListPendingLinkRequests(ctx, userID)
@@ -1083,18 +1465,52 @@ func (s *Service) CountLinkRequests(ctx context.Context, userID string) (int, er
 
 // AcceptLinkRequest accepts a pending link request and creates a reciprocal contact
 func (s *Service) AcceptLinkRequest(ctx context.Context, userID, contactID, contactName string, existingContactID *string) error {
-	// 1. Verify the contact exists and is linked to this user
+	// 1. Verify the contact exists and is linked to this user, or suggested to
+	// them. A suggestion leaves the contact unlinked until it is accepted, so
+	// it is claimed first in case someone else linked the contact meanwhile.
 	contact, err := s.repo.GetContact(ctx, contactID)
 	if err != nil {
 		return err
 	}
 
-	if contact.LinkedUserID == nil || *contact.LinkedUserID != userID {
-		return ErrNotAuthorized
+	suggested := contact.LinkedUserID == nil
+	if suggested {
+		if err := s.checkLinkSuggestion(ctx, userID, contact); err != nil {
+			return err
+		}
+		if err := s.requireVerifiedEmail(ctx, userID); err != nil {
+			return err
+		}
+	} else {
+		if *contact.LinkedUserID != userID {
+			return ErrNotAuthorized
+		}
+		if contact.LinkStatus != "PENDING" {
+			return ErrLinkRequestNotPending
+		}
 	}
 
-	if contact.LinkStatus != "PENDING" {
-		return ErrLinkRequestNotPending
+	// The reciprocal contact goes into the household the acceptor selected, so
+	// their role there must allow managing contacts. Someone who just signed up
+	// may not have a household yet; the reciprocal contact is then skipped.
+	acceptorHouseholdID, err := AuthorizeSelected(ctx, s.repo, userID, PermManageContacts)
+	if err != nil && !errors.Is(err, ErrNoHousehold) {
+		return err
+	}
+	if existingContactID != nil && *existingContactID != "" {
+		existing, err := s.repo.GetContact(ctx, *existingContactID)
+		if err != nil {
+			return err
+		}
+		if existing.HouseholdID != acceptorHouseholdID {
+			return ErrNotAuthorized
+		}
+	}
+
+	if suggested {
+		if err := s.repo.AcceptLinkSuggestion(ctx, contactID, userID); err != nil {
+			return err
+		}
 	}
 
 	// 2. Find the requester's user ID (the owner of the household that created this contact)
@@ -1116,81 +1532,91 @@ func (s *Service) AcceptLinkRequest(ctx context.Context, userID, contactID, cont
 		return errors.New("could not find requester user")
 	}
 
-	// 3. Get the acceptor's household
-	acceptorHouseholdID, err := s.repo.GetUserHouseholdID(ctx, userID)
-	if err != nil {
-		return err
-	}
-
-	// 4. Create or update reciprocal contact in acceptor's household
-	if existingContactID != nil && *existingContactID != "" {
-		// Update existing contact with linked_user_id
-		// When accepting, the original requester is the one who initiated
-		err = s.repo.UpdateContactLinkedUser(ctx, *existingContactID, requesterUserID, requesterUserID, "ACCEPTED")
-		if err != nil {
-			return err
-		}
-	} else {
-		// Check if acceptor already has a contact linked to the requester
-		existingContacts, err := s.repo.ListContacts(ctx, acceptorHouseholdID)
-		if err != nil {
-			return err
-		}
-		var alreadyLinked bool
-		for _, c := range existingContacts {
-			if c.LinkedUserID != nil && *c.LinkedUserID == requesterUserID {
-				// Already have a reciprocal contact — just update its status
-				err = s.repo.UpdateContactLinkStatus(ctx, c.ID, "ACCEPTED")
-				if err != nil {
-					return err
-				}
-				alreadyLinked = true
-				break
+	// 3. Create or update reciprocal contact in acceptor's household
+	if acceptorHouseholdID != "" {
+		if existingContactID != nil && *existingContactID != "" {
+			// Update existing contact with linked_user_id
+			// When accepting, the original requester is the one who initiated
+			err = s.repo.UpdateContactLinkedUser(ctx, *existingContactID, requesterUserID, requesterUserID, "ACCEPTED")
+			if err != nil {
+				return err
 			}
-		}
-
-		if !alreadyLinked {
-			// Get requester's email for the new contact
-			requester, err := s.userRepo.GetByID(ctx, requesterUserID)
+		} else {
+			// Check if acceptor already has a contact linked to the requester
+			existingContacts, err := s.repo.ListContacts(ctx, acceptorHouseholdID)
 			if err != nil {
 				return err
 			}
-
-			// Use provided name or default to requester's member name
-			name := contactName
-			if name == "" {
-				// Find requester's display name from their household
-				for _, m := range members {
-					if m.UserID == requesterUserID {
-						name = m.UserName
-						break
+			var alreadyLinked bool
+			for _, c := range existingContacts {
+				if c.LinkedUserID != nil && *c.LinkedUserID == requesterUserID {
+					// Already have a reciprocal contact — just update its status
+					err = s.repo.UpdateContactLinkStatus(ctx, c.ID, "ACCEPTED")
+					if err != nil {
+						return err
 					}
+					alreadyLinked = true
+					break
 				}
 			}
-			if name == "" {
-				name = contact.Name // last resort: name the requester gave this contact
-			}
 
-			now := time.Now()
-			newContact := &Contact{
-				HouseholdID:     acceptorHouseholdID,
-				Name:            name,
-				Email:           &requester.Email,
-				LinkedUserID:    &requesterUserID,
-				LinkStatus:      "ACCEPTED",
-				LinkRequestedAt: &now,
-				LinkRespondedAt: &now,
-				IsActive:        true,
-			}
-			_, err = s.repo.CreateContact(ctx, newContact)
-			if err != nil {
-				return err
+			if !alreadyLinked {
+				// Get requester's email for the new contact
+				requester, err := s.userRepo.GetByID(ctx, requesterUserID)
+				if err != nil {
+					return err
+				}
+
+				// Use provided name or default to requester's member name
+				name := contactName
+				if name == "" {
+					// Find requester's display name from their household
+					for _, m := range members {
+						if m.UserID == requesterUserID {
+							name = m.UserName
+							break
+						}
+					}
+				}
+				if name == "" {
+					name = contact.Name // last resort: name the requester gave this contact
+				}
+
+				now := time.Now()
+				newContact := &Contact{
+					HouseholdID:     acceptorHouseholdID,
+					Name:            name,
+					Email:           &requester.Email,
+					LinkedUserID:    &requesterUserID,
+					LinkStatus:      "ACCEPTED",
+					LinkRequestedAt: &now,
+					LinkRespondedAt: &now,
+					IsActive:        true,
+				}
+				_, err = s.repo.CreateContact(ctx, newContact)
+				if err != nil {
+					return err
+				}
 			}
 		}
 	}
 
-	// 5. Update source contact to ACCEPTED
-	return s.repo.UpdateContactLinkStatus(ctx, contactID, "ACCEPTED")
+	// 4. Update source contact to ACCEPTED
+	if !suggested {
+		if err := s.repo.UpdateContactLinkStatus(ctx, contactID, "ACCEPTED"); err != nil {
+			return err
+		}
+	}
+
+	// 5. Notify whoever requested the link
+	notifyUserID := requesterUserID
+	if contact.LinkRequestedByUserID != nil {
+		notifyUserID = *contact.LinkRequestedByUserID
+	}
+	s.notifyLink(ctx, notifyUserID, notifications.TypeLinkAccepted,
+		fmt.Sprintf("%s aceptó tu solicitud de vinculación", s.userName(ctx, userID)), contact)
+
+	return nil
 }
 
 // RejectLinkRequest rejects a pending link request
@@ -1201,7 +1627,15 @@ func (s *Service) RejectLinkRequest(ctx context.Context, userID, contactID strin
 		return err
 	}
 
-	if contact.LinkedUserID == nil || *contact.LinkedUserID != userID {
+	// Rejecting a suggestion only stops it from being offered again
+	if contact.LinkedUserID == nil {
+		if err := s.checkLinkSuggestion(ctx, userID, contact); err != nil {
+			return err
+		}
+		return s.repo.RejectLinkSuggestion(ctx, contactID, userID)
+	}
+
+	if *contact.LinkedUserID != userID {
 		return ErrNotAuthorized
 	}
 
@@ -1210,5 +1644,14 @@ func (s *Service) RejectLinkRequest(ctx context.Context, userID, contactID strin
 	}
 
 	// Set status to REJECTED (keep linked_user_id so re-requesting is possible)
-	return s.repo.UpdateContactLinkStatus(ctx, contactID, "REJECTED")
+	if err := s.repo.UpdateContactLinkStatus(ctx, contactID, "REJECTED"); err != nil {
+		return err
+	}
+
+	if contact.LinkRequestedByUserID != nil {
+		s.notifyLink(ctx, *contact.LinkRequestedByUserID, notifications.TypeLinkRejected,
+			fmt.Sprintf("%s rechazó tu solicitud de vinculación", s.userName(ctx, userID)), contact)
+	}
+
+	return nil
 }
@@ -2,8 +2,10 @@ package households
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestCreateHousehold(t *testing.T) {
@@ -624,6 +626,52 @@ func TestPromoteContactToMember(t *testing.T) {
 	}
 }
 
+func TestLinkingRequiresVerifiedEmail(t *testing.T) {
+	repo := NewMockRepository()
+	userRepo := NewMockUserRepository()
+	svc := NewService(repo, userRepo, &MockCategoriesRepo{}, &MockAuditService{}, &MockEmailSender{})
+	ctx := context.Background()
+
+	owner := userRepo.AddTestUser("owner", "owner@example.com", "Owner")
+	unverified := userRepo.AddTestUser("unverified", "unverified@example.com", "Unverified")
+	unverified.EmailVerifiedAt = nil
+	target := userRepo.AddTestUser("target", "target@example.com", "Target")
+
+	household, _ := svc.CreateHousehold(ctx, &CreateHouseholdInput{Name: "My Household", UserID: owner.ID})
+	repo.AddMember(ctx, household.ID, unverified.ID, RoleMember)
+
+	targetEmail := target.Email
+	contact, err := svc.CreateContact(ctx, &CreateContactInput{
+		HouseholdID: household.ID,
+		Name:        "Target",
+		Email:       &targetEmail,
+		UserID:      owner.ID,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := svc.CheckEmail(ctx, unverified.ID, target.Email); err != ErrEmailNotVerified {
+		t.Errorf("CheckEmail by unverified user: expected ErrEmailNotVerified, got %v", err)
+	}
+	if err := svc.RequestLink(ctx, unverified.ID, contact.ID); err != ErrEmailNotVerified {
+		t.Errorf("RequestLink by unverified user: expected ErrEmailNotVerified, got %v", err)
+	}
+
+	// Unverified accounts look unregistered to others
+	result, err := svc.CheckEmail(ctx, owner.ID, unverified.Email)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsRegistered {
+		t.Error("expected unverified email to be reported as not registered")
+	}
+
+	if err := svc.RequestLink(ctx, owner.ID, contact.ID); err != nil {
+		t.Errorf("RequestLink by verified user: unexpected error: %v", err)
+	}
+}
+
 func TestGenerateInvitationToken(t *testing.T) {
 	token1, err := GenerateInvitationToken()
 	if err != nil {
@@ -642,6 +690,145 @@ func TestGenerateInvitationToken(t *testing.T) {
 	}
 }
 
+// denyAfterLimiter allows the first n calls per key
+type denyAfterLimiter struct {
+	n     int
+	calls map[string]int
+}
+
+func (l *denyAfterLimiter) Allow(key string) bool {
+	l.calls[key]++
+	return l.calls[key] <= l.n
+}
+
+func TestInvitationLifecycle(t *testing.T) {
+	ctx := context.Background()
+
+	newFixture := func(t *testing.T) (*Service, *MockHouseholdRepository, string) {
+		repo := NewMockRepository()
+		userRepo := NewMockUserRepository()
+		svc := NewService(repo, userRepo, &MockCategoriesRepo{}, &MockAuditService{}, &MockEmailSender{})
+		userRepo.AddTestUser("owner", "owner@example.com", "Owner")
+		userRepo.AddTestUser("guest", "guest@example.com", "Guest")
+		household, err := svc.CreateHousehold(ctx, &CreateHouseholdInput{Name: "Casa", UserID: "owner"})
+		if err != nil {
+			t.Fatalf("create household: %v", err)
+		}
+		return svc, repo, household.ID
+	}
+
+	invite := func(t *testing.T, svc *Service, householdID string) *HouseholdInvitation {
+		inv, err := svc.CreateInvitation(ctx, &CreateInvitationInput{
+			HouseholdID: householdID,
+			Email:       "guest@example.com",
+			UserID:      "owner",
+		})
+		if err != nil {
+			t.Fatalf("create invitation: %v", err)
+		}
+		return inv
+	}
+
+	t.Run("new invitation uses configured expiry", func(t *testing.T) {
+		svc, _, householdID := newFixture(t)
+		svc.SetInvitationTTL(2 * time.Hour)
+
+		inv := invite(t, svc, householdID)
+		if inv.ExpiresAt == nil || time.Until(*inv.ExpiresAt) > 2*time.Hour {
+			t.Errorf("expected expiry within 2h, got %v", inv.ExpiresAt)
+		}
+		if inv.Status != InvitationPending {
+			t.Errorf("expected pending status, got %q", inv.Status)
+		}
+	})
+
+	t.Run("expired invitation cannot be accepted", func(t *testing.T) {
+		svc, _, householdID := newFixture(t)
+		inv := invite(t, svc, householdID)
+		past := time.Now().Add(-time.Minute)
+		inv.ExpiresAt = &past
+
+		_, err := svc.AcceptInvitationByToken(ctx, &AcceptInvitationByTokenInput{Token: inv.Token, UserID: "guest"})
+		if !errors.Is(err, ErrInvitationExpired) {
+			t.Errorf("expected ErrInvitationExpired, got %v", err)
+		}
+	})
+
+	t.Run("revoked invitation cannot be accepted", func(t *testing.T) {
+		svc, _, householdID := newFixture(t)
+		inv := invite(t, svc, householdID)
+
+		if err := svc.RevokeInvitation(ctx, householdID, inv.ID, "owner"); err != nil {
+			t.Fatalf("revoke: %v", err)
+		}
+		_, err := svc.AcceptInvitationByToken(ctx, &AcceptInvitationByTokenInput{Token: inv.Token, UserID: "guest"})
+		if !errors.Is(err, ErrInvitationRevoked) {
+			t.Errorf("expected ErrInvitationRevoked, got %v", err)
+		}
+		invitations, _ := svc.ListInvitations(ctx, householdID, "owner")
+		if len(invitations) != 0 {
+			t.Errorf("expected revoked invitation to be hidden, got %d", len(invitations))
+		}
+	})
+
+	t.Run("resend rotates token", func(t *testing.T) {
+		svc, _, householdID := newFixture(t)
+		inv := invite(t, svc, householdID)
+		oldToken := inv.Token
+
+		resent, err := svc.ResendInvitation(ctx, householdID, inv.ID, "owner")
+		if err != nil {
+			t.Fatalf("resend: %v", err)
+		}
+		if resent.Token == oldToken || resent.SendCount != 2 {
+			t.Errorf("expected new token and send count 2, got count %d", resent.SendCount)
+		}
+		_, err = svc.AcceptInvitationByToken(ctx, &AcceptInvitationByTokenInput{Token: oldToken, UserID: "guest"})
+		if !errors.Is(err, ErrInvitationNotFound) {
+			t.Errorf("expected old token to be invalid, got %v", err)
+		}
+		if _, err := svc.AcceptInvitationByToken(ctx, &AcceptInvitationByTokenInput{Token: resent.Token, UserID: "guest"}); err != nil {
+			t.Errorf("expected new token to work, got %v", err)
+		}
+	})
+
+	t.Run("expired invitation is replaced on re-invite", func(t *testing.T) {
+		svc, _, householdID := newFixture(t)
+		inv := invite(t, svc, householdID)
+
+		if _, err := svc.CreateInvitation(ctx, &CreateInvitationInput{HouseholdID: householdID, Email: "guest@example.com", UserID: "owner"}); err == nil {
+			t.Fatal("expected duplicate pending invitation to be rejected")
+		}
+
+		past := time.Now().Add(-time.Minute)
+		inv.ExpiresAt = &past
+		replacement := invite(t, svc, householdID)
+		if replacement.ID == inv.ID || !inv.IsRevoked() {
+			t.Error("expected expired invitation to be revoked and replaced")
+		}
+	})
+
+	t.Run("rate limited per household", func(t *testing.T) {
+		svc, _, householdID := newFixture(t)
+		svc.SetInvitationLimiter(&denyAfterLimiter{n: 1, calls: map[string]int{}})
+		inv := invite(t, svc, householdID)
+
+		_, err := svc.ResendInvitation(ctx, householdID, inv.ID, "owner")
+		if !errors.Is(err, ErrInvitationRateLimited) {
+			t.Errorf("expected ErrInvitationRateLimited, got %v", err)
+		}
+	})
+
+	t.Run("only managers can list invitations", func(t *testing.T) {
+		svc, _, householdID := newFixture(t)
+		invite(t, svc, householdID)
+
+		if _, err := svc.ListInvitations(ctx, householdID, "guest"); !errors.Is(err, ErrNotAuthorized) {
+			t.Errorf("expected ErrNotAuthorized, got %v", err)
+		}
+	})
+}
+
 func TestDeleteHousehold(t *testing.T) {
 	repo := NewMockRepository()
 	userRepo := NewMockUserRepository()
@@ -701,3 +888,344 @@ func TestDeleteHousehold(t *testing.T) {
 		})
 	}
 }
+
+func TestTransferOwnership(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockRepository()
+	userRepo := NewMockUserRepository()
+	svc := NewService(repo, userRepo, &MockCategoriesRepo{}, &MockAuditService{}, &MockEmailSender{})
+
+	userRepo.AddTestUser("owner", "owner@example.com", "Owner")
+	userRepo.AddTestUser("member", "member@example.com", "Member")
+	household, _ := svc.CreateHousehold(ctx, &CreateHouseholdInput{Name: "Casa", UserID: "owner"})
+	repo.members[household.ID] = append(repo.members[household.ID], &HouseholdMember{
+		ID: "member-2", HouseholdID: household.ID, UserID: "member", Role: RoleMember,
+	})
+
+	if _, err := svc.TransferOwnership(ctx, &TransferOwnershipInput{HouseholdID: household.ID, NewOwnerID: "owner", UserID: "owner"}); !errors.Is(err, ErrTransferToSelf) {
+		t.Errorf("expected ErrTransferToSelf, got %v", err)
+	}
+	if _, err := svc.TransferOwnership(ctx, &TransferOwnershipInput{HouseholdID: household.ID, NewOwnerID: "owner", UserID: "member"}); !errors.Is(err, ErrNotAuthorized) {
+		t.Errorf("expected member to be rejected, got %v", err)
+	}
+
+	member, err := svc.TransferOwnership(ctx, &TransferOwnershipInput{HouseholdID: household.ID, NewOwnerID: "member", UserID: "owner"})
+	if err != nil {
+		t.Fatalf("transfer: %v", err)
+	}
+	if member.Role != RoleOwner {
+		t.Errorf("expected new owner role, got %q", member.Role)
+	}
+	previous, _ := repo.GetMemberByUserID(ctx, household.ID, "owner")
+	if previous.Role != RoleMember {
+		t.Errorf("expected previous owner to become member, got %q", previous.Role)
+	}
+
+	// The previous owner can now leave
+	if err := svc.RemoveMember(ctx, &RemoveMemberInput{HouseholdID: household.ID, MemberID: "owner", UserID: "owner"}); err != nil {
+		t.Errorf("expected previous owner to be able to leave, got %v", err)
+	}
+}
+
+func TestMergeHouseholds(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockRepository()
+	userRepo := NewMockUserRepository()
+	svc := NewService(repo, userRepo, &MockCategoriesRepo{}, &MockAuditService{}, &MockEmailSender{})
+
+	userRepo.AddTestUser("owner", "owner@example.com", "Owner")
+	userRepo.AddTestUser("other", "other@example.com", "Other")
+	target, _ := svc.CreateHousehold(ctx, &CreateHouseholdInput{Name: "Casa", UserID: "owner"})
+
+	addHousehold := func(id, currency, ownerID string) {
+		repo.households[id] = &Household{ID: id, Name: id, Currency: currency}
+		repo.members[id] = []*HouseholdMember{{ID: "member-" + id, HouseholdID: id, UserID: ownerID, Role: RoleOwner}}
+	}
+	addHousehold("source", "COP", "owner")
+	addHousehold("foreign", "USD", "owner")
+	addHousehold("not-mine", "COP", "other")
+
+	tests := []struct {
+		name    string
+		source  string
+		wantErr error
+	}{
+		{name: "same household", source: target.ID, wantErr: ErrMergeSameHousehold},
+		{name: "source owned by someone else", source: "not-mine", wantErr: ErrNotAuthorized},
+		{name: "different currency", source: "foreign", wantErr: ErrCurrencyMismatch},
+		{name: "dry run", source: "source"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := svc.MergeHouseholds(ctx, &MergeHouseholdsInput{
+				SourceHouseholdID: tt.source,
+				TargetHouseholdID: target.ID,
+				UserID:            "owner",
+				DryRun:            true,
+			})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !report.DryRun {
+				t.Error("expected a dry-run report")
+			}
+		})
+	}
+}
+
+func TestMergeContacts(t *testing.T) {
+	ctx := context.Background()
+
+	strPtr := func(s string) *string { return &s }
+	newFixture := func() (*Service, *MockHouseholdRepository, string) {
+		repo := NewMockRepository()
+		userRepo := NewMockUserRepository()
+		svc := NewService(repo, userRepo, &MockCategoriesRepo{}, &MockAuditService{}, &MockEmailSender{})
+		userRepo.AddTestUser("owner", "owner@example.com", "Owner")
+		household, _ := svc.CreateHousehold(ctx, &CreateHouseholdInput{Name: "Casa", UserID: "owner"})
+		return svc, repo, household.ID
+	}
+	addContact := func(repo *MockHouseholdRepository, householdID, id, name string, linkedUserID *string, status string) *Contact {
+		c := &Contact{ID: id, HouseholdID: householdID, Name: name, LinkedUserID: linkedUserID, LinkStatus: status, IsActive: true}
+		repo.contacts[householdID] = append(repo.contacts[householdID], c)
+		return c
+	}
+
+	t.Run("source link carries over to unlinked target", func(t *testing.T) {
+		svc, repo, householdID := newFixture()
+		addContact(repo, householdID, "mama", "Mama", strPtr("user-mama"), "ACCEPTED")
+		target := addContact(repo, householdID, "maria", "María Isabel", nil, "NONE")
+		target.Phone = strPtr("3001234567")
+
+		merged, err := svc.MergeContacts(ctx, &MergeContactsInput{
+			HouseholdID: householdID, SourceContactID: "mama", TargetContactID: "maria", UserID: "owner",
+		})
+		if err != nil {
+			t.Fatalf("merge: %v", err)
+		}
+		if merged.LinkedUserID == nil || *merged.LinkedUserID != "user-mama" || merged.LinkStatus != "ACCEPTED" {
+			t.Errorf("expected link to carry over, got %v %q", merged.LinkedUserID, merged.LinkStatus)
+		}
+		if _, err := repo.GetContact(ctx, "mama"); !errors.Is(err, ErrContactNotFound) {
+			t.Errorf("expected source contact to be deleted, got %v", err)
+		}
+	})
+
+	t.Run("target keeps stronger link", func(t *testing.T) {
+		svc, repo, householdID := newFixture()
+		addContact(repo, householdID, "a", "Ana", strPtr("user-ana"), "PENDING")
+		addContact(repo, householdID, "b", "Ana María", strPtr("user-ana"), "ACCEPTED")
+
+		merged, err := svc.MergeContacts(ctx, &MergeContactsInput{
+			HouseholdID: householdID, SourceContactID: "a", TargetContactID: "b", UserID: "owner",
+		})
+		if err != nil {
+			t.Fatalf("merge: %v", err)
+		}
+		if merged.LinkStatus != "ACCEPTED" {
+			t.Errorf("expected ACCEPTED link to be kept, got %q", merged.LinkStatus)
+		}
+	})
+
+	t.Run("contacts linked to different users", func(t *testing.T) {
+		svc, repo, householdID := newFixture()
+		addContact(repo, householdID, "a", "Ana", strPtr("user-ana"), "ACCEPTED")
+		addContact(repo, householdID, "b", "Beto", strPtr("user-beto"), "ACCEPTED")
+
+		_, err := svc.MergeContacts(ctx, &MergeContactsInput{
+			HouseholdID: householdID, SourceContactID: "a", TargetContactID: "b", UserID: "owner",
+		})
+		if !errors.Is(err, ErrContactLinkConflict) {
+			t.Errorf("expected ErrContactLinkConflict, got %v", err)
+		}
+	})
+
+	t.Run("contact from another household", func(t *testing.T) {
+		svc, repo, householdID := newFixture()
+		addContact(repo, householdID, "a", "Ana", nil, "NONE")
+		addContact(repo, "other-household", "b", "Ana", nil, "NONE")
+
+		_, err := svc.MergeContacts(ctx, &MergeContactsInput{
+			HouseholdID: householdID, SourceContactID: "a", TargetContactID: "b", UserID: "owner",
+		})
+		if !errors.Is(err, ErrNotAuthorized) {
+			t.Errorf("expected ErrNotAuthorized, got %v", err)
+		}
+	})
+}
+
+func TestSuggestLinksForVerifiedUser(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockRepository()
+	userRepo := NewMockUserRepository()
+	svc := NewService(repo, userRepo, &MockCategoriesRepo{}, &MockAuditService{}, &MockEmailSender{})
+
+	userRepo.AddTestUser("owner", "owner@example.com", "Owner")
+	household, _ := svc.CreateHousehold(ctx, &CreateHouseholdInput{Name: "Casa", UserID: "owner"})
+
+	mamaEmail := "Mama@example.com"
+	papaEmail := "papa@example.com"
+	tiaPhone := "3001234567"
+	repo.contacts[household.ID] = []*Contact{
+		{ID: "mama", HouseholdID: household.ID, Name: "Mama", Email: &mamaEmail, LinkStatus: "NONE", IsActive: true},
+		{ID: "papa", HouseholdID: household.ID, Name: "Papa", Email: &papaEmail, LinkStatus: "NONE", IsActive: true},
+		{ID: "tia", HouseholdID: household.ID, Name: "Tía", Phone: &tiaPhone, LinkStatus: "NONE", IsActive: true},
+	}
+
+	newUser := userRepo.AddTestUser("new-user", "mama@example.com", "María Isabel")
+	if err := svc.SuggestLinksForVerifiedUser(ctx, newUser.ID, newUser.Email); err != nil {
+		t.Fatalf("suggest: %v", err)
+	}
+
+	// Suggestions never touch the other household's contacts
+	for _, c := range repo.contacts[household.ID] {
+		if c.LinkedUserID != nil || c.LinkStatus != "NONE" {
+			t.Errorf("contact %s changed before accepting: %v %q", c.ID, c.LinkedUserID, c.LinkStatus)
+		}
+	}
+	if ok, _ := repo.HasPendingLinkSuggestion(ctx, "mama", newUser.ID); !ok {
+		t.Error("expected a suggestion for the contact with the user's email")
+	}
+	if ok, _ := repo.HasPendingLinkSuggestion(ctx, "tia", newUser.ID); ok {
+		t.Error("phone numbers must not be matched")
+	}
+
+	t.Run("other users cannot answer the suggestion", func(t *testing.T) {
+		userRepo.AddTestUser("intruder", "intruder@example.com", "Intruder")
+		if err := svc.AcceptLinkRequest(ctx, "intruder", "mama", "", nil); !errors.Is(err, ErrNotAuthorized) {
+			t.Errorf("expected ErrNotAuthorized, got %v", err)
+		}
+		if err := svc.AcceptLinkRequest(ctx, newUser.ID, "papa", "", nil); !errors.Is(err, ErrNotAuthorized) {
+			t.Errorf("expected ErrNotAuthorized for a contact that was not suggested, got %v", err)
+		}
+	})
+
+	t.Run("accepting links the contact", func(t *testing.T) {
+		if err := svc.AcceptLinkRequest(ctx, newUser.ID, "mama", "", nil); err != nil {
+			t.Fatalf("accept: %v", err)
+		}
+		mama, _ := repo.GetContact(ctx, "mama")
+		if mama.LinkedUserID == nil || *mama.LinkedUserID != newUser.ID || mama.LinkStatus != "ACCEPTED" {
+			t.Errorf("expected contact linked and ACCEPTED, got %v %q", mama.LinkedUserID, mama.LinkStatus)
+		}
+	})
+
+	t.Run("suggestions are not repeated", func(t *testing.T) {
+		mama, _ := repo.GetContact(ctx, "mama")
+		mama.LinkedUserID = nil
+		mama.LinkStatus = "NONE"
+		if err := svc.SuggestLinksForVerifiedUser(ctx, newUser.ID, newUser.Email); err != nil {
+			t.Fatalf("suggest: %v", err)
+		}
+		if ok, _ := repo.HasPendingLinkSuggestion(ctx, "mama", newUser.ID); ok {
+			t.Error("an answered suggestion must not be offered again")
+		}
+	})
+}
+
+func TestRejectLinkSuggestion(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockRepository()
+	userRepo := NewMockUserRepository()
+	svc := NewService(repo, userRepo, &MockCategoriesRepo{}, &MockAuditService{}, &MockEmailSender{})
+
+	userRepo.AddTestUser("owner", "owner@example.com", "Owner")
+	household, _ := svc.CreateHousehold(ctx, &CreateHouseholdInput{Name: "Casa", UserID: "owner"})
+
+	email := "mama@example.com"
+	repo.contacts[household.ID] = []*Contact{
+		{ID: "mama", HouseholdID: household.ID, Name: "Mama", Email: &email, LinkStatus: "NONE", IsActive: true},
+	}
+	newUser := userRepo.AddTestUser("new-user", email, "María Isabel")
+	if err := svc.SuggestLinksForVerifiedUser(ctx, newUser.ID, newUser.Email); err != nil {
+		t.Fatalf("suggest: %v", err)
+	}
+
+	if err := svc.RejectLinkRequest(ctx, newUser.ID, "mama"); err != nil {
+		t.Fatalf("reject: %v", err)
+	}
+	if err := svc.AcceptLinkRequest(ctx, newUser.ID, "mama", "", nil); !errors.Is(err, ErrNotAuthorized) {
+		t.Errorf("expected ErrNotAuthorized after rejecting, got %v", err)
+	}
+	mama, _ := repo.GetContact(ctx, "mama")
+	if mama.LinkedUserID != nil || mama.LinkStatus != "NONE" {
+		t.Errorf("rejecting must leave the contact untouched, got %v %q", mama.LinkedUserID, mama.LinkStatus)
+	}
+}
+
+func TestAcceptLinkRequestUsesSelectedHousehold(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockRepository()
+	userRepo := NewMockUserRepository()
+	svc := NewService(repo, userRepo, &MockCategoriesRepo{}, &MockAuditService{}, &MockEmailSender{})
+
+	userRepo.AddTestUser("requester", "requester@example.com", "Requester")
+	acceptor := userRepo.AddTestUser("acceptor", "acceptor@example.com", "Acceptor")
+	now := time.Now()
+	for i, hh := range []struct {
+		id, userID string
+		role       HouseholdRole
+	}{
+		{"casa", "requester", RoleOwner},
+		{"propia", acceptor.ID, RoleOwner},
+		{"segunda", acceptor.ID, RoleMember},
+		{"ajena", acceptor.ID, RoleViewer},
+	} {
+		repo.households[hh.id] = &Household{ID: hh.id, Name: hh.id, Currency: "COP"}
+		repo.members[hh.id] = []*HouseholdMember{{
+			ID: "member-" + hh.id, HouseholdID: hh.id, UserID: hh.userID, Role: hh.role,
+			JoinedAt: now.Add(time.Duration(i) * time.Minute),
+		}}
+	}
+	newRequest := func() {
+		repo.contacts["casa"] = []*Contact{{
+			ID: "acceptor-contact", HouseholdID: "casa", Name: "Acceptor",
+			LinkedUserID: &acceptor.ID, LinkStatus: "PENDING", IsActive: true,
+		}}
+	}
+
+	t.Run("role in the selected household must allow managing contacts", func(t *testing.T) {
+		newRequest()
+		selected := WithSelectedHousehold(ctx, "ajena")
+		if err := svc.AcceptLinkRequest(selected, acceptor.ID, "acceptor-contact", "", nil); !errors.Is(err, ErrNotAuthorized) {
+			t.Errorf("expected ErrNotAuthorized, got %v", err)
+		}
+		if len(repo.contacts["ajena"]) != 0 {
+			t.Error("no reciprocal contact expected in a household the acceptor cannot manage")
+		}
+	})
+
+	t.Run("existing contact must belong to the selected household", func(t *testing.T) {
+		newRequest()
+		repo.contacts["propia"] = []*Contact{{ID: "own-contact", HouseholdID: "propia", Name: "Requester", IsActive: true}}
+		selected := WithSelectedHousehold(ctx, "segunda")
+		own := "own-contact"
+		if err := svc.AcceptLinkRequest(selected, acceptor.ID, "acceptor-contact", "", &own); !errors.Is(err, ErrNotAuthorized) {
+			t.Errorf("expected ErrNotAuthorized, got %v", err)
+		}
+	})
+
+	t.Run("reciprocal contact goes into the selected household", func(t *testing.T) {
+		newRequest()
+		selected := WithSelectedHousehold(ctx, "segunda")
+		if err := svc.AcceptLinkRequest(selected, acceptor.ID, "acceptor-contact", "", nil); err != nil {
+			t.Fatalf("accept: %v", err)
+		}
+		if len(repo.contacts["segunda"]) != 1 {
+			t.Fatalf("expected the reciprocal contact in the selected household, got %d", len(repo.contacts["segunda"]))
+		}
+		if c := repo.contacts["segunda"][0]; c.LinkedUserID == nil || *c.LinkedUserID != "requester" {
+			t.Errorf("expected the reciprocal contact linked to the requester, got %v", c.LinkedUserID)
+		}
+		if len(repo.contacts["propia"]) != 1 {
+			t.Error("the first household must not receive the reciprocal contact")
+		}
+	})
+}
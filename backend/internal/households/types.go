@@ -15,26 +15,43 @@ var (
 	ErrUserAlreadyMember      = errors.New("user is already a member")
 	ErrCannotRemoveLastOwner  = errors.New("cannot remove last owner")
 	ErrNotAuthorized          = errors.New("not authorized")
+	ErrNoHousehold            = errors.New("user has no household")
 	ErrContactNotLinked       = errors.New("contact is not linked to a user account")
 	ErrContactAlreadyLinked   = errors.New("contact is already linked")
 	ErrContactNoEmail         = errors.New("contact has no email")
 	ErrEmailNotRegistered     = errors.New("email is not registered")
+	ErrEmailNotVerified       = errors.New("email not verified")
 	ErrInvalidRole            = errors.New("invalid role")
 	ErrLinkRequestNotPending  = errors.New("link request is not pending")
+	ErrInvitationExpired      = errors.New("invitation has expired")
+	ErrInvitationRevoked      = errors.New("invitation has been revoked")
+	ErrInvitationAccepted     = errors.New("invitation has already been accepted")
+	ErrInvitationRateLimited  = errors.New("too many invitations sent for this household")
+	ErrMergeSameHousehold     = errors.New("cannot merge a household into itself")
+	ErrCurrencyMismatch       = errors.New("households use different currencies")
+	ErrMergeConflict          = errors.New("merge conflict")
+	ErrTransferToSelf         = errors.New("cannot transfer ownership to yourself")
+	ErrContactMergeSame       = errors.New("cannot merge a contact into itself")
+	ErrContactLinkConflict    = errors.New("contacts are linked to different users")
 )
 
+// DefaultInvitationTTL is how long an invitation link stays valid unless configured otherwise
+const DefaultInvitationTTL = 7 * 24 * time.Hour
+
 // HouseholdRole represents the role of a user in a household
 type HouseholdRole string
 
 const (
-	RoleOwner  HouseholdRole = "owner"
-	RoleMember HouseholdRole = "member"
+	RoleOwner      HouseholdRole = "owner"
+	RoleMember     HouseholdRole = "member"
+	RoleBookkeeper HouseholdRole = "bookkeeper" // Can record movements but not manage accounts or members
+	RoleViewer     HouseholdRole = "viewer"     // Read-only access
 )
 
 // Validate checks if the role is valid
 func (r HouseholdRole) Validate() error {
 	switch r {
-	case RoleOwner, RoleMember:
+	case RoleOwner, RoleMember, RoleBookkeeper, RoleViewer:
 		return nil
 	default:
 		return ErrInvalidRole
@@ -76,6 +93,14 @@ type HouseholdMember struct {
 	UserName  string `json:"user_name,omitempty"`
 }
 
+// Membership is one of the households a user belongs to.
+type Membership struct {
+	HouseholdID   string        `json:"household_id"`
+	HouseholdName string        `json:"household_name"`
+	Role          HouseholdRole `json:"role"`
+	JoinedAt      time.Time     `json:"joined_at"`
+}
+
 // Contact represents an external person with whom the household has transactions
 type Contact struct {
 	ID              string     `json:"id"`
@@ -106,6 +131,7 @@ type LinkRequest struct {
 	HouseholdName string    `json:"household_name"`
 	HouseholdID   string    `json:"household_id"`
 	RequestedAt   time.Time `json:"requested_at"`
+	MatchedBy     *string   `json:"matched_by,omitempty"` // "email" for suggestions matched on the user's verified email
 }
 
 // Validate validates contact fields
@@ -134,17 +160,34 @@ type HouseholdInvitation struct {
 	InvitedBy   string     `json:"invited_by"`
 	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
 	AcceptedAt  *time.Time `json:"accepted_at,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	LastSentAt  time.Time  `json:"last_sent_at"`
+	SendCount   int        `json:"send_count"`
 	CreatedAt   time.Time  `json:"created_at"`
 	
 	// Populated from joins - not in DB table
 	HouseholdName string `json:"household_name,omitempty"`
 	InviterName   string `json:"inviter_name,omitempty"`
+
+	// Computed field - not in DB
+	Status InvitationStatus `json:"status"`
 }
 
-// IsExpired checks if the invitation has expired
+// InvitationStatus is where an invitation is in its lifecycle
+type InvitationStatus string
+
+const (
+	InvitationPending  InvitationStatus = "pending"
+	InvitationExpired  InvitationStatus = "expired"
+	InvitationRevoked  InvitationStatus = "revoked"
+	InvitationAccepted InvitationStatus = "accepted"
+)
+
+// IsExpired checks if the invitation has expired. Invitations without an
+// expiry (created before expiry existed) never expire.
 func (i *HouseholdInvitation) IsExpired() bool {
 	if i.ExpiresAt == nil {
-		return false // No expiration set (Phase 2)
+		return false
 	}
 	return time.Now().After(*i.ExpiresAt)
 }
@@ -154,12 +197,32 @@ func (i *HouseholdInvitation) IsAccepted() bool {
 	return i.AcceptedAt != nil
 }
 
+// IsRevoked checks if the invitation has been revoked by the household
+func (i *HouseholdInvitation) IsRevoked() bool {
+	return i.RevokedAt != nil
+}
+
+// CurrentStatus computes the invitation status; accepted and revoked win over expiry
+func (i *HouseholdInvitation) CurrentStatus() InvitationStatus {
+	switch {
+	case i.IsAccepted():
+		return InvitationAccepted
+	case i.IsRevoked():
+		return InvitationRevoked
+	case i.IsExpired():
+		return InvitationExpired
+	default:
+		return InvitationPending
+	}
+}
+
 // LinkedContact represents a contact in another household that is linked to a user
 type LinkedContact struct {
 	ContactID     string `json:"contact_id"`
 	HouseholdID   string `json:"household_id"`
 	HouseholdName string `json:"household_name"`
 	ContactName   string `json:"contact_name"`
+	LinkedUserID  string `json:"linked_user_id"`
 }
 
 // HouseholdRepository defines the interface for household persistence
@@ -178,6 +241,7 @@ type HouseholdRepository interface {
 	GetMembers(ctx context.Context, householdID string) ([]*HouseholdMember, error)
 	GetMemberByUserID(ctx context.Context, householdID, userID string) (*HouseholdMember, error)
 	CountOwners(ctx context.Context, householdID string) (int, error)
+	TransferOwnership(ctx context.Context, householdID, fromUserID, toUserID string) error
 	
 	// Contact management
 	CreateContact(ctx context.Context, contact *Contact) (*Contact, error)
@@ -187,6 +251,7 @@ type HouseholdRepository interface {
 	ListContacts(ctx context.Context, householdID string) ([]*Contact, error)
 	FindContactByEmail(ctx context.Context, householdID, email string) (*Contact, error)
 	FindLinkedContactsByHousehold(ctx context.Context, householdID string) ([]LinkedContact, error)
+	MergeContacts(ctx context.Context, sourceID, targetID string, carryLink bool) error
 	
 	// Link request management
 	ListPendingLinkRequests(ctx context.Context, userID string) ([]LinkRequest, error)
@@ -197,14 +262,27 @@ type HouseholdRepository interface {
 	SetWasUnlinkedAt(ctx context.Context, contactID string) error
 	DismissUnlinkBanner(ctx context.Context, contactID string) error
 	FindContactByLinkedUserID(ctx context.Context, householdID string, linkedUserID string) (*Contact, error)
+	SuggestLinksForUser(ctx context.Context, userID, email string) ([]*Contact, error)
+	HasPendingLinkSuggestion(ctx context.Context, contactID, userID string) (bool, error)
+	AcceptLinkSuggestion(ctx context.Context, contactID, userID string) error
+	RejectLinkSuggestion(ctx context.Context, contactID, userID string) error
 	
 	// Invitation management
-	CreateInvitation(ctx context.Context, householdID, email, token, invitedBy string) (*HouseholdInvitation, error)
+	CreateInvitation(ctx context.Context, householdID, email, token, invitedBy string, expiresAt time.Time) (*HouseholdInvitation, error)
+	GetInvitationByID(ctx context.Context, id string) (*HouseholdInvitation, error)
 	GetInvitationByToken(ctx context.Context, token string) (*HouseholdInvitation, error)
+	FindOpenInvitationByEmail(ctx context.Context, householdID, email string) (*HouseholdInvitation, error)
 	AcceptInvitation(ctx context.Context, id string) error
+	RotateInvitationToken(ctx context.Context, id, token string, expiresAt time.Time) (*HouseholdInvitation, error)
+	RevokeInvitation(ctx context.Context, id string) error
 	ListPendingInvitations(ctx context.Context, householdID string) ([]*HouseholdInvitation, error)
 	
+	// Merge
+	MergeHouseholds(ctx context.Context, sourceID, targetID string, apply bool) (*MergeReport, error)
+	
 	// Helper methods
 	GetUserHouseholdID(ctx context.Context, userID string) (string, error)
+	ResolveHouseholdID(ctx context.Context, userID string) (string, error)
+	ListMemberships(ctx context.Context, userID string) ([]*Membership, error)
 	IsUserMember(ctx context.Context, householdID, userID string) (bool, error)
 }
@@ -3,6 +3,7 @@ package httpserver
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -22,16 +23,19 @@ import (
 	"github.com/blanquicet/conti/backend/internal/creditcardpayments"
 	"github.com/blanquicet/conti/backend/internal/creditcards"
 	"github.com/blanquicet/conti/backend/internal/email"
+	"github.com/blanquicet/conti/backend/internal/events"
 	"github.com/blanquicet/conti/backend/internal/households"
 	"github.com/blanquicet/conti/backend/internal/income"
 	"github.com/blanquicet/conti/backend/internal/middleware"
 	"github.com/blanquicet/conti/backend/internal/movements"
+	"github.com/blanquicet/conti/backend/internal/notifications"
 	"github.com/blanquicet/conti/backend/internal/paymentmethods"
 	"github.com/blanquicet/conti/backend/internal/pockets"
 	"github.com/blanquicet/conti/backend/internal/recurringmovements"
 	"github.com/blanquicet/conti/backend/internal/sessions"
 	"github.com/blanquicet/conti/backend/internal/stt"
 	"github.com/blanquicet/conti/backend/internal/users"
+	"github.com/blanquicet/conti/backend/internal/webhooks"
 )
 
 // Server wraps the HTTP server and its dependencies.
@@ -78,21 +82,34 @@ func New(ctx context.Context, cfg *config.Config, logger *slog.Logger) (*Server,
 	userRepo := users.NewRepository(pool)
 	sessionRepo := sessions.NewRepository(pool)
 	passwordResetRepo := users.NewPasswordResetRepository(pool)
+	accessTokenRepo := users.NewAccessTokenRepository(pool)
+	twoFactorRepo := users.NewTwoFactorRepository(pool)
+	emailTokenRepo := users.NewEmailTokenRepository(pool)
+	loginFailureRepo := users.NewLoginFailureRepository(pool)
 	householdRepo := households.NewRepository(pool)
 	
 	// Create audit log repository and service (needs to be early for other services)
 	auditRepo := audit.NewRepository(pool)
 	auditService := audit.NewService(auditRepo, logger)
 
+	// Create notifications repository and service (publishers are wired below)
+	notificationsRepo := notifications.NewRepository(pool)
+	notificationsService := notifications.NewService(notificationsRepo, logger)
+
 	// Create auth service
 	authService := auth.NewService(
 		userRepo,
 		sessionRepo,
 		passwordResetRepo,
+		accessTokenRepo,
+		twoFactorRepo,
+		emailTokenRepo,
+		loginFailureRepo,
 		emailSender,
 		auditService,
 		cfg.SessionDuration,
 	)
+	authService.SetMagicLinkEnabled(cfg.MagicLinkEnabled)
 
 	// Create auth handler
 	authHandler := auth.NewHandler(
@@ -101,6 +118,27 @@ func New(ctx context.Context, cfg *config.Config, logger *slog.Logger) (*Server,
 		cfg.SessionCookieSecure,
 		logger,
 	)
+	authHandler.SetListMembershipsFn(func(ctx context.Context, userID string) ([]auth.HouseholdMembership, error) {
+		memberships, err := householdRepo.ListMemberships(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]auth.HouseholdMembership, len(memberships))
+		for i, m := range memberships {
+			permissions := make([]string, 0, len(m.Role.Permissions()))
+			for _, p := range m.Role.Permissions() {
+				permissions = append(permissions, string(p))
+			}
+			result[i] = auth.HouseholdMembership{
+				HouseholdID:   m.HouseholdID,
+				HouseholdName: m.HouseholdName,
+				Role:          string(m.Role),
+				Permissions:   permissions,
+				JoinedAt:      m.JoinedAt,
+			}
+		}
+		return result, nil
+	})
 
 	// Create payment methods service and handler
 	paymentMethodsRepo := paymentmethods.NewRepository(pool)
@@ -134,6 +172,22 @@ func New(ctx context.Context, cfg *config.Config, logger *slog.Logger) (*Server,
 		cfg.SessionCookieName,
 		logger,
 	)
+
+	// Create movement comments/disputes service and handler
+	movementCommentsRepo := movements.NewCommentsRepository(pool)
+	movementCommentsService := movements.NewCommentsService(
+		movementCommentsRepo,
+		movementsRepo,
+		householdRepo,
+		auditService,
+		logger,
+	)
+	movementCommentsHandler := movements.NewCommentsHandler(
+		movementCommentsService,
+		authService,
+		cfg.SessionCookieName,
+		logger,
+	)
 	
 	// Create income service and handler
 	incomeRepo := income.NewRepository(pool)
@@ -173,6 +227,8 @@ func New(ctx context.Context, cfg *config.Config, logger *slog.Logger) (*Server,
 
 	// Create household service (needs categoriesRepo for default categories)
 	householdService := households.NewService(householdRepo, userRepo, categoriesRepo, auditService, emailSender)
+	householdService.SetInvitationTTL(cfg.InvitationTTL)
+	authService.SetLinkSuggester(householdService)
 	householdHandler := households.NewHandler(
 		householdService,
 		authService,
@@ -191,6 +247,17 @@ func New(ctx context.Context, cfg *config.Config, logger *slog.Logger) (*Server,
 		logger,
 	)
 
+	// Moving a category into a group must keep the group within its budget
+	categoriesService.SetGroupCapFn(func(ctx context.Context, householdID, categoryID, groupID string) error {
+		if err := budgetsService.CheckCategoryMove(ctx, householdID, categoryID, groupID); err != nil {
+			if errors.Is(err, budgets.ErrExceedsGroupBudget) {
+				return categories.ErrExceedsGroupBudget
+			}
+			return err
+		}
+		return nil
+	})
+
 	// Create budget items service and handler (monthly snapshots)
 	budgetItemsRepo := budgets.NewBudgetItemsRepository(pool)
 	budgetItemsService := budgets.NewBudgetItemsService(budgetItemsRepo, logger)
@@ -287,8 +354,8 @@ func New(ctx context.Context, cfg *config.Config, logger *slog.Logger) (*Server,
 		if sum <= 0 {
 			return nil // No items → don't create a zero budget record
 		}
-		// Upsert: create with items sum, or update to items sum if it's higher than current
-		return budgetsRepo.UpsertBudgetFromItems(ctx, householdID, categoryID, month, sum)
+		// Upsert to the items sum; groups pushed past their budget report it as over-allocated
+		return budgetsService.SyncBudgetFromItems(ctx, householdID, categoryID, month, sum)
 	})
 
 	// Create form config handler for movements (with templates closure to avoid import cycles)
@@ -437,16 +504,112 @@ func New(ctx context.Context, cfg *config.Config, logger *slog.Logger) (*Server,
 		return pocketsService.DeleteTransactionByMovementID(ctx, movementID, householdID)
 	})
 
+	// Wire in-app notifications publishers
+	notificationsHandler := notifications.NewHandler(
+		notificationsService,
+		authService,
+		cfg.SessionCookieName,
+		logger,
+	)
+	householdService.SetNotifier(notificationsService)
+	budgetsService.SetNotifier(notificationsService)
+	budgetsService.SetAlertEmailSender(emailSender)
+	movementsService.SetNotifier(notificationsService)
+	movementsService.SetCheckBudgetFn(budgetsService.CheckAlerts)
+	movementCommentsService.SetNotifyFn(func(ctx context.Context, event *movements.ActivityEvent) {
+		var notificationType notifications.Type
+		var title string
+		switch event.Kind {
+		case movements.ActivityCommentAdded:
+			notificationType, title = notifications.TypeMovementComment, "Nuevo comentario en un movimiento"
+		case movements.ActivityDisputeOpened:
+			notificationType, title = notifications.TypeMovementDisputed, "Un movimiento fue disputado"
+		case movements.ActivityDisputeResolved:
+			notificationType, title = notifications.TypeMovementDisputeResolved, "Se resolvió la disputa de un movimiento"
+		default:
+			return
+		}
+		notificationsService.Publish(ctx, &notifications.PublishInput{
+			UserIDs:      event.RecipientUserIDs,
+			Type:         notificationType,
+			Title:        title,
+			Body:         notifications.StringPtr(event.MovementDescription),
+			ResourceType: notifications.StringPtr("movement"),
+			ResourceID:   notifications.StringPtr(event.MovementID),
+			Data:         map[string]interface{}{"actor_user_id": event.ActorUserID},
+		})
+	})
+	generator.SetOnGeneratedFn(func(ctx context.Context, template *recurringmovements.RecurringMovementTemplate, movement *movements.Movement) {
+		members, err := householdRepo.GetMembers(ctx, template.HouseholdID)
+		if err != nil {
+			logger.Warn("failed to get household members for recurring movement notification", "error", err)
+			return
+		}
+		userIDs := make([]string, len(members))
+		for i, m := range members {
+			userIDs[i] = m.UserID
+		}
+		notificationsService.Publish(ctx, &notifications.PublishInput{
+			UserIDs:      userIDs,
+			HouseholdID:  notifications.StringPtr(template.HouseholdID),
+			Type:         notifications.TypeRecurringMovementCreated,
+			Title:        fmt.Sprintf("Se registró automáticamente: %s", template.Name),
+			ResourceType: notifications.StringPtr("movement"),
+			ResourceID:   notifications.StringPtr(movement.ID),
+			Data: map[string]interface{}{
+				"template_id": template.ID,
+				"amount":      movement.Amount,
+			},
+		})
+	})
+
+	// Create the household change event bus (Postgres LISTEN/NOTIFY, shared by all replicas).
+	// Database triggers publish the changes.
+	eventBus := events.NewBus(pool, logger)
+	go eventBus.Run(ctx)
+	eventsHandler := events.NewHandler(
+		eventBus,
+		authService,
+		householdRepo,
+		cfg.SessionCookieName,
+		logger,
+	)
+
+	// Create outgoing webhooks (delivery worker claims rows, so it runs on every replica)
+	webhooksRepo := webhooks.NewRepository(pool)
+	webhooksService := webhooks.NewService(webhooksRepo, householdRepo, auditService, logger)
+	go webhooksService.Start(ctx)
+	movementsService.SetWebhookDispatcher(webhooksService)
+	budgetsService.SetWebhookDispatcher(webhooksService)
+	pocketsService.SetWebhookDispatcher(webhooksService)
+	webhooksHandler := webhooks.NewHandler(webhooksService, authService, cfg.SessionCookieName, logger)
+
 	// Create rate limiters for auth endpoints (if enabled)
 	// Login/Register: 5 requests per minute per IP (strict to prevent brute force)
 	// Password reset: 3 requests per minute per IP (even stricter)
 	var rateLimitAuth, rateLimitReset func(http.Handler) http.Handler
 	if cfg.RateLimitEnabled {
-		authLimiter := middleware.NewRateLimiter(5, time.Minute)
-		resetLimiter := middleware.NewRateLimiter(3, time.Minute)
+		// Postgres counters are shared by all replicas; memory counters are per process
+		var authLimiter, resetLimiter middleware.Limiter
+		if cfg.RateLimitStore == "memory" {
+			authLimiter = middleware.NewRateLimiter(5, time.Minute)
+			resetLimiter = middleware.NewRateLimiter(3, time.Minute)
+		} else {
+			authLimiter = middleware.NewPostgresRateLimiter(ctx, pool, "auth", 5, time.Minute, logger)
+			resetLimiter = middleware.NewPostgresRateLimiter(ctx, pool, "reset", 3, time.Minute, logger)
+		}
 		rateLimitAuth = middleware.RateLimit(authLimiter)
 		rateLimitReset = middleware.RateLimit(resetLimiter)
-		logger.Info("rate limiting enabled for auth endpoints")
+
+		// Invitation emails are limited per household rather than per IP
+		var invitationLimiter middleware.Limiter
+		if cfg.RateLimitStore == "memory" {
+			invitationLimiter = middleware.NewRateLimiter(cfg.InvitationRateLimit, time.Hour)
+		} else {
+			invitationLimiter = middleware.NewPostgresRateLimiter(ctx, pool, "invitations", cfg.InvitationRateLimit, time.Hour, logger)
+		}
+		householdService.SetInvitationLimiter(invitationLimiter)
+		logger.Info("rate limiting enabled for auth endpoints", "store", cfg.RateLimitStore)
 	} else {
 		// No-op middleware when rate limiting is disabled
 		rateLimitAuth = func(next http.Handler) http.Handler { return next }
@@ -464,9 +627,27 @@ func New(ctx context.Context, cfg *config.Config, logger *slog.Logger) (*Server,
 	// Auth endpoints with optional rate limiting
 	mux.Handle("POST /auth/register", rateLimitAuth(http.HandlerFunc(authHandler.Register)))
 	mux.Handle("POST /auth/login", rateLimitAuth(http.HandlerFunc(authHandler.Login)))
+	mux.Handle("POST /auth/login/2fa", rateLimitAuth(http.HandlerFunc(authHandler.LoginTwoFactor)))
+	mux.Handle("POST /auth/magic-link", rateLimitReset(http.HandlerFunc(authHandler.RequestMagicLink)))
+	mux.Handle("POST /auth/magic-link/login", rateLimitAuth(http.HandlerFunc(authHandler.LoginMagicLink)))
+	mux.Handle("POST /auth/verify-email", rateLimitAuth(http.HandlerFunc(authHandler.VerifyEmail)))
+	mux.Handle("POST /auth/unlock-account", rateLimitAuth(http.HandlerFunc(authHandler.UnlockAccount)))
 	mux.HandleFunc("POST /auth/logout", authHandler.Logout)
 	mux.HandleFunc("GET /me", authHandler.Me)
 	mux.HandleFunc("POST /me/onboarding/complete", authHandler.CompleteOnboarding)
+	mux.Handle("POST /me/email-verification", rateLimitReset(http.HandlerFunc(authHandler.ResendEmailVerification)))
+	mux.HandleFunc("GET /me/tokens", authHandler.ListAccessTokens)
+	mux.HandleFunc("POST /me/tokens", authHandler.CreateAccessToken)
+	mux.HandleFunc("DELETE /me/tokens/{id}", authHandler.RevokeAccessToken)
+	mux.HandleFunc("GET /me/sessions", authHandler.ListSessions)
+	mux.HandleFunc("DELETE /me/sessions/{id}", authHandler.RevokeSession)
+	mux.HandleFunc("POST /me/sessions/revoke-others", authHandler.RevokeOtherSessions)
+	mux.HandleFunc("GET /me/2fa", authHandler.TwoFactorStatus)
+	mux.HandleFunc("GET /me/2fa/events", authHandler.TwoFactorEvents)
+	mux.HandleFunc("POST /me/2fa/setup", authHandler.SetupTwoFactor)
+	mux.HandleFunc("POST /me/2fa/enable", authHandler.EnableTwoFactor)
+	mux.Handle("POST /me/2fa/disable", rateLimitAuth(http.HandlerFunc(authHandler.DisableTwoFactor)))
+	mux.Handle("POST /me/2fa/recovery-codes", rateLimitAuth(http.HandlerFunc(authHandler.RegenerateRecoveryCodes)))
 	mux.Handle("POST /auth/forgot-password", rateLimitReset(http.HandlerFunc(authHandler.ForgotPassword)))
 	mux.Handle("POST /auth/reset-password", rateLimitReset(http.HandlerFunc(authHandler.ResetPassword)))
 	mux.HandleFunc("DELETE /auth/account", authHandler.DeleteAccount)
@@ -478,6 +659,8 @@ func New(ctx context.Context, cfg *config.Config, logger *slog.Logger) (*Server,
 	mux.HandleFunc("PATCH /households/{id}", householdHandler.UpdateHousehold)
 	mux.HandleFunc("DELETE /households/{id}", householdHandler.DeleteHousehold)
 	mux.HandleFunc("POST /households/{id}/leave", householdHandler.LeaveHousehold)
+	mux.HandleFunc("POST /households/{id}/transfer-ownership", householdHandler.TransferOwnership)
+	mux.HandleFunc("POST /households/{id}/merge", householdHandler.MergeHousehold)
 	
 	// Member management endpoints
 	mux.HandleFunc("POST /households/{id}/members", householdHandler.AddMember)
@@ -490,6 +673,7 @@ func New(ctx context.Context, cfg *config.Config, logger *slog.Logger) (*Server,
 	mux.HandleFunc("PATCH /households/{household_id}/contacts/{contact_id}", householdHandler.UpdateContact)
 	mux.HandleFunc("DELETE /households/{household_id}/contacts/{contact_id}", householdHandler.DeleteContact)
 	mux.HandleFunc("POST /households/{household_id}/contacts/{contact_id}/promote", householdHandler.PromoteContact)
+	mux.HandleFunc("POST /households/{household_id}/contacts/{contact_id}/merge", householdHandler.MergeContact)
 
 	// Contact linking endpoints
 	mux.HandleFunc("GET /contacts/check-email", householdHandler.CheckEmail)
@@ -499,6 +683,9 @@ func New(ctx context.Context, cfg *config.Config, logger *slog.Logger) (*Server,
 	
 	// Invitation endpoints
 	mux.HandleFunc("POST /households/{id}/invitations", householdHandler.CreateInvitation)
+	mux.HandleFunc("GET /households/{id}/invitations", householdHandler.ListInvitations)
+	mux.HandleFunc("POST /households/{household_id}/invitations/{invitation_id}/resend", householdHandler.ResendInvitation)
+	mux.HandleFunc("DELETE /households/{household_id}/invitations/{invitation_id}", householdHandler.RevokeInvitation)
 	mux.HandleFunc("GET /invitations/{token}", householdHandler.GetInvitationInfo)
 	mux.HandleFunc("POST /invitations/accept", householdHandler.AcceptInvitation)
 
@@ -511,6 +698,7 @@ func New(ctx context.Context, cfg *config.Config, logger *slog.Logger) (*Server,
 	// Accounts endpoints
 	mux.HandleFunc("POST /accounts", accountsHandler.CreateAccount)
 	mux.HandleFunc("GET /accounts", accountsHandler.ListAccounts)
+	mux.HandleFunc("GET /accounts/summary", accountsHandler.GetAccountSummary)
 	mux.HandleFunc("GET /accounts/{id}", accountsHandler.GetAccount)
 	mux.HandleFunc("PATCH /accounts/{id}", accountsHandler.UpdateAccount)
 	mux.HandleFunc("DELETE /accounts/{id}", accountsHandler.DeleteAccount)
@@ -539,7 +727,35 @@ func New(ctx context.Context, cfg *config.Config, logger *slog.Logger) (*Server,
 	
 	// Debt consolidation (for Resume page)
 	mux.HandleFunc("GET /movements/debts/consolidate", movementsHandler.HandleGetDebtConsolidation)
+
+	// Movements from other households shared with the user (linked contacts)
+	mux.HandleFunc("GET /movements/shared", movementsHandler.HandleListShared)
+
+	// Movement comments and disputes (household members and linked participants)
+	mux.HandleFunc("GET /movements/{id}/comments", movementCommentsHandler.HandleListComments)
+	mux.HandleFunc("POST /movements/{id}/comments", movementCommentsHandler.HandleAddComment)
+	mux.HandleFunc("DELETE /movements/{id}/comments/{comment_id}", movementCommentsHandler.HandleDeleteComment)
+	mux.HandleFunc("GET /movements/{id}/disputes", movementCommentsHandler.HandleListDisputes)
+	mux.HandleFunc("POST /movements/{id}/dispute", movementCommentsHandler.HandleOpenDispute)
+	mux.HandleFunc("POST /movements/{id}/dispute/resolve", movementCommentsHandler.HandleResolveDispute)
 	
+	// Real-time household change events (Server-Sent Events)
+	mux.HandleFunc("GET /events/stream", eventsHandler.HandleStream)
+
+	// Notification inbox endpoints
+	mux.HandleFunc("GET /notifications", notificationsHandler.HandleList)
+	mux.HandleFunc("GET /notifications/unread-count", notificationsHandler.HandleUnreadCount)
+	mux.HandleFunc("POST /notifications/read-all", notificationsHandler.HandleMarkAllRead)
+	mux.HandleFunc("POST /notifications/{id}/read", notificationsHandler.HandleMarkRead)
+
+	// Outgoing webhook endpoints (household owners only)
+	mux.HandleFunc("GET /webhooks", webhooksHandler.HandleList)
+	mux.HandleFunc("POST /webhooks", webhooksHandler.HandleCreate)
+	mux.HandleFunc("PATCH /webhooks/{id}", webhooksHandler.HandleUpdate)
+	mux.HandleFunc("DELETE /webhooks/{id}", webhooksHandler.HandleDelete)
+	mux.HandleFunc("GET /webhooks/{id}/deliveries", webhooksHandler.HandleListDeliveries)
+	mux.HandleFunc("POST /webhooks/{id}/deliveries/{delivery_id}/redeliver", webhooksHandler.HandleRedeliver)
+
 	// Movement form config endpoint
 	mux.HandleFunc("GET /movement-form-config", formConfigHandler.GetFormConfig)
 
@@ -565,6 +781,29 @@ func New(ctx context.Context, cfg *config.Config, logger *slog.Logger) (*Server,
 	mux.HandleFunc("PUT /budgets", budgetsHandler.SetBudget)
 	mux.HandleFunc("DELETE /budgets/{id}", budgetsHandler.DeleteBudget)
 	mux.HandleFunc("POST /budgets/copy", budgetsHandler.CopyBudgets)
+	mux.HandleFunc("PUT /budgets/settings/{category_id}", budgetsHandler.SetCategorySettings)
+	mux.HandleFunc("PUT /budgets/settings/{category_id}/projection", budgetsHandler.SetCategoryProjection)
+	mux.HandleFunc("POST /budgets/reassign", budgetsHandler.ReassignBudget)
+	mux.HandleFunc("GET /budgets/reassignments", budgetsHandler.ListReassignments)
+	mux.HandleFunc("GET /budgets/periods", budgetsHandler.ListPeriodBudgets)
+	mux.HandleFunc("PUT /budgets/periods", budgetsHandler.SetPeriodBudget)
+	mux.HandleFunc("DELETE /budgets/periods/{id}", budgetsHandler.DeletePeriodBudget)
+	mux.HandleFunc("PUT /budgets/groups", budgetsHandler.SetGroupBudget)
+	mux.HandleFunc("DELETE /budgets/groups/{id}", budgetsHandler.DeleteGroupBudget)
+	mux.HandleFunc("GET /budgets/allowances", budgetsHandler.ListAllowances)
+	mux.HandleFunc("PUT /budgets/allowances", budgetsHandler.SetAllowance)
+	mux.HandleFunc("DELETE /budgets/allowances/{id}", budgetsHandler.DeleteAllowance)
+	mux.HandleFunc("PUT /budgets/allowances/categories/{category_id}", budgetsHandler.SetCategoryOwner)
+	mux.HandleFunc("DELETE /budgets/allowances/categories/{category_id}", budgetsHandler.ClearCategoryOwner)
+	mux.HandleFunc("GET /budgets/alerts", budgetsHandler.ListAlerts)
+	mux.HandleFunc("GET /budgets/alerts/settings", budgetsHandler.GetAlertSettings)
+	mux.HandleFunc("PUT /budgets/alerts/settings", budgetsHandler.SetHouseholdAlertThresholds)
+	mux.HandleFunc("PUT /budgets/alerts/categories/{category_id}", budgetsHandler.SetCategoryAlertThresholds)
+	mux.HandleFunc("PUT /budgets/alerts/categories/{category_id}/mute", budgetsHandler.MuteCategoryAlerts)
+	mux.HandleFunc("DELETE /budgets/alerts/categories/{category_id}/mute", budgetsHandler.UnmuteCategoryAlerts)
+
+	// Reports endpoints
+	mux.HandleFunc("GET /reports/budget-trend", budgetsHandler.GetBudgetTrend)
 
 	// Budget items endpoints (monthly snapshots)
 	mux.HandleFunc("GET /api/budget-items/{month}", budgetItemsHandler.HandleListByMonth)
@@ -659,6 +898,7 @@ func New(ctx context.Context, cfg *config.Config, logger *slog.Logger) (*Server,
 	var handler http.Handler = mux
 	handler = middleware.NoCache()(handler)
 	handler = middleware.Gzip()(handler)
+	handler = middleware.HouseholdSelection(authService, householdRepo, cfg.SessionCookieName)(handler) // Active household from X-Household-ID or path
 	handler = middleware.AuditContext()(handler) // Add request metadata to context for audit logging
 	handler = middleware.Logging(logger)(handler)
 	handler = middleware.CORS(cfg.AllowedOrigins)(handler)
@@ -42,6 +42,8 @@ type CreateIncomeRequest struct {
 	Amount      float64 `json:"amount"`
 	Description string  `json:"description"`
 	IncomeDate  string  `json:"income_date"` // YYYY-MM-DD format
+
+	IsSharedWithHousehold *bool `json:"is_shared_with_household,omitempty"` // Optional, defaults to true
 }
 
 type UpdateIncomeRequest struct {
@@ -50,6 +52,8 @@ type UpdateIncomeRequest struct {
 	Amount      *float64 `json:"amount,omitempty"`
 	Description *string  `json:"description,omitempty"`
 	IncomeDate  *string  `json:"income_date,omitempty"` // YYYY-MM-DD format
+
+	IsSharedWithHousehold *bool `json:"is_shared_with_household,omitempty"`
 }
 
 type ErrorResponse struct {
@@ -59,12 +63,7 @@ type ErrorResponse struct {
 // Helper methods
 
 func (h *Handler) getUserFromRequest(r *http.Request) (*auth.User, error) {
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		return nil, errors.New("no session cookie")
-	}
-
-	user, err := h.authSvc.GetUserBySession(r.Context(), cookie.Value)
+	user, err := h.authSvc.UserFromRequest(r, h.cookieName)
 	if err != nil {
 		return nil, err
 	}
@@ -114,6 +113,8 @@ func (h *Handler) HandleCreate(w http.ResponseWriter, r *http.Request) {
 		Amount:      req.Amount,
 		Description: req.Description,
 		IncomeDate:  incomeDate,
+
+		IsSharedWithHousehold: req.IsSharedWithHousehold,
 	}
 
 	income, err := h.service.Create(r.Context(), user.ID, input)
@@ -246,6 +247,8 @@ func (h *Handler) HandleUpdate(w http.ResponseWriter, r *http.Request) {
 		AccountID:   req.AccountID,
 		Description: req.Description,
 		Amount:      req.Amount,
+
+		IsSharedWithHousehold: req.IsSharedWithHousehold,
 	}
 
 	if req.Type != nil {
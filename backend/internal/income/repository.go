@@ -26,13 +26,14 @@ func (r *repository) Create(ctx context.Context, input *CreateIncomeInput, house
 	var income Income
 	err := r.pool.QueryRow(ctx, `
 		INSERT INTO income (
-			household_id, member_id, account_id, type, amount, description, income_date
+			household_id, member_id, account_id, type, amount, description, income_date,
+			is_shared_with_household
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE($8, TRUE))
 		RETURNING id, household_id, member_id, account_id, type, amount, description, 
-		          income_date, created_at, updated_at
+		          income_date, is_shared_with_household, created_at, updated_at
 	`, householdID, input.MemberID, input.AccountID, input.Type, input.Amount,
-		input.Description, input.IncomeDate).Scan(
+		input.Description, input.IncomeDate, input.IsSharedWithHousehold).Scan(
 		&income.ID,
 		&income.HouseholdID,
 		&income.MemberID,
@@ -41,6 +42,7 @@ func (r *repository) Create(ctx context.Context, input *CreateIncomeInput, house
 		&income.Amount,
 		&income.Description,
 		&income.IncomeDate,
+		&income.IsSharedWithHousehold,
 		&income.CreatedAt,
 		&income.UpdatedAt,
 	)
@@ -63,7 +65,7 @@ func (r *repository) GetByID(ctx context.Context, id string) (*Income, error) {
 	var income Income
 	err := r.pool.QueryRow(ctx, `
 		SELECT i.id, i.household_id, i.member_id, i.account_id, i.type, i.amount, 
-		       i.description, i.income_date, i.created_at, i.updated_at,
+		       i.description, i.income_date, i.is_shared_with_household, i.created_at, i.updated_at,
 		       u.name as member_name, a.name as account_name
 		FROM income i
 		JOIN users u ON i.member_id = u.id
@@ -78,6 +80,7 @@ func (r *repository) GetByID(ctx context.Context, id string) (*Income, error) {
 		&income.Amount,
 		&income.Description,
 		&income.IncomeDate,
+		&income.IsSharedWithHousehold,
 		&income.CreatedAt,
 		&income.UpdatedAt,
 		&income.MemberName,
@@ -98,7 +101,7 @@ func (r *repository) GetByID(ctx context.Context, id string) (*Income, error) {
 func (r *repository) ListByHousehold(ctx context.Context, householdID string, filters *ListIncomeFilters) ([]*Income, error) {
 	query := `
 		SELECT i.id, i.household_id, i.member_id, i.account_id, i.type, i.amount, 
-		       i.description, i.income_date, i.created_at, i.updated_at,
+		       i.description, i.income_date, i.is_shared_with_household, i.created_at, i.updated_at,
 		       u.name as member_name, a.name as account_name
 		FROM income i
 		JOIN users u ON i.member_id = u.id
@@ -159,6 +162,7 @@ func (r *repository) ListByHousehold(ctx context.Context, householdID string, fi
 			&income.Amount,
 			&income.Description,
 			&income.IncomeDate,
+			&income.IsSharedWithHousehold,
 			&income.CreatedAt,
 			&income.UpdatedAt,
 			&income.MemberName,
@@ -323,6 +327,11 @@ func (r *repository) Update(ctx context.Context, id string, input *UpdateIncomeI
 		args = append(args, *input.IncomeDate)
 		argNum++
 	}
+	if input.IsSharedWithHousehold != nil {
+		setParts = append(setParts, fmt.Sprintf("is_shared_with_household = $%d", argNum))
+		args = append(args, *input.IsSharedWithHousehold)
+		argNum++
+	}
 
 	if len(setParts) == 0 {
 		// Nothing to update, just return existing income
@@ -342,7 +351,7 @@ func (r *repository) Update(ctx context.Context, id string, input *UpdateIncomeI
 		SET %s
 		WHERE id = $%d
 		RETURNING id, household_id, member_id, account_id, type, amount, description, 
-		          income_date, created_at, updated_at
+		          income_date, is_shared_with_household, created_at, updated_at
 	`, strings.Join(setParts, ", "), argNum)
 
 	var income Income
@@ -355,6 +364,7 @@ func (r *repository) Update(ctx context.Context, id string, input *UpdateIncomeI
 		&income.Amount,
 		&income.Description,
 		&income.IncomeDate,
+		&income.IsSharedWithHousehold,
 		&income.CreatedAt,
 		&income.UpdatedAt,
 	)
@@ -31,6 +31,15 @@ func NewService(repo Repository, accountsRepo accounts.Repository, householdsRep
 	}
 }
 
+// authorizeEdit resolves the user's household and checks their role may record income
+func (s *service) authorizeEdit(ctx context.Context, userID string) (string, error) {
+	householdID, err := households.AuthorizeSelected(ctx, s.householdsRepo, userID, households.PermEditMovements)
+	if errors.Is(err, households.ErrNotAuthorized) {
+		return "", ErrNotAuthorized
+	}
+	return householdID, err
+}
+
 // Create creates a new income entry
 func (s *service) Create(ctx context.Context, userID string, input *CreateIncomeInput) (*Income, error) {
 	// Validate input
@@ -39,7 +48,7 @@ func (s *service) Create(ctx context.Context, userID string, input *CreateIncome
 	}
 
 	// Get user's household
-	householdID, err := s.householdsRepo.GetUserHouseholdID(ctx, userID)
+	householdID, err := s.authorizeEdit(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -53,6 +62,11 @@ func (s *service) Create(ctx context.Context, userID string, input *CreateIncome
 		return nil, ErrMemberNotInHousehold
 	}
 
+	// A member can only keep their own income private
+	if input.IsSharedWithHousehold != nil && !*input.IsSharedWithHousehold && input.MemberID != userID {
+		return nil, ErrNotAuthorized
+	}
+
 	// Verify account exists and belongs to household
 	account, err := s.accountsRepo.GetByID(ctx, input.AccountID)
 	if err != nil {
@@ -61,7 +75,7 @@ func (s *service) Create(ctx context.Context, userID string, input *CreateIncome
 		}
 		return nil, err
 	}
-	if account.HouseholdID != householdID {
+	if account.HouseholdID != householdID || !account.VisibleTo(userID) {
 		return nil, ErrNotAuthorized
 	}
 
@@ -108,7 +122,7 @@ func (s *service) GetByID(ctx context.Context, userID, id string) (*Income, erro
 	}
 
 	// Verify user has access to this income (belongs to same household)
-	householdID, err := s.householdsRepo.GetUserHouseholdID(ctx, userID)
+	householdID, err := s.householdsRepo.ResolveHouseholdID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -116,6 +130,9 @@ func (s *service) GetByID(ctx context.Context, userID, id string) (*Income, erro
 	if income.HouseholdID != householdID {
 		return nil, ErrNotAuthorized
 	}
+	if !income.VisibleTo(userID) {
+		return nil, ErrIncomeNotFound
+	}
 
 	return income, nil
 }
@@ -123,7 +140,7 @@ func (s *service) GetByID(ctx context.Context, userID, id string) (*Income, erro
 // ListByHousehold retrieves all income entries for user's household
 func (s *service) ListByHousehold(ctx context.Context, userID string, filters *ListIncomeFilters) (*ListIncomeResponse, error) {
 	// Get user's household
-	householdID, err := s.householdsRepo.GetUserHouseholdID(ctx, userID)
+	householdID, err := s.householdsRepo.ResolveHouseholdID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -156,16 +173,26 @@ func (s *service) ListByHousehold(ctx context.Context, userID string, filters *L
 		return nil, err
 	}
 
-	// Get totals
+	// Get totals (private income included)
 	totals, err := s.repo.GetTotals(ctx, householdID, filters)
 	if err != nil {
 		return nil, err
 	}
 
-	return &ListIncomeResponse{
-		IncomeEntries: incomes,
+	resp := &ListIncomeResponse{
+		IncomeEntries: make([]*Income, 0, len(incomes)),
 		Totals:        totals,
-	}, nil
+	}
+	for _, income := range incomes {
+		if income.VisibleTo(userID) {
+			resp.IncomeEntries = append(resp.IncomeEntries, income)
+			continue
+		}
+		resp.PrivateCount++
+		resp.PrivateAmount += income.Amount
+	}
+
+	return resp, nil
 }
 
 // Update updates an income entry
@@ -182,7 +209,7 @@ func (s *service) Update(ctx context.Context, userID, id string, input *UpdateIn
 	}
 
 	// Verify user has access
-	householdID, err := s.householdsRepo.GetUserHouseholdID(ctx, userID)
+	householdID, err := s.authorizeEdit(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -190,6 +217,15 @@ func (s *service) Update(ctx context.Context, userID, id string, input *UpdateIn
 	if existing.HouseholdID != householdID {
 		return nil, ErrNotAuthorized
 	}
+	if !existing.VisibleTo(userID) {
+		return nil, ErrIncomeNotFound
+	}
+
+	// Only the member can change who sees their income
+	if input.IsSharedWithHousehold != nil && *input.IsSharedWithHousehold != existing.IsSharedWithHousehold &&
+		existing.MemberID != userID {
+		return nil, ErrNotAuthorized
+	}
 
 	// If updating account, verify it exists, belongs to household, and can receive income
 	if input.AccountID != nil {
@@ -200,7 +236,7 @@ func (s *service) Update(ctx context.Context, userID, id string, input *UpdateIn
 			}
 			return nil, err
 		}
-		if account.HouseholdID != householdID {
+		if account.HouseholdID != householdID || !account.VisibleTo(userID) {
 			return nil, ErrNotAuthorized
 		}
 		if !account.Type.CanReceiveIncome() {
@@ -254,7 +290,7 @@ func (s *service) Delete(ctx context.Context, userID, id string) error {
 	}
 
 	// Verify user has access
-	householdID, err := s.householdsRepo.GetUserHouseholdID(ctx, userID)
+	householdID, err := s.authorizeEdit(ctx, userID)
 	if err != nil {
 		return err
 	}
@@ -262,6 +298,9 @@ func (s *service) Delete(ctx context.Context, userID, id string) error {
 	if existing.HouseholdID != householdID {
 		return ErrNotAuthorized
 	}
+	if !existing.VisibleTo(userID) {
+		return ErrIncomeNotFound
+	}
 
 	// Delete income
 	err = s.repo.Delete(ctx, id)
@@ -74,6 +74,14 @@ type Income struct {
 	IncomeDate  time.Time  `json:"income_date"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
+
+	// Private income is listed only to its member; other members see it in the totals
+	IsSharedWithHousehold bool `json:"is_shared_with_household"`
+}
+
+// VisibleTo reports whether userID may see the income entry itself
+func (i *Income) VisibleTo(userID string) bool {
+	return i.IsSharedWithHousehold || i.MemberID == userID
 }
 
 // CreateIncomeInput represents the input for creating an income entry
@@ -84,6 +92,8 @@ type CreateIncomeInput struct {
 	Amount      float64    `json:"amount"`
 	Description string     `json:"description"`
 	IncomeDate  time.Time  `json:"income_date"`
+
+	IsSharedWithHousehold *bool `json:"is_shared_with_household,omitempty"` // Optional, defaults to true
 }
 
 // Validate validates the create income input
@@ -116,6 +126,8 @@ type UpdateIncomeInput struct {
 	Amount      *float64    `json:"amount,omitempty"`
 	Description *string     `json:"description,omitempty"`
 	IncomeDate  *time.Time  `json:"income_date,omitempty"`
+
+	IsSharedWithHousehold *bool `json:"is_shared_with_household,omitempty"` // Only the member may change it
 }
 
 // Validate validates the update income input
@@ -160,10 +172,14 @@ type MemberTotals struct {
 	InternalMovements  float64 `json:"internal_movements"`
 }
 
-// ListIncomeResponse represents the response for listing income
+// ListIncomeResponse represents the response for listing income. Totals
+// include other members' private income, which is left out of IncomeEntries
+// and reported under the Private fields.
 type ListIncomeResponse struct {
 	IncomeEntries []*Income       `json:"income_entries"`
 	Totals        *IncomeTotals   `json:"totals"`
+	PrivateCount  int             `json:"private_count"`
+	PrivateAmount float64         `json:"private_amount"`
 }
 
 // Repository defines the interface for income data access
@@ -2,8 +2,10 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
 
+	"github.com/blanquicet/conti/backend/internal/auth"
 	"github.com/blanquicet/conti/backend/internal/sessions"
 )
 
@@ -15,12 +17,38 @@ const (
 	UserIDKey contextKey = "user_id"
 	// SessionIDKey is the context key for the session ID.
 	SessionIDKey contextKey = "session_id"
+	// AccessTokenIDKey is the context key for the personal access token ID.
+	AccessTokenIDKey contextKey = "access_token_id"
 )
 
-// Auth returns a middleware that validates session cookies.
-func Auth(sessionStore *sessions.Store, cookieName string) func(http.Handler) http.Handler {
+// AccessTokenAuthenticator validates personal access tokens.
+type AccessTokenAuthenticator interface {
+	AuthenticateAccessToken(ctx context.Context, token, method, path string) (*auth.AccessToken, error)
+}
+
+// Auth returns a middleware that validates session cookies or, when an
+// "Authorization: Bearer" header is present, personal access tokens.
+func Auth(sessionStore *sessions.Store, tokens AccessTokenAuthenticator, cookieName string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if plain, ok := auth.BearerToken(r); ok {
+				token, err := tokens.AuthenticateAccessToken(r.Context(), plain, r.Method, r.URL.Path)
+				if err != nil {
+					if errors.Is(err, auth.ErrTokenScope) {
+						http.Error(w, "forbidden", http.StatusForbidden)
+						return
+					}
+					http.Error(w, "unauthorized", http.StatusUnauthorized)
+					return
+				}
+
+				ctx := context.WithValue(r.Context(), UserIDKey, token.UserID)
+				ctx = context.WithValue(ctx, AccessTokenIDKey, token.ID)
+
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
 			cookie, err := r.Cookie(cookieName)
 			if err != nil {
 				http.Error(w, "unauthorized", http.StatusUnauthorized)
@@ -33,6 +61,9 @@ func Auth(sessionStore *sessions.Store, cookieName string) func(http.Handler) ht
 				return
 			}
 
+			// Best effort: failing to record activity must not block the request
+			_ = sessionStore.Touch(r.Context(), session.ID)
+
 			// Add user ID and session ID to context
 			ctx := context.WithValue(r.Context(), UserIDKey, session.UserID)
 			ctx = context.WithValue(ctx, SessionIDKey, session.ID)
@@ -53,3 +84,9 @@ func GetSessionID(ctx context.Context) (string, bool) {
 	sessionID, ok := ctx.Value(SessionIDKey).(string)
 	return sessionID, ok
 }
+
+// GetAccessTokenID retrieves the personal access token ID from the request context.
+func GetAccessTokenID(ctx context.Context) (string, bool) {
+	tokenID, ok := ctx.Value(AccessTokenIDKey).(string)
+	return tokenID, ok
+}
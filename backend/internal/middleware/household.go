@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/blanquicet/conti/backend/internal/auth"
+	"github.com/blanquicet/conti/backend/internal/households"
+)
+
+// RequestAuthenticator resolves the user behind a request.
+type RequestAuthenticator interface {
+	UserFromRequest(r *http.Request, cookieName string) (*auth.User, error)
+}
+
+// HouseholdMembershipChecker reports whether a user belongs to a household.
+type HouseholdMembershipChecker interface {
+	IsUserMember(ctx context.Context, householdID, userID string) (bool, error)
+}
+
+// HouseholdSelection returns a middleware that stores the household a request
+// acts on (from the /households/{id} path or the X-Household-ID header) in the
+// context. A header naming a household the user does not belong to is
+// rejected up front; path routes keep authorizing in their handlers.
+func HouseholdSelection(users RequestAuthenticator, members HouseholdMembershipChecker, cookieName string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			householdID, fromHeader := households.SelectionFromRequest(r)
+			if householdID == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if fromHeader {
+				if !households.IsValidHouseholdID(householdID) {
+					http.Error(w, "invalid "+households.HouseholdIDHeader+" header", http.StatusBadRequest)
+					return
+				}
+				// Unauthenticated requests are left to the handler to reject
+				if user, err := users.UserFromRequest(r, cookieName); err == nil {
+					isMember, err := members.IsUserMember(r.Context(), householdID, user.ID)
+					if err != nil {
+						http.Error(w, "internal server error", http.StatusInternalServerError)
+						return
+					}
+					if !isMember {
+						http.Error(w, "forbidden", http.StatusForbidden)
+						return
+					}
+				}
+			}
+
+			ctx := households.WithSelectedHousehold(r.Context(), householdID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
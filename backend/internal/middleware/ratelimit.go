@@ -6,7 +6,14 @@ import (
 	"time"
 )
 
+// Limiter decides whether another request for a key (usually the client IP)
+// fits in the current window.
+type Limiter interface {
+	Allow(key string) bool
+}
+
 // RateLimiter implements a simple in-memory rate limiter per IP.
+// Counters are per process; use PostgresRateLimiter when running several replicas.
 type RateLimiter struct {
 	mu       sync.RWMutex
 	requests map[string]*rateLimitEntry
@@ -80,7 +87,7 @@ func (rl *RateLimiter) cleanup() {
 }
 
 // RateLimit returns a middleware that limits requests per IP.
-func RateLimit(limiter *RateLimiter) func(http.Handler) http.Handler {
+func RateLimit(limiter Limiter) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ip := getClientIP(r)
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// postgresLimiterTimeout bounds each counter update so a slow database
+// cannot stall the request it is guarding.
+const postgresLimiterTimeout = 2 * time.Second
+
+// PostgresRateLimiter is a fixed-window rate limiter whose counters live in
+// PostgreSQL, so limits hold across replicas and restarts.
+type PostgresRateLimiter struct {
+	pool   *pgxpool.Pool
+	name   string        // distinguishes limiters sharing the table
+	limit  int           // max requests
+	window time.Duration // time window
+	logger *slog.Logger
+}
+
+// NewPostgresRateLimiter creates a new PostgreSQL-backed rate limiter.
+// name: unique limiter name, e.g. "auth"
+// limit: max requests allowed in the window
+// window: time duration for the window
+func NewPostgresRateLimiter(ctx context.Context, pool *pgxpool.Pool, name string, limit int, window time.Duration, logger *slog.Logger) *PostgresRateLimiter {
+	rl := &PostgresRateLimiter{
+		pool:   pool,
+		name:   name,
+		limit:  limit,
+		window: window,
+		logger: logger,
+	}
+
+	// Start cleanup goroutine
+	go rl.cleanup(ctx)
+
+	return rl
+}
+
+// Allow checks if a request for the given key is allowed. If the database is
+// unavailable the request is allowed (fail open) and the error is logged:
+// per-account lockout still protects logins.
+func (rl *PostgresRateLimiter) Allow(key string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), postgresLimiterTimeout)
+	defer cancel()
+
+	var count int
+	err := rl.pool.QueryRow(ctx, `
+		INSERT INTO rate_limit_counters (limiter, key, count, window_expires_at)
+		VALUES ($1, $2, 1, NOW() + $3 * INTERVAL '1 second')
+		ON CONFLICT (limiter, key) DO UPDATE
+		SET count = CASE
+				WHEN rate_limit_counters.window_expires_at <= NOW() THEN 1
+				ELSE rate_limit_counters.count + 1
+			END,
+			window_expires_at = CASE
+				WHEN rate_limit_counters.window_expires_at <= NOW() THEN EXCLUDED.window_expires_at
+				ELSE rate_limit_counters.window_expires_at
+			END
+		RETURNING count
+	`, rl.name, key, rl.window.Seconds()).Scan(&count)
+	if err != nil {
+		rl.logger.Error("rate limiter query failed, allowing request",
+			"error", err,
+			"limiter", rl.name,
+		)
+		return true
+	}
+
+	return count <= rl.limit
+}
+
+// cleanup removes expired counters periodically until ctx is cancelled.
+func (rl *PostgresRateLimiter) cleanup(ctx context.Context) {
+	ticker := time.NewTicker(rl.window)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, err := rl.pool.Exec(ctx, `
+				DELETE FROM rate_limit_counters
+				WHERE limiter = $1 AND window_expires_at <= NOW()
+			`, rl.name)
+			if err != nil && ctx.Err() == nil {
+				rl.logger.Error("failed to clean up rate limit counters", "error", err, "limiter", rl.name)
+			}
+		}
+	}
+}
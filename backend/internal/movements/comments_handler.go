@@ -0,0 +1,200 @@
+package movements
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/blanquicet/conti/backend/internal/auth"
+)
+
+// CommentsHandler handles HTTP requests for movement comments and disputes
+type CommentsHandler struct {
+	service    *CommentsService
+	authSvc    *auth.Service
+	cookieName string
+	logger     *slog.Logger
+}
+
+// NewCommentsHandler creates a new movement comments handler
+func NewCommentsHandler(service *CommentsService, authSvc *auth.Service, cookieName string, logger *slog.Logger) *CommentsHandler {
+	return &CommentsHandler{
+		service:    service,
+		authSvc:    authSvc,
+		cookieName: cookieName,
+		logger:     logger,
+	}
+}
+
+func (h *CommentsHandler) getUserID(r *http.Request) (string, error) {
+	user, err := h.authSvc.UserFromRequest(r, h.cookieName)
+	if err != nil {
+		return "", err
+	}
+	return user.ID, nil
+}
+
+func (h *CommentsHandler) writeError(w http.ResponseWriter, err error) {
+	switch err {
+	case ErrMovementNotFound, ErrCommentNotFound, ErrDisputeNotFound:
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case ErrNotAuthorized:
+		http.Error(w, "Not authorized", http.StatusForbidden)
+	case ErrOnlyCreatorCanResolve, ErrOnlyAuthorCanDelete:
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case ErrDisputeAlreadyOpen:
+		http.Error(w, err.Error(), http.StatusConflict)
+	case ErrCommentBodyRequired, ErrDisputeReasonRequired:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (h *CommentsHandler) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+// HandleListComments returns the comment thread of a movement
+// GET /movements/{id}/comments
+func (h *CommentsHandler) HandleListComments(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserID(r)
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	movementID := r.PathValue("id")
+	comments, err := h.service.ListComments(r.Context(), userID, movementID)
+	if err != nil {
+		h.logger.Error("failed to list comments", "error", err, "movement_id", movementID, "user_id", userID)
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{"comments": comments})
+}
+
+// HandleAddComment adds a comment to a movement
+// POST /movements/{id}/comments
+func (h *CommentsHandler) HandleAddComment(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserID(r)
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var input CreateCommentInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	movementID := r.PathValue("id")
+	comment, err := h.service.AddComment(r.Context(), userID, movementID, &input)
+	if err != nil {
+		h.logger.Error("failed to add comment", "error", err, "movement_id", movementID, "user_id", userID)
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, comment)
+}
+
+// HandleDeleteComment deletes one of the user's comments
+// DELETE /movements/{id}/comments/{comment_id}
+func (h *CommentsHandler) HandleDeleteComment(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserID(r)
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	movementID := r.PathValue("id")
+	commentID := r.PathValue("comment_id")
+	if err := h.service.DeleteComment(r.Context(), userID, movementID, commentID); err != nil {
+		h.logger.Error("failed to delete comment", "error", err, "comment_id", commentID, "user_id", userID)
+		h.writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleListDisputes returns the dispute history of a movement
+// GET /movements/{id}/disputes
+func (h *CommentsHandler) HandleListDisputes(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserID(r)
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	movementID := r.PathValue("id")
+	disputes, err := h.service.ListDisputes(r.Context(), userID, movementID)
+	if err != nil {
+		h.logger.Error("failed to list disputes", "error", err, "movement_id", movementID, "user_id", userID)
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{"disputes": disputes})
+}
+
+// HandleOpenDispute disputes a movement
+// POST /movements/{id}/dispute
+func (h *CommentsHandler) HandleOpenDispute(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserID(r)
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var input OpenDisputeInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	movementID := r.PathValue("id")
+	dispute, err := h.service.OpenDispute(r.Context(), userID, movementID, &input)
+	if err != nil {
+		h.logger.Error("failed to open dispute", "error", err, "movement_id", movementID, "user_id", userID)
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, dispute)
+}
+
+// HandleResolveDispute resolves the open dispute of a movement
+// POST /movements/{id}/dispute/resolve
+func (h *CommentsHandler) HandleResolveDispute(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserID(r)
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var input ResolveDisputeInput
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	movementID := r.PathValue("id")
+	dispute, err := h.service.ResolveDispute(r.Context(), userID, movementID, &input)
+	if err != nil {
+		h.logger.Error("failed to resolve dispute", "error", err, "movement_id", movementID, "user_id", userID)
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, dispute)
+}
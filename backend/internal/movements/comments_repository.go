@@ -0,0 +1,181 @@
+package movements
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type commentsRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCommentsRepository creates a new movement comments repository
+func NewCommentsRepository(pool *pgxpool.Pool) CommentsRepository {
+	return &commentsRepository{pool: pool}
+}
+
+// ListComments returns the comment thread of a movement, oldest first
+func (r *commentsRepository) ListComments(ctx context.Context, movementID string) ([]*Comment, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT mc.id, mc.movement_id, mc.author_user_id, u.name, mc.body, mc.created_at, mc.updated_at
+		FROM movement_comments mc
+		JOIN users u ON mc.author_user_id = u.id
+		WHERE mc.movement_id = $1
+		ORDER BY mc.created_at ASC
+	`, movementID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := make([]*Comment, 0)
+	for rows.Next() {
+		var c Comment
+		if err := rows.Scan(&c.ID, &c.MovementID, &c.AuthorUserID, &c.AuthorName, &c.Body, &c.CreatedAt, &c.UpdatedAt); err != nil {
+			return nil, err
+		}
+		comments = append(comments, &c)
+	}
+	return comments, rows.Err()
+}
+
+// GetComment retrieves a comment by ID
+func (r *commentsRepository) GetComment(ctx context.Context, id string) (*Comment, error) {
+	var c Comment
+	err := r.pool.QueryRow(ctx, `
+		SELECT mc.id, mc.movement_id, mc.author_user_id, u.name, mc.body, mc.created_at, mc.updated_at
+		FROM movement_comments mc
+		JOIN users u ON mc.author_user_id = u.id
+		WHERE mc.id = $1
+	`, id).Scan(&c.ID, &c.MovementID, &c.AuthorUserID, &c.AuthorName, &c.Body, &c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+// CreateComment adds a comment to a movement
+func (r *commentsRepository) CreateComment(ctx context.Context, movementID, authorUserID, body string) (*Comment, error) {
+	var id string
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO movement_comments (movement_id, author_user_id, body)
+		VALUES ($1, $2, $3)
+		RETURNING id
+	`, movementID, authorUserID, body).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetComment(ctx, id)
+}
+
+// DeleteComment deletes a comment
+func (r *commentsRepository) DeleteComment(ctx context.Context, id string) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM movement_comments WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrCommentNotFound
+	}
+	return nil
+}
+
+const disputeSelect = `
+	SELECT d.id, d.movement_id, d.raised_by_user_id, u.name, d.reason, d.status,
+	       d.resolved_by_user_id, d.resolution_note, d.created_at, d.resolved_at
+	FROM movement_disputes d
+	JOIN users u ON d.raised_by_user_id = u.id
+`
+
+func scanDispute(row pgx.Row) (*Dispute, error) {
+	var d Dispute
+	err := row.Scan(
+		&d.ID,
+		&d.MovementID,
+		&d.RaisedByUserID,
+		&d.RaisedByName,
+		&d.Reason,
+		&d.Status,
+		&d.ResolvedByUserID,
+		&d.ResolutionNote,
+		&d.CreatedAt,
+		&d.ResolvedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrDisputeNotFound
+		}
+		return nil, err
+	}
+	return &d, nil
+}
+
+// ListDisputes returns all disputes of a movement, newest first
+func (r *commentsRepository) ListDisputes(ctx context.Context, movementID string) ([]*Dispute, error) {
+	rows, err := r.pool.Query(ctx, disputeSelect+`
+		WHERE d.movement_id = $1
+		ORDER BY d.created_at DESC
+	`, movementID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	disputes := make([]*Dispute, 0)
+	for rows.Next() {
+		d, err := scanDispute(rows)
+		if err != nil {
+			return nil, err
+		}
+		disputes = append(disputes, d)
+	}
+	return disputes, rows.Err()
+}
+
+// GetOpenDispute returns the open dispute of a movement, or ErrDisputeNotFound
+func (r *commentsRepository) GetOpenDispute(ctx context.Context, movementID string) (*Dispute, error) {
+	return scanDispute(r.pool.QueryRow(ctx, disputeSelect+`
+		WHERE d.movement_id = $1 AND d.status = 'OPEN'
+	`, movementID))
+}
+
+// CreateDispute opens a dispute on a movement
+func (r *commentsRepository) CreateDispute(ctx context.Context, movementID, raisedByUserID, reason string) (*Dispute, error) {
+	var id string
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO movement_disputes (movement_id, raised_by_user_id, reason)
+		VALUES ($1, $2, $3)
+		RETURNING id
+	`, movementID, raisedByUserID, reason).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, ErrDisputeAlreadyOpen
+		}
+		return nil, err
+	}
+	return scanDispute(r.pool.QueryRow(ctx, disputeSelect+`WHERE d.id = $1`, id))
+}
+
+// ResolveDispute marks an open dispute as resolved
+func (r *commentsRepository) ResolveDispute(ctx context.Context, id, resolvedByUserID string, note *string) (*Dispute, error) {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE movement_disputes
+		SET status = 'RESOLVED', resolved_by_user_id = $2, resolution_note = $3, resolved_at = NOW()
+		WHERE id = $1 AND status = 'OPEN'
+	`, id, resolvedByUserID, note)
+	if err != nil {
+		return nil, err
+	}
+	if result.RowsAffected() == 0 {
+		return nil, ErrDisputeNotFound
+	}
+	return scanDispute(r.pool.QueryRow(ctx, disputeSelect+`WHERE d.id = $1`, id))
+}
@@ -0,0 +1,335 @@
+package movements
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/blanquicet/conti/backend/internal/audit"
+	"github.com/blanquicet/conti/backend/internal/households"
+)
+
+// CommentsService handles comment threads and disputes on movements
+type CommentsService struct {
+	repo           CommentsRepository
+	movementsRepo  Repository
+	householdsRepo households.HouseholdRepository
+	auditService   audit.Service
+	logger         *slog.Logger
+	notifyFn       func(ctx context.Context, event *ActivityEvent)
+}
+
+// NewCommentsService creates a new movement comments service
+func NewCommentsService(
+	repo CommentsRepository,
+	movementsRepo Repository,
+	householdsRepo households.HouseholdRepository,
+	auditService audit.Service,
+	logger *slog.Logger,
+) *CommentsService {
+	return &CommentsService{
+		repo:           repo,
+		movementsRepo:  movementsRepo,
+		householdsRepo: householdsRepo,
+		auditService:   auditService,
+		logger:         logger,
+	}
+}
+
+// SetNotifyFn sets the hook called after comment and dispute actions
+func (s *CommentsService) SetNotifyFn(fn func(ctx context.Context, event *ActivityEvent)) {
+	s.notifyFn = fn
+}
+
+// involvedContactIDs returns the contact IDs referenced by a movement
+// (payer, counterparty and participants)
+func involvedContactIDs(m *Movement) []string {
+	var ids []string
+	if m.PayerContactID != nil {
+		ids = append(ids, *m.PayerContactID)
+	}
+	if m.CounterpartyContactID != nil {
+		ids = append(ids, *m.CounterpartyContactID)
+	}
+	for _, p := range m.Participants {
+		if p.ParticipantContactID != nil {
+			ids = append(ids, *p.ParticipantContactID)
+		}
+	}
+	return ids
+}
+
+// linkedParticipantUserIDs returns the users linked (ACCEPTED) to contacts involved in the movement
+func (s *CommentsService) linkedParticipantUserIDs(ctx context.Context, m *Movement) []string {
+	var userIDs []string
+	for _, contactID := range involvedContactIDs(m) {
+		contact, err := s.householdsRepo.GetContact(ctx, contactID)
+		if err != nil {
+			if !errors.Is(err, households.ErrContactNotFound) {
+				s.logger.Warn("failed to load contact for movement thread", "contact_id", contactID, "error", err)
+			}
+			continue
+		}
+		if contact.LinkedUserID != nil && contact.LinkStatus == "ACCEPTED" {
+			userIDs = append(userIDs, *contact.LinkedUserID)
+		}
+	}
+	return userIDs
+}
+
+// authorize loads the movement and verifies the user can act on its thread:
+// a member of the movement's household whose role grants perm, or a linked
+// participant.
+func (s *CommentsService) authorize(ctx context.Context, userID, movementID string, perm households.Permission) (*Movement, error) {
+	m, err := s.movementsRepo.GetByID(ctx, movementID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = households.Authorize(ctx, s.householdsRepo, m.HouseholdID, userID, perm)
+	if err == nil {
+		if !m.VisibleTo(userID) {
+			return nil, ErrMovementNotFound
+		}
+		return m, nil
+	}
+	if !errors.Is(err, households.ErrNotAuthorized) {
+		return nil, err
+	}
+
+	for _, linkedUserID := range s.linkedParticipantUserIDs(ctx, m) {
+		if linkedUserID == userID {
+			return m, nil
+		}
+	}
+
+	return nil, ErrNotAuthorized
+}
+
+// notify sends an activity event to everyone who can see the movement, except the actor
+func (s *CommentsService) notify(ctx context.Context, kind ActivityKind, m *Movement, actorUserID string) {
+	if s.notifyFn == nil {
+		return
+	}
+
+	seen := map[string]bool{actorUserID: true}
+	var recipients []string
+	members, err := s.householdsRepo.GetMembers(ctx, m.HouseholdID)
+	if err != nil {
+		s.logger.Warn("failed to get household members for movement notification", "error", err)
+	}
+	for _, member := range members {
+		if !seen[member.UserID] && m.VisibleTo(member.UserID) {
+			seen[member.UserID] = true
+			recipients = append(recipients, member.UserID)
+		}
+	}
+	for _, userID := range s.linkedParticipantUserIDs(ctx, m) {
+		if !seen[userID] {
+			seen[userID] = true
+			recipients = append(recipients, userID)
+		}
+	}
+
+	if len(recipients) == 0 {
+		return
+	}
+
+	s.notifyFn(ctx, &ActivityEvent{
+		Kind:                kind,
+		MovementID:          m.ID,
+		MovementDescription: m.Description,
+		HouseholdID:         m.HouseholdID,
+		ActorUserID:         actorUserID,
+		RecipientUserIDs:    recipients,
+	})
+}
+
+// ListComments returns the comment thread of a movement
+func (s *CommentsService) ListComments(ctx context.Context, userID, movementID string) ([]*Comment, error) {
+	if _, err := s.authorize(ctx, userID, movementID, households.PermView); err != nil {
+		return nil, err
+	}
+	return s.repo.ListComments(ctx, movementID)
+}
+
+// AddComment adds a comment to a movement's thread
+func (s *CommentsService) AddComment(ctx context.Context, userID, movementID string, input *CreateCommentInput) (*Comment, error) {
+	body := strings.TrimSpace(input.Body)
+	if body == "" {
+		return nil, ErrCommentBodyRequired
+	}
+
+	m, err := s.authorize(ctx, userID, movementID, households.PermEditMovements)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := s.repo.CreateComment(ctx, movementID, userID, body)
+	if err != nil {
+		s.auditService.LogAsync(ctx, &audit.LogInput{
+			UserID:       audit.StringPtr(userID),
+			Action:       audit.ActionMovementCommentAdded,
+			ResourceType: "movement",
+			ResourceID:   audit.StringPtr(movementID),
+			HouseholdID:  audit.StringPtr(m.HouseholdID),
+			Success:      false,
+			ErrorMessage: audit.StringPtr(err.Error()),
+		})
+		return nil, err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		UserID:       audit.StringPtr(userID),
+		Action:       audit.ActionMovementCommentAdded,
+		ResourceType: "movement",
+		ResourceID:   audit.StringPtr(movementID),
+		HouseholdID:  audit.StringPtr(m.HouseholdID),
+		NewValues:    audit.StructToMap(comment),
+		Success:      true,
+	})
+
+	s.notify(ctx, ActivityCommentAdded, m, userID)
+
+	return comment, nil
+}
+
+// DeleteComment deletes a comment. Only its author can delete it.
+func (s *CommentsService) DeleteComment(ctx context.Context, userID, movementID, commentID string) error {
+	m, err := s.authorize(ctx, userID, movementID, households.PermEditMovements)
+	if err != nil {
+		return err
+	}
+
+	comment, err := s.repo.GetComment(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	if comment.MovementID != movementID {
+		return ErrCommentNotFound
+	}
+	if comment.AuthorUserID != userID {
+		return ErrOnlyAuthorCanDelete
+	}
+
+	if err := s.repo.DeleteComment(ctx, commentID); err != nil {
+		return err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		UserID:       audit.StringPtr(userID),
+		Action:       audit.ActionMovementCommentDeleted,
+		ResourceType: "movement",
+		ResourceID:   audit.StringPtr(movementID),
+		HouseholdID:  audit.StringPtr(m.HouseholdID),
+		OldValues:    audit.StructToMap(comment),
+		Success:      true,
+	})
+
+	return nil
+}
+
+// ListDisputes returns the dispute history of a movement
+func (s *CommentsService) ListDisputes(ctx context.Context, userID, movementID string) ([]*Dispute, error) {
+	if _, err := s.authorize(ctx, userID, movementID, households.PermView); err != nil {
+		return nil, err
+	}
+	return s.repo.ListDisputes(ctx, movementID)
+}
+
+// OpenDispute flags a movement as disputed
+func (s *CommentsService) OpenDispute(ctx context.Context, userID, movementID string, input *OpenDisputeInput) (*Dispute, error) {
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" {
+		return nil, ErrDisputeReasonRequired
+	}
+
+	m, err := s.authorize(ctx, userID, movementID, households.PermEditMovements)
+	if err != nil {
+		return nil, err
+	}
+
+	dispute, err := s.repo.CreateDispute(ctx, movementID, userID, reason)
+	if err != nil {
+		s.auditService.LogAsync(ctx, &audit.LogInput{
+			UserID:       audit.StringPtr(userID),
+			Action:       audit.ActionMovementDisputed,
+			ResourceType: "movement",
+			ResourceID:   audit.StringPtr(movementID),
+			HouseholdID:  audit.StringPtr(m.HouseholdID),
+			Success:      false,
+			ErrorMessage: audit.StringPtr(err.Error()),
+		})
+		return nil, err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		UserID:       audit.StringPtr(userID),
+		Action:       audit.ActionMovementDisputed,
+		ResourceType: "movement",
+		ResourceID:   audit.StringPtr(movementID),
+		HouseholdID:  audit.StringPtr(m.HouseholdID),
+		NewValues:    audit.StructToMap(dispute),
+		Success:      true,
+	})
+
+	s.notify(ctx, ActivityDisputeOpened, m, userID)
+
+	return dispute, nil
+}
+
+// ResolveDispute closes the open dispute of a movement. Only the person who
+// created the movement can resolve it; for movements created before the
+// creator was tracked, any member of the movement's household can.
+func (s *CommentsService) ResolveDispute(ctx context.Context, userID, movementID string, input *ResolveDisputeInput) (*Dispute, error) {
+	m, err := s.authorize(ctx, userID, movementID, households.PermEditMovements)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.CreatedByUserID != nil {
+		if *m.CreatedByUserID != userID {
+			return nil, ErrOnlyCreatorCanResolve
+		}
+	} else {
+		if _, err := households.Authorize(ctx, s.householdsRepo, m.HouseholdID, userID, households.PermEditMovements); err != nil {
+			if errors.Is(err, households.ErrNotAuthorized) {
+				return nil, ErrOnlyCreatorCanResolve
+			}
+			return nil, err
+		}
+	}
+
+	open, err := s.repo.GetOpenDispute(ctx, movementID)
+	if err != nil {
+		return nil, err
+	}
+
+	var note *string
+	if input.Note != nil {
+		if trimmed := strings.TrimSpace(*input.Note); trimmed != "" {
+			note = &trimmed
+		}
+	}
+
+	resolved, err := s.repo.ResolveDispute(ctx, open.ID, userID, note)
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		UserID:       audit.StringPtr(userID),
+		Action:       audit.ActionMovementDisputeResolved,
+		ResourceType: "movement",
+		ResourceID:   audit.StringPtr(movementID),
+		HouseholdID:  audit.StringPtr(m.HouseholdID),
+		OldValues:    audit.StructToMap(open),
+		NewValues:    audit.StructToMap(resolved),
+		Success:      true,
+	})
+
+	s.notify(ctx, ActivityDisputeResolved, m, userID)
+
+	return resolved, nil
+}
@@ -0,0 +1,101 @@
+package movements
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Errors for comment and dispute operations
+var (
+	ErrCommentNotFound       = errors.New("comment not found")
+	ErrCommentBodyRequired   = errors.New("comment body is required")
+	ErrDisputeNotFound       = errors.New("dispute not found")
+	ErrDisputeReasonRequired = errors.New("dispute reason is required")
+	ErrDisputeAlreadyOpen    = errors.New("movement already has an open dispute")
+	ErrOnlyCreatorCanResolve = errors.New("only the person who created the movement can resolve the dispute")
+	ErrOnlyAuthorCanDelete   = errors.New("only the author can delete a comment")
+)
+
+// Comment is a message in a movement's thread. Visible to the movement's
+// household and to any linked participant from another household.
+type Comment struct {
+	ID           string    `json:"id"`
+	MovementID   string    `json:"movement_id"`
+	AuthorUserID string    `json:"author_user_id"`
+	AuthorName   string    `json:"author_name"` // Populated from join
+	Body         string    `json:"body"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// DisputeStatus represents the state of a dispute
+type DisputeStatus string
+
+const (
+	DisputeOpen     DisputeStatus = "OPEN"
+	DisputeResolved DisputeStatus = "RESOLVED"
+)
+
+// Dispute flags a movement as contested. While OPEN, the movement is marked
+// in debt consolidation until the person who created it resolves the dispute.
+type Dispute struct {
+	ID               string        `json:"id"`
+	MovementID       string        `json:"movement_id"`
+	RaisedByUserID   string        `json:"raised_by_user_id"`
+	RaisedByName     string        `json:"raised_by_name"` // Populated from join
+	Reason           string        `json:"reason"`
+	Status           DisputeStatus `json:"status"`
+	ResolvedByUserID *string       `json:"resolved_by_user_id,omitempty"`
+	ResolutionNote   *string       `json:"resolution_note,omitempty"`
+	CreatedAt        time.Time     `json:"created_at"`
+	ResolvedAt       *time.Time    `json:"resolved_at,omitempty"`
+}
+
+// ActivityKind identifies a comment or dispute action for notification hooks
+type ActivityKind string
+
+const (
+	ActivityCommentAdded    ActivityKind = "movement.comment_added"
+	ActivityDisputeOpened   ActivityKind = "movement.dispute_opened"
+	ActivityDisputeResolved ActivityKind = "movement.dispute_resolved"
+)
+
+// ActivityEvent is passed to the notification hook after a comment or dispute
+// action. Recipients are everyone who can see the movement, except the actor.
+type ActivityEvent struct {
+	Kind                ActivityKind
+	MovementID          string
+	MovementDescription string
+	HouseholdID         string
+	ActorUserID         string
+	RecipientUserIDs    []string
+}
+
+// CreateCommentInput represents input for adding a comment
+type CreateCommentInput struct {
+	Body string `json:"body"`
+}
+
+// OpenDisputeInput represents input for disputing a movement
+type OpenDisputeInput struct {
+	Reason string `json:"reason"`
+}
+
+// ResolveDisputeInput represents input for resolving a dispute
+type ResolveDisputeInput struct {
+	Note *string `json:"note,omitempty"`
+}
+
+// CommentsRepository defines data access for movement comments and disputes
+type CommentsRepository interface {
+	ListComments(ctx context.Context, movementID string) ([]*Comment, error)
+	GetComment(ctx context.Context, id string) (*Comment, error)
+	CreateComment(ctx context.Context, movementID, authorUserID, body string) (*Comment, error)
+	DeleteComment(ctx context.Context, id string) error
+
+	ListDisputes(ctx context.Context, movementID string) ([]*Dispute, error)
+	GetOpenDispute(ctx context.Context, movementID string) (*Dispute, error)
+	CreateDispute(ctx context.Context, movementID, raisedByUserID, reason string) (*Dispute, error)
+	ResolveDispute(ctx context.Context, id, resolvedByUserID string, note *string) (*Dispute, error)
+}
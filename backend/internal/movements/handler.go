@@ -3,8 +3,10 @@ package movements
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/blanquicet/conti/backend/internal/auth"
@@ -40,14 +42,7 @@ func NewHandler(
 // POST /movements
 func (h *Handler) HandleCreate(w http.ResponseWriter, r *http.Request) {
 	// Get user from session
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		h.logger.Error("no session cookie", "error", err)
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
-		return
-	}
-
-	user, err := h.authSvc.GetUserBySession(r.Context(), cookie.Value)
+	user, err := h.authSvc.UserFromRequest(r, h.cookieName)
 	if err != nil {
 		h.logger.Error("failed to get user by session", "error", err)
 		http.Error(w, "unauthorized", http.StatusUnauthorized)
@@ -82,7 +77,8 @@ func (h *Handler) HandleCreate(w http.ResponseWriter, r *http.Request) {
 		case ErrInvalidMovementType, ErrInvalidAmount, ErrPayerRequired,
 			ErrCounterpartyRequired, ErrCounterpartyNotAllowed,
 			ErrParticipantsRequired, ErrParticipantsNotAllowed,
-			ErrInvalidPercentageSum, ErrCategoryRequired, ErrPaymentMethodRequired:
+			ErrInvalidPercentageSum, ErrCategoryRequired, ErrPaymentMethodRequired,
+			ErrPrivateNotAllowed:
 			http.Error(w, err.Error(), http.StatusBadRequest)
 		default:
 			http.Error(w, "Internal server error", http.StatusInternalServerError)
@@ -103,14 +99,7 @@ func (h *Handler) HandleCreate(w http.ResponseWriter, r *http.Request) {
 // GET /movements
 func (h *Handler) HandleList(w http.ResponseWriter, r *http.Request) {
 	// Get user from session
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		h.logger.Error("no session cookie", "error", err)
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
-		return
-	}
-
-	user, err := h.authSvc.GetUserBySession(r.Context(), cookie.Value)
+	user, err := h.authSvc.UserFromRequest(r, h.cookieName)
 	if err != nil {
 		h.logger.Error("failed to get user by session", "error", err)
 		http.Error(w, "unauthorized", http.StatusUnauthorized)
@@ -154,14 +143,7 @@ func (h *Handler) HandleList(w http.ResponseWriter, r *http.Request) {
 // GET /movements/{id}
 func (h *Handler) HandleGetByID(w http.ResponseWriter, r *http.Request) {
 	// Get user from session
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		h.logger.Error("no session cookie", "error", err)
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
-		return
-	}
-
-	user, err := h.authSvc.GetUserBySession(r.Context(), cookie.Value)
+	user, err := h.authSvc.UserFromRequest(r, h.cookieName)
 	if err != nil {
 		h.logger.Error("failed to get user by session", "error", err)
 		http.Error(w, "unauthorized", http.StatusUnauthorized)
@@ -201,14 +183,7 @@ func (h *Handler) HandleGetByID(w http.ResponseWriter, r *http.Request) {
 // PATCH /movements/{id}
 func (h *Handler) HandleUpdate(w http.ResponseWriter, r *http.Request) {
 	// Get user from session
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		h.logger.Error("no session cookie", "error", err)
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
-		return
-	}
-
-	user, err := h.authSvc.GetUserBySession(r.Context(), cookie.Value)
+	user, err := h.authSvc.UserFromRequest(r, h.cookieName)
 	if err != nil {
 		h.logger.Error("failed to get user by session", "error", err)
 		http.Error(w, "unauthorized", http.StatusUnauthorized)
@@ -240,7 +215,7 @@ func (h *Handler) HandleUpdate(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "Movement not found", http.StatusNotFound)
 		case ErrNotAuthorized:
 			http.Error(w, "Not authorized", http.StatusForbidden)
-		case ErrInvalidAmount:
+		case ErrInvalidAmount, ErrPrivateNotAllowed:
 			http.Error(w, err.Error(), http.StatusBadRequest)
 		default:
 			http.Error(w, "Internal server error", http.StatusInternalServerError)
@@ -258,14 +233,7 @@ func (h *Handler) HandleUpdate(w http.ResponseWriter, r *http.Request) {
 // GET /movements/debts/consolidate?month=YYYY-MM
 func (h *Handler) HandleGetDebtConsolidation(w http.ResponseWriter, r *http.Request) {
 	// Get user from session
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		h.logger.Error("no session cookie", "error", err)
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
-		return
-	}
-
-	user, err := h.authSvc.GetUserBySession(r.Context(), cookie.Value)
+	user, err := h.authSvc.UserFromRequest(r, h.cookieName)
 	if err != nil {
 		h.logger.Error("failed to get user by session", "error", err)
 		http.Error(w, "unauthorized", http.StatusUnauthorized)
@@ -292,18 +260,57 @@ func (h *Handler) HandleGetDebtConsolidation(w http.ResponseWriter, r *http.Requ
 	}
 }
 
-// HandleDelete deletes a movement
-// DELETE /movements/{id}
-func (h *Handler) HandleDelete(w http.ResponseWriter, r *http.Request) {
+// HandleListShared lists movements from other households shared with the user
+// GET /movements/shared?month=YYYY-MM&limit=50&offset=0
+func (h *Handler) HandleListShared(w http.ResponseWriter, r *http.Request) {
 	// Get user from session
-	cookie, err := r.Cookie(h.cookieName)
+	user, err := h.authSvc.UserFromRequest(r, h.cookieName)
 	if err != nil {
-		h.logger.Error("no session cookie", "error", err)
+		h.logger.Error("failed to get user by session", "error", err)
 		http.Error(w, "unauthorized", http.StatusUnauthorized)
 		return
 	}
 
-	user, err := h.authSvc.GetUserBySession(r.Context(), cookie.Value)
+	filters := &SharedMovementsFilters{
+		Limit:  50,
+		Offset: 0,
+	}
+	if month := r.URL.Query().Get("month"); month != "" {
+		filters.Month = &month
+	}
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil && l > 0 && l <= 100 {
+			filters.Limit = l
+		}
+	}
+	if offset := r.URL.Query().Get("offset"); offset != "" {
+		if o, err := strconv.Atoi(offset); err == nil && o >= 0 {
+			filters.Offset = o
+		}
+	}
+
+	response, err := h.service.ListSharedWithMe(r.Context(), user.ID, filters)
+	if err != nil {
+		h.logger.Error("failed to list shared movements", "error", err, "user_id", user.ID)
+		if err.Error() == "user has no household" {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+// HandleDelete deletes a movement
+// DELETE /movements/{id}
+func (h *Handler) HandleDelete(w http.ResponseWriter, r *http.Request) {
+	// Get user from session
+	user, err := h.authSvc.UserFromRequest(r, h.cookieName)
 	if err != nil {
 		h.logger.Error("failed to get user by session", "error", err)
 		http.Error(w, "unauthorized", http.StatusUnauthorized)
@@ -362,6 +369,9 @@ type CreateMovementRequest struct {
 	
 	// Template reference (when movement is created from a recurring template)
 	GeneratedFromTemplateID *string `json:"generated_from_template_id,omitempty"`
+
+	// Visibility (optional, defaults to true)
+	IsSharedWithHousehold *bool `json:"is_shared_with_household,omitempty"`
 }
 
 // ParticipantRequestItem represents a participant in the HTTP request
@@ -394,6 +404,7 @@ func (r *CreateMovementRequest) ToInput() (*CreateMovementInput, error) {
 		PaymentMethodID:         r.PaymentMethodID,
 		ReceiverAccountID:       r.ReceiverAccountID,
 		GeneratedFromTemplateID: r.GeneratedFromTemplateID,
+		IsSharedWithHousehold:   r.IsSharedWithHousehold,
 	}
 
 	// Convert participants
@@ -487,14 +498,7 @@ type RecurringMovementTemplateInfo struct {
 // GetFormConfig handles GET /api/movement-form-config
 func (h *FormConfigHandler) GetFormConfig(w http.ResponseWriter, r *http.Request) {
 	// Get user from session
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		h.logger.Error("no session cookie", "error", err)
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
-		return
-	}
-
-	user, err := h.authSvc.GetUserBySession(r.Context(), cookie.Value)
+	user, err := h.authSvc.UserFromRequest(r, h.cookieName)
 	if err != nil {
 		h.logger.Error("failed to get user by session", "error", err)
 		http.Error(w, "unauthorized", http.StatusUnauthorized)
@@ -502,20 +506,24 @@ func (h *FormConfigHandler) GetFormConfig(w http.ResponseWriter, r *http.Request
 	}
 
 	// Get user's household
-	households, err := h.householdRepo.ListByUser(r.Context(), user.ID)
+	householdID, err := h.householdRepo.ResolveHouseholdID(r.Context(), user.ID)
 	if err != nil {
-		h.logger.Error("failed to list households", "error", err)
+		if errors.Is(err, households.ErrNoHousehold) {
+			http.Error(w, "user has no household", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to resolve household", "error", err)
 		http.Error(w, "internal server error", http.StatusInternalServerError)
 		return
 	}
 
-	if len(households) == 0 {
-		http.Error(w, "user has no household", http.StatusNotFound)
+	household, err := h.householdRepo.GetByID(r.Context(), householdID)
+	if err != nil {
+		h.logger.Error("failed to get household", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
 		return
 	}
 
-	household := households[0]
-
 	// Get household members
 	members, err := h.householdRepo.GetMembers(r.Context(), household.ID)
 	if err != nil {
@@ -37,21 +37,24 @@ func (r *repository) Create(ctx context.Context, input *CreateMovementInput, hou
 			payer_user_id, payer_contact_id,
 			counterparty_user_id, counterparty_contact_id,
 			payment_method_id, receiver_account_id,
-			generated_from_template_id, source_pocket_id
+			generated_from_template_id, source_pocket_id, created_by_user_id,
+			is_shared_with_household
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, COALESCE($17, TRUE))
 		RETURNING id, household_id, type, description, amount, category_id, movement_date,
 		          currency, payer_user_id, payer_contact_id,
 		          counterparty_user_id, counterparty_contact_id,
 		          payment_method_id, receiver_account_id,
-		          generated_from_template_id, source_pocket_id, created_at, updated_at
+		          generated_from_template_id, source_pocket_id, created_by_user_id,
+		          is_shared_with_household, created_at, updated_at
 	`,
 		householdID, input.Type, input.Description, input.Amount, input.CategoryID,
 		input.MovementDate, "COP", // Currency defaults to COP
 		input.PayerUserID, input.PayerContactID,
 		input.CounterpartyUserID, input.CounterpartyContactID,
 		input.PaymentMethodID, input.ReceiverAccountID,
-		input.GeneratedFromTemplateID, input.SourcePocketID,
+		input.GeneratedFromTemplateID, input.SourcePocketID, input.CreatedByUserID,
+		input.IsSharedWithHousehold,
 	).Scan(
 		&movement.ID,
 		&movement.HouseholdID,
@@ -69,6 +72,8 @@ func (r *repository) Create(ctx context.Context, input *CreateMovementInput, hou
 		&movement.ReceiverAccountID,
 		&movement.GeneratedFromTemplateID,
 		&movement.SourcePocketID,
+		&movement.CreatedByUserID,
+		&movement.IsSharedWithHousehold,
 		&movement.CreatedAt,
 		&movement.UpdatedAt,
 	)
@@ -119,6 +124,8 @@ func (r *repository) GetByID(ctx context.Context, id string) (*Movement, error)
 			m.payment_method_id, m.receiver_account_id,
 			m.generated_from_template_id,
 			m.source_pocket_id,
+			m.created_by_user_id,
+			m.is_shared_with_household,
 			m.created_at, m.updated_at,
 			-- Payer name (user or contact)
 			COALESCE(payer_user.name, payer_contact.name) as payer_name,
@@ -134,7 +141,11 @@ func (r *repository) GetByID(ctx context.Context, id string) (*Movement, error)
 			cg.id as category_group_id,
 			cg.name as category_group_name,
 			cg.icon as category_group_icon,
-			pk.name as source_pocket_name
+			pk.name as source_pocket_name,
+			EXISTS (
+				SELECT 1 FROM movement_disputes d
+				WHERE d.movement_id = m.id AND d.status = 'OPEN'
+			) as is_disputed
 		FROM movements m
 		LEFT JOIN users payer_user ON m.payer_user_id = payer_user.id
 		LEFT JOIN contacts payer_contact ON m.payer_contact_id = payer_contact.id
@@ -164,6 +175,8 @@ func (r *repository) GetByID(ctx context.Context, id string) (*Movement, error)
 		&movement.ReceiverAccountID,
 		&movement.GeneratedFromTemplateID,
 		&movement.SourcePocketID,
+		&movement.CreatedByUserID,
+		&movement.IsSharedWithHousehold,
 		&movement.CreatedAt,
 		&movement.UpdatedAt,
 		&movement.PayerName,
@@ -176,6 +189,7 @@ func (r *repository) GetByID(ctx context.Context, id string) (*Movement, error)
 		&movement.CategoryGroupName,
 		&movement.CategoryGroupIcon,
 		&movement.SourcePocketName,
+		&movement.IsDisputed,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -271,6 +285,8 @@ func (r *repository) ListByHousehold(ctx context.Context, householdID string, fi
 			m.payment_method_id, m.receiver_account_id,
 			m.generated_from_template_id,
 			m.source_pocket_id,
+			m.created_by_user_id,
+			m.is_shared_with_household,
 			m.created_at, m.updated_at,
 			COALESCE(payer_user.name, payer_contact.name) as payer_name,
 			COALESCE(counterparty_user.name, counterparty_contact.name) as counterparty_name,
@@ -281,7 +297,11 @@ func (r *repository) ListByHousehold(ctx context.Context, householdID string, fi
 			cg.id as category_group_id,
 			cg.name as category_group_name,
 			cg.icon as category_group_icon,
-			pk.name as source_pocket_name
+			pk.name as source_pocket_name,
+			EXISTS (
+				SELECT 1 FROM movement_disputes d
+				WHERE d.movement_id = m.id AND d.status = 'OPEN'
+			) as is_disputed
 		FROM movements m
 		LEFT JOIN users payer_user ON m.payer_user_id = payer_user.id
 		LEFT JOIN contacts payer_contact ON m.payer_contact_id = payer_contact.id
@@ -355,6 +375,8 @@ func (r *repository) ListByHousehold(ctx context.Context, householdID string, fi
 			&m.ReceiverAccountID,
 			&m.GeneratedFromTemplateID,
 			&m.SourcePocketID,
+			&m.CreatedByUserID,
+			&m.IsSharedWithHousehold,
 			&m.CreatedAt,
 			&m.UpdatedAt,
 			&m.PayerName,
@@ -367,6 +389,7 @@ func (r *repository) ListByHousehold(ctx context.Context, householdID string, fi
 			&m.CategoryGroupName,
 			&m.CategoryGroupIcon,
 			&m.SourcePocketName,
+			&m.IsDisputed,
 		)
 		if err != nil {
 			return nil, err
@@ -392,13 +415,59 @@ func (r *repository) ListByHousehold(ctx context.Context, householdID string, fi
 	return movements, nil
 }
 
+// contactMovementsWhere matches SPLIT and DEBT_PAYMENT movements where any of
+// the contact IDs ($1) is the payer, the counterparty or a participant.
+const contactMovementsWhere = `
+		WHERE m.type IN ('SPLIT', 'DEBT_PAYMENT')
+		  AND (
+			m.payer_contact_id = ANY($1)
+			OR m.counterparty_contact_id = ANY($1)
+			OR EXISTS (
+				SELECT 1 FROM movement_participants mp
+				WHERE mp.movement_id = m.id
+				  AND mp.participant_contact_id = ANY($1)
+			)
+		  )
+`
+
 // ListMovementsByContactIDs retrieves SPLIT and DEBT_PAYMENT movements involving any of the given contact IDs.
 // Used for cross-household debt visibility.
 func (r *repository) ListMovementsByContactIDs(ctx context.Context, contactIDs []string, month *string) ([]*Movement, error) {
 	if len(contactIDs) == 0 {
 		return nil, nil
 	}
+	return r.listByContactIDs(ctx, contactIDs, month, 0, 0)
+}
+
+// ListMovementsByContactIDsPage is the paginated variant of ListMovementsByContactIDs.
+// It also returns the total number of matching movements.
+func (r *repository) ListMovementsByContactIDsPage(ctx context.Context, contactIDs []string, filters *SharedMovementsFilters) ([]*Movement, int, error) {
+	if len(contactIDs) == 0 {
+		return []*Movement{}, 0, nil
+	}
+
+	countQuery := "SELECT COUNT(*) FROM movements m" + contactMovementsWhere
+	args := []interface{}{contactIDs}
+	if filters.Month != nil {
+		countQuery += " AND TO_CHAR(m.movement_date, 'YYYY-MM') = $2"
+		args = append(args, *filters.Month)
+	}
+
+	var total int
+	if err := r.pool.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
 
+	movements, err := r.listByContactIDs(ctx, contactIDs, filters.Month, filters.Limit, filters.Offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return movements, total, nil
+}
+
+// listByContactIDs runs the contact movements query. A limit of 0 means no limit.
+func (r *repository) listByContactIDs(ctx context.Context, contactIDs []string, month *string, limit, offset int) ([]*Movement, error) {
 	query := `
 		SELECT
 			m.id, m.household_id, m.type, m.description, m.amount,
@@ -408,6 +477,8 @@ func (r *repository) ListMovementsByContactIDs(ctx context.Context, contactIDs [
 			m.payment_method_id, m.receiver_account_id,
 			m.generated_from_template_id,
 			m.source_pocket_id,
+			m.created_by_user_id,
+			m.is_shared_with_household,
 			m.created_at, m.updated_at,
 			COALESCE(payer_user.name, payer_contact.name) as payer_name,
 			COALESCE(counterparty_user.name, counterparty_contact.name) as counterparty_name,
@@ -418,7 +489,11 @@ func (r *repository) ListMovementsByContactIDs(ctx context.Context, contactIDs [
 			cg.id as category_group_id,
 			cg.name as category_group_name,
 			cg.icon as category_group_icon,
-			pk.name as source_pocket_name
+			pk.name as source_pocket_name,
+			EXISTS (
+				SELECT 1 FROM movement_disputes d
+				WHERE d.movement_id = m.id AND d.status = 'OPEN'
+			) as is_disputed
 		FROM movements m
 		LEFT JOIN users payer_user ON m.payer_user_id = payer_user.id
 		LEFT JOIN contacts payer_contact ON m.payer_contact_id = payer_contact.id
@@ -429,17 +504,7 @@ func (r *repository) ListMovementsByContactIDs(ctx context.Context, contactIDs [
 		LEFT JOIN categories c ON m.category_id = c.id
 		LEFT JOIN category_groups cg ON c.category_group_id = cg.id
 		LEFT JOIN pockets pk ON m.source_pocket_id = pk.id
-		WHERE m.type IN ('SPLIT', 'DEBT_PAYMENT')
-		  AND (
-			m.payer_contact_id = ANY($1)
-			OR m.counterparty_contact_id = ANY($1)
-			OR EXISTS (
-				SELECT 1 FROM movement_participants mp
-				WHERE mp.movement_id = m.id
-				  AND mp.participant_contact_id = ANY($1)
-			)
-		  )
-	`
+	` + contactMovementsWhere
 
 	args := []interface{}{contactIDs}
 	argNum := 2
@@ -449,10 +514,14 @@ func (r *repository) ListMovementsByContactIDs(ctx context.Context, contactIDs [
 		args = append(args, *month)
 		argNum++
 	}
-	_ = argNum
 
 	query += " ORDER BY m.movement_date DESC, m.created_at DESC"
 
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argNum, argNum+1)
+		args = append(args, limit, offset)
+	}
+
 	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
@@ -478,6 +547,8 @@ func (r *repository) ListMovementsByContactIDs(ctx context.Context, contactIDs [
 			&m.ReceiverAccountID,
 			&m.GeneratedFromTemplateID,
 			&m.SourcePocketID,
+			&m.CreatedByUserID,
+			&m.IsSharedWithHousehold,
 			&m.CreatedAt,
 			&m.UpdatedAt,
 			&m.PayerName,
@@ -490,6 +561,7 @@ func (r *repository) ListMovementsByContactIDs(ctx context.Context, contactIDs [
 			&m.CategoryGroupName,
 			&m.CategoryGroupIcon,
 			&m.SourcePocketName,
+			&m.IsDisputed,
 		)
 		if err != nil {
 			return nil, err
@@ -713,6 +785,11 @@ func (r *repository) Update(ctx context.Context, id string, input *UpdateMovemen
 		args = append(args, *input.GeneratedFromTemplateID)
 		argNum++
 	}
+	if input.IsSharedWithHousehold != nil {
+		setClauses = append(setClauses, fmt.Sprintf("is_shared_with_household = $%d", argNum))
+		args = append(args, *input.IsSharedWithHousehold)
+		argNum++
+	}
 
 	if len(setClauses) > 0 {
 		// Always update updated_at
@@ -3,12 +3,15 @@ package movements
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 
 	"github.com/blanquicet/conti/backend/internal/accounts"
 	"github.com/blanquicet/conti/backend/internal/audit"
 	"github.com/blanquicet/conti/backend/internal/households"
+	"github.com/blanquicet/conti/backend/internal/notifications"
 	"github.com/blanquicet/conti/backend/internal/paymentmethods"
+	"github.com/blanquicet/conti/backend/internal/webhooks"
 )
 
 // service implements Service interface
@@ -20,6 +23,9 @@ type service struct {
 	auditService              audit.Service
 	logger                    *slog.Logger
 	deletePocketTransactionFn func(ctx context.Context, movementID, householdID string) error
+	checkBudgetFn             func(ctx context.Context, householdID, categoryID, month string) error
+	notifier                  notifications.Publisher
+	webhooks                  webhooks.Dispatcher
 }
 
 // NewService creates a new movements service
@@ -45,6 +51,45 @@ func (s *service) SetDeletePocketTransactionFn(fn func(ctx context.Context, move
 	s.deletePocketTransactionFn = fn
 }
 
+// SetCheckBudgetFn sets the hook called after a categorized movement is created or
+// updated, so the budget for that category and month can be re-evaluated
+func (s *service) SetCheckBudgetFn(fn func(ctx context.Context, householdID, categoryID, month string) error) {
+	s.checkBudgetFn = fn
+}
+
+// SetNotifier sets the publisher used to notify linked users of shared movements
+func (s *service) SetNotifier(notifier notifications.Publisher) {
+	s.notifier = notifier
+}
+
+// SetWebhookDispatcher sets the dispatcher used to send movement events to webhook endpoints
+func (s *service) SetWebhookDispatcher(dispatcher webhooks.Dispatcher) {
+	s.webhooks = dispatcher
+}
+
+// dispatchWebhook sends a movement event to the household's webhook endpoints.
+// Private movements are skipped: endpoints belong to the household, and only
+// the payer may see them.
+func (s *service) dispatchWebhook(ctx context.Context, householdID string, eventType webhooks.EventType, m *Movement) {
+	if s.webhooks == nil || !m.IsSharedWithHousehold {
+		return
+	}
+	s.webhooks.Dispatch(ctx, &webhooks.Event{
+		HouseholdID: householdID,
+		Type:        eventType,
+		Data:        m,
+	})
+}
+
+// authorizeEdit resolves the user's household and checks their role may record movements
+func (s *service) authorizeEdit(ctx context.Context, userID string) (string, error) {
+	householdID, err := households.AuthorizeSelected(ctx, s.householdsRepo, userID, households.PermEditMovements)
+	if errors.Is(err, households.ErrNotAuthorized) {
+		return "", ErrNotAuthorized
+	}
+	return householdID, err
+}
+
 // Create creates a new movement
 func (s *service) Create(ctx context.Context, userID string, input *CreateMovementInput) (*Movement, error) {
 	// Validate input
@@ -53,11 +98,16 @@ func (s *service) Create(ctx context.Context, userID string, input *CreateMoveme
 	}
 
 	// Get user's household
-	householdID, err := s.householdsRepo.GetUserHouseholdID(ctx, userID)
+	householdID, err := s.authorizeEdit(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
+	// A member can only keep their own spending private
+	if input.IsSharedWithHousehold != nil && !*input.IsSharedWithHousehold && *input.PayerUserID != userID {
+		return nil, ErrNotAuthorized
+	}
+
 	// Verify payer belongs to household (if user) or is a contact of household
 	if input.PayerUserID != nil {
 		isMember, err := s.householdsRepo.IsUserMember(ctx, householdID, *input.PayerUserID)
@@ -163,6 +213,8 @@ func (s *service) Create(ctx context.Context, userID string, input *CreateMoveme
 		}
 	}
 
+	input.CreatedByUserID = &userID
+
 	// Create movement
 	movement, err := s.repo.Create(ctx, input, householdID)
 	if err != nil {
@@ -189,6 +241,10 @@ func (s *service) Create(ctx context.Context, userID string, input *CreateMoveme
 		Success:      true,
 	})
 
+	s.dispatchWebhook(ctx, householdID, webhooks.EventMovementCreated, movement)
+	s.checkBudget(ctx, movement)
+	s.notifyLinkedUsers(ctx, movement, userID)
+
 	return movement, nil
 }
 
@@ -201,7 +257,7 @@ func (s *service) GetByID(ctx context.Context, userID, id string) (*Movement, er
 	}
 
 	// Verify user has access to this movement (belongs to same household)
-	householdID, err := s.householdsRepo.GetUserHouseholdID(ctx, userID)
+	householdID, err := s.householdsRepo.ResolveHouseholdID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -209,6 +265,9 @@ func (s *service) GetByID(ctx context.Context, userID, id string) (*Movement, er
 	if movement.HouseholdID != householdID {
 		return nil, ErrNotAuthorized
 	}
+	if !movement.VisibleTo(userID) {
+		return nil, ErrMovementNotFound
+	}
 
 	return movement, nil
 }
@@ -216,7 +275,7 @@ func (s *service) GetByID(ctx context.Context, userID, id string) (*Movement, er
 // ListByHousehold retrieves all movements for the user's household
 func (s *service) ListByHousehold(ctx context.Context, userID string, filters *ListMovementsFilters) (*ListMovementsResponse, error) {
 	// Get user's household
-	householdID, err := s.householdsRepo.GetUserHouseholdID(ctx, userID)
+	householdID, err := s.householdsRepo.ResolveHouseholdID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -227,22 +286,56 @@ func (s *service) ListByHousehold(ctx context.Context, userID string, filters *L
 		return nil, err
 	}
 
-	// Get totals
+	// Get totals (private movements included)
 	totals, err := s.repo.GetTotals(ctx, householdID, filters)
 	if err != nil {
 		return nil, err
 	}
 
-	return &ListMovementsResponse{
-		Movements: movements,
+	resp := &ListMovementsResponse{
+		Movements: make([]*Movement, 0, len(movements)),
 		Totals:    totals,
-	}, nil
+	}
+	private := make(map[[4]string]*PrivateSpending)
+	for _, m := range movements {
+		if m.VisibleTo(userID) {
+			resp.Movements = append(resp.Movements, m)
+			continue
+		}
+		resp.PrivateCount++
+		resp.PrivateAmount += m.Amount
+
+		key := [4]string{stringOrEmpty(m.CategoryGroupName), stringOrEmpty(m.CategoryName), m.PayerName, stringOrEmpty(m.PaymentMethodName)}
+		ps, ok := private[key]
+		if !ok {
+			ps = &PrivateSpending{
+				CategoryName:      m.CategoryName,
+				CategoryGroupName: m.CategoryGroupName,
+				PayerName:         m.PayerName,
+				PaymentMethodName: m.PaymentMethodName,
+			}
+			private[key] = ps
+			resp.PrivateSpending = append(resp.PrivateSpending, ps)
+		}
+		ps.Amount += m.Amount
+		ps.Count++
+	}
+
+	return resp, nil
+}
+
+// stringOrEmpty returns the pointed-to string, or "" for nil
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
 }
 
 // GetDebtConsolidation calculates who owes whom based on SPLIT and DEBT_PAYMENT movements
 func (s *service) GetDebtConsolidation(ctx context.Context, userID string, month *string) (*DebtConsolidationResponse, error) {
 	// Get user's household
-	householdID, err := s.householdsRepo.GetUserHouseholdID(ctx, userID)
+	householdID, err := s.householdsRepo.ResolveHouseholdID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -357,6 +450,7 @@ func (s *service) GetDebtConsolidation(ctx context.Context, userID string, month
 							Type:         string(TypeSplit),
 							PayerID:      payerID,
 							PayerName:    payerName,
+							IsDisputed:   m.IsDisputed,
 						},
 					)
 				}
@@ -419,6 +513,7 @@ func (s *service) GetDebtConsolidation(ctx context.Context, userID string, month
 						Type:         string(TypeDebtPayment),
 						PayerID:      payerID,      // Who made the payment
 						PayerName:    payerName,    // Name of who made the payment
+						IsDisputed:   m.IsDisputed,
 					},
 				)
 			}
@@ -559,6 +654,7 @@ func (s *service) GetDebtConsolidation(ctx context.Context, userID string, month
 									PayerName:           payerName,
 									IsCrossHousehold:    true,
 									SourceHouseholdName: sourceHouseholdName,
+									IsDisputed:          m.IsDisputed,
 								},
 							)
 						}
@@ -621,6 +717,7 @@ func (s *service) GetDebtConsolidation(ctx context.Context, userID string, month
 								PayerName:           payerName,
 								IsCrossHousehold:    true,
 								SourceHouseholdName: sourceHouseholdName,
+								IsDisputed:          m.IsDisputed,
 							},
 						)
 					}
@@ -676,12 +773,15 @@ func (s *service) GetDebtConsolidation(ctx context.Context, userID string, month
 				movements = append(movements, movementDetails[creditorID][debtorID]...)
 			}
 
-			// Check if any movement in this pair is cross-household
+			// Check if any movement in this pair is cross-household or disputed
 			hasCrossHousehold := false
+			hasDisputes := false
 			for _, md := range movements {
 				if md.IsCrossHousehold {
 					hasCrossHousehold = true
-					break
+				}
+				if md.IsDisputed {
+					hasDisputes = true
 				}
 			}
 			
@@ -698,6 +798,7 @@ func (s *service) GetDebtConsolidation(ctx context.Context, userID string, month
 					Amount:           netAmount,
 					Currency:         "COP", // TODO: handle multi-currency
 					IsCrossHousehold: hasCrossHousehold,
+					HasDisputes:      hasDisputes,
 					Movements:        movements,
 				})
 				processed[pairKey] = true
@@ -711,6 +812,7 @@ func (s *service) GetDebtConsolidation(ctx context.Context, userID string, month
 					Amount:           -netAmount,
 					Currency:         "COP",
 					IsCrossHousehold: hasCrossHousehold,
+					HasDisputes:      hasDisputes,
 					Movements:        movements,
 				})
 				processed[reversePairKey] = true
@@ -732,6 +834,7 @@ func (s *service) GetDebtConsolidation(ctx context.Context, userID string, month
 					Amount:           0,
 					Currency:         "COP",
 					IsCrossHousehold: hasCrossHousehold,
+					HasDisputes:      hasDisputes,
 					Movements:        movements,
 				})
 				processed[pairKey] = true
@@ -786,6 +889,115 @@ func (s *service) GetDebtConsolidation(ctx context.Context, userID string, month
 	}, nil
 }
 
+// ListSharedWithMe lists movements from other households in which the user
+// participates as a linked contact. Each movement is reduced to the user's
+// share so the source household's internal data stays private.
+func (s *service) ListSharedWithMe(ctx context.Context, userID string, filters *SharedMovementsFilters) (*ListSharedMovementsResponse, error) {
+	householdID, err := s.householdsRepo.ResolveHouseholdID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	linkedContacts, err := s.householdsRepo.FindLinkedContactsByHousehold(ctx, householdID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the contacts that represent this user (not other household members)
+	myContactIDs := make(map[string]bool)
+	householdNames := make(map[string]string) // householdID → name
+	var contactIDs []string
+	for _, lc := range linkedContacts {
+		if lc.LinkedUserID != userID {
+			continue
+		}
+		myContactIDs[lc.ContactID] = true
+		householdNames[lc.HouseholdID] = lc.HouseholdName
+		contactIDs = append(contactIDs, lc.ContactID)
+	}
+
+	movements, total, err := s.repo.ListMovementsByContactIDsPage(ctx, contactIDs, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	shared := make([]*SharedMovement, 0, len(movements))
+	for _, m := range movements {
+		if sm := toSharedMovement(m, myContactIDs, householdNames[m.HouseholdID]); sm != nil {
+			shared = append(shared, sm)
+		}
+	}
+
+	return &ListSharedMovementsResponse{
+		Movements: shared,
+		Total:     total,
+		Limit:     filters.Limit,
+		Offset:    filters.Offset,
+	}, nil
+}
+
+// toSharedMovement reduces a movement from another household to the part that
+// concerns the user (identified by their contact IDs in that household).
+// Returns nil if the user is not involved in the movement.
+func toSharedMovement(m *Movement, myContactIDs map[string]bool, householdName string) *SharedMovement {
+	isMine := func(contactID *string) bool {
+		return contactID != nil && myContactIDs[*contactID]
+	}
+
+	currency := m.Currency
+	if currency == "" {
+		currency = "COP"
+	}
+
+	sm := &SharedMovement{
+		MovementID:          m.ID,
+		SourceHouseholdID:   m.HouseholdID,
+		SourceHouseholdName: householdName,
+		Type:                m.Type,
+		Description:         m.Description,
+		MovementDate:        m.MovementDate,
+		Currency:            currency,
+		PayerName:           m.PayerName,
+		PaidByMe:            isMine(m.PayerContactID),
+		IsDisputed:          m.IsDisputed,
+	}
+
+	switch m.Type {
+	case TypeSplit:
+		involved := sm.PaidByMe
+		for _, p := range m.Participants {
+			if !isMine(p.ParticipantContactID) {
+				continue
+			}
+			percentage := p.Percentage
+			sm.MyPercentage = &percentage
+			sm.MyAmount = m.Amount * p.Percentage
+			involved = true
+			break
+		}
+		if !involved {
+			return nil
+		}
+		if sm.PaidByMe {
+			// The total is the user's own payment, so it is not private
+			amount := m.Amount
+			sm.PaidAmount = &amount
+		}
+
+	case TypeDebtPayment:
+		if !sm.PaidByMe && !isMine(m.CounterpartyContactID) {
+			return nil
+		}
+		sm.CounterpartyName = m.CounterpartyName
+		sm.MyAmount = m.Amount
+
+	default:
+		return nil
+	}
+
+	return sm
+}
+
 // Update updates a movement
 func (s *service) Update(ctx context.Context, userID, id string, input *UpdateMovementInput) (*Movement, error) {
 	// Validate input
@@ -800,7 +1012,7 @@ func (s *service) Update(ctx context.Context, userID, id string, input *UpdateMo
 	}
 
 	// Verify user has access to this movement (belongs to same household)
-	householdID, err := s.householdsRepo.GetUserHouseholdID(ctx, userID)
+	householdID, err := s.authorizeEdit(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -808,6 +1020,27 @@ func (s *service) Update(ctx context.Context, userID, id string, input *UpdateMo
 	if existing.HouseholdID != householdID {
 		return nil, ErrNotAuthorized
 	}
+	if !existing.VisibleTo(userID) {
+		return nil, ErrMovementNotFound
+	}
+
+	// Only the payer can change who sees the movement, and a private movement
+	// must stay paid by them
+	isShared := existing.IsSharedWithHousehold
+	if input.IsSharedWithHousehold != nil && *input.IsSharedWithHousehold != existing.IsSharedWithHousehold {
+		if existing.PayerUserID == nil || *existing.PayerUserID != userID {
+			return nil, ErrNotAuthorized
+		}
+		isShared = *input.IsSharedWithHousehold
+	}
+	if !isShared {
+		if existing.Type != TypeHousehold || input.PayerContactID != nil {
+			return nil, ErrPrivateNotAllowed
+		}
+		if input.PayerUserID != nil && *input.PayerUserID != userID {
+			return nil, ErrNotAuthorized
+		}
+	}
 
 	// Validate payer if being updated (must belong to household)
 	if input.PayerUserID != nil {
@@ -968,6 +1201,9 @@ func (s *service) Update(ctx context.Context, userID, id string, input *UpdateMo
 		Success:      true,
 	})
 
+	s.dispatchWebhook(ctx, householdID, webhooks.EventMovementUpdated, updated)
+	s.checkBudget(ctx, updated)
+
 	return updated, nil
 }
 
@@ -980,7 +1216,7 @@ func (s *service) Delete(ctx context.Context, userID, id string) error {
 	}
 
 	// Verify user has access to this movement (belongs to same household)
-	householdID, err := s.householdsRepo.GetUserHouseholdID(ctx, userID)
+	householdID, err := s.authorizeEdit(ctx, userID)
 	if err != nil {
 		return err
 	}
@@ -988,6 +1224,9 @@ func (s *service) Delete(ctx context.Context, userID, id string) error {
 	if existing.HouseholdID != householdID {
 		return ErrNotAuthorized
 	}
+	if !existing.VisibleTo(userID) {
+		return ErrMovementNotFound
+	}
 
 	// Cascade delete linked pocket transaction (if any)
 	if s.deletePocketTransactionFn != nil {
@@ -1030,3 +1269,63 @@ func (s *service) Delete(ctx context.Context, userID, id string) error {
 
 	return nil
 }
+
+// checkBudget re-evaluates the budget of the movement's category for its month
+func (s *service) checkBudget(ctx context.Context, m *Movement) {
+	if s.checkBudgetFn == nil || m.CategoryID == nil {
+		return
+	}
+	month := m.MovementDate.Format("2006-01")
+	if err := s.checkBudgetFn(ctx, m.HouseholdID, *m.CategoryID, month); err != nil {
+		s.logger.Warn("failed to check budget after movement change",
+			"movement_id", m.ID,
+			"category_id", *m.CategoryID,
+			"month", month,
+			"error", err,
+		)
+	}
+}
+
+// notifyLinkedUsers notifies users from other households whose linked contact
+// takes part in a new SPLIT or DEBT_PAYMENT movement
+func (s *service) notifyLinkedUsers(ctx context.Context, m *Movement, actorUserID string) {
+	if s.notifier == nil || (m.Type != TypeSplit && m.Type != TypeDebtPayment) {
+		return
+	}
+
+	var userIDs []string
+	for _, contactID := range involvedContactIDs(m) {
+		contact, err := s.householdsRepo.GetContact(ctx, contactID)
+		if err != nil {
+			if !errors.Is(err, households.ErrContactNotFound) {
+				s.logger.Warn("failed to load contact for shared movement notification", "contact_id", contactID, "error", err)
+			}
+			continue
+		}
+		if contact.LinkedUserID != nil && contact.LinkStatus == "ACCEPTED" && *contact.LinkedUserID != actorUserID {
+			userIDs = append(userIDs, *contact.LinkedUserID)
+		}
+	}
+	if len(userIDs) == 0 {
+		return
+	}
+
+	householdName := ""
+	if household, err := s.householdsRepo.GetByID(ctx, m.HouseholdID); err == nil {
+		householdName = household.Name
+	}
+
+	s.notifier.Publish(ctx, &notifications.PublishInput{
+		UserIDs:      userIDs,
+		Type:         notifications.TypeSharedMovementCreated,
+		Title:        fmt.Sprintf("%s registró un movimiento compartido contigo", householdName),
+		Body:         notifications.StringPtr(m.Description),
+		ResourceType: notifications.StringPtr("movement"),
+		ResourceID:   &m.ID,
+		Data: map[string]interface{}{
+			"movement_type":         string(m.Type),
+			"source_household_id":   m.HouseholdID,
+			"source_household_name": householdName,
+		},
+	})
+}
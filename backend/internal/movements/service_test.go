@@ -0,0 +1,212 @@
+package movements
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/blanquicet/conti/backend/internal/webhooks"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestToSharedMovement_SplitParticipant(t *testing.T) {
+	// Maria's household paid 100k, split 50/30/20 between Maria, me and a private contact
+	m := &Movement{
+		ID:              "mov-1",
+		HouseholdID:     "household-maria",
+		Type:            TypeSplit,
+		Description:     "Cena",
+		Amount:          100000,
+		MovementDate:    time.Date(2026, time.January, 10, 0, 0, 0, 0, time.UTC),
+		PayerUserID:     strPtr("user-maria"),
+		PayerName:       "Maria",
+		PaymentMethodID: strPtr("pm-private"),
+		Participants: []Participant{
+			{ParticipantUserID: strPtr("user-maria"), Percentage: 0.5},
+			{ParticipantContactID: strPtr("contact-me"), Percentage: 0.3},
+			{ParticipantContactID: strPtr("contact-other"), Percentage: 0.2},
+		},
+	}
+
+	sm := toSharedMovement(m, map[string]bool{"contact-me": true}, "Casa Maria")
+	if sm == nil {
+		t.Fatal("expected shared movement, got nil")
+	}
+	if sm.MyAmount != 30000 {
+		t.Errorf("MyAmount = %v, want 30000", sm.MyAmount)
+	}
+	if sm.MyPercentage == nil || *sm.MyPercentage != 0.3 {
+		t.Errorf("MyPercentage = %v, want 0.3", sm.MyPercentage)
+	}
+	if sm.PaidByMe {
+		t.Error("PaidByMe = true, want false")
+	}
+	if sm.PaidAmount != nil {
+		t.Errorf("PaidAmount = %v, want nil (total is private)", *sm.PaidAmount)
+	}
+	if sm.SourceHouseholdName != "Casa Maria" {
+		t.Errorf("SourceHouseholdName = %q, want %q", sm.SourceHouseholdName, "Casa Maria")
+	}
+	if sm.Currency != "COP" {
+		t.Errorf("Currency = %q, want COP", sm.Currency)
+	}
+}
+
+func TestToSharedMovement_SplitPaidByMe(t *testing.T) {
+	m := &Movement{
+		ID:             "mov-2",
+		HouseholdID:    "household-maria",
+		Type:           TypeSplit,
+		Amount:         80000,
+		PayerContactID: strPtr("contact-me"),
+		Participants: []Participant{
+			{ParticipantUserID: strPtr("user-maria"), Percentage: 1},
+		},
+	}
+
+	sm := toSharedMovement(m, map[string]bool{"contact-me": true}, "Casa Maria")
+	if sm == nil {
+		t.Fatal("expected shared movement, got nil")
+	}
+	if !sm.PaidByMe {
+		t.Error("PaidByMe = false, want true")
+	}
+	if sm.PaidAmount == nil || *sm.PaidAmount != 80000 {
+		t.Errorf("PaidAmount = %v, want 80000", sm.PaidAmount)
+	}
+	if sm.MyAmount != 0 {
+		t.Errorf("MyAmount = %v, want 0", sm.MyAmount)
+	}
+}
+
+func TestToSharedMovement_DebtPaymentToMe(t *testing.T) {
+	m := &Movement{
+		ID:                    "mov-3",
+		HouseholdID:           "household-maria",
+		Type:                  TypeDebtPayment,
+		Amount:                50000,
+		PayerUserID:           strPtr("user-maria"),
+		PayerName:             "Maria",
+		CounterpartyContactID: strPtr("contact-me"),
+		CounterpartyName:      strPtr("Jose"),
+	}
+
+	sm := toSharedMovement(m, map[string]bool{"contact-me": true}, "Casa Maria")
+	if sm == nil {
+		t.Fatal("expected shared movement, got nil")
+	}
+	if sm.MyAmount != 50000 {
+		t.Errorf("MyAmount = %v, want 50000", sm.MyAmount)
+	}
+	if sm.PaidByMe {
+		t.Error("PaidByMe = true, want false")
+	}
+	if sm.CounterpartyName == nil || *sm.CounterpartyName != "Jose" {
+		t.Errorf("CounterpartyName = %v, want Jose", sm.CounterpartyName)
+	}
+}
+
+func TestToSharedMovement_NotInvolved(t *testing.T) {
+	// Movement matched another household member's contact, not mine
+	m := &Movement{
+		ID:          "mov-4",
+		HouseholdID: "household-maria",
+		Type:        TypeSplit,
+		Amount:      10000,
+		PayerUserID: strPtr("user-maria"),
+		Participants: []Participant{
+			{ParticipantContactID: strPtr("contact-partner"), Percentage: 1},
+		},
+	}
+
+	if sm := toSharedMovement(m, map[string]bool{"contact-me": true}, "Casa Maria"); sm != nil {
+		t.Errorf("expected nil, got %+v", sm)
+	}
+}
+
+func TestInvolvedContactIDs(t *testing.T) {
+	m := &Movement{
+		Type:           TypeSplit,
+		PayerContactID: strPtr("contact-payer"),
+		Participants: []Participant{
+			{ParticipantUserID: strPtr("user-1"), Percentage: 0.5},
+			{ParticipantContactID: strPtr("contact-participant"), Percentage: 0.5},
+		},
+	}
+
+	got := involvedContactIDs(m)
+	want := []string{"contact-payer", "contact-participant"}
+	if len(got) != len(want) {
+		t.Fatalf("involvedContactIDs() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("involvedContactIDs()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestCreateMovementInput_PrivateRequiresMemberPaidHousehold(t *testing.T) {
+	private := false
+	base := CreateMovementInput{
+		Type:                  TypeHousehold,
+		Description:           "Mercado",
+		Amount:                1000,
+		CategoryID:            strPtr("cat-1"),
+		MovementDate:          time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC),
+		PaymentMethodID:       strPtr("pm-1"),
+		IsSharedWithHousehold: &private,
+	}
+
+	paidByMember := base
+	paidByMember.PayerUserID = strPtr("user-1")
+	if err := paidByMember.Validate(); err != nil {
+		t.Errorf("expected private HOUSEHOLD movement paid by a member to be valid, got %v", err)
+	}
+
+	paidByContact := base
+	paidByContact.PayerContactID = strPtr("contact-1")
+	if err := paidByContact.Validate(); err != ErrPrivateNotAllowed {
+		t.Errorf("expected ErrPrivateNotAllowed for contact payer, got %v", err)
+	}
+}
+
+func TestMovementVisibleTo(t *testing.T) {
+	m := &Movement{PayerUserID: strPtr("user-1")}
+	if !m.VisibleTo("user-1") {
+		t.Error("payer should see their private movement")
+	}
+	if m.VisibleTo("user-2") {
+		t.Error("other members should not see a private movement")
+	}
+	m.IsSharedWithHousehold = true
+	if !m.VisibleTo("user-2") {
+		t.Error("shared movement should be visible to every member")
+	}
+}
+
+type recordingDispatcher struct {
+	events []*webhooks.Event
+}
+
+func (d *recordingDispatcher) Dispatch(ctx context.Context, event *webhooks.Event) {
+	d.events = append(d.events, event)
+}
+
+func TestDispatchWebhookSkipsPrivateMovements(t *testing.T) {
+	dispatcher := &recordingDispatcher{}
+	s := &service{webhooks: dispatcher}
+
+	s.dispatchWebhook(context.Background(), "household-1", webhooks.EventMovementCreated,
+		&Movement{ID: "private", PayerUserID: strPtr("user-1")})
+	if len(dispatcher.events) != 0 {
+		t.Fatalf("private movement must not reach webhook endpoints, got %d events", len(dispatcher.events))
+	}
+
+	s.dispatchWebhook(context.Background(), "household-1", webhooks.EventMovementCreated,
+		&Movement{ID: "shared", PayerUserID: strPtr("user-1"), IsSharedWithHousehold: true})
+	if len(dispatcher.events) != 1 {
+		t.Fatalf("expected the shared movement to be dispatched, got %d events", len(dispatcher.events))
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"time"
+
+	"github.com/blanquicet/conti/backend/internal/notifications"
+	"github.com/blanquicet/conti/backend/internal/webhooks"
 )
 
 // Errors for movement operations
@@ -21,6 +24,7 @@ var (
 	ErrCategoryRequired       = errors.New("category is required for this movement type")
 	ErrPaymentMethodRequired        = errors.New("payment method is required")
 	ErrPocketDeleteWouldOverdraft   = errors.New("deleting this deposit would cause negative balance")
+	ErrPrivateNotAllowed            = errors.New("only HOUSEHOLD movements paid by a member can be private")
 )
 
 // MovementType represents the type of movement
@@ -88,10 +92,26 @@ type Movement struct {
 	SourcePocketID   *string `json:"source_pocket_id,omitempty"`
 	SourcePocketName *string `json:"source_pocket_name,omitempty"` // Populated from join
 
+	// Who registered the movement (nil for movements created before this was tracked)
+	CreatedByUserID *string `json:"created_by_user_id,omitempty"`
+
+	// True while the movement has an open dispute (computed)
+	IsDisputed bool `json:"is_disputed"`
+
+	// Private movements are listed only to their payer; other members see
+	// them in the totals
+	IsSharedWithHousehold bool `json:"is_shared_with_household"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// VisibleTo reports whether userID may see the movement itself, not just its
+// share of the household totals
+func (m *Movement) VisibleTo(userID string) bool {
+	return m.IsSharedWithHousehold || (m.PayerUserID != nil && *m.PayerUserID == userID)
+}
+
 // Participant represents a participant in a shared expense
 type Participant struct {
 	ID                   string    `json:"id"`
@@ -136,6 +156,13 @@ type CreateMovementInput struct {
 
 	// Source pocket (set when movement is created from a pocket transaction)
 	SourcePocketID *string `json:"source_pocket_id,omitempty"`
+
+	// Visibility (optional, defaults to true). Only HOUSEHOLD movements paid
+	// by a member can be private.
+	IsSharedWithHousehold *bool `json:"is_shared_with_household,omitempty"`
+
+	// Set by the service from the authenticated user, never from the request
+	CreatedByUserID *string `json:"-"`
 }
 
 // ParticipantInput represents input for a participant
@@ -175,6 +202,11 @@ func (i *CreateMovementInput) Validate() error {
 		return errors.New("cannot specify both payer_user_id and payer_contact_id")
 	}
 
+	// Only the paying member can own a private movement
+	if i.IsSharedWithHousehold != nil && !*i.IsSharedWithHousehold && (i.Type != TypeHousehold || !hasPayerUser) {
+		return ErrPrivateNotAllowed
+	}
+
 	// Type-specific validations
 	switch i.Type {
 	case TypeHousehold:
@@ -286,6 +318,9 @@ type UpdateMovementInput struct {
 	
 	// Generated from template (can be updated when linking movement to a template)
 	GeneratedFromTemplateID *string `json:"generated_from_template_id,omitempty"`
+
+	// Visibility (only the payer may change it)
+	IsSharedWithHousehold *bool `json:"is_shared_with_household,omitempty"`
 	
 	// Note: Cannot update type after creation
 }
@@ -344,6 +379,7 @@ type DebtMovementDetail struct {
 	PayerName           string  `json:"payer_name,omitempty"` // Name of who paid (for SPLIT movements)
 	IsCrossHousehold    bool    `json:"is_cross_household,omitempty"`
 	SourceHouseholdName string  `json:"source_household_name,omitempty"`
+	IsDisputed          bool    `json:"is_disputed,omitempty"`
 }
 
 // DebtBalance represents who owes whom and how much
@@ -355,6 +391,7 @@ type DebtBalance struct {
 	Amount           float64 `json:"amount"`      // Amount owed
 	Currency         string  `json:"currency"`
 	IsCrossHousehold bool    `json:"is_cross_household,omitempty"` // True if any movement is from another household
+	HasDisputes      bool    `json:"has_disputes,omitempty"`       // True if any movement has an open dispute
 	Movements        []DebtMovementDetail `json:"movements,omitempty"` // Breakdown of movements contributing to this debt
 }
 
@@ -371,10 +408,66 @@ type DebtSummary struct {
 	WeOwe     float64 `json:"we_owe"`      // What household members owe to external contacts
 }
 
-// ListMovementsResponse represents the response for listing movements
+// ListMovementsResponse represents the response for listing movements.
+// Totals include other members' private movements, which are left out of
+// Movements and reported under the Private fields.
 type ListMovementsResponse struct {
-	Movements []*Movement     `json:"movements"`
-	Totals    *MovementTotals `json:"totals"`
+	Movements     []*Movement     `json:"movements"`
+	Totals        *MovementTotals `json:"totals"`
+	PrivateCount  int             `json:"private_count"`
+	PrivateAmount float64         `json:"private_amount"`
+
+	// Breakdown of the private movements for in-process summaries; not sent to clients
+	PrivateSpending []*PrivateSpending `json:"-"`
+}
+
+// PrivateSpending adds up other members' private movements that share a
+// category, payer and payment method, so summaries can count them without
+// revealing the movements themselves
+type PrivateSpending struct {
+	CategoryName      *string
+	CategoryGroupName *string
+	PayerName         string
+	PaymentMethodName *string
+	Amount            float64
+	Count             int
+}
+
+// SharedMovementsFilters represents filters for listing movements shared
+// with the user from other households
+type SharedMovementsFilters struct {
+	Month  *string // YYYY-MM format
+	Limit  int
+	Offset int
+}
+
+// SharedMovement is a movement from another household in which the user
+// participates as a linked contact. Only the user's share is exposed:
+// categories, payment methods, accounts and other participants stay private
+// to the source household.
+type SharedMovement struct {
+	MovementID          string       `json:"movement_id"`
+	SourceHouseholdID   string       `json:"source_household_id"`
+	SourceHouseholdName string       `json:"source_household_name"`
+	Type                MovementType `json:"type"`
+	Description         string       `json:"description"`
+	MovementDate        time.Time    `json:"movement_date"`
+	Currency            string       `json:"currency"`
+	PayerName           string       `json:"payer_name"`
+	PaidByMe            bool         `json:"paid_by_me"`
+	CounterpartyName    *string      `json:"counterparty_name,omitempty"` // Only for DEBT_PAYMENT
+	MyAmount            float64      `json:"my_amount"`                   // My share (SPLIT) or payment amount (DEBT_PAYMENT)
+	MyPercentage        *float64     `json:"my_percentage,omitempty"`     // Only for SPLIT when I am a participant
+	PaidAmount          *float64     `json:"paid_amount,omitempty"`       // Only for SPLIT when I am the payer
+	IsDisputed          bool         `json:"is_disputed"`
+}
+
+// ListSharedMovementsResponse represents the response for listing shared movements
+type ListSharedMovementsResponse struct {
+	Movements []*SharedMovement `json:"movements"`
+	Total     int               `json:"total"`
+	Limit     int               `json:"limit"`
+	Offset    int               `json:"offset"`
 }
 
 // Repository defines the interface for movement data access
@@ -384,6 +477,7 @@ type Repository interface {
 	GetCategoryIDByName(ctx context.Context, householdID string, categoryName string) (string, error)
 	ListByHousehold(ctx context.Context, householdID string, filters *ListMovementsFilters) ([]*Movement, error)
 	ListMovementsByContactIDs(ctx context.Context, contactIDs []string, month *string) ([]*Movement, error)
+	ListMovementsByContactIDsPage(ctx context.Context, contactIDs []string, filters *SharedMovementsFilters) ([]*Movement, int, error)
 	GetTotals(ctx context.Context, householdID string, filters *ListMovementsFilters) (*MovementTotals, error)
 	Update(ctx context.Context, id string, input *UpdateMovementInput) (*Movement, error)
 	Delete(ctx context.Context, id string) error
@@ -395,7 +489,11 @@ type Service interface {
 	GetByID(ctx context.Context, userID, id string) (*Movement, error)
 	ListByHousehold(ctx context.Context, userID string, filters *ListMovementsFilters) (*ListMovementsResponse, error)
 	GetDebtConsolidation(ctx context.Context, userID string, month *string) (*DebtConsolidationResponse, error)
+	ListSharedWithMe(ctx context.Context, userID string, filters *SharedMovementsFilters) (*ListSharedMovementsResponse, error)
 	Update(ctx context.Context, userID, id string, input *UpdateMovementInput) (*Movement, error)
 	Delete(ctx context.Context, userID, id string) error
 	SetDeletePocketTransactionFn(fn func(ctx context.Context, movementID, householdID string) error)
+	SetCheckBudgetFn(fn func(ctx context.Context, householdID, categoryID, month string) error)
+	SetNotifier(notifier notifications.Publisher)
+	SetWebhookDispatcher(dispatcher webhooks.Dispatcher)
 }
@@ -0,0 +1,141 @@
+package notifications
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/blanquicet/conti/backend/internal/auth"
+)
+
+// Handler handles HTTP requests for the notification inbox
+type Handler struct {
+	service    Service
+	authSvc    *auth.Service
+	cookieName string
+	logger     *slog.Logger
+}
+
+// NewHandler creates a new notifications handler
+func NewHandler(service Service, authSvc *auth.Service, cookieName string, logger *slog.Logger) *Handler {
+	return &Handler{
+		service:    service,
+		authSvc:    authSvc,
+		cookieName: cookieName,
+		logger:     logger,
+	}
+}
+
+func (h *Handler) getUserID(r *http.Request) (string, error) {
+	user, err := h.authSvc.UserFromRequest(r, h.cookieName)
+	if err != nil {
+		return "", err
+	}
+	return user.ID, nil
+}
+
+func (h *Handler) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+// HandleList returns the user's notifications, newest first
+// GET /notifications?unread_only=true&limit=50&offset=0
+func (h *Handler) HandleList(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserID(r)
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	q := r.URL.Query()
+	filters := &ListFilters{UnreadOnly: q.Get("unread_only") == "true"}
+	if v := q.Get("limit"); v != "" {
+		if filters.Limit, err = strconv.Atoi(v); err != nil {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+	if v := q.Get("offset"); v != "" {
+		if filters.Offset, err = strconv.Atoi(v); err != nil {
+			http.Error(w, "invalid offset", http.StatusBadRequest)
+			return
+		}
+	}
+
+	resp, err := h.service.List(r.Context(), userID, filters)
+	if err != nil {
+		h.logger.Error("failed to list notifications", "error", err, "user_id", userID)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+// HandleUnreadCount returns the number of unread notifications
+// GET /notifications/unread-count
+func (h *Handler) HandleUnreadCount(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserID(r)
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	count, err := h.service.CountUnread(r.Context(), userID)
+	if err != nil {
+		h.logger.Error("failed to count unread notifications", "error", err, "user_id", userID)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]int{"unread_count": count})
+}
+
+// HandleMarkRead marks a notification as read
+// POST /notifications/{id}/read
+func (h *Handler) HandleMarkRead(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserID(r)
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id := r.PathValue("id")
+	n, err := h.service.MarkRead(r.Context(), userID, id)
+	if err != nil {
+		if errors.Is(err, ErrNotificationNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to mark notification as read", "error", err, "notification_id", id, "user_id", userID)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, n)
+}
+
+// HandleMarkAllRead marks all of the user's notifications as read
+// POST /notifications/read-all
+func (h *Handler) HandleMarkAllRead(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserID(r)
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	updated, err := h.service.MarkAllRead(r.Context(), userID)
+	if err != nil {
+		h.logger.Error("failed to mark all notifications as read", "error", err, "user_id", userID)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]int64{"updated": updated})
+}
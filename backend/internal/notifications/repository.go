@@ -0,0 +1,148 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type repository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRepository creates a new notifications repository
+func NewRepository(pool *pgxpool.Pool) Repository {
+	return &repository{pool: pool}
+}
+
+const notificationSelect = `
+	SELECT id, user_id, household_id, type, title, body, resource_type, resource_id, data, read_at, created_at
+	FROM notifications
+`
+
+func scanNotification(row pgx.Row) (*Notification, error) {
+	var n Notification
+	var dataJSON []byte
+	err := row.Scan(
+		&n.ID,
+		&n.UserID,
+		&n.HouseholdID,
+		&n.Type,
+		&n.Title,
+		&n.Body,
+		&n.ResourceType,
+		&n.ResourceID,
+		&dataJSON,
+		&n.ReadAt,
+		&n.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotificationNotFound
+		}
+		return nil, err
+	}
+	if len(dataJSON) > 0 {
+		if err := json.Unmarshal(dataJSON, &n.Data); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal data: %w", err)
+		}
+	}
+	return &n, nil
+}
+
+// Create inserts one notification per recipient in a single batch
+func (r *repository) Create(ctx context.Context, input *PublishInput) error {
+	var dataJSON []byte
+	if input.Data != nil {
+		var err error
+		dataJSON, err = json.Marshal(input.Data)
+		if err != nil {
+			return fmt.Errorf("failed to marshal data: %w", err)
+		}
+	}
+
+	batch := &pgx.Batch{}
+	for _, userID := range input.UserIDs {
+		batch.Queue(`
+			INSERT INTO notifications (user_id, household_id, type, title, body, resource_type, resource_id, data, dedup_key)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			ON CONFLICT (user_id, dedup_key) WHERE dedup_key IS NOT NULL DO NOTHING
+		`, userID, input.HouseholdID, input.Type, input.Title, input.Body,
+			input.ResourceType, input.ResourceID, dataJSON, input.DedupKey)
+	}
+
+	results := r.pool.SendBatch(ctx, batch)
+	defer results.Close()
+	for range input.UserIDs {
+		if _, err := results.Exec(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// List returns a page of the user's notifications, newest first, with the total count
+func (r *repository) List(ctx context.Context, userID string, filters *ListFilters) ([]*Notification, int, error) {
+	where := `WHERE user_id = $1`
+	if filters.UnreadOnly {
+		where += ` AND read_at IS NULL`
+	}
+
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM notifications `+where, userID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.pool.Query(ctx, notificationSelect+where+`
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`, userID, filters.Limit, filters.Offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := make([]*Notification, 0)
+	for rows.Next() {
+		n, err := scanNotification(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, n)
+	}
+	return items, total, rows.Err()
+}
+
+// CountUnread returns the number of unread notifications of a user
+func (r *repository) CountUnread(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL
+	`, userID).Scan(&count)
+	return count, err
+}
+
+// MarkRead marks one of the user's notifications as read (idempotent)
+func (r *repository) MarkRead(ctx context.Context, userID, id string) (*Notification, error) {
+	return scanNotification(r.pool.QueryRow(ctx, `
+		UPDATE notifications
+		SET read_at = COALESCE(read_at, NOW())
+		WHERE id = $1 AND user_id = $2
+		RETURNING id, user_id, household_id, type, title, body, resource_type, resource_id, data, read_at, created_at
+	`, id, userID))
+}
+
+// MarkAllRead marks all of the user's unread notifications as read
+func (r *repository) MarkAllRead(ctx context.Context, userID string) (int64, error) {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE notifications SET read_at = NOW() WHERE user_id = $1 AND read_at IS NULL
+	`, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
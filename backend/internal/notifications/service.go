@@ -0,0 +1,113 @@
+package notifications
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type service struct {
+	repo   Repository
+	logger *slog.Logger
+
+	// Buffered channel for async publishing
+	asyncChan chan *PublishInput
+}
+
+// NewService creates a new notifications service
+func NewService(repo Repository, logger *slog.Logger) Service {
+	s := &service{
+		repo:      repo,
+		logger:    logger,
+		asyncChan: make(chan *PublishInput, 1000),
+	}
+
+	// Start background worker
+	go s.asyncWorker()
+
+	return s
+}
+
+// Publish queues a notification for delivery (non-blocking).
+// Duplicate and empty recipients are dropped; nothing is queued if none remain.
+func (s *service) Publish(ctx context.Context, input *PublishInput) {
+	input.UserIDs = uniqueUserIDs(input.UserIDs)
+	if len(input.UserIDs) == 0 {
+		return
+	}
+
+	select {
+	case s.asyncChan <- input:
+		// Successfully queued
+	default:
+		s.logger.Warn("Notification channel full, dropping notification", "type", input.Type)
+	}
+}
+
+// asyncWorker persists queued notifications
+func (s *service) asyncWorker() {
+	for input := range s.asyncChan {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := s.repo.Create(ctx, input); err != nil {
+			s.logger.Error("failed to publish notification", "error", err, "type", input.Type)
+		}
+		cancel()
+	}
+}
+
+// List returns a page of the user's inbox along with the unread count
+func (s *service) List(ctx context.Context, userID string, filters *ListFilters) (*ListResponse, error) {
+	if filters.Limit <= 0 || filters.Limit > 100 {
+		filters.Limit = 50
+	}
+	if filters.Offset < 0 {
+		filters.Offset = 0
+	}
+
+	items, total, err := s.repo.List(ctx, userID, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	unread, err := s.repo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListResponse{
+		Notifications: items,
+		Total:         total,
+		UnreadCount:   unread,
+		Limit:         filters.Limit,
+		Offset:        filters.Offset,
+	}, nil
+}
+
+// CountUnread returns the number of unread notifications of the user
+func (s *service) CountUnread(ctx context.Context, userID string) (int, error) {
+	return s.repo.CountUnread(ctx, userID)
+}
+
+// MarkRead marks one notification as read
+func (s *service) MarkRead(ctx context.Context, userID, id string) (*Notification, error) {
+	return s.repo.MarkRead(ctx, userID, id)
+}
+
+// MarkAllRead marks every unread notification of the user as read
+func (s *service) MarkAllRead(ctx context.Context, userID string) (int64, error) {
+	return s.repo.MarkAllRead(ctx, userID)
+}
+
+// uniqueUserIDs removes empty and duplicate user IDs, preserving order
+func uniqueUserIDs(userIDs []string) []string {
+	seen := make(map[string]bool, len(userIDs))
+	result := make([]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
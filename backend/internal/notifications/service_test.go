@@ -0,0 +1,20 @@
+package notifications
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUniqueUserIDs(t *testing.T) {
+	got := uniqueUserIDs([]string{"user-1", "", "user-2", "user-1", "user-3", "user-2"})
+	want := []string{"user-1", "user-2", "user-3"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("uniqueUserIDs() = %v, want %v", got, want)
+	}
+}
+
+func TestUniqueUserIDs_Empty(t *testing.T) {
+	if got := uniqueUserIDs(nil); len(got) != 0 {
+		t.Errorf("uniqueUserIDs(nil) = %v, want empty", got)
+	}
+}
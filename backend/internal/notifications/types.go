@@ -0,0 +1,110 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Errors for notification operations
+var (
+	ErrNotificationNotFound = errors.New("notification not found")
+)
+
+// Type identifies the kind of notification
+type Type string
+
+const (
+	TypeBudgetExceeded           Type = "budget_exceeded"
+	TypeBudgetAlert              Type = "budget_alert"
+	TypeRecurringMovementCreated Type = "recurring_movement_created"
+	TypeLinkRequested            Type = "link_requested"
+	TypeLinkAccepted             Type = "link_accepted"
+	TypeLinkRejected             Type = "link_rejected"
+	TypeSharedMovementCreated    Type = "shared_movement_created"
+	TypeMovementComment          Type = "movement_comment"
+	TypeMovementDisputed         Type = "movement_disputed"
+	TypeMovementDisputeResolved  Type = "movement_dispute_resolved"
+)
+
+// Notification is an entry in a user's inbox
+type Notification struct {
+	ID           string                 `json:"id"`
+	UserID       string                 `json:"user_id"`
+	HouseholdID  *string                `json:"household_id,omitempty"`
+	Type         Type                   `json:"type"`
+	Title        string                 `json:"title"`
+	Body         *string                `json:"body,omitempty"`
+	ResourceType *string                `json:"resource_type,omitempty"`
+	ResourceID   *string                `json:"resource_id,omitempty"`
+	Data         map[string]interface{} `json:"data,omitempty"`
+	ReadAt       *time.Time             `json:"read_at,omitempty"`
+	CreatedAt    time.Time              `json:"created_at"`
+}
+
+// IsRead reports whether the notification has been read
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
+
+// PublishInput describes a notification to deliver to one or more users.
+// One inbox entry is created per recipient.
+type PublishInput struct {
+	UserIDs      []string
+	HouseholdID  *string
+	Type         Type
+	Title        string
+	Body         *string
+	ResourceType *string
+	ResourceID   *string
+	Data         map[string]interface{}
+	// DedupKey, when set, ensures each recipient gets at most one
+	// notification with this key (later publishes are ignored)
+	DedupKey *string
+}
+
+// ListFilters represents filters for listing a user's notifications
+type ListFilters struct {
+	UnreadOnly bool
+	Limit      int // Default 50, max 100
+	Offset     int
+}
+
+// ListResponse is the response for listing notifications
+type ListResponse struct {
+	Notifications []*Notification `json:"notifications"`
+	Total         int             `json:"total"`
+	UnreadCount   int             `json:"unread_count"`
+	Limit         int             `json:"limit"`
+	Offset        int             `json:"offset"`
+}
+
+// Repository defines data access for notifications
+type Repository interface {
+	// Create inserts one notification per recipient, skipping duplicates by dedup key
+	Create(ctx context.Context, input *PublishInput) error
+	List(ctx context.Context, userID string, filters *ListFilters) ([]*Notification, int, error)
+	CountUnread(ctx context.Context, userID string) (int, error)
+	MarkRead(ctx context.Context, userID, id string) (*Notification, error)
+	MarkAllRead(ctx context.Context, userID string) (int64, error)
+}
+
+// Publisher is the narrow interface other packages use to emit notifications.
+// Publishing is asynchronous and never fails the caller.
+type Publisher interface {
+	Publish(ctx context.Context, input *PublishInput)
+}
+
+// Service defines business logic for the notification inbox
+type Service interface {
+	Publisher
+	List(ctx context.Context, userID string, filters *ListFilters) (*ListResponse, error)
+	CountUnread(ctx context.Context, userID string) (int, error)
+	MarkRead(ctx context.Context, userID, id string) (*Notification, error)
+	MarkAllRead(ctx context.Context, userID string) (int64, error)
+}
+
+// StringPtr returns a pointer to s (helper for building PublishInput)
+func StringPtr(s string) *string {
+	return &s
+}
@@ -69,12 +69,7 @@ Error string `json:"error"`
 // Helper methods
 
 func (h *Handler) getUserFromRequest(r *http.Request) (*auth.User, error) {
-cookie, err := r.Cookie(h.cookieName)
-if err != nil {
-return nil, errors.New("no session cookie")
-}
-
-user, err := h.authSvc.GetUserBySession(r.Context(), cookie.Value)
+user, err := h.authSvc.UserFromRequest(r, h.cookieName)
 if err != nil {
 return nil, err
 }
@@ -82,17 +77,24 @@ return nil, err
 return user, nil
 }
 
-func (h *Handler) getUserHousehold(ctx context.Context, userID string) (*households.Household, error) {
-households, err := h.householdRepo.ListByUser(ctx, userID)
+// getUserHousehold returns the household the request acts on after checking
+// the user's role there grants perm
+func (h *Handler) getUserHousehold(ctx context.Context, userID string, perm households.Permission) (*households.Household, error) {
+householdID, err := households.AuthorizeSelected(ctx, h.householdRepo, userID, perm)
 if err != nil {
 return nil, err
 }
 
-if len(households) == 0 {
-return nil, errors.New("user has no household")
+return h.householdRepo.GetByID(ctx, householdID)
 }
 
-return households[0], nil
+// respondHouseholdError answers 403 when the user's role does not allow the request
+func (h *Handler) respondHouseholdError(w http.ResponseWriter, err error) {
+if errors.Is(err, households.ErrNotAuthorized) {
+h.respondError(w, errors.New("forbidden: your role does not allow this action"), http.StatusForbidden)
+return
+}
+h.respondError(w, errors.New("user has no household"), http.StatusNotFound)
 }
 
 func (h *Handler) respondJSON(w http.ResponseWriter, data interface{}, statusCode int) {
@@ -123,9 +125,9 @@ return
 }
 
 // Get user's household
-household, err := h.getUserHousehold(r.Context(), user.ID)
+household, err := h.getUserHousehold(r.Context(), user.ID, households.PermEditAccounts)
 if err != nil {
-h.respondError(w, errors.New("user has no household"), http.StatusNotFound)
+h.respondHouseholdError(w, err)
 return
 }
 
@@ -167,9 +169,9 @@ func (h *Handler) ListPaymentMethods(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get user's household
-	household, err := h.getUserHousehold(r.Context(), user.ID)
+	household, err := h.getUserHousehold(r.Context(), user.ID, households.PermView)
 	if err != nil {
-		h.respondError(w, errors.New("user has no household"), http.StatusNotFound)
+		h.respondHouseholdError(w, err)
 		return
 	}
 
@@ -241,6 +243,11 @@ h.respondError(w, errors.New("payment method ID is required"), http.StatusBadReq
 return
 }
 
+if _, err := h.getUserHousehold(r.Context(), user.ID, households.PermEditAccounts); err != nil {
+h.respondHouseholdError(w, err)
+return
+}
+
 var req UpdatePaymentMethodRequest
 if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 h.respondError(w, errors.New("invalid request body"), http.StatusBadRequest)
@@ -295,6 +302,11 @@ h.respondError(w, errors.New("payment method ID is required"), http.StatusBadReq
 return
 }
 
+if _, err := h.getUserHousehold(r.Context(), user.ID, households.PermEditAccounts); err != nil {
+h.respondHouseholdError(w, err)
+return
+}
+
 err = h.service.Delete(r.Context(), id, user.ID)
 if err != nil {
 if errors.Is(err, ErrPaymentMethodNotFound) {
@@ -47,6 +47,8 @@ type CreatePocketRequest struct {
 	Icon       string   `json:"icon"`
 	GoalAmount *float64 `json:"goal_amount,omitempty"`
 	Note       *string  `json:"note,omitempty"`
+
+	IsSharedWithHousehold *bool `json:"is_shared_with_household,omitempty"` // Optional, defaults to true
 }
 
 // UpdatePocketRequest is the request body for updating a pocket
@@ -57,6 +59,8 @@ type UpdatePocketRequest struct {
 	ClearGoal  bool     `json:"clear_goal,omitempty"`
 	Note       *string  `json:"note,omitempty"`
 	ClearNote  bool     `json:"clear_note,omitempty"`
+
+	IsSharedWithHousehold *bool `json:"is_shared_with_household,omitempty"`
 }
 
 // DepositRequest is the request body for depositing into a pocket
@@ -92,12 +96,7 @@ type ErrorResponse struct {
 // Helper methods
 
 func (h *Handler) getUserFromRequest(r *http.Request) (*auth.User, error) {
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		return nil, errors.New("no session cookie")
-	}
-
-	user, err := h.authSvc.GetUserBySession(r.Context(), cookie.Value)
+	user, err := h.authSvc.UserFromRequest(r, h.cookieName)
 	if err != nil {
 		return nil, err
 	}
@@ -105,17 +104,24 @@ func (h *Handler) getUserFromRequest(r *http.Request) (*auth.User, error) {
 	return user, nil
 }
 
-func (h *Handler) getUserHousehold(ctx context.Context, userID string) (*households.Household, error) {
-	households, err := h.householdRepo.ListByUser(ctx, userID)
+// getUserHousehold returns the household the request acts on after checking
+// the user's role there grants perm
+func (h *Handler) getUserHousehold(ctx context.Context, userID string, perm households.Permission) (*households.Household, error) {
+	householdID, err := households.AuthorizeSelected(ctx, h.householdRepo, userID, perm)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(households) == 0 {
-		return nil, errors.New("user has no household")
-	}
+	return h.householdRepo.GetByID(ctx, householdID)
+}
 
-	return households[0], nil
+// respondHouseholdError answers 403 when the user's role does not allow the request
+func (h *Handler) respondHouseholdError(w http.ResponseWriter, err error) {
+	if errors.Is(err, households.ErrNotAuthorized) {
+		h.respondError(w, errors.New("forbidden: your role does not allow this action"), http.StatusForbidden)
+		return
+	}
+	h.respondError(w, errors.New("user has no household"), http.StatusNotFound)
 }
 
 func (h *Handler) respondJSON(w http.ResponseWriter, data any, statusCode int) {
@@ -167,9 +173,9 @@ func (h *Handler) HandleCreate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	household, err := h.getUserHousehold(r.Context(), user.ID)
+	household, err := h.getUserHousehold(r.Context(), user.ID, households.PermEditAccounts)
 	if err != nil {
-		h.respondError(w, errors.New("user has no household"), http.StatusNotFound)
+		h.respondHouseholdError(w, err)
 		return
 	}
 
@@ -186,6 +192,8 @@ func (h *Handler) HandleCreate(w http.ResponseWriter, r *http.Request) {
 		Icon:        req.Icon,
 		GoalAmount:  req.GoalAmount,
 		Note:        req.Note,
+
+		IsSharedWithHousehold: req.IsSharedWithHousehold,
 	}
 
 	pocket, err := h.service.Create(r.Context(), input)
@@ -210,13 +218,13 @@ func (h *Handler) HandleList(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	household, err := h.getUserHousehold(r.Context(), user.ID)
+	household, err := h.getUserHousehold(r.Context(), user.ID, households.PermView)
 	if err != nil {
-		h.respondError(w, errors.New("user has no household"), http.StatusNotFound)
+		h.respondHouseholdError(w, err)
 		return
 	}
 
-	pockets, err := h.service.ListByHousehold(r.Context(), household.ID)
+	pockets, err := h.service.ListByHousehold(r.Context(), household.ID, user.ID)
 	if err != nil {
 		h.respondError(w, err, http.StatusInternalServerError)
 		return
@@ -233,13 +241,13 @@ func (h *Handler) HandleGetSummary(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	household, err := h.getUserHousehold(r.Context(), user.ID)
+	household, err := h.getUserHousehold(r.Context(), user.ID, households.PermView)
 	if err != nil {
-		h.respondError(w, errors.New("user has no household"), http.StatusNotFound)
+		h.respondHouseholdError(w, err)
 		return
 	}
 
-	summary, err := h.service.GetSummary(r.Context(), household.ID)
+	summary, err := h.service.GetSummary(r.Context(), household.ID, user.ID)
 	if err != nil {
 		h.respondError(w, err, http.StatusInternalServerError)
 		return
@@ -256,9 +264,9 @@ func (h *Handler) HandleGetByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	household, err := h.getUserHousehold(r.Context(), user.ID)
+	household, err := h.getUserHousehold(r.Context(), user.ID, households.PermView)
 	if err != nil {
-		h.respondError(w, errors.New("user has no household"), http.StatusNotFound)
+		h.respondHouseholdError(w, err)
 		return
 	}
 
@@ -268,7 +276,7 @@ func (h *Handler) HandleGetByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pocket, err := h.service.GetByID(r.Context(), id, household.ID)
+	pocket, err := h.service.GetByID(r.Context(), id, household.ID, user.ID)
 	if err != nil {
 		h.handleServiceError(w, err)
 		return
@@ -285,9 +293,9 @@ func (h *Handler) HandleUpdate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	household, err := h.getUserHousehold(r.Context(), user.ID)
+	household, err := h.getUserHousehold(r.Context(), user.ID, households.PermEditAccounts)
 	if err != nil {
-		h.respondError(w, errors.New("user has no household"), http.StatusNotFound)
+		h.respondHouseholdError(w, err)
 		return
 	}
 
@@ -311,6 +319,8 @@ func (h *Handler) HandleUpdate(w http.ResponseWriter, r *http.Request) {
 		ClearGoal:  req.ClearGoal,
 		Note:       req.Note,
 		ClearNote:  req.ClearNote,
+
+		IsSharedWithHousehold: req.IsSharedWithHousehold,
 	}
 
 	pocket, err := h.service.Update(r.Context(), user.ID, household.ID, input)
@@ -335,9 +345,9 @@ func (h *Handler) HandleDelete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	household, err := h.getUserHousehold(r.Context(), user.ID)
+	household, err := h.getUserHousehold(r.Context(), user.ID, households.PermEditAccounts)
 	if err != nil {
-		h.respondError(w, errors.New("user has no household"), http.StatusNotFound)
+		h.respondHouseholdError(w, err)
 		return
 	}
 
@@ -366,9 +376,9 @@ func (h *Handler) HandleDeposit(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	household, err := h.getUserHousehold(r.Context(), user.ID)
+	household, err := h.getUserHousehold(r.Context(), user.ID, households.PermEditMovements)
 	if err != nil {
-		h.respondError(w, errors.New("user has no household"), http.StatusNotFound)
+		h.respondHouseholdError(w, err)
 		return
 	}
 
@@ -423,9 +433,9 @@ func (h *Handler) HandleWithdraw(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	household, err := h.getUserHousehold(r.Context(), user.ID)
+	household, err := h.getUserHousehold(r.Context(), user.ID, households.PermEditMovements)
 	if err != nil {
-		h.respondError(w, errors.New("user has no household"), http.StatusNotFound)
+		h.respondHouseholdError(w, err)
 		return
 	}
 
@@ -480,9 +490,9 @@ func (h *Handler) HandleListTransactions(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	household, err := h.getUserHousehold(r.Context(), user.ID)
+	household, err := h.getUserHousehold(r.Context(), user.ID, households.PermView)
 	if err != nil {
-		h.respondError(w, errors.New("user has no household"), http.StatusNotFound)
+		h.respondHouseholdError(w, err)
 		return
 	}
 
@@ -492,7 +502,7 @@ func (h *Handler) HandleListTransactions(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	transactions, err := h.service.ListTransactions(r.Context(), id, household.ID)
+	transactions, err := h.service.ListTransactions(r.Context(), id, household.ID, user.ID)
 	if err != nil {
 		h.handleServiceError(w, err)
 		return
@@ -509,9 +519,9 @@ func (h *Handler) HandleEditTransaction(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	household, err := h.getUserHousehold(r.Context(), user.ID)
+	household, err := h.getUserHousehold(r.Context(), user.ID, households.PermEditMovements)
 	if err != nil {
-		h.respondError(w, errors.New("user has no household"), http.StatusNotFound)
+		h.respondHouseholdError(w, err)
 		return
 	}
 
@@ -567,9 +577,9 @@ func (h *Handler) HandleDeleteTransaction(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	household, err := h.getUserHousehold(r.Context(), user.ID)
+	household, err := h.getUserHousehold(r.Context(), user.ID, households.PermEditMovements)
 	if err != nil {
-		h.respondError(w, errors.New("user has no household"), http.StatusNotFound)
+		h.respondHouseholdError(w, err)
 		return
 	}
 
@@ -25,10 +25,11 @@ func NewRepository(pool *pgxpool.Pool) Repository {
 func (r *repository) Create(ctx context.Context, pocket *Pocket) (*Pocket, error) {
 	var id string
 	err := r.pool.QueryRow(ctx, `
-		INSERT INTO pockets (household_id, owner_id, name, icon, goal_amount, note)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO pockets (household_id, owner_id, name, icon, goal_amount, note, is_shared_with_household)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 		RETURNING id
-	`, pocket.HouseholdID, pocket.OwnerID, pocket.Name, pocket.Icon, pocket.GoalAmount, pocket.Note).Scan(&id)
+	`, pocket.HouseholdID, pocket.OwnerID, pocket.Name, pocket.Icon, pocket.GoalAmount, pocket.Note,
+		pocket.IsSharedWithHousehold).Scan(&id)
 
 	if err != nil {
 		var pgErr *pgconn.PgError
@@ -47,6 +48,7 @@ func (r *repository) GetByID(ctx context.Context, id string) (*Pocket, error) {
 	err := r.pool.QueryRow(ctx, `
 		SELECT p.id, p.household_id, p.owner_id, u.name as owner_name,
 		       p.name, p.icon, p.goal_amount, p.note, p.category_id, p.is_active,
+		       p.is_shared_with_household,
 		       p.created_at, p.updated_at
 		FROM pockets p
 		JOIN users u ON p.owner_id = u.id
@@ -62,6 +64,7 @@ func (r *repository) GetByID(ctx context.Context, id string) (*Pocket, error) {
 		&pocket.Note,
 		&pocket.CategoryID,
 		&pocket.IsActive,
+		&pocket.IsSharedWithHousehold,
 		&pocket.CreatedAt,
 		&pocket.UpdatedAt,
 	)
@@ -86,9 +89,11 @@ func (r *repository) GetByID(ctx context.Context, id string) (*Pocket, error) {
 func (r *repository) Update(ctx context.Context, pocket *Pocket) (*Pocket, error) {
 	result, err := r.pool.Exec(ctx, `
 		UPDATE pockets
-		SET name = $1, icon = $2, goal_amount = $3, note = $4, category_id = $5, updated_at = NOW()
-		WHERE id = $6
-	`, pocket.Name, pocket.Icon, pocket.GoalAmount, pocket.Note, pocket.CategoryID, pocket.ID)
+		SET name = $1, icon = $2, goal_amount = $3, note = $4, category_id = $5,
+		    is_shared_with_household = $6, updated_at = NOW()
+		WHERE id = $7
+	`, pocket.Name, pocket.Icon, pocket.GoalAmount, pocket.Note, pocket.CategoryID,
+		pocket.IsSharedWithHousehold, pocket.ID)
 
 	if err != nil {
 		var pgErr *pgconn.PgError
@@ -136,6 +141,7 @@ func (r *repository) listPockets(ctx context.Context, householdID string, active
 	query := `
 		SELECT p.id, p.household_id, p.owner_id, u.name as owner_name,
 		       p.name, p.icon, p.goal_amount, p.note, p.category_id, p.is_active,
+		       p.is_shared_with_household,
 		       p.created_at, p.updated_at
 		FROM pockets p
 		JOIN users u ON p.owner_id = u.id
@@ -166,6 +172,7 @@ func (r *repository) listPockets(ctx context.Context, householdID string, active
 			&pocket.Note,
 			&pocket.CategoryID,
 			&pocket.IsActive,
+			&pocket.IsSharedWithHousehold,
 			&pocket.CreatedAt,
 			&pocket.UpdatedAt,
 		)
@@ -207,6 +214,7 @@ func (r *repository) FindByName(ctx context.Context, householdID, name string) (
 	err := r.pool.QueryRow(ctx, `
 		SELECT p.id, p.household_id, p.owner_id, u.name as owner_name,
 		       p.name, p.icon, p.goal_amount, p.note, p.category_id, p.is_active,
+		       p.is_shared_with_household,
 		       p.created_at, p.updated_at
 		FROM pockets p
 		JOIN users u ON p.owner_id = u.id
@@ -222,6 +230,7 @@ func (r *repository) FindByName(ctx context.Context, householdID, name string) (
 		&pocket.Note,
 		&pocket.CategoryID,
 		&pocket.IsActive,
+		&pocket.IsSharedWithHousehold,
 		&pocket.CreatedAt,
 		&pocket.UpdatedAt,
 	)
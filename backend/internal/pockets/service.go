@@ -9,6 +9,7 @@ import (
 	"github.com/blanquicet/conti/backend/internal/audit"
 	"github.com/blanquicet/conti/backend/internal/households"
 	"github.com/blanquicet/conti/backend/internal/movements"
+	"github.com/blanquicet/conti/backend/internal/webhooks"
 )
 
 // Service handles pocket business logic
@@ -21,6 +22,7 @@ type Service struct {
 	categoryRepo     CategoryRepo
 	auditService     audit.Service
 	logger           *slog.Logger
+	webhooks         webhooks.Dispatcher
 }
 
 // NewService creates a new pocket service
@@ -46,6 +48,43 @@ func NewService(
 	}
 }
 
+// SetWebhookDispatcher sets the dispatcher used to send pocket events to webhook endpoints
+func (s *Service) SetWebhookDispatcher(dispatcher webhooks.Dispatcher) {
+	s.webhooks = dispatcher
+}
+
+// checkGoalReached dispatches pocket.goal_reached when a deposit of amount takes the
+// balance from below the goal to at or above it. Private pockets are skipped.
+func (s *Service) checkGoalReached(ctx context.Context, pocket *Pocket, amount float64) {
+	if s.webhooks == nil || pocket.GoalAmount == nil || !pocket.IsSharedWithHousehold {
+		return
+	}
+
+	balance, err := s.repo.GetBalance(ctx, pocket.ID)
+	if err != nil {
+		s.logger.Error("failed to get pocket balance for goal check", "pocket_id", pocket.ID, "error", err)
+		return
+	}
+
+	goal := *pocket.GoalAmount
+	if balance-amount >= goal || balance < goal {
+		return
+	}
+
+	dedupKey := fmt.Sprintf("pocket_goal_reached:%s:%.2f", pocket.ID, goal)
+	s.webhooks.Dispatch(ctx, &webhooks.Event{
+		HouseholdID: pocket.HouseholdID,
+		Type:        webhooks.EventPocketGoalReached,
+		Data: map[string]interface{}{
+			"pocket_id":   pocket.ID,
+			"pocket_name": pocket.Name,
+			"goal_amount": goal,
+			"balance":     balance,
+		},
+		DedupKey: &dedupKey,
+	})
+}
+
 // Create creates a new pocket
 func (s *Service) Create(ctx context.Context, input *CreatePocketInput) (*Pocket, error) {
 	// Validate input
@@ -80,14 +119,20 @@ func (s *Service) Create(ctx context.Context, input *CreatePocketInput) (*Pocket
 		return nil, ErrNotAuthorized
 	}
 
+	isShared := true
+	if input.IsSharedWithHousehold != nil {
+		isShared = *input.IsSharedWithHousehold
+	}
+
 	// Create pocket
 	pocket := &Pocket{
-		HouseholdID: input.HouseholdID,
-		OwnerID:     input.OwnerID,
-		Name:        input.Name,
-		Icon:        input.Icon,
-		GoalAmount:  input.GoalAmount,
-		Note:        input.Note,
+		HouseholdID:           input.HouseholdID,
+		OwnerID:               input.OwnerID,
+		Name:                  input.Name,
+		Icon:                  input.Icon,
+		GoalAmount:            input.GoalAmount,
+		Note:                  input.Note,
+		IsSharedWithHousehold: isShared,
 	}
 
 	pocket, err = s.repo.Create(ctx, pocket)
@@ -109,8 +154,9 @@ func (s *Service) Create(ctx context.Context, input *CreatePocketInput) (*Pocket
 	return pocket, nil
 }
 
-// GetByID retrieves a pocket by ID, verifying household access
-func (s *Service) GetByID(ctx context.Context, id, householdID string) (*Pocket, error) {
+// getVisiblePocket loads a pocket of householdID that userID is allowed to see.
+// Another member's private pocket reads as not found.
+func (s *Service) getVisiblePocket(ctx context.Context, id, householdID, userID string) (*Pocket, error) {
 	pocket, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -119,17 +165,37 @@ func (s *Service) GetByID(ctx context.Context, id, householdID string) (*Pocket,
 	if pocket.HouseholdID != householdID {
 		return nil, ErrNotAuthorized
 	}
+	if !pocket.VisibleTo(userID) {
+		return nil, ErrPocketNotFound
+	}
 
 	return pocket, nil
 }
 
-// ListByHousehold lists all active pockets for a household
-func (s *Service) ListByHousehold(ctx context.Context, householdID string) ([]*Pocket, error) {
-	return s.repo.ListActiveByHousehold(ctx, householdID)
+// GetByID retrieves a pocket by ID, verifying household access
+func (s *Service) GetByID(ctx context.Context, id, householdID, userID string) (*Pocket, error) {
+	return s.getVisiblePocket(ctx, id, householdID, userID)
+}
+
+// ListByHousehold lists the active pockets of a household visible to userID
+func (s *Service) ListByHousehold(ctx context.Context, householdID, userID string) ([]*Pocket, error) {
+	pockets, err := s.repo.ListActiveByHousehold(ctx, householdID)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make([]*Pocket, 0, len(pockets))
+	for _, p := range pockets {
+		if p.VisibleTo(userID) {
+			visible = append(visible, p)
+		}
+	}
+	return visible, nil
 }
 
-// GetSummary returns aggregated pocket data for a household
-func (s *Service) GetSummary(ctx context.Context, householdID string) (*PocketSummary, error) {
+// GetSummary returns aggregated pocket data for a household. Other members'
+// private pockets count towards the totals but are not listed.
+func (s *Service) GetSummary(ctx context.Context, householdID, userID string) (*PocketSummary, error) {
 	pockets, err := s.repo.ListActiveByHousehold(ctx, householdID)
 	if err != nil {
 		return nil, fmt.Errorf("listing pockets: %w", err)
@@ -137,7 +203,7 @@ func (s *Service) GetSummary(ctx context.Context, householdID string) (*PocketSu
 
 	summary := &PocketSummary{
 		PocketCount: len(pockets),
-		Pockets:     pockets,
+		Pockets:     make([]*Pocket, 0, len(pockets)),
 	}
 
 	var totalBalance float64
@@ -145,13 +211,22 @@ func (s *Service) GetSummary(ctx context.Context, householdID string) (*PocketSu
 	hasGoal := false
 
 	for _, p := range pockets {
+		var balance float64
 		if p.Balance != nil {
-			totalBalance += *p.Balance
+			balance = *p.Balance
 		}
+		totalBalance += balance
 		if p.GoalAmount != nil {
 			totalGoal += *p.GoalAmount
 			hasGoal = true
 		}
+
+		if p.VisibleTo(userID) {
+			summary.Pockets = append(summary.Pockets, p)
+			continue
+		}
+		summary.PrivateCount++
+		summary.PrivateBalance += balance
 	}
 
 	summary.TotalBalance = totalBalance
@@ -170,16 +245,11 @@ func (s *Service) Update(ctx context.Context, userID, householdID string, input
 	}
 
 	// Get existing pocket
-	pocket, err := s.repo.GetByID(ctx, input.ID)
+	pocket, err := s.getVisiblePocket(ctx, input.ID, householdID, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Verify household access
-	if pocket.HouseholdID != householdID {
-		return nil, ErrNotAuthorized
-	}
-
 	// Check name uniqueness if name is being changed
 	if input.Name != nil && *input.Name != pocket.Name {
 		existing, err := s.repo.FindByName(ctx, householdID, *input.Name)
@@ -211,6 +281,12 @@ func (s *Service) Update(ctx context.Context, userID, householdID string, input
 	if input.ClearNote {
 		pocket.Note = nil
 	}
+	if input.IsSharedWithHousehold != nil && *input.IsSharedWithHousehold != pocket.IsSharedWithHousehold {
+		if pocket.OwnerID != userID {
+			return nil, ErrNotAuthorized
+		}
+		pocket.IsSharedWithHousehold = *input.IsSharedWithHousehold
+	}
 
 	// Persist
 	pocket, err = s.repo.Update(ctx, pocket)
@@ -248,17 +324,11 @@ func (s *Service) Update(ctx context.Context, userID, householdID string, input
 
 // Deactivate deactivates a pocket (soft delete)
 func (s *Service) Deactivate(ctx context.Context, id, userID, householdID string, force bool) error {
-	// Get pocket
-	pocket, err := s.repo.GetByID(ctx, id)
-	if err != nil {
+	// Get pocket and verify access
+	if _, err := s.getVisiblePocket(ctx, id, householdID, userID); err != nil {
 		return err
 	}
 
-	// Verify household access
-	if pocket.HouseholdID != householdID {
-		return ErrNotAuthorized
-	}
-
 	// Check balance if not forcing
 	if !force {
 		balance, err := s.repo.GetBalance(ctx, id)
@@ -300,6 +370,9 @@ func (s *Service) Deposit(ctx context.Context, input *DepositInput) (*PocketTran
 	if err != nil {
 		return nil, err
 	}
+	if !pocket.VisibleTo(input.CreatedBy) {
+		return nil, ErrPocketNotFound
+	}
 	if !pocket.IsActive {
 		return nil, ErrPocketNotActive
 	}
@@ -309,7 +382,7 @@ func (s *Service) Deposit(ctx context.Context, input *DepositInput) (*PocketTran
 	if err != nil {
 		return nil, fmt.Errorf("getting source account: %w", err)
 	}
-	if account.HouseholdID != pocket.HouseholdID {
+	if account.HouseholdID != pocket.HouseholdID || !account.VisibleTo(input.CreatedBy) {
 		return nil, ErrNotAuthorized
 	}
 
@@ -343,6 +416,8 @@ func (s *Service) Deposit(ctx context.Context, input *DepositInput) (*PocketTran
 		MovementDate:   input.TransactionDate,
 		PayerUserID:    &input.CreatedBy,
 		SourcePocketID: &input.PocketID,
+		// Deposits into a private pocket stay out of the other members' movement lists
+		IsSharedWithHousehold: &pocket.IsSharedWithHousehold,
 		// PaymentMethodID intentionally nil — avoids double-counting in account balance
 	}
 
@@ -406,6 +481,16 @@ func (s *Service) Deposit(ctx context.Context, input *DepositInput) (*PocketTran
 		Success:      true,
 	})
 
+	// Deposits into a private pocket stay off the household's webhook endpoints
+	if s.webhooks != nil && movement.IsSharedWithHousehold {
+		s.webhooks.Dispatch(ctx, &webhooks.Event{
+			HouseholdID: pocket.HouseholdID,
+			Type:        webhooks.EventMovementCreated,
+			Data:        movement,
+		})
+	}
+	s.checkGoalReached(ctx, pocket, input.Amount)
+
 	result.CategoryCreated = categoryCreated
 	return result, nil
 }
@@ -451,6 +536,9 @@ func (s *Service) Withdraw(ctx context.Context, input *WithdrawInput) (*PocketTr
 	if err != nil {
 		return nil, err
 	}
+	if !pocket.VisibleTo(input.CreatedBy) {
+		return nil, ErrPocketNotFound
+	}
 	if !pocket.IsActive {
 		return nil, ErrPocketNotActive
 	}
@@ -460,7 +548,7 @@ func (s *Service) Withdraw(ctx context.Context, input *WithdrawInput) (*PocketTr
 	if err != nil {
 		return nil, fmt.Errorf("getting destination account: %w", err)
 	}
-	if account.HouseholdID != pocket.HouseholdID {
+	if account.HouseholdID != pocket.HouseholdID || !account.VisibleTo(input.CreatedBy) {
 		return nil, ErrNotAuthorized
 	}
 
@@ -539,13 +627,10 @@ func (s *Service) EditTransaction(ctx context.Context, userID, householdID strin
 	}
 
 	// Get pocket and verify access
-	pocket, err := s.repo.GetByID(ctx, existing.PocketID)
+	pocket, err := s.getVisiblePocket(ctx, existing.PocketID, householdID, userID)
 	if err != nil {
 		return nil, err
 	}
-	if pocket.HouseholdID != householdID {
-		return nil, ErrNotAuthorized
-	}
 
 	// Balance check: reject edits that would cause negative balance
 	if input.Amount != nil {
@@ -634,13 +719,10 @@ func (s *Service) DeleteTransaction(ctx context.Context, transactionID, userID,
 	}
 
 	// Get pocket and verify access
-	pocket, err := s.repo.GetByID(ctx, existing.PocketID)
+	pocket, err := s.getVisiblePocket(ctx, existing.PocketID, householdID, userID)
 	if err != nil {
 		return err
 	}
-	if pocket.HouseholdID != householdID {
-		return ErrNotAuthorized
-	}
 
 	// If deposit, check deleting won't cause overdraft
 	if existing.Type == TransactionTypeDeposit {
@@ -743,19 +825,17 @@ func (s *Service) DeleteTransactionByMovementID(ctx context.Context, movementID,
 		Success:      true,
 	})
 
+	// The movement deletion itself is published by the movements service
+
 	return nil
 }
 
 // ListTransactions lists all transactions for a pocket
-func (s *Service) ListTransactions(ctx context.Context, pocketID, householdID string) ([]*PocketTransaction, error) {
-	// Get pocket and verify household access
-	pocket, err := s.repo.GetByID(ctx, pocketID)
-	if err != nil {
+func (s *Service) ListTransactions(ctx context.Context, pocketID, householdID, userID string) ([]*PocketTransaction, error) {
+	// Get pocket and verify access
+	if _, err := s.getVisiblePocket(ctx, pocketID, householdID, userID); err != nil {
 		return nil, err
 	}
-	if pocket.HouseholdID != householdID {
-		return nil, ErrNotAuthorized
-	}
 
 	return s.repo.ListTransactions(ctx, pocketID)
 }
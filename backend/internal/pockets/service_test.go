@@ -197,6 +197,9 @@ func (m *mockMovementsRepo) ListByHousehold(ctx context.Context, hid string, f *
 func (m *mockMovementsRepo) ListMovementsByContactIDs(ctx context.Context, ids []string, month *string) ([]*movements.Movement, error) {
 	return nil, nil
 }
+func (m *mockMovementsRepo) ListMovementsByContactIDsPage(ctx context.Context, ids []string, f *movements.SharedMovementsFilters) ([]*movements.Movement, int, error) {
+	return nil, 0, nil
+}
 func (m *mockMovementsRepo) GetTotals(ctx context.Context, hid string, f *movements.ListMovementsFilters) (*movements.MovementTotals, error) {
 	return nil, nil
 }
@@ -222,7 +225,7 @@ func (m *mockAccountsRepo) GetByID(ctx context.Context, id string) (*accounts.Ac
 	if m.getByIDFn != nil {
 		return m.getByIDFn(ctx, id)
 	}
-	return &accounts.Account{ID: id, HouseholdID: "household-1"}, nil
+	return &accounts.Account{ID: id, HouseholdID: "household-1", IsSharedWithHousehold: true}, nil
 }
 func (m *mockAccountsRepo) Create(ctx context.Context, a *accounts.Account) (*accounts.Account, error) {
 	return a, nil
@@ -279,6 +282,12 @@ func (m *mockHouseholdRepo) GetMemberByUserID(ctx context.Context, hid, uid stri
 	return nil, nil
 }
 func (m *mockHouseholdRepo) CountOwners(ctx context.Context, hid string) (int, error) { return 1, nil }
+func (m *mockHouseholdRepo) TransferOwnership(ctx context.Context, hid, fromUserID, toUserID string) error {
+	return nil
+}
+func (m *mockHouseholdRepo) MergeHouseholds(ctx context.Context, sourceID, targetID string, apply bool) (*households.MergeReport, error) {
+	return nil, nil
+}
 func (m *mockHouseholdRepo) CreateContact(ctx context.Context, c *households.Contact) (*households.Contact, error) {
 	return nil, nil
 }
@@ -298,19 +307,38 @@ func (m *mockHouseholdRepo) FindContactByEmail(ctx context.Context, hid, email s
 func (m *mockHouseholdRepo) FindLinkedContactsByHousehold(ctx context.Context, hid string) ([]households.LinkedContact, error) {
 	return nil, nil
 }
-func (m *mockHouseholdRepo) CreateInvitation(ctx context.Context, hid, email, token, invitedBy string) (*households.HouseholdInvitation, error) {
+func (m *mockHouseholdRepo) MergeContacts(ctx context.Context, sourceID, targetID string, carryLink bool) error {
+	return nil
+}
+func (m *mockHouseholdRepo) CreateInvitation(ctx context.Context, hid, email, token, invitedBy string, expiresAt time.Time) (*households.HouseholdInvitation, error) {
+	return nil, nil
+}
+func (m *mockHouseholdRepo) GetInvitationByID(ctx context.Context, id string) (*households.HouseholdInvitation, error) {
 	return nil, nil
 }
 func (m *mockHouseholdRepo) GetInvitationByToken(ctx context.Context, token string) (*households.HouseholdInvitation, error) {
 	return nil, nil
 }
+func (m *mockHouseholdRepo) FindOpenInvitationByEmail(ctx context.Context, hid, email string) (*households.HouseholdInvitation, error) {
+	return nil, nil
+}
 func (m *mockHouseholdRepo) AcceptInvitation(ctx context.Context, id string) error { return nil }
+func (m *mockHouseholdRepo) RotateInvitationToken(ctx context.Context, id, token string, expiresAt time.Time) (*households.HouseholdInvitation, error) {
+	return nil, nil
+}
+func (m *mockHouseholdRepo) RevokeInvitation(ctx context.Context, id string) error { return nil }
 func (m *mockHouseholdRepo) ListPendingInvitations(ctx context.Context, hid string) ([]*households.HouseholdInvitation, error) {
 	return nil, nil
 }
 func (m *mockHouseholdRepo) GetUserHouseholdID(ctx context.Context, uid string) (string, error) {
 	return "household-1", nil
 }
+func (m *mockHouseholdRepo) ResolveHouseholdID(ctx context.Context, uid string) (string, error) {
+	return "household-1", nil
+}
+func (m *mockHouseholdRepo) ListMemberships(ctx context.Context, uid string) ([]*households.Membership, error) {
+	return nil, nil
+}
 func (m *mockHouseholdRepo) ListPendingLinkRequests(ctx context.Context, uid string) ([]households.LinkRequest, error) {
 	return nil, nil
 }
@@ -329,6 +357,21 @@ func (m *mockHouseholdRepo) DismissUnlinkBanner(ctx context.Context, cid string)
 func (m *mockHouseholdRepo) FindContactByLinkedUserID(ctx context.Context, hid, uid string) (*households.Contact, error) {
 	return nil, nil
 }
+func (m *mockHouseholdRepo) SuggestLinksForUser(ctx context.Context, userID, email string) ([]*households.Contact, error) {
+	return nil, nil
+}
+
+func (m *mockHouseholdRepo) HasPendingLinkSuggestion(ctx context.Context, contactID, userID string) (bool, error) {
+	return false, nil
+}
+
+func (m *mockHouseholdRepo) AcceptLinkSuggestion(ctx context.Context, contactID, userID string) error {
+	return nil
+}
+
+func (m *mockHouseholdRepo) RejectLinkSuggestion(ctx context.Context, contactID, userID string) error {
+	return nil
+}
 
 // mockAuditService
 type mockAuditService struct{}
@@ -396,6 +439,8 @@ func defaultPocket() *Pocket {
 		GoalAmount:  f64(500000),
 		IsActive:    true,
 		Balance:     &bal,
+
+		IsSharedWithHousehold: true,
 	}
 }
 
@@ -577,7 +622,7 @@ func TestGetByID(t *testing.T) {
 		}
 		svc := defaultService(repo, &mockMovementsRepo{}, &mockAccountsRepo{}, &mockHouseholdRepo{})
 
-		pocket, err := svc.GetByID(context.Background(), "pocket-1", "household-1")
+		pocket, err := svc.GetByID(context.Background(), "pocket-1", "household-1", "user-1")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -594,11 +639,28 @@ func TestGetByID(t *testing.T) {
 		}
 		svc := defaultService(repo, &mockMovementsRepo{}, &mockAccountsRepo{}, &mockHouseholdRepo{})
 
-		_, err := svc.GetByID(context.Background(), "pocket-1", "other-household")
+		_, err := svc.GetByID(context.Background(), "pocket-1", "other-household", "user-1")
 		if !errors.Is(err, ErrNotAuthorized) {
 			t.Errorf("expected ErrNotAuthorized, got %v", err)
 		}
 	})
+
+	t.Run("another member's private pocket", func(t *testing.T) {
+		p := defaultPocket()
+		p.IsSharedWithHousehold = false
+		repo := &mockRepository{
+			getByIDFn: func(_ context.Context, _ string) (*Pocket, error) { return p, nil },
+		}
+		svc := defaultService(repo, &mockMovementsRepo{}, &mockAccountsRepo{}, &mockHouseholdRepo{})
+
+		if _, err := svc.GetByID(context.Background(), "pocket-1", "household-1", "user-1"); err != nil {
+			t.Fatalf("owner should see their private pocket: %v", err)
+		}
+		_, err := svc.GetByID(context.Background(), "pocket-1", "household-1", "user-2")
+		if !errors.Is(err, ErrPocketNotFound) {
+			t.Errorf("expected ErrPocketNotFound, got %v", err)
+		}
+	})
 }
 
 // ============================================================
@@ -1169,7 +1231,7 @@ func TestGetSummary(t *testing.T) {
 		}
 		svc := defaultService(repo, &mockMovementsRepo{}, &mockAccountsRepo{}, &mockHouseholdRepo{})
 
-		summary, err := svc.GetSummary(context.Background(), "household-1")
+		summary, err := svc.GetSummary(context.Background(), "household-1", "user-1")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -1191,14 +1253,14 @@ func TestGetSummary(t *testing.T) {
 		repo := &mockRepository{
 			listActiveByHouseholdFn: func(_ context.Context, _ string) ([]*Pocket, error) {
 				return []*Pocket{
-					{ID: "p1", Balance: &bal1, GoalAmount: &goal1},
-					{ID: "p2", Balance: &bal2, GoalAmount: nil},
+					{ID: "p1", Balance: &bal1, GoalAmount: &goal1, IsSharedWithHousehold: true},
+					{ID: "p2", Balance: &bal2, GoalAmount: nil, IsSharedWithHousehold: true},
 				}, nil
 			},
 		}
 		svc := defaultService(repo, &mockMovementsRepo{}, &mockAccountsRepo{}, &mockHouseholdRepo{})
 
-		summary, err := svc.GetSummary(context.Background(), "household-1")
+		summary, err := svc.GetSummary(context.Background(), "household-1", "user-1")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -1212,6 +1274,34 @@ func TestGetSummary(t *testing.T) {
 			t.Errorf("expected total_goal 100000, got %v", summary.TotalGoal)
 		}
 	})
+
+	t.Run("other members' private pockets only count in totals", func(t *testing.T) {
+		bal1 := 50000.0
+		bal2 := 30000.0
+		repo := &mockRepository{
+			listActiveByHouseholdFn: func(_ context.Context, _ string) ([]*Pocket, error) {
+				return []*Pocket{
+					{ID: "p1", OwnerID: "user-1", Balance: &bal1, IsSharedWithHousehold: true},
+					{ID: "p2", OwnerID: "user-2", Balance: &bal2},
+				}, nil
+			},
+		}
+		svc := defaultService(repo, &mockMovementsRepo{}, &mockAccountsRepo{}, &mockHouseholdRepo{})
+
+		summary, err := svc.GetSummary(context.Background(), "household-1", "user-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if summary.TotalBalance != 80000 || summary.PocketCount != 2 {
+			t.Errorf("expected totals over 2 pockets (80000), got %d pockets (%f)", summary.PocketCount, summary.TotalBalance)
+		}
+		if len(summary.Pockets) != 1 || summary.Pockets[0].ID != "p1" {
+			t.Errorf("expected only p1 listed, got %v", summary.Pockets)
+		}
+		if summary.PrivateCount != 1 || summary.PrivateBalance != 30000 {
+			t.Errorf("expected 1 private pocket (30000), got %d (%f)", summary.PrivateCount, summary.PrivateBalance)
+		}
+	})
 }
 
 // ============================================================
@@ -1229,7 +1319,7 @@ func TestListTransactions(t *testing.T) {
 		}
 		svc := defaultService(repo, &mockMovementsRepo{}, &mockAccountsRepo{}, &mockHouseholdRepo{})
 
-		txs, err := svc.ListTransactions(context.Background(), "pocket-1", "household-1")
+		txs, err := svc.ListTransactions(context.Background(), "pocket-1", "household-1", "user-1")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -1245,7 +1335,7 @@ func TestListTransactions(t *testing.T) {
 		}
 		svc := defaultService(repo, &mockMovementsRepo{}, &mockAccountsRepo{}, &mockHouseholdRepo{})
 
-		_, err := svc.ListTransactions(context.Background(), "pocket-1", "other-household")
+		_, err := svc.ListTransactions(context.Background(), "pocket-1", "other-household", "user-1")
 		if !errors.Is(err, ErrNotAuthorized) {
 			t.Errorf("expected ErrNotAuthorized, got %v", err)
 		}
@@ -43,10 +43,18 @@ type Pocket struct {
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 
+	// Private pockets are only listed to their owner
+	IsSharedWithHousehold bool `json:"is_shared_with_household"`
+
 	// Calculated fields
 	Balance *float64 `json:"balance,omitempty"`
 }
 
+// VisibleTo reports whether userID may see the pocket and its transactions
+func (p *Pocket) VisibleTo(userID string) bool {
+	return p.IsSharedWithHousehold || p.OwnerID == userID
+}
+
 // PocketTransaction represents a deposit or withdrawal
 type PocketTransaction struct {
 	ID                   string                `json:"id"`
@@ -70,11 +78,15 @@ type PocketTransaction struct {
 }
 
 // PocketSummary represents aggregated pocket data for the summary endpoint
+// TotalBalance, TotalGoal and PocketCount include other members' private
+// pockets, which are left out of Pockets and reported under the Private fields.
 type PocketSummary struct {
-	TotalBalance float64   `json:"total_balance"`
-	TotalGoal    *float64  `json:"total_goal,omitempty"`
-	PocketCount  int       `json:"pocket_count"`
-	Pockets      []*Pocket `json:"pockets"`
+	TotalBalance   float64   `json:"total_balance"`
+	TotalGoal      *float64  `json:"total_goal,omitempty"`
+	PocketCount    int       `json:"pocket_count"`
+	PrivateCount   int       `json:"private_count"`
+	PrivateBalance float64   `json:"private_balance"`
+	Pockets        []*Pocket `json:"pockets"`
 }
 
 // CreatePocketInput contains data for creating a pocket
@@ -85,6 +97,8 @@ type CreatePocketInput struct {
 	Icon        string
 	GoalAmount  *float64
 	Note        *string
+
+	IsSharedWithHousehold *bool // Optional, defaults to true
 }
 
 func (i *CreatePocketInput) Validate() error {
@@ -119,6 +133,8 @@ type UpdatePocketInput struct {
 	ClearGoal  bool // Set to true to remove goal_amount
 	Note       *string
 	ClearNote  bool // Set to true to remove note
+
+	IsSharedWithHousehold *bool // Only the owner may change it
 }
 
 func (i *UpdatePocketInput) Validate() error {
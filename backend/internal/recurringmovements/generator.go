@@ -17,6 +17,7 @@ type Generator struct {
 	logger               *slog.Logger
 	getHouseholdMemberFn func(ctx context.Context, householdID string) (string, error) // returns any user_id from the household
 	getBudgetItemFn      func(ctx context.Context, templateID string, month string) (*BudgetItemOverride, error) // returns per-month override from budget items
+	onGeneratedFn        func(ctx context.Context, template *RecurringMovementTemplate, movement *movements.Movement) // called after a movement is generated
 }
 
 // NewGenerator creates a new movement generator
@@ -45,6 +46,12 @@ func (g *Generator) SetGetBudgetItemFn(fn func(ctx context.Context, templateID s
 	g.getBudgetItemFn = fn
 }
 
+// SetOnGeneratedFn sets the hook called after a movement is generated from a template
+// (used to notify household members)
+func (g *Generator) SetOnGeneratedFn(fn func(ctx context.Context, template *RecurringMovementTemplate, movement *movements.Movement)) {
+	g.onGeneratedFn = fn
+}
+
 // ProcessPendingTemplates generates movements for all pending templates
 // This is called by the scheduler
 func (g *Generator) ProcessPendingTemplates(ctx context.Context) error {
@@ -264,6 +271,10 @@ func (g *Generator) GenerateMovement(ctx context.Context, template *RecurringMov
 		"movement_date", input.MovementDate.Format("2006-01-02"),
 	)
 
+	if g.onGeneratedFn != nil {
+		g.onGeneratedFn(ctx, template, movement)
+	}
+
 	// Calculate next scheduled date (always from template's recurrence, not budget item)
 	var nextScheduled time.Time
 
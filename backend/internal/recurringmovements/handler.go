@@ -55,14 +55,7 @@ func parseScope(r *http.Request) (string, error) {
 // POST /api/recurring-movements
 func (h *Handler) HandleCreate(w http.ResponseWriter, r *http.Request) {
 	// Get user from session
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		h.logger.Error("no session cookie", "error", err)
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
-		return
-	}
-
-	user, err := h.authSvc.GetUserBySession(r.Context(), cookie.Value)
+	user, err := h.authSvc.UserFromRequest(r, h.cookieName)
 	if err != nil {
 		h.logger.Error("failed to get user by session", "error", err)
 		http.Error(w, "unauthorized", http.StatusUnauthorized)
@@ -108,13 +101,7 @@ func (h *Handler) HandleCreate(w http.ResponseWriter, r *http.Request) {
 // GET /api/recurring-movements/{id}
 func (h *Handler) HandleGet(w http.ResponseWriter, r *http.Request) {
 	// Get user from session
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
-		return
-	}
-
-	user, err := h.authSvc.GetUserBySession(r.Context(), cookie.Value)
+	user, err := h.authSvc.UserFromRequest(r, h.cookieName)
 	if err != nil {
 		http.Error(w, "unauthorized", http.StatusUnauthorized)
 		return
@@ -151,13 +138,7 @@ func (h *Handler) HandleGet(w http.ResponseWriter, r *http.Request) {
 // GET /api/recurring-movements
 func (h *Handler) HandleList(w http.ResponseWriter, r *http.Request) {
 	// Get user from session
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
-		return
-	}
-
-	user, err := h.authSvc.GetUserBySession(r.Context(), cookie.Value)
+	user, err := h.authSvc.UserFromRequest(r, h.cookieName)
 	if err != nil {
 		http.Error(w, "unauthorized", http.StatusUnauthorized)
 		return
@@ -209,13 +190,7 @@ func (h *Handler) HandleList(w http.ResponseWriter, r *http.Request) {
 // GET /api/recurring-movements/by-category/{category_id}
 func (h *Handler) HandleGetByCategory(w http.ResponseWriter, r *http.Request) {
 	// Get user from session
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
-		return
-	}
-
-	user, err := h.authSvc.GetUserBySession(r.Context(), cookie.Value)
+	user, err := h.authSvc.UserFromRequest(r, h.cookieName)
 	if err != nil {
 		http.Error(w, "unauthorized", http.StatusUnauthorized)
 		return
@@ -245,13 +220,7 @@ func (h *Handler) HandleGetByCategory(w http.ResponseWriter, r *http.Request) {
 // GET /api/recurring-movements/{id}/prefill?invert_roles=false
 func (h *Handler) HandleGetPreFillData(w http.ResponseWriter, r *http.Request) {
 	// Get user from session
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
-		return
-	}
-
-	user, err := h.authSvc.GetUserBySession(r.Context(), cookie.Value)
+	user, err := h.authSvc.UserFromRequest(r, h.cookieName)
 	if err != nil {
 		http.Error(w, "unauthorized", http.StatusUnauthorized)
 		return
@@ -296,13 +265,7 @@ func (h *Handler) HandleGetPreFillData(w http.ResponseWriter, r *http.Request) {
 // PUT /api/recurring-movements/{id}?scope=THIS|ALL
 func (h *Handler) HandleUpdate(w http.ResponseWriter, r *http.Request) {
 	// Get user from session
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
-		return
-	}
-
-	user, err := h.authSvc.GetUserBySession(r.Context(), cookie.Value)
+	user, err := h.authSvc.UserFromRequest(r, h.cookieName)
 	if err != nil {
 		http.Error(w, "unauthorized", http.StatusUnauthorized)
 		return
@@ -364,13 +327,7 @@ func (h *Handler) HandleUpdate(w http.ResponseWriter, r *http.Request) {
 // DELETE /api/recurring-movements/{id}?scope=THIS|ALL
 func (h *Handler) HandleDelete(w http.ResponseWriter, r *http.Request) {
 	// Get user from session
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
-		return
-	}
-
-	user, err := h.authSvc.GetUserBySession(r.Context(), cookie.Value)
+	user, err := h.authSvc.UserFromRequest(r, h.cookieName)
 	if err != nil {
 		http.Error(w, "unauthorized", http.StatusUnauthorized)
 		return
@@ -422,14 +379,7 @@ func (h *Handler) HandleDelete(w http.ResponseWriter, r *http.Request) {
 // POST /api/recurring-movements/generate
 func (h *Handler) HandleGeneratePending(w http.ResponseWriter, r *http.Request) {
 	// Get user from session (authentication required)
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		h.logger.Error("no session cookie", "error", err)
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
-		return
-	}
-
-	user, err := h.authSvc.GetUserBySession(r.Context(), cookie.Value)
+	user, err := h.authSvc.UserFromRequest(r, h.cookieName)
 	if err != nil {
 		h.logger.Error("failed to get user by session", "error", err)
 		http.Error(w, "unauthorized", http.StatusUnauthorized)
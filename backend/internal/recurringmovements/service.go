@@ -41,9 +41,12 @@ func (s *service) Create(ctx context.Context, userID string, input *CreateTempla
 		return nil, err
 	}
 
-	// Get user's household
-	householdID, err := s.householdsRepo.GetUserHouseholdID(ctx, userID)
+	// Get user's household and check they may edit templates
+	householdID, err := households.AuthorizeSelected(ctx, s.householdsRepo, userID, households.PermEditMovements)
 	if err != nil {
+		if errors.Is(err, households.ErrNotAuthorized) {
+			return nil, ErrNotAuthorized
+		}
 		return nil, err
 	}
 
@@ -119,19 +122,22 @@ func (s *service) Create(ctx context.Context, userID string, input *CreateTempla
 
 // GetByID retrieves a template by ID
 func (s *service) GetByID(ctx context.Context, userID, id string) (*RecurringMovementTemplate, error) {
+	return s.getAuthorized(ctx, userID, id, households.PermView)
+}
+
+// getAuthorized loads a template and checks the user's role in its household grants perm
+func (s *service) getAuthorized(ctx context.Context, userID, id string, perm households.Permission) (*RecurringMovementTemplate, error) {
 	template, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	// Verify user has access to this template's household
-	isMember, err := s.householdsRepo.IsUserMember(ctx, template.HouseholdID, userID)
-	if err != nil {
+	if _, err := households.Authorize(ctx, s.householdsRepo, template.HouseholdID, userID, perm); err != nil {
+		if errors.Is(err, households.ErrNotAuthorized) {
+			return nil, ErrNotAuthorized
+		}
 		return nil, err
 	}
-	if !isMember {
-		return nil, ErrNotAuthorized
-	}
 
 	return template, nil
 }
@@ -139,7 +145,7 @@ func (s *service) GetByID(ctx context.Context, userID, id string) (*RecurringMov
 // ListByHousehold lists all templates for user's household
 func (s *service) ListByHousehold(ctx context.Context, userID string, filters *ListTemplatesFilters) ([]*RecurringMovementTemplate, error) {
 	// Get user's household
-	householdID, err := s.householdsRepo.GetUserHouseholdID(ctx, userID)
+	householdID, err := s.householdsRepo.ResolveHouseholdID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -168,7 +174,7 @@ func (s *service) ListByHousehold(ctx context.Context, userID string, filters *L
 // ListByCategory lists all active templates for a category
 func (s *service) ListByCategory(ctx context.Context, userID, categoryID string) ([]*RecurringMovementTemplate, error) {
 	// Get user's household
-	householdID, err := s.householdsRepo.GetUserHouseholdID(ctx, userID)
+	householdID, err := s.householdsRepo.ResolveHouseholdID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -194,7 +200,7 @@ func (s *service) ListByCategory(ctx context.Context, userID, categoryID string)
 // ListByCategoryMap returns all templates grouped by category_id
 func (s *service) ListByCategoryMap(ctx context.Context, userID string) (map[string][]*RecurringMovementTemplate, error) {
 	// Get user's household
-	householdID, err := s.householdsRepo.GetUserHouseholdID(ctx, userID)
+	householdID, err := s.householdsRepo.ResolveHouseholdID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -307,8 +313,8 @@ func (s *service) Update(ctx context.Context, userID, id string, input *UpdateTe
 		return nil, err
 	}
 
-	// Verify user has access
-	template, err := s.GetByID(ctx, userID, id)
+	// Verify user may edit templates in its household
+	template, err := s.getAuthorized(ctx, userID, id, households.PermEditMovements)
 	if err != nil {
 		return nil, err
 	}
@@ -396,8 +402,8 @@ func (s *service) Update(ctx context.Context, userID, id string, input *UpdateTe
 
 // Delete deletes a template
 func (s *service) Delete(ctx context.Context, userID, id string) error {
-	// Verify user has access and get template info before deleting
-	template, err := s.GetByID(ctx, userID, id)
+	// Verify user may edit templates and get template info before deleting
+	template, err := s.getAuthorized(ctx, userID, id, households.PermEditMovements)
 	if err != nil {
 		return err
 	}
@@ -430,14 +436,10 @@ func (s *service) Delete(ctx context.Context, userID, id string) error {
 // This is used by the budgets service to validate manual budgets
 func (s *service) CalculateTemplatesSum(ctx context.Context, userID, categoryID string) (float64, error) {
 	// Get household for authorization
-	households, err := s.householdsRepo.ListByUser(ctx, userID)
+	householdID, err := s.householdsRepo.ResolveHouseholdID(ctx, userID)
 	if err != nil {
 		return 0, err
 	}
-	if len(households) == 0 {
-		return 0, errors.New("user does not belong to any household")
-	}
-	householdID := households[0].ID
 	
 	// Get all active templates for this category
 	filters := &ListTemplatesFilters{
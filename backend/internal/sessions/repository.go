@@ -20,17 +20,17 @@ func NewRepository(pool *pgxpool.Pool) *Repository {
 	return &Repository{pool: pool}
 }
 
-// Create creates a new session.
-func (r *Repository) Create(ctx context.Context, userID string, expiresAt time.Time) (*auth.Session, error) {
+const sessionColumns = `id, user_id, user_agent, ip_address, expires_at, last_seen_at, created_at`
+
+func scanSession(row pgx.Row) (*auth.Session, error) {
 	var session auth.Session
-	err := r.pool.QueryRow(ctx, `
-		INSERT INTO sessions (user_id, expires_at)
-		VALUES ($1, $2)
-		RETURNING id, user_id, expires_at, created_at
-	`, userID, expiresAt).Scan(
+	err := row.Scan(
 		&session.ID,
 		&session.UserID,
+		&session.UserAgent,
+		&session.IPAddress,
 		&session.ExpiresAt,
+		&session.LastSeenAt,
 		&session.CreatedAt,
 	)
 	if err != nil {
@@ -39,26 +39,64 @@ func (r *Repository) Create(ctx context.Context, userID string, expiresAt time.T
 	return &session, nil
 }
 
+// Create creates a new session.
+func (r *Repository) Create(ctx context.Context, userID string, expiresAt time.Time, meta auth.SessionMetadata) (*auth.Session, error) {
+	return scanSession(r.pool.QueryRow(ctx, `
+		INSERT INTO sessions (user_id, expires_at, user_agent, ip_address)
+		VALUES ($1, $2, $3, $4)
+		RETURNING `+sessionColumns,
+		userID, expiresAt, meta.UserAgent, meta.IPAddress,
+	))
+}
+
 // Get retrieves a session by ID, returning nil if expired.
 func (r *Repository) Get(ctx context.Context, id string) (*auth.Session, error) {
-	var session auth.Session
-	err := r.pool.QueryRow(ctx, `
-		SELECT id, user_id, expires_at, created_at
+	session, err := scanSession(r.pool.QueryRow(ctx, `
+		SELECT `+sessionColumns+`
 		FROM sessions
 		WHERE id = $1 AND expires_at > NOW()
-	`, id).Scan(
-		&session.ID,
-		&session.UserID,
-		&session.ExpiresAt,
-		&session.CreatedAt,
-	)
+	`, id))
 	if err == pgx.ErrNoRows {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, err
 	}
-	return &session, nil
+	return session, nil
+}
+
+// ListByUserID returns the user's unexpired sessions, most recently seen first.
+func (r *Repository) ListByUserID(ctx context.Context, userID string) ([]*auth.Session, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+sessionColumns+`
+		FROM sessions
+		WHERE user_id = $1 AND expires_at > NOW()
+		ORDER BY last_seen_at DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []*auth.Session{}
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, rows.Err()
+}
+
+// Touch updates last_seen_at. Writes are throttled to one per minute per session.
+func (r *Repository) Touch(ctx context.Context, id string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE sessions
+		SET last_seen_at = NOW()
+		WHERE id = $1 AND last_seen_at < NOW() - INTERVAL '1 minute'
+	`, id)
+	return err
 }
 
 // Delete deletes a session by ID.
@@ -67,6 +105,27 @@ func (r *Repository) Delete(ctx context.Context, id string) error {
 	return err
 }
 
+// DeleteForUser deletes one of the user's sessions.
+func (r *Repository) DeleteForUser(ctx context.Context, id, userID string) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM sessions WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return auth.ErrSessionNotFound
+	}
+	return nil
+}
+
+// DeleteOthers deletes every session of the user except keepID.
+func (r *Repository) DeleteOthers(ctx context.Context, userID, keepID string) (int64, error) {
+	result, err := r.pool.Exec(ctx, `DELETE FROM sessions WHERE user_id = $1 AND id <> $2`, userID, keepID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 // DeleteByUserID deletes all sessions for a user.
 func (r *Repository) DeleteByUserID(ctx context.Context, userID string) error {
 	_, err := r.pool.Exec(ctx, `DELETE FROM sessions WHERE user_id = $1`, userID)
@@ -78,3 +137,20 @@ func (r *Repository) DeleteExpired(ctx context.Context) error {
 	_, err := r.pool.Exec(ctx, `DELETE FROM sessions WHERE expires_at < NOW()`)
 	return err
 }
+
+// RecordDevice remembers the device a session was started from and reports
+// whether it is the first time the user is seen on it.
+func (r *Repository) RecordDevice(ctx context.Context, userID, fingerprint string, meta auth.SessionMetadata) (bool, error) {
+	var inserted bool
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO user_devices (user_id, fingerprint, user_agent, last_ip_address)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, fingerprint) DO UPDATE
+		SET last_seen_at = NOW(), last_ip_address = EXCLUDED.last_ip_address
+		RETURNING (xmax = 0)
+	`, userID, fingerprint, meta.UserAgent, meta.IPAddress).Scan(&inserted)
+	if err != nil {
+		return false, err
+	}
+	return inserted, nil
+}
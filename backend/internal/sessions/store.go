@@ -27,9 +27,9 @@ func NewStore(repo auth.SessionRepository, cookieName string, duration time.Dura
 }
 
 // Create creates a new session and returns it.
-func (s *Store) Create(ctx context.Context, userID string) (*auth.Session, error) {
+func (s *Store) Create(ctx context.Context, userID string, meta auth.SessionMetadata) (*auth.Session, error) {
 	expiresAt := time.Now().Add(s.duration)
-	return s.repo.Create(ctx, userID, expiresAt)
+	return s.repo.Create(ctx, userID, expiresAt, meta)
 }
 
 // Get retrieves a session by ID.
@@ -37,6 +37,11 @@ func (s *Store) Get(ctx context.Context, sessionID string) (*auth.Session, error
 	return s.repo.Get(ctx, sessionID)
 }
 
+// Touch records that a session was just used.
+func (s *Store) Touch(ctx context.Context, sessionID string) error {
+	return s.repo.Touch(ctx, sessionID)
+}
+
 // Delete deletes a session.
 func (s *Store) Delete(ctx context.Context, sessionID string) error {
 	return s.repo.Delete(ctx, sessionID)
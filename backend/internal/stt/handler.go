@@ -83,15 +83,8 @@ func (h *Handler) HandleSTT(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Auth via session cookie
-	cookie, err := r.Cookie(h.cookieName)
-	if err != nil {
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode(map[string]string{"error": "unauthorized"})
-		return
-	}
-
-	user, err := h.authService.GetUserBySession(r.Context(), cookie.Value)
+	// Auth via session cookie or access token
+	user, err := h.authService.UserFromRequest(r, h.cookieName)
 	if err != nil {
 		w.WriteHeader(http.StatusUnauthorized)
 		json.NewEncoder(w).Encode(map[string]string{"error": "unauthorized"})
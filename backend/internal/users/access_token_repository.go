@@ -0,0 +1,122 @@
+package users
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/blanquicet/conti/backend/internal/auth"
+)
+
+// AccessTokenRepository implements auth.AccessTokenRepository using PostgreSQL.
+type AccessTokenRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewAccessTokenRepository creates a new personal access token repository.
+func NewAccessTokenRepository(pool *pgxpool.Pool) *AccessTokenRepository {
+	return &AccessTokenRepository{pool: pool}
+}
+
+const accessTokenColumns = `id, user_id, name, token_hash, token_prefix, scope, resource_types,
+		expires_at, last_used_at, revoked_at, created_at`
+
+func scanAccessToken(row pgx.Row) (*auth.AccessToken, error) {
+	var token auth.AccessToken
+	err := row.Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Name,
+		&token.TokenHash,
+		&token.TokenPrefix,
+		&token.Scope,
+		&token.ResourceTypes,
+		&token.ExpiresAt,
+		&token.LastUsedAt,
+		&token.RevokedAt,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Create stores a new access token.
+func (r *AccessTokenRepository) Create(ctx context.Context, token *auth.AccessToken) (*auth.AccessToken, error) {
+	resourceTypes := token.ResourceTypes
+	if resourceTypes == nil {
+		resourceTypes = []string{}
+	}
+	return scanAccessToken(r.pool.QueryRow(ctx, `
+		INSERT INTO personal_access_tokens (user_id, name, token_hash, token_prefix, scope, resource_types, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+accessTokenColumns,
+		token.UserID, token.Name, token.TokenHash, token.TokenPrefix, token.Scope, resourceTypes, token.ExpiresAt,
+	))
+}
+
+// GetByTokenHash retrieves an access token by token hash, returning nil if none matches.
+func (r *AccessTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*auth.AccessToken, error) {
+	token, err := scanAccessToken(r.pool.QueryRow(ctx, `
+		SELECT `+accessTokenColumns+`
+		FROM personal_access_tokens
+		WHERE token_hash = $1
+	`, tokenHash))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return token, err
+}
+
+// ListByUserID returns a user's access tokens, newest first.
+func (r *AccessTokenRepository) ListByUserID(ctx context.Context, userID string) ([]*auth.AccessToken, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+accessTokenColumns+`
+		FROM personal_access_tokens
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := []*auth.AccessToken{}
+	for rows.Next() {
+		token, err := scanAccessToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, rows.Err()
+}
+
+// Revoke revokes one of the user's access tokens.
+func (r *AccessTokenRepository) Revoke(ctx context.Context, id, userID string) error {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE personal_access_tokens
+		SET revoked_at = COALESCE(revoked_at, NOW())
+		WHERE id = $1 AND user_id = $2
+	`, id, userID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return auth.ErrTokenNotFound
+	}
+	return nil
+}
+
+// TouchLastUsed records that a token was used. Writes are throttled to one per
+// minute so busy scripts do not update the row on every request.
+func (r *AccessTokenRepository) TouchLastUsed(ctx context.Context, id string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE personal_access_tokens
+		SET last_used_at = NOW()
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')
+	`, id)
+	return err
+}
@@ -0,0 +1,88 @@
+package users
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/blanquicet/conti/backend/internal/auth"
+)
+
+// EmailTokenRepository implements auth.EmailTokenRepository using PostgreSQL.
+type EmailTokenRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewEmailTokenRepository creates a new email token repository.
+func NewEmailTokenRepository(pool *pgxpool.Pool) *EmailTokenRepository {
+	return &EmailTokenRepository{pool: pool}
+}
+
+// Create creates a new email token.
+func (r *EmailTokenRepository) Create(ctx context.Context, userID string, purpose auth.EmailTokenPurpose, tokenHash string, expiresAt time.Time) (*auth.EmailToken, error) {
+	var token auth.EmailToken
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO email_tokens (user_id, purpose, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, user_id, purpose, token_hash, expires_at, used_at, created_at
+	`, userID, purpose, tokenHash, expiresAt).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Purpose,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.UsedAt,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// GetByTokenHash retrieves an email token of the given purpose by its hash.
+func (r *EmailTokenRepository) GetByTokenHash(ctx context.Context, purpose auth.EmailTokenPurpose, tokenHash string) (*auth.EmailToken, error) {
+	var token auth.EmailToken
+	err := r.pool.QueryRow(ctx, `
+		SELECT id, user_id, purpose, token_hash, expires_at, used_at, created_at
+		FROM email_tokens
+		WHERE token_hash = $1 AND purpose = $2
+	`, tokenHash, purpose).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Purpose,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.UsedAt,
+		&token.CreatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkUsed consumes a token. The conditional update makes concurrent uses of
+// the same link race-safe: only one caller gets true.
+func (r *EmailTokenRepository) MarkUsed(ctx context.Context, id string) (bool, error) {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE email_tokens
+		SET used_at = NOW()
+		WHERE id = $1 AND used_at IS NULL
+	`, id)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// DeleteExpired deletes all expired email tokens.
+func (r *EmailTokenRepository) DeleteExpired(ctx context.Context) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM email_tokens WHERE expires_at < NOW()`)
+	return err
+}
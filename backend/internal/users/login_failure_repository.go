@@ -0,0 +1,87 @@
+package users
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/blanquicet/conti/backend/internal/auth"
+)
+
+// LoginFailureRepository implements auth.LoginFailureRepository using PostgreSQL.
+type LoginFailureRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewLoginFailureRepository creates a new login failure repository.
+func NewLoginFailureRepository(pool *pgxpool.Pool) *LoginFailureRepository {
+	return &LoginFailureRepository{pool: pool}
+}
+
+func scanLoginFailures(row pgx.Row) (*auth.LoginFailures, error) {
+	var f auth.LoginFailures
+	err := row.Scan(
+		&f.UserID,
+		&f.FailedCount,
+		&f.FirstFailedAt,
+		&f.LastFailedAt,
+		&f.LockedUntil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// Get retrieves the failure record for a user.
+func (r *LoginFailureRepository) Get(ctx context.Context, userID string) (*auth.LoginFailures, error) {
+	f, err := scanLoginFailures(r.pool.QueryRow(ctx, `
+		SELECT user_id, failed_count, first_failed_at, last_failed_at, locked_until
+		FROM login_failures
+		WHERE user_id = $1
+	`, userID))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// RecordFailure atomically counts a failed login. A failure outside the
+// window of an unlocked account starts a new count. SET expressions all see
+// the row as it was before the update.
+func (r *LoginFailureRepository) RecordFailure(ctx context.Context, userID string, window time.Duration) (*auth.LoginFailures, error) {
+	const stale = `(login_failures.first_failed_at < NOW() - $2 * INTERVAL '1 second'
+		AND (login_failures.locked_until IS NULL OR login_failures.locked_until <= NOW()))`
+
+	return scanLoginFailures(r.pool.QueryRow(ctx, `
+		INSERT INTO login_failures (user_id, failed_count, first_failed_at, last_failed_at)
+		VALUES ($1, 1, NOW(), NOW())
+		ON CONFLICT (user_id) DO UPDATE
+		SET failed_count = CASE WHEN `+stale+` THEN 1 ELSE login_failures.failed_count + 1 END,
+			first_failed_at = CASE WHEN `+stale+` THEN NOW() ELSE login_failures.first_failed_at END,
+			locked_until = CASE WHEN `+stale+` THEN NULL ELSE login_failures.locked_until END,
+			last_failed_at = NOW()
+		RETURNING user_id, failed_count, first_failed_at, last_failed_at, locked_until
+	`, userID, window.Seconds()))
+}
+
+// Lock locks the account until the given time.
+func (r *LoginFailureRepository) Lock(ctx context.Context, userID string, until time.Time) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE login_failures
+		SET locked_until = $2
+		WHERE user_id = $1
+	`, userID, until)
+	return err
+}
+
+// Reset clears failures and any lock, after a successful login or an unlock.
+func (r *LoginFailureRepository) Reset(ctx context.Context, userID string) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM login_failures WHERE user_id = $1`, userID)
+	return err
+}
@@ -26,13 +26,14 @@ func (r *Repository) Create(ctx context.Context, email, name, passwordHash strin
 	err := r.pool.QueryRow(ctx, `
 		INSERT INTO users (email, name, password_hash)
 		VALUES ($1, $2, $3)
-		RETURNING id, email, name, password_hash, onboarding_completed_at, created_at, updated_at
+		RETURNING id, email, name, password_hash, onboarding_completed_at, email_verified_at, created_at, updated_at
 	`, email, name, passwordHash).Scan(
 		&user.ID,
 		&user.Email,
 		&user.Name,
 		&user.PasswordHash,
 		&user.OnboardingCompletedAt,
+		&user.EmailVerifiedAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -46,7 +47,7 @@ func (r *Repository) Create(ctx context.Context, email, name, passwordHash strin
 func (r *Repository) GetByID(ctx context.Context, id string) (*auth.User, error) {
 	var user auth.User
 	err := r.pool.QueryRow(ctx, `
-		SELECT id, email, name, password_hash, onboarding_completed_at, created_at, updated_at
+		SELECT id, email, name, password_hash, onboarding_completed_at, email_verified_at, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`, id).Scan(
@@ -55,6 +56,7 @@ func (r *Repository) GetByID(ctx context.Context, id string) (*auth.User, error)
 		&user.Name,
 		&user.PasswordHash,
 		&user.OnboardingCompletedAt,
+		&user.EmailVerifiedAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -71,7 +73,7 @@ func (r *Repository) GetByID(ctx context.Context, id string) (*auth.User, error)
 func (r *Repository) GetByEmail(ctx context.Context, email string) (*auth.User, error) {
 	var user auth.User
 	err := r.pool.QueryRow(ctx, `
-		SELECT id, email, name, password_hash, onboarding_completed_at, created_at, updated_at
+		SELECT id, email, name, password_hash, onboarding_completed_at, email_verified_at, created_at, updated_at
 		FROM users
 		WHERE email = $1
 	`, email).Scan(
@@ -80,6 +82,7 @@ func (r *Repository) GetByEmail(ctx context.Context, email string) (*auth.User,
 		&user.Name,
 		&user.PasswordHash,
 		&user.OnboardingCompletedAt,
+		&user.EmailVerifiedAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -112,6 +115,16 @@ func (r *Repository) CompleteOnboarding(ctx context.Context, id string) error {
 	return err
 }
 
+// MarkEmailVerified records that the user proved ownership of their email.
+func (r *Repository) MarkEmailVerified(ctx context.Context, id string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE users
+		SET email_verified_at = COALESCE(email_verified_at, NOW()), updated_at = NOW()
+		WHERE id = $1
+	`, id)
+	return err
+}
+
 // Delete deletes a user and all related data (cascades handle sessions, households, etc).
 func (r *Repository) Delete(ctx context.Context, id string) error {
 	result, err := r.pool.Exec(ctx, `DELETE FROM users WHERE id = $1`, id)
@@ -0,0 +1,241 @@
+package users
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/blanquicet/conti/backend/internal/auth"
+)
+
+// TwoFactorRepository implements auth.TwoFactorRepository using PostgreSQL.
+type TwoFactorRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewTwoFactorRepository creates a new two-factor repository.
+func NewTwoFactorRepository(pool *pgxpool.Pool) *TwoFactorRepository {
+	return &TwoFactorRepository{pool: pool}
+}
+
+// Get returns the user's enrolment, or nil if there is none.
+func (r *TwoFactorRepository) Get(ctx context.Context, userID string) (*auth.TwoFactor, error) {
+	var tf auth.TwoFactor
+	err := r.pool.QueryRow(ctx, `
+		SELECT user_id, secret, enabled_at, last_used_step, created_at, updated_at
+		FROM user_two_factor
+		WHERE user_id = $1
+	`, userID).Scan(
+		&tf.UserID,
+		&tf.Secret,
+		&tf.EnabledAt,
+		&tf.LastUsedStep,
+		&tf.CreatedAt,
+		&tf.UpdatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tf, nil
+}
+
+// SavePending stores a new, unconfirmed secret. Confirmed enrolments are left untouched.
+func (r *TwoFactorRepository) SavePending(ctx context.Context, userID, secret string) (*auth.TwoFactor, error) {
+	var tf auth.TwoFactor
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO user_two_factor (user_id, secret)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, last_used_step = 0, updated_at = NOW()
+		WHERE user_two_factor.enabled_at IS NULL
+		RETURNING user_id, secret, enabled_at, last_used_step, created_at, updated_at
+	`, userID, secret).Scan(
+		&tf.UserID,
+		&tf.Secret,
+		&tf.EnabledAt,
+		&tf.LastUsedStep,
+		&tf.CreatedAt,
+		&tf.UpdatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, auth.ErrTwoFactorAlreadyEnabled
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tf, nil
+}
+
+// Enable confirms the enrolment and replaces the recovery codes in one transaction.
+func (r *TwoFactorRepository) Enable(ctx context.Context, userID string, step int64, recoveryCodeHashes []string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE user_two_factor
+		SET enabled_at = NOW(), last_used_step = $2, updated_at = NOW()
+		WHERE user_id = $1 AND enabled_at IS NULL
+	`, userID, step)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return auth.ErrTwoFactorNotSetUp
+	}
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, recoveryCodeHashes); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Disable removes the enrolment and its recovery codes.
+func (r *TwoFactorRepository) Disable(ctx context.Context, userID string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM two_factor_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM user_two_factor WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// UseStep records an accepted time step. The conditional update makes a code
+// usable only once even when two requests race.
+func (r *TwoFactorRepository) UseStep(ctx context.Context, userID string, step int64) (bool, error) {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE user_two_factor
+		SET last_used_step = $2, updated_at = NOW()
+		WHERE user_id = $1 AND last_used_step < $2
+	`, userID, step)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// ReplaceRecoveryCodes deletes the user's recovery codes and stores new ones.
+func (r *TwoFactorRepository) ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, codeHashes); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func replaceRecoveryCodes(ctx context.Context, tx pgx.Tx, userID string, codeHashes []string) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM two_factor_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	_, err := tx.Exec(ctx, `
+		INSERT INTO two_factor_recovery_codes (user_id, code_hash)
+		SELECT $1, unnest($2::text[])
+	`, userID, codeHashes)
+	return err
+}
+
+// UseRecoveryCode marks an unused code as used; it returns false if none matched.
+func (r *TwoFactorRepository) UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error) {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE two_factor_recovery_codes
+		SET used_at = NOW()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`, userID, codeHash)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// CountUnusedRecoveryCodes returns how many recovery codes the user has left.
+func (r *TwoFactorRepository) CountUnusedRecoveryCodes(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM two_factor_recovery_codes
+		WHERE user_id = $1 AND used_at IS NULL
+	`, userID).Scan(&count)
+	return count, err
+}
+
+// CreateChallenge stores a pending login.
+func (r *TwoFactorRepository) CreateChallenge(ctx context.Context, userID string, expiresAt time.Time) (*auth.TwoFactorChallenge, error) {
+	var c auth.TwoFactorChallenge
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO two_factor_challenges (user_id, expires_at)
+		VALUES ($1, $2)
+		RETURNING id, user_id, attempts, expires_at, created_at
+	`, userID, expiresAt).Scan(
+		&c.ID,
+		&c.UserID,
+		&c.Attempts,
+		&c.ExpiresAt,
+		&c.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// GetChallenge returns an unexpired challenge, or nil.
+func (r *TwoFactorRepository) GetChallenge(ctx context.Context, id string) (*auth.TwoFactorChallenge, error) {
+	var c auth.TwoFactorChallenge
+	err := r.pool.QueryRow(ctx, `
+		SELECT id, user_id, attempts, expires_at, created_at
+		FROM two_factor_challenges
+		WHERE id = $1 AND expires_at > NOW()
+	`, id).Scan(
+		&c.ID,
+		&c.UserID,
+		&c.Attempts,
+		&c.ExpiresAt,
+		&c.CreatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// IncrementChallengeAttempts records a wrong code for a challenge.
+func (r *TwoFactorRepository) IncrementChallengeAttempts(ctx context.Context, id string) error {
+	_, err := r.pool.Exec(ctx, `UPDATE two_factor_challenges SET attempts = attempts + 1 WHERE id = $1`, id)
+	return err
+}
+
+// DeleteChallenge removes a challenge.
+func (r *TwoFactorRepository) DeleteChallenge(ctx context.Context, id string) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM two_factor_challenges WHERE id = $1`, id)
+	return err
+}
+
+// DeleteExpiredChallenges removes all expired challenges.
+func (r *TwoFactorRepository) DeleteExpiredChallenges(ctx context.Context) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM two_factor_challenges WHERE expires_at < NOW()`)
+	return err
+}
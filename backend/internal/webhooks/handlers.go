@@ -0,0 +1,205 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/blanquicet/conti/backend/internal/auth"
+	"github.com/blanquicet/conti/backend/internal/households"
+)
+
+// Handler handles HTTP requests for webhook endpoints
+type Handler struct {
+	service    *Service
+	authSvc    *auth.Service
+	cookieName string
+	logger     *slog.Logger
+}
+
+// NewHandler creates a new webhooks handler
+func NewHandler(service *Service, authSvc *auth.Service, cookieName string, logger *slog.Logger) *Handler {
+	return &Handler{
+		service:    service,
+		authSvc:    authSvc,
+		cookieName: cookieName,
+		logger:     logger,
+	}
+}
+
+func (h *Handler) getUserID(r *http.Request) (string, error) {
+	user, err := h.authSvc.UserFromRequest(r, h.cookieName)
+	if err != nil {
+		return "", err
+	}
+	return user.ID, nil
+}
+
+func (h *Handler) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+// handleError maps service errors to HTTP responses
+func (h *Handler) handleError(w http.ResponseWriter, err error, msg string, args ...any) {
+	switch {
+	case errors.Is(err, ErrEndpointNotFound), errors.Is(err, ErrDeliveryNotFound),
+		errors.Is(err, households.ErrHouseholdNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrNotAuthorized):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, ErrInvalidURL), errors.Is(err, ErrPrivateAddress),
+		errors.Is(err, ErrUnresolvableHost), errors.Is(err, ErrInvalidEventType),
+		errors.Is(err, ErrDescriptionTooLong):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, ErrEndpointDisabled):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		h.logger.Error(msg, append([]any{"error", err}, args...)...)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// HandleList returns the household's webhook endpoints
+// GET /webhooks
+func (h *Handler) HandleList(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserID(r)
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	endpoints, err := h.service.ListEndpoints(r.Context(), userID)
+	if err != nil {
+		h.handleError(w, err, "failed to list webhooks", "user_id", userID)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{"webhooks": endpoints})
+}
+
+// HandleCreate registers a webhook endpoint. The response includes the signing secret.
+// POST /webhooks
+func (h *Handler) HandleCreate(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserID(r)
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var input CreateEndpointInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	endpoint, err := h.service.CreateEndpoint(r.Context(), userID, &input)
+	if err != nil {
+		h.handleError(w, err, "failed to create webhook", "user_id", userID)
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, endpoint)
+}
+
+// HandleUpdate edits a webhook endpoint
+// PATCH /webhooks/{id}
+func (h *Handler) HandleUpdate(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserID(r)
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var input UpdateEndpointInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	id := r.PathValue("id")
+	endpoint, err := h.service.UpdateEndpoint(r.Context(), userID, id, &input)
+	if err != nil {
+		h.handleError(w, err, "failed to update webhook", "webhook_id", id, "user_id", userID)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, endpoint)
+}
+
+// HandleDelete removes a webhook endpoint
+// DELETE /webhooks/{id}
+func (h *Handler) HandleDelete(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserID(r)
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id := r.PathValue("id")
+	if err := h.service.DeleteEndpoint(r.Context(), userID, id); err != nil {
+		h.handleError(w, err, "failed to delete webhook", "webhook_id", id, "user_id", userID)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleListDeliveries returns the endpoint's delivery log, newest first
+// GET /webhooks/{id}/deliveries?limit=50&offset=0
+func (h *Handler) HandleListDeliveries(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserID(r)
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	q := r.URL.Query()
+	filters := &ListDeliveriesFilters{}
+	if v := q.Get("limit"); v != "" {
+		if filters.Limit, err = strconv.Atoi(v); err != nil {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+	if v := q.Get("offset"); v != "" {
+		if filters.Offset, err = strconv.Atoi(v); err != nil {
+			http.Error(w, "invalid offset", http.StatusBadRequest)
+			return
+		}
+	}
+
+	id := r.PathValue("id")
+	resp, err := h.service.ListDeliveries(r.Context(), userID, id, filters)
+	if err != nil {
+		h.handleError(w, err, "failed to list webhook deliveries", "webhook_id", id, "user_id", userID)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+// HandleRedeliver queues a logged event to be sent again
+// POST /webhooks/{id}/deliveries/{delivery_id}/redeliver
+func (h *Handler) HandleRedeliver(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserID(r)
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id := r.PathValue("id")
+	deliveryID := r.PathValue("delivery_id")
+	delivery, err := h.service.Redeliver(r.Context(), userID, id, deliveryID)
+	if err != nil {
+		h.handleError(w, err, "failed to redeliver webhook", "webhook_id", id, "delivery_id", deliveryID, "user_id", userID)
+		return
+	}
+
+	h.writeJSON(w, http.StatusAccepted, delivery)
+}
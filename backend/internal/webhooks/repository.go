@@ -0,0 +1,311 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type repository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRepository creates a new webhooks repository
+func NewRepository(pool *pgxpool.Pool) Repository {
+	return &repository{pool: pool}
+}
+
+const endpointColumns = `
+	id, household_id, url, description, secret, event_types, is_active,
+	consecutive_failures, disabled_at, created_by_user_id, created_at, updated_at
+`
+
+func scanEndpoint(row pgx.Row) (*Endpoint, error) {
+	var e Endpoint
+	var eventTypes []string
+	err := row.Scan(
+		&e.ID,
+		&e.HouseholdID,
+		&e.URL,
+		&e.Description,
+		&e.Secret,
+		&eventTypes,
+		&e.IsActive,
+		&e.ConsecutiveFailures,
+		&e.DisabledAt,
+		&e.CreatedByUserID,
+		&e.CreatedAt,
+		&e.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEndpointNotFound
+		}
+		return nil, err
+	}
+	e.EventTypes = make([]EventType, len(eventTypes))
+	for i, t := range eventTypes {
+		e.EventTypes[i] = EventType(t)
+	}
+	return &e, nil
+}
+
+func eventTypeStrings(types []EventType) []string {
+	result := make([]string, len(types))
+	for i, t := range types {
+		result[i] = string(t)
+	}
+	return result
+}
+
+// CreateEndpoint inserts a new endpoint
+func (r *repository) CreateEndpoint(ctx context.Context, endpoint *Endpoint) (*Endpoint, error) {
+	return scanEndpoint(r.pool.QueryRow(ctx, `
+		INSERT INTO webhook_endpoints (household_id, url, description, secret, event_types, created_by_user_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+endpointColumns,
+		endpoint.HouseholdID, endpoint.URL, endpoint.Description, endpoint.Secret,
+		eventTypeStrings(endpoint.EventTypes), endpoint.CreatedByUserID,
+	))
+}
+
+// GetEndpoint retrieves an endpoint by ID
+func (r *repository) GetEndpoint(ctx context.Context, id string) (*Endpoint, error) {
+	return scanEndpoint(r.pool.QueryRow(ctx, `SELECT `+endpointColumns+` FROM webhook_endpoints WHERE id = $1`, id))
+}
+
+func (r *repository) listEndpoints(ctx context.Context, query string, args ...any) ([]*Endpoint, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	endpoints := make([]*Endpoint, 0)
+	for rows.Next() {
+		e, err := scanEndpoint(rows)
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, e)
+	}
+	return endpoints, rows.Err()
+}
+
+// ListEndpoints returns all endpoints of a household
+func (r *repository) ListEndpoints(ctx context.Context, householdID string) ([]*Endpoint, error) {
+	return r.listEndpoints(ctx, `
+		SELECT `+endpointColumns+` FROM webhook_endpoints
+		WHERE household_id = $1
+		ORDER BY created_at ASC
+	`, householdID)
+}
+
+// ListActiveEndpoints returns the enabled endpoints of a household
+func (r *repository) ListActiveEndpoints(ctx context.Context, householdID string) ([]*Endpoint, error) {
+	return r.listEndpoints(ctx, `
+		SELECT `+endpointColumns+` FROM webhook_endpoints
+		WHERE household_id = $1 AND is_active = TRUE
+	`, householdID)
+}
+
+// UpdateEndpoint saves the editable fields of an endpoint
+func (r *repository) UpdateEndpoint(ctx context.Context, endpoint *Endpoint) (*Endpoint, error) {
+	return scanEndpoint(r.pool.QueryRow(ctx, `
+		UPDATE webhook_endpoints
+		SET url = $2, description = $3, event_types = $4, is_active = $5,
+		    consecutive_failures = $6, disabled_at = $7, updated_at = NOW()
+		WHERE id = $1
+		RETURNING `+endpointColumns,
+		endpoint.ID, endpoint.URL, endpoint.Description, eventTypeStrings(endpoint.EventTypes),
+		endpoint.IsActive, endpoint.ConsecutiveFailures, endpoint.DisabledAt,
+	))
+}
+
+// DeleteEndpoint deletes an endpoint and its delivery log
+func (r *repository) DeleteEndpoint(ctx context.Context, id string) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM webhook_endpoints WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrEndpointNotFound
+	}
+	return nil
+}
+
+// RecordEndpointSuccess resets the endpoint's failure streak
+func (r *repository) RecordEndpointSuccess(ctx context.Context, id string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE webhook_endpoints SET consecutive_failures = 0
+		WHERE id = $1 AND consecutive_failures <> 0
+	`, id)
+	return err
+}
+
+// RecordEndpointFailure increments the failure streak and disables the endpoint at the threshold
+func (r *repository) RecordEndpointFailure(ctx context.Context, id string, threshold int) (bool, error) {
+	var disabled bool
+	err := r.pool.QueryRow(ctx, `
+		UPDATE webhook_endpoints
+		SET consecutive_failures = consecutive_failures + 1,
+		    is_active = CASE WHEN consecutive_failures + 1 >= $2 THEN FALSE ELSE is_active END,
+		    disabled_at = CASE WHEN consecutive_failures + 1 >= $2 AND is_active THEN NOW() ELSE disabled_at END,
+		    updated_at = NOW()
+		WHERE id = $1
+		RETURNING (consecutive_failures = $2)
+	`, id, threshold).Scan(&disabled)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, ErrEndpointNotFound
+		}
+		return false, err
+	}
+	return disabled, nil
+}
+
+const deliveryColumns = `
+	id, endpoint_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+	response_status, last_error, created_at, delivered_at
+`
+
+func scanDelivery(row pgx.Row) (*Delivery, error) {
+	var d Delivery
+	err := row.Scan(
+		&d.ID,
+		&d.EndpointID,
+		&d.EventID,
+		&d.EventType,
+		&d.Payload,
+		&d.Status,
+		&d.Attempts,
+		&d.NextAttemptAt,
+		&d.ResponseStatus,
+		&d.LastError,
+		&d.CreatedAt,
+		&d.DeliveredAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+	return &d, nil
+}
+
+// CreateDelivery queues a delivery; returns (nil, nil) if the dedup key was already used
+func (r *repository) CreateDelivery(ctx context.Context, delivery *Delivery, dedupKey *string) (*Delivery, error) {
+	d, err := scanDelivery(r.pool.QueryRow(ctx, `
+		INSERT INTO webhook_deliveries (endpoint_id, event_id, event_type, payload, dedup_key)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (endpoint_id, dedup_key) WHERE dedup_key IS NOT NULL DO NOTHING
+		RETURNING `+deliveryColumns,
+		delivery.EndpointID, delivery.EventID, delivery.EventType, delivery.Payload, dedupKey,
+	))
+	if errors.Is(err, ErrDeliveryNotFound) {
+		return nil, nil
+	}
+	return d, err
+}
+
+// GetDelivery retrieves a delivery by ID
+func (r *repository) GetDelivery(ctx context.Context, id string) (*Delivery, error) {
+	return scanDelivery(r.pool.QueryRow(ctx, `SELECT `+deliveryColumns+` FROM webhook_deliveries WHERE id = $1`, id))
+}
+
+// ListDeliveries returns a page of an endpoint's delivery log, newest first
+func (r *repository) ListDeliveries(ctx context.Context, endpointID string, filters *ListDeliveriesFilters) ([]*Delivery, int, error) {
+	var total int
+	if err := r.pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM webhook_deliveries WHERE endpoint_id = $1
+	`, endpointID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+deliveryColumns+` FROM webhook_deliveries
+		WHERE endpoint_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`, endpointID, filters.Limit, filters.Offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]*Delivery, 0)
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, total, rows.Err()
+}
+
+// ClaimDueDeliveries leases due deliveries by pushing next_attempt_at forward,
+// so concurrent workers (other replicas) skip them while they are being sent
+func (r *repository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*Delivery, error) {
+	rows, err := r.pool.Query(ctx, `
+		UPDATE webhook_deliveries
+		SET attempts = attempts + 1, next_attempt_at = NOW() + make_interval(secs => $2)
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'PENDING' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+deliveryColumns,
+		limit, lease.Seconds(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]*Delivery, 0)
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+// MarkDeliverySucceeded records a successful delivery
+func (r *repository) MarkDeliverySucceeded(ctx context.Context, id string, responseStatus int) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET status = 'SUCCEEDED', response_status = $2, last_error = NULL, delivered_at = NOW()
+		WHERE id = $1
+	`, id, responseStatus)
+	return err
+}
+
+// MarkDeliveryRetry records a failed attempt and schedules the next one
+func (r *repository) MarkDeliveryRetry(ctx context.Context, id string, responseStatus *int, lastError string, nextAttemptAt time.Time) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET response_status = $2, last_error = $3, next_attempt_at = $4
+		WHERE id = $1
+	`, id, responseStatus, lastError, nextAttemptAt)
+	return err
+}
+
+// MarkDeliveryFailed records the final failed attempt
+func (r *repository) MarkDeliveryFailed(ctx context.Context, id string, responseStatus *int, lastError string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET status = 'FAILED', response_status = $2, last_error = $3
+		WHERE id = $1
+	`, id, responseStatus, lastError)
+	return err
+}
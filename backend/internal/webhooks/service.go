@@ -0,0 +1,568 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/blanquicet/conti/backend/internal/audit"
+	"github.com/blanquicet/conti/backend/internal/households"
+)
+
+// Request headers sent with every delivery
+const (
+	HeaderEvent     = "X-Conti-Event"
+	HeaderDelivery  = "X-Conti-Delivery"
+	HeaderTimestamp = "X-Conti-Timestamp"
+	HeaderSignature = "X-Conti-Signature"
+)
+
+const (
+	// workerInterval is how often the worker polls for due deliveries
+	workerInterval = 15 * time.Second
+	// claimBatchSize is how many deliveries a worker sends per poll
+	claimBatchSize = 20
+	// claimLease keeps a claimed delivery away from other workers while it is sent
+	claimLease = 2 * time.Minute
+	// requestTimeout bounds each HTTP request to an endpoint
+	requestTimeout = 10 * time.Second
+	// maxErrorBodyBytes is how much of a failed response body is kept in the log
+	maxErrorBodyBytes = 512
+)
+
+// queuedEvent is an event with its payload already serialized
+type queuedEvent struct {
+	event   *Event
+	eventID string
+	payload []byte
+}
+
+// Service manages webhook endpoints and delivers events to them
+type Service struct {
+	repo          Repository
+	householdRepo households.HouseholdRepository
+	auditService  audit.Service
+	logger        *slog.Logger
+	client        *http.Client
+
+	dispatchChan chan *queuedEvent
+	wake         chan struct{}
+}
+
+// NewService creates a new webhooks service
+func NewService(repo Repository, householdRepo households.HouseholdRepository, auditService audit.Service, logger *slog.Logger) *Service {
+	s := &Service{
+		repo:          repo,
+		householdRepo: householdRepo,
+		auditService:  auditService,
+		logger:        logger,
+		client:        newDeliveryClient(),
+		dispatchChan:  make(chan *queuedEvent, 1000),
+		wake:          make(chan struct{}, 1),
+	}
+
+	// Start background enqueuer
+	go s.dispatchWorker()
+
+	return s
+}
+
+// ListDeliveriesResponse is the response for the delivery log
+type ListDeliveriesResponse struct {
+	Deliveries []*Delivery `json:"deliveries"`
+	Total      int         `json:"total"`
+	Limit      int         `json:"limit"`
+	Offset     int         `json:"offset"`
+}
+
+// authorizeOwner returns the user's household if their role may manage it.
+// Webhook secrets give access to household data, so only owners manage them.
+func (s *Service) authorizeOwner(ctx context.Context, userID string) (string, error) {
+	householdID, err := households.AuthorizeSelected(ctx, s.householdRepo, userID, households.PermManageHousehold)
+	if errors.Is(err, households.ErrNotAuthorized) {
+		return "", ErrNotAuthorized
+	}
+	return householdID, err
+}
+
+// getOwnedEndpoint loads an endpoint and verifies the user owns its household
+func (s *Service) getOwnedEndpoint(ctx context.Context, userID, endpointID string) (*Endpoint, error) {
+	householdID, err := s.authorizeOwner(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	endpoint, err := s.repo.GetEndpoint(ctx, endpointID)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint.HouseholdID != householdID {
+		return nil, ErrEndpointNotFound
+	}
+	return endpoint, nil
+}
+
+// ListEndpoints returns the household's webhook endpoints
+func (s *Service) ListEndpoints(ctx context.Context, userID string) ([]*Endpoint, error) {
+	householdID, err := s.authorizeOwner(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	endpoints, err := s.repo.ListEndpoints(ctx, householdID)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range endpoints {
+		e.Secret = ""
+	}
+	return endpoints, nil
+}
+
+// CreateEndpoint registers a new endpoint. The returned endpoint includes the
+// signing secret; it is not shown again.
+func (s *Service) CreateEndpoint(ctx context.Context, userID string, input *CreateEndpointInput) (*Endpoint, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+	if err := checkURLResolves(ctx, input.URL); err != nil {
+		return nil, err
+	}
+
+	householdID, err := s.authorizeOwner(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	eventTypes := input.EventTypes
+	if eventTypes == nil {
+		eventTypes = []EventType{}
+	}
+
+	endpoint, err := s.repo.CreateEndpoint(ctx, &Endpoint{
+		HouseholdID:     householdID,
+		URL:             input.URL,
+		Description:     input.Description,
+		Secret:          secret,
+		EventTypes:      eventTypes,
+		CreatedByUserID: &userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		UserID:       audit.StringPtr(userID),
+		Action:       audit.ActionWebhookCreated,
+		ResourceType: "webhook",
+		ResourceID:   audit.StringPtr(endpoint.ID),
+		HouseholdID:  audit.StringPtr(householdID),
+		NewValues: map[string]interface{}{
+			"url":         endpoint.URL,
+			"event_types": endpoint.EventTypes,
+		},
+		Success: true,
+	})
+
+	return endpoint, nil
+}
+
+// UpdateEndpoint edits an endpoint. Re-enabling it clears its failure streak.
+func (s *Service) UpdateEndpoint(ctx context.Context, userID, endpointID string, input *UpdateEndpointInput) (*Endpoint, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+	if input.URL != nil {
+		if err := checkURLResolves(ctx, *input.URL); err != nil {
+			return nil, err
+		}
+	}
+
+	endpoint, err := s.getOwnedEndpoint(ctx, userID, endpointID)
+	if err != nil {
+		return nil, err
+	}
+	old := *endpoint
+
+	if input.URL != nil {
+		endpoint.URL = *input.URL
+	}
+	if input.Description != nil {
+		endpoint.Description = input.Description
+	}
+	if input.EventTypes != nil {
+		endpoint.EventTypes = *input.EventTypes
+	}
+	if input.IsActive != nil {
+		if *input.IsActive && !endpoint.IsActive {
+			endpoint.ConsecutiveFailures = 0
+			endpoint.DisabledAt = nil
+		}
+		endpoint.IsActive = *input.IsActive
+	}
+
+	updated, err := s.repo.UpdateEndpoint(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	updated.Secret = ""
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		UserID:       audit.StringPtr(userID),
+		Action:       audit.ActionWebhookUpdated,
+		ResourceType: "webhook",
+		ResourceID:   audit.StringPtr(endpointID),
+		HouseholdID:  audit.StringPtr(updated.HouseholdID),
+		OldValues: map[string]interface{}{
+			"url":         old.URL,
+			"event_types": old.EventTypes,
+			"is_active":   old.IsActive,
+		},
+		NewValues: map[string]interface{}{
+			"url":         updated.URL,
+			"event_types": updated.EventTypes,
+			"is_active":   updated.IsActive,
+		},
+		Success: true,
+	})
+
+	return updated, nil
+}
+
+// DeleteEndpoint removes an endpoint and its delivery log
+func (s *Service) DeleteEndpoint(ctx context.Context, userID, endpointID string) error {
+	endpoint, err := s.getOwnedEndpoint(ctx, userID, endpointID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteEndpoint(ctx, endpointID); err != nil {
+		return err
+	}
+
+	s.auditService.LogAsync(ctx, &audit.LogInput{
+		UserID:       audit.StringPtr(userID),
+		Action:       audit.ActionWebhookDeleted,
+		ResourceType: "webhook",
+		ResourceID:   audit.StringPtr(endpointID),
+		HouseholdID:  audit.StringPtr(endpoint.HouseholdID),
+		OldValues:    map[string]interface{}{"url": endpoint.URL},
+		Success:      true,
+	})
+
+	return nil
+}
+
+// ListDeliveries returns a page of an endpoint's delivery log
+func (s *Service) ListDeliveries(ctx context.Context, userID, endpointID string, filters *ListDeliveriesFilters) (*ListDeliveriesResponse, error) {
+	if _, err := s.getOwnedEndpoint(ctx, userID, endpointID); err != nil {
+		return nil, err
+	}
+
+	if filters.Limit <= 0 || filters.Limit > 100 {
+		filters.Limit = 50
+	}
+	if filters.Offset < 0 {
+		filters.Offset = 0
+	}
+
+	deliveries, total, err := s.repo.ListDeliveries(ctx, endpointID, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListDeliveriesResponse{
+		Deliveries: deliveries,
+		Total:      total,
+		Limit:      filters.Limit,
+		Offset:     filters.Offset,
+	}, nil
+}
+
+// Redeliver queues a new delivery of a logged event with the same event ID and payload
+func (s *Service) Redeliver(ctx context.Context, userID, endpointID, deliveryID string) (*Delivery, error) {
+	endpoint, err := s.getOwnedEndpoint(ctx, userID, endpointID)
+	if err != nil {
+		return nil, err
+	}
+	if !endpoint.IsActive {
+		return nil, ErrEndpointDisabled
+	}
+
+	original, err := s.repo.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if original.EndpointID != endpointID {
+		return nil, ErrDeliveryNotFound
+	}
+
+	delivery, err := s.repo.CreateDelivery(ctx, &Delivery{
+		EndpointID: endpointID,
+		EventID:    original.EventID,
+		EventType:  original.EventType,
+		Payload:    original.Payload,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	s.wakeWorker()
+	return delivery, nil
+}
+
+// Dispatch queues an event for every active endpoint of the household that
+// subscribes to it (non-blocking)
+func (s *Service) Dispatch(ctx context.Context, event *Event) {
+	eventID, err := newEventID()
+	if err != nil {
+		s.logger.Error("failed to generate webhook event id", "error", err)
+		return
+	}
+
+	// Serialize now so later changes to event.Data are not sent
+	payload, err := json.Marshal(&Payload{
+		ID:          eventID,
+		Type:        event.Type,
+		HouseholdID: event.HouseholdID,
+		CreatedAt:   time.Now().UTC(),
+		Data:        event.Data,
+	})
+	if err != nil {
+		s.logger.Error("failed to marshal webhook payload", "error", err, "type", event.Type)
+		return
+	}
+
+	select {
+	case s.dispatchChan <- &queuedEvent{event: event, eventID: eventID, payload: payload}:
+	default:
+		s.logger.Warn("Webhook dispatch channel full, dropping event", "type", event.Type)
+	}
+}
+
+// dispatchWorker creates deliveries for queued events
+func (s *Service) dispatchWorker() {
+	for queued := range s.dispatchChan {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := s.enqueue(ctx, queued); err != nil {
+			s.logger.Error("failed to enqueue webhook deliveries", "error", err, "type", queued.event.Type)
+		}
+		cancel()
+	}
+}
+
+func (s *Service) enqueue(ctx context.Context, queued *queuedEvent) error {
+	endpoints, err := s.repo.ListActiveEndpoints(ctx, queued.event.HouseholdID)
+	if err != nil {
+		return err
+	}
+
+	created := false
+	for _, endpoint := range endpoints {
+		if !endpoint.Subscribes(queued.event.Type) {
+			continue
+		}
+		d, err := s.repo.CreateDelivery(ctx, &Delivery{
+			EndpointID: endpoint.ID,
+			EventID:    queued.eventID,
+			EventType:  queued.event.Type,
+			Payload:    queued.payload,
+		}, queued.event.DedupKey)
+		if err != nil {
+			return err
+		}
+		created = created || d != nil
+	}
+
+	if created {
+		s.wakeWorker()
+	}
+	return nil
+}
+
+// wakeWorker asks the delivery worker to poll now instead of at the next tick
+func (s *Service) wakeWorker() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Start runs the delivery worker until ctx is cancelled. Deliveries are claimed
+// through the database, so every replica can run a worker.
+func (s *Service) Start(ctx context.Context) {
+	ticker := time.NewTicker(workerInterval)
+	defer ticker.Stop()
+
+	s.logger.Info("webhook delivery worker started")
+
+	for {
+		s.processDue(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-s.wake:
+		case <-ctx.Done():
+			s.logger.Info("webhook delivery worker stopped")
+			return
+		}
+	}
+}
+
+// processDue sends every due delivery, one batch at a time
+func (s *Service) processDue(ctx context.Context) {
+	for {
+		deliveries, err := s.repo.ClaimDueDeliveries(ctx, claimBatchSize, claimLease)
+		if err != nil {
+			if ctx.Err() == nil {
+				s.logger.Error("failed to claim webhook deliveries", "error", err)
+			}
+			return
+		}
+		for _, d := range deliveries {
+			s.deliver(ctx, d)
+		}
+		if len(deliveries) < claimBatchSize {
+			return
+		}
+	}
+}
+
+// deliver sends one claimed delivery and records the outcome
+func (s *Service) deliver(ctx context.Context, d *Delivery) {
+	endpoint, err := s.repo.GetEndpoint(ctx, d.EndpointID)
+	if err != nil {
+		s.logger.Error("failed to load webhook endpoint", "error", err, "endpoint_id", d.EndpointID)
+		return
+	}
+	if !endpoint.IsActive {
+		if err := s.repo.MarkDeliveryFailed(ctx, d.ID, nil, ErrEndpointDisabled.Error()); err != nil {
+			s.logger.Error("failed to update webhook delivery", "error", err, "delivery_id", d.ID)
+		}
+		return
+	}
+
+	status, sendErr := s.send(ctx, endpoint, d)
+	if sendErr == nil {
+		if err := s.repo.MarkDeliverySucceeded(ctx, d.ID, *status); err != nil {
+			s.logger.Error("failed to update webhook delivery", "error", err, "delivery_id", d.ID)
+		}
+		if err := s.repo.RecordEndpointSuccess(ctx, endpoint.ID); err != nil {
+			s.logger.Error("failed to reset webhook failure streak", "error", err, "endpoint_id", endpoint.ID)
+		}
+		return
+	}
+
+	if d.Attempts < MaxAttempts {
+		next := time.Now().Add(Backoff(d.Attempts))
+		if err := s.repo.MarkDeliveryRetry(ctx, d.ID, status, sendErr.Error(), next); err != nil {
+			s.logger.Error("failed to update webhook delivery", "error", err, "delivery_id", d.ID)
+		}
+		return
+	}
+
+	// Out of retries
+	if err := s.repo.MarkDeliveryFailed(ctx, d.ID, status, sendErr.Error()); err != nil {
+		s.logger.Error("failed to update webhook delivery", "error", err, "delivery_id", d.ID)
+	}
+	disabled, err := s.repo.RecordEndpointFailure(ctx, endpoint.ID, DisableAfterFailures)
+	if err != nil {
+		s.logger.Error("failed to record webhook failure", "error", err, "endpoint_id", endpoint.ID)
+		return
+	}
+	if disabled {
+		s.logger.Warn("webhook endpoint disabled after repeated failures", "endpoint_id", endpoint.ID, "url", endpoint.URL)
+		s.auditService.LogAsync(ctx, &audit.LogInput{
+			Action:       audit.ActionWebhookDisabled,
+			ResourceType: "webhook",
+			ResourceID:   audit.StringPtr(endpoint.ID),
+			HouseholdID:  audit.StringPtr(endpoint.HouseholdID),
+			Metadata:     map[string]interface{}{"consecutive_failures": DisableAfterFailures},
+			Success:      true,
+		})
+	}
+}
+
+// send POSTs the payload to the endpoint. Any 2xx response is a success.
+func (s *Service) send(ctx context.Context, endpoint *Endpoint, d *Delivery) (*int, error) {
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Conti-Webhooks/1.0")
+	req.Header.Set(HeaderEvent, string(d.EventType))
+	req.Header.Set(HeaderDelivery, d.ID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, "sha256="+Sign(endpoint.Secret, timestamp, d.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	status := resp.StatusCode
+	if status >= 200 && status < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return &status, nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+	return &status, fmt.Errorf("endpoint responded %d: %s", status, bytes.TrimSpace(body))
+}
+
+// Sign returns the hex HMAC-SHA256 of "<timestamp>.<body>" using the endpoint secret.
+// Receivers should recompute it and compare with the X-Conti-Signature header.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Backoff returns the wait before the next attempt after the given number of
+// attempts: InitialBackoff, then doubling each time
+func Backoff(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	return InitialBackoff << (attempts - 1)
+}
+
+// generateSecret creates a random signing secret
+func generateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// newEventID generates a random (version 4) UUID shared by all deliveries of an event
+func newEventID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
@@ -0,0 +1,125 @@
+package webhooks
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+
+	got := Sign("whsec_test", 1700000000, body)
+	if len(got) != 64 {
+		t.Fatalf("expected 64 hex chars, got %d", len(got))
+	}
+	if got != Sign("whsec_test", 1700000000, body) {
+		t.Error("signature is not deterministic")
+	}
+	if got == Sign("whsec_other", 1700000000, body) {
+		t.Error("signature does not depend on the secret")
+	}
+	if got == Sign("whsec_test", 1700000001, body) {
+		t.Error("signature does not depend on the timestamp")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, InitialBackoff},
+		{1, InitialBackoff},
+		{2, 2 * InitialBackoff},
+		{5, 16 * InitialBackoff},
+	}
+	for _, tt := range tests {
+		if got := Backoff(tt.attempts); got != tt.want {
+			t.Errorf("Backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestEndpointSubscribes(t *testing.T) {
+	all := &Endpoint{}
+	if !all.Subscribes(EventMovementCreated) {
+		t.Error("endpoint without event types should receive every event")
+	}
+
+	some := &Endpoint{EventTypes: []EventType{EventBudgetExceeded}}
+	if !some.Subscribes(EventBudgetExceeded) {
+		t.Error("expected subscription to budget.exceeded")
+	}
+	if some.Subscribes(EventMovementCreated) {
+		t.Error("unexpected subscription to movement.created")
+	}
+}
+
+func TestCreateEndpointInputValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   CreateEndpointInput
+		wantErr error
+	}{
+		{"https", CreateEndpointInput{URL: " https://example.com/hook "}, nil},
+		{"http", CreateEndpointInput{URL: "http://example.com:9000/hook"}, nil},
+		{"localhost", CreateEndpointInput{URL: "http://localhost:9000/hook"}, ErrPrivateAddress},
+		{"loopback", CreateEndpointInput{URL: "http://127.0.0.1/hook"}, ErrPrivateAddress},
+		{"private", CreateEndpointInput{URL: "https://10.0.0.5/hook"}, ErrPrivateAddress},
+		{"metadata", CreateEndpointInput{URL: "http://169.254.169.254/latest/meta-data"}, ErrPrivateAddress},
+		{"ipv6 loopback", CreateEndpointInput{URL: "http://[::1]:8080/hook"}, ErrPrivateAddress},
+		{"no scheme", CreateEndpointInput{URL: "example.com/hook"}, ErrInvalidURL},
+		{"ftp", CreateEndpointInput{URL: "ftp://example.com"}, ErrInvalidURL},
+		{"bad event", CreateEndpointInput{URL: "https://example.com", EventTypes: []EventType{"nope"}}, ErrInvalidEventType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.input.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	tests := map[string]bool{
+		"93.184.216.34":    true,
+		"2606:4700::1111":  true,
+		"127.0.0.1":        false,
+		"10.1.2.3":         false,
+		"172.16.0.1":       false,
+		"192.168.1.1":      false,
+		"169.254.169.254":  false,
+		"100.100.100.200":  false,
+		"0.0.0.0":          false,
+		"::1":              false,
+		"fd00:ec2::254":    false,
+		"fe80::1":          false,
+		"::ffff:127.0.0.1": false,
+	}
+	for raw, want := range tests {
+		if got := isPublicAddr(netip.MustParseAddr(raw)); got != want {
+			t.Errorf("isPublicAddr(%s) = %v, want %v", raw, got, want)
+		}
+	}
+}
+
+func TestDeliveryClientRefusesInternalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	resp, err := newDeliveryClient().Post(server.URL, "application/json", nil)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("expected the delivery client to refuse a loopback server")
+	}
+	if !errors.Is(err, ErrPrivateAddress) {
+		t.Errorf("expected ErrPrivateAddress, got %v", err)
+	}
+}
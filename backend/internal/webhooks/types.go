@@ -0,0 +1,236 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Errors for webhook operations
+var (
+	ErrEndpointNotFound   = errors.New("webhook endpoint not found")
+	ErrDeliveryNotFound   = errors.New("webhook delivery not found")
+	ErrNotAuthorized      = errors.New("not authorized")
+	ErrInvalidURL         = errors.New("url must be an absolute http or https URL")
+	ErrPrivateAddress     = errors.New("url must not point to a private or internal address")
+	ErrUnresolvableHost   = errors.New("url host could not be resolved")
+	ErrInvalidEventType   = errors.New("invalid event type")
+	ErrEndpointDisabled   = errors.New("webhook endpoint is disabled")
+	ErrDescriptionTooLong = errors.New("description must be 255 characters or less")
+)
+
+// EventType identifies the kind of event sent to webhook endpoints
+type EventType string
+
+const (
+	EventMovementCreated   EventType = "movement.created"
+	EventMovementUpdated   EventType = "movement.updated"
+	EventBudgetExceeded    EventType = "budget.exceeded"
+	EventPocketGoalReached EventType = "pocket.goal_reached"
+)
+
+// AllEventTypes lists every event type an endpoint can subscribe to
+var AllEventTypes = []EventType{
+	EventMovementCreated,
+	EventMovementUpdated,
+	EventBudgetExceeded,
+	EventPocketGoalReached,
+}
+
+// Validate checks that the event type is known
+func (t EventType) Validate() error {
+	for _, known := range AllEventTypes {
+		if t == known {
+			return nil
+		}
+	}
+	return ErrInvalidEventType
+}
+
+// DeliveryStatus represents the state of a delivery
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "PENDING"
+	DeliverySucceeded DeliveryStatus = "SUCCEEDED"
+	DeliveryFailed    DeliveryStatus = "FAILED"
+)
+
+// Delivery retry policy
+const (
+	// MaxAttempts is how many times a delivery is tried before it is marked FAILED
+	MaxAttempts = 6
+	// InitialBackoff is the wait before the first retry; it doubles on every attempt
+	InitialBackoff = time.Minute
+	// DisableAfterFailures disables an endpoint after this many deliveries in a row fail
+	DisableAfterFailures = 5
+)
+
+// Endpoint is a URL configured by a household to receive events
+type Endpoint struct {
+	ID                  string      `json:"id"`
+	HouseholdID         string      `json:"household_id"`
+	URL                 string      `json:"url"`
+	Description         *string     `json:"description,omitempty"`
+	EventTypes          []EventType `json:"event_types"` // Empty means all events
+	IsActive            bool        `json:"is_active"`
+	ConsecutiveFailures int         `json:"consecutive_failures"`
+	DisabledAt          *time.Time  `json:"disabled_at,omitempty"`
+	CreatedByUserID     *string     `json:"created_by_user_id,omitempty"`
+	CreatedAt           time.Time   `json:"created_at"`
+	UpdatedAt           time.Time   `json:"updated_at"`
+
+	// Secret signs every payload. Only returned when the endpoint is created.
+	Secret string `json:"secret,omitempty"`
+}
+
+// Subscribes reports whether the endpoint wants events of the given type
+func (e *Endpoint) Subscribes(eventType EventType) bool {
+	if len(e.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range e.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Delivery is one attempt series to send an event to an endpoint
+type Delivery struct {
+	ID             string          `json:"id"`
+	EndpointID     string          `json:"endpoint_id"`
+	EventID        string          `json:"event_id"`
+	EventType      EventType       `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         DeliveryStatus  `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	ResponseStatus *int            `json:"response_status,omitempty"`
+	LastError      *string         `json:"last_error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+}
+
+// Event is what services hand to the dispatcher
+type Event struct {
+	HouseholdID string
+	Type        EventType
+	Data        interface{}
+	// DedupKey, when set, sends the event at most once per endpoint
+	DedupKey *string
+}
+
+// Payload is the JSON body POSTed to endpoints
+type Payload struct {
+	ID          string      `json:"id"`
+	Type        EventType   `json:"type"`
+	HouseholdID string      `json:"household_id"`
+	CreatedAt   time.Time   `json:"created_at"`
+	Data        interface{} `json:"data"`
+}
+
+// CreateEndpointInput represents input for creating an endpoint
+type CreateEndpointInput struct {
+	URL         string      `json:"url"`
+	Description *string     `json:"description,omitempty"`
+	EventTypes  []EventType `json:"event_types,omitempty"`
+}
+
+// Validate validates the input
+func (i *CreateEndpointInput) Validate() error {
+	i.URL = strings.TrimSpace(i.URL)
+	if err := validateURL(i.URL); err != nil {
+		return err
+	}
+	if i.Description != nil && len(*i.Description) > 255 {
+		return ErrDescriptionTooLong
+	}
+	for _, t := range i.EventTypes {
+		if err := t.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateEndpointInput represents input for updating an endpoint.
+// Setting IsActive to true re-enables an automatically disabled endpoint.
+type UpdateEndpointInput struct {
+	URL         *string      `json:"url,omitempty"`
+	Description *string      `json:"description,omitempty"`
+	EventTypes  *[]EventType `json:"event_types,omitempty"`
+	IsActive    *bool        `json:"is_active,omitempty"`
+}
+
+// Validate validates the input
+func (i *UpdateEndpointInput) Validate() error {
+	if i.URL != nil {
+		trimmed := strings.TrimSpace(*i.URL)
+		i.URL = &trimmed
+		if err := validateURL(trimmed); err != nil {
+			return err
+		}
+	}
+	if i.Description != nil && len(*i.Description) > 255 {
+		return ErrDescriptionTooLong
+	}
+	if i.EventTypes != nil {
+		for _, t := range *i.EventTypes {
+			if err := t.Validate(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return ErrInvalidURL
+	}
+	return checkHostLiteral(u.Hostname())
+}
+
+// ListDeliveriesFilters represents pagination for the delivery log
+type ListDeliveriesFilters struct {
+	Limit  int // Default 50, max 100
+	Offset int
+}
+
+// Repository defines data access for webhooks
+type Repository interface {
+	CreateEndpoint(ctx context.Context, endpoint *Endpoint) (*Endpoint, error)
+	GetEndpoint(ctx context.Context, id string) (*Endpoint, error)
+	ListEndpoints(ctx context.Context, householdID string) ([]*Endpoint, error)
+	ListActiveEndpoints(ctx context.Context, householdID string) ([]*Endpoint, error)
+	UpdateEndpoint(ctx context.Context, endpoint *Endpoint) (*Endpoint, error)
+	DeleteEndpoint(ctx context.Context, id string) error
+
+	// RecordEndpointSuccess resets the endpoint's failure streak
+	RecordEndpointSuccess(ctx context.Context, id string) error
+	// RecordEndpointFailure increments the failure streak and disables the endpoint
+	// once it reaches the threshold. Returns true if this call disabled it.
+	RecordEndpointFailure(ctx context.Context, id string, threshold int) (bool, error)
+
+	// CreateDelivery queues a delivery; returns nil (no error) if the dedup key was already used
+	CreateDelivery(ctx context.Context, delivery *Delivery, dedupKey *string) (*Delivery, error)
+	GetDelivery(ctx context.Context, id string) (*Delivery, error)
+	ListDeliveries(ctx context.Context, endpointID string, filters *ListDeliveriesFilters) ([]*Delivery, int, error)
+	// ClaimDueDeliveries leases up to limit due deliveries so only one worker sends each
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*Delivery, error)
+	MarkDeliverySucceeded(ctx context.Context, id string, responseStatus int) error
+	MarkDeliveryRetry(ctx context.Context, id string, responseStatus *int, lastError string, nextAttemptAt time.Time) error
+	MarkDeliveryFailed(ctx context.Context, id string, responseStatus *int, lastError string) error
+}
+
+// Dispatcher is the narrow interface other packages use to emit webhook events.
+// Dispatching never fails the caller; errors are logged.
+type Dispatcher interface {
+	Dispatch(ctx context.Context, event *Event)
+}
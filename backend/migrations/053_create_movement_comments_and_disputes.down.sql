@@ -0,0 +1,5 @@
+-- Note: audit_action enum values cannot be removed in PostgreSQL; they are left in place.
+DROP TABLE IF EXISTS movement_disputes;
+DROP TYPE IF EXISTS movement_dispute_status;
+DROP TABLE IF EXISTS movement_comments;
+ALTER TABLE movements DROP COLUMN IF EXISTS created_by_user_id;
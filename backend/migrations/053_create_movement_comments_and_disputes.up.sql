@@ -0,0 +1,39 @@
+-- Track who created each movement (NULL for movements created before this migration)
+ALTER TABLE movements ADD COLUMN created_by_user_id UUID REFERENCES users(id) ON DELETE SET NULL;
+
+-- Comment threads on movements, visible to the household and linked participants
+CREATE TABLE movement_comments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    movement_id UUID NOT NULL REFERENCES movements(id) ON DELETE CASCADE,
+    author_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    body TEXT NOT NULL CHECK (length(trim(body)) > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_movement_comments_movement ON movement_comments(movement_id, created_at);
+
+-- Disputes raised on movements; an OPEN dispute flags the movement in debt consolidation
+CREATE TYPE movement_dispute_status AS ENUM ('OPEN', 'RESOLVED');
+
+CREATE TABLE movement_disputes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    movement_id UUID NOT NULL REFERENCES movements(id) ON DELETE CASCADE,
+    raised_by_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    status movement_dispute_status NOT NULL DEFAULT 'OPEN',
+    resolved_by_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    resolution_note TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_movement_disputes_movement ON movement_disputes(movement_id);
+-- At most one open dispute per movement
+CREATE UNIQUE INDEX idx_movement_disputes_one_open ON movement_disputes(movement_id) WHERE status = 'OPEN';
+
+-- Audit actions
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'MOVEMENT_COMMENT_ADDED';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'MOVEMENT_COMMENT_DELETED';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'MOVEMENT_DISPUTED';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'MOVEMENT_DISPUTE_RESOLVED';
@@ -0,0 +1,4 @@
+-- Note: audit_action enum values cannot be removed in PostgreSQL; they are left in place.
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TYPE IF EXISTS webhook_delivery_status;
+DROP TABLE IF EXISTS webhook_endpoints;
@@ -0,0 +1,50 @@
+-- Household-configured webhook endpoints
+CREATE TABLE webhook_endpoints (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    household_id UUID NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    description VARCHAR(255),
+    -- Secret used to sign payloads (HMAC-SHA256); must be readable to sign, so not hashed
+    secret VARCHAR(100) NOT NULL,
+    -- Subscribed event types; empty means all events
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    -- Deliveries that exhausted their retries in a row; reset on success
+    consecutive_failures INT NOT NULL DEFAULT 0,
+    disabled_at TIMESTAMPTZ,
+    created_by_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_webhook_endpoints_household ON webhook_endpoints(household_id);
+
+CREATE TYPE webhook_delivery_status AS ENUM ('PENDING', 'SUCCEEDED', 'FAILED');
+
+-- One row per (endpoint, event) delivery, including its retry state
+CREATE TABLE webhook_deliveries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    endpoint_id UUID NOT NULL REFERENCES webhook_endpoints(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    -- Optional key to send an event at most once per endpoint (e.g. budget exceeded once per month)
+    dedup_key VARCHAR(255),
+    status webhook_delivery_status NOT NULL DEFAULT 'PENDING',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    response_status INT,
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_webhook_deliveries_endpoint ON webhook_deliveries(endpoint_id, created_at DESC);
+CREATE INDEX idx_webhook_deliveries_pending ON webhook_deliveries(next_attempt_at) WHERE status = 'PENDING';
+CREATE UNIQUE INDEX idx_webhook_deliveries_dedup ON webhook_deliveries(endpoint_id, dedup_key) WHERE dedup_key IS NOT NULL;
+
+-- Audit actions
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'WEBHOOK_CREATED';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'WEBHOOK_UPDATED';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'WEBHOOK_DELETED';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'WEBHOOK_DISABLED';
@@ -0,0 +1,2 @@
+-- Note: audit_action enum values cannot be removed in PostgreSQL; they are left in place.
+DROP TABLE IF EXISTS personal_access_tokens;
@@ -0,0 +1,23 @@
+-- Personal access tokens for scripting the API (sent as "Authorization: Bearer <token>")
+CREATE TABLE personal_access_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    -- SHA-256 of the token; the plain token is only shown once, on creation
+    token_hash VARCHAR(255) NOT NULL UNIQUE,
+    -- First characters of the token so users can tell their tokens apart
+    token_prefix VARCHAR(20) NOT NULL,
+    scope VARCHAR(20) NOT NULL CHECK (scope IN ('read', 'read_write')),
+    -- Empty means every resource type
+    resource_types TEXT[] NOT NULL DEFAULT '{}',
+    expires_at TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_personal_access_tokens_user ON personal_access_tokens(user_id, created_at DESC);
+
+-- Audit actions
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'ACCESS_TOKEN_CREATED';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'ACCESS_TOKEN_REVOKED';
@@ -0,0 +1,4 @@
+-- Note: audit_action enum values cannot be removed in PostgreSQL; they are left in place.
+DROP TABLE IF EXISTS two_factor_challenges;
+DROP TABLE IF EXISTS two_factor_recovery_codes;
+DROP TABLE IF EXISTS user_two_factor;
@@ -0,0 +1,40 @@
+-- TOTP two-factor authentication. A row with enabled_at NULL is an enrolment
+-- that has not been verified yet.
+CREATE TABLE user_two_factor (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret VARCHAR(64) NOT NULL,
+    enabled_at TIMESTAMPTZ,
+    -- Last accepted 30-second time step, so a code cannot be replayed
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- One-time recovery codes, stored hashed
+CREATE TABLE two_factor_recovery_codes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(255) NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_two_factor_recovery_codes_user ON two_factor_recovery_codes(user_id);
+CREATE UNIQUE INDEX idx_two_factor_recovery_codes_hash ON two_factor_recovery_codes(user_id, code_hash);
+
+-- Pending logins: the password was correct and the second factor is still missing
+CREATE TABLE two_factor_challenges (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    attempts INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_two_factor_challenges_expires ON two_factor_challenges(expires_at);
+
+-- Audit actions
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'AUTH_2FA_ENABLED';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'AUTH_2FA_DISABLED';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'AUTH_2FA_RECOVERY_CODES_REGENERATED';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'AUTH_2FA_RECOVERY_CODE_USED';
@@ -0,0 +1,6 @@
+-- Note: audit_action enum values cannot be removed in PostgreSQL; they are left in place.
+DROP TABLE IF EXISTS user_devices;
+DROP INDEX IF EXISTS idx_sessions_user_last_seen;
+ALTER TABLE sessions DROP COLUMN IF EXISTS last_seen_at;
+ALTER TABLE sessions DROP COLUMN IF EXISTS ip_address;
+ALTER TABLE sessions DROP COLUMN IF EXISTS user_agent;
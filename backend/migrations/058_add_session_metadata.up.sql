@@ -0,0 +1,22 @@
+-- Device details so users can see where they are logged in
+ALTER TABLE sessions ADD COLUMN user_agent TEXT;
+ALTER TABLE sessions ADD COLUMN ip_address TEXT;
+ALTER TABLE sessions ADD COLUMN last_seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+CREATE INDEX IF NOT EXISTS idx_sessions_user_last_seen ON sessions(user_id, last_seen_at DESC);
+
+-- Devices a user has logged in from, kept after sessions end so a login from a
+-- new device can be detected. The fingerprint is a hash of the user agent.
+CREATE TABLE user_devices (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    fingerprint VARCHAR(255) NOT NULL,
+    user_agent TEXT,
+    last_ip_address TEXT,
+    first_seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, fingerprint)
+);
+
+-- Audit actions
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'AUTH_SESSION_REVOKED';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'AUTH_NEW_DEVICE_LOGIN';
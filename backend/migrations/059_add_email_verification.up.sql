@@ -0,0 +1,23 @@
+-- Email verification. Accounts created before this migration were already
+-- trusted by invitations and contact linking, so they are treated as verified.
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMPTZ;
+UPDATE users SET email_verified_at = created_at;
+
+-- Single-use tokens sent by email: address verification and magic-link sign-in
+CREATE TABLE email_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    purpose VARCHAR(20) NOT NULL CHECK (purpose IN ('VERIFY_EMAIL', 'MAGIC_LINK')),
+    token_hash VARCHAR(255) NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_email_tokens_user ON email_tokens(user_id, purpose);
+CREATE INDEX idx_email_tokens_expires ON email_tokens(expires_at);
+
+-- Audit actions
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'AUTH_EMAIL_VERIFICATION_REQUEST';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'AUTH_EMAIL_VERIFIED';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'AUTH_MAGIC_LINK_REQUEST';
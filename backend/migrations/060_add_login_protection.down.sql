@@ -0,0 +1,8 @@
+-- Note: audit_action enum values cannot be removed in PostgreSQL; they are left in place.
+DELETE FROM email_tokens WHERE purpose = 'UNLOCK_ACCOUNT';
+ALTER TABLE email_tokens DROP CONSTRAINT IF EXISTS email_tokens_purpose_check;
+ALTER TABLE email_tokens ADD CONSTRAINT email_tokens_purpose_check
+    CHECK (purpose IN ('VERIFY_EMAIL', 'MAGIC_LINK'));
+
+DROP TABLE IF EXISTS login_failures;
+DROP TABLE IF EXISTS rate_limit_counters;
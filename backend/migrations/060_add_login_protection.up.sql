@@ -0,0 +1,28 @@
+-- Fixed-window rate limit counters shared by every API replica
+CREATE TABLE rate_limit_counters (
+    limiter VARCHAR(50) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    count INT NOT NULL DEFAULT 0,
+    window_expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (limiter, key)
+);
+
+CREATE INDEX idx_rate_limit_counters_expires ON rate_limit_counters(window_expires_at);
+
+-- Failed password logins per account, for progressive delays and lockout
+CREATE TABLE login_failures (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    failed_count INT NOT NULL DEFAULT 0,
+    first_failed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_failed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    locked_until TIMESTAMPTZ
+);
+
+-- Unlock links are emailed tokens too
+ALTER TABLE email_tokens DROP CONSTRAINT IF EXISTS email_tokens_purpose_check;
+ALTER TABLE email_tokens ADD CONSTRAINT email_tokens_purpose_check
+    CHECK (purpose IN ('VERIFY_EMAIL', 'MAGIC_LINK', 'UNLOCK_ACCOUNT'));
+
+-- Audit actions
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'AUTH_ACCOUNT_LOCKED';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'AUTH_ACCOUNT_UNLOCKED';
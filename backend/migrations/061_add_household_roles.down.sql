@@ -0,0 +1,3 @@
+-- Note: household_role enum values cannot be removed in PostgreSQL; they are left in place.
+-- Demote the new roles to plain members so the application keeps working.
+UPDATE household_members SET role = 'member' WHERE role IN ('bookkeeper', 'viewer');
@@ -0,0 +1,3 @@
+-- Read-only viewers and bookkeepers who can only record movements
+ALTER TYPE household_role ADD VALUE IF NOT EXISTS 'bookkeeper';
+ALTER TYPE household_role ADD VALUE IF NOT EXISTS 'viewer';
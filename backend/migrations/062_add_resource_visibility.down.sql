@@ -0,0 +1,5 @@
+ALTER TABLE movements DROP CONSTRAINT IF EXISTS movements_private_household_check;
+ALTER TABLE movements DROP COLUMN IF EXISTS is_shared_with_household;
+ALTER TABLE income DROP COLUMN IF EXISTS is_shared_with_household;
+ALTER TABLE pockets DROP COLUMN IF EXISTS is_shared_with_household;
+ALTER TABLE accounts DROP COLUMN IF EXISTS is_shared_with_household;
@@ -0,0 +1,11 @@
+-- Per-resource visibility: items are shared with the household by default and
+-- can be made private to their owner. Other members only see them in aggregates.
+ALTER TABLE accounts ADD COLUMN is_shared_with_household BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE pockets ADD COLUMN is_shared_with_household BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE income ADD COLUMN is_shared_with_household BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE movements ADD COLUMN is_shared_with_household BOOLEAN NOT NULL DEFAULT TRUE;
+
+-- Only HOUSEHOLD movements paid by a member have an owner who can keep them private
+ALTER TABLE movements
+ADD CONSTRAINT movements_private_household_check
+CHECK (is_shared_with_household OR (type = 'HOUSEHOLD' AND payer_user_id IS NOT NULL));
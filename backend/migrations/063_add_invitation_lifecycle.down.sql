@@ -0,0 +1,16 @@
+-- Note: audit_action enum values cannot be removed in PostgreSQL; they are left in place.
+DROP INDEX IF EXISTS idx_household_invitations_open_email;
+
+-- Keep only the newest invitation per email so the old constraint can return
+DELETE FROM household_invitations i
+USING household_invitations newer
+WHERE i.household_id = newer.household_id
+  AND i.email = newer.email
+  AND i.created_at < newer.created_at;
+
+ALTER TABLE household_invitations ADD CONSTRAINT household_invitations_household_id_email_key
+    UNIQUE (household_id, email);
+
+ALTER TABLE household_invitations DROP COLUMN IF EXISTS send_count;
+ALTER TABLE household_invitations DROP COLUMN IF EXISTS last_sent_at;
+ALTER TABLE household_invitations DROP COLUMN IF EXISTS revoked_at;
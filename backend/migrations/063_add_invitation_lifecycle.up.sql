@@ -0,0 +1,22 @@
+-- Invitation lifecycle: expiry, resend and revoke
+ALTER TABLE household_invitations ADD COLUMN revoked_at TIMESTAMPTZ;
+ALTER TABLE household_invitations ADD COLUMN last_sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+ALTER TABLE household_invitations ADD COLUMN send_count INT NOT NULL DEFAULT 1;
+
+UPDATE household_invitations SET last_sent_at = created_at;
+
+-- Invitations created before expiry existed get the default 7 days from now
+UPDATE household_invitations
+SET expires_at = NOW() + INTERVAL '7 days'
+WHERE expires_at IS NULL AND accepted_at IS NULL;
+
+-- Only one open invitation per email per household; accepted and revoked
+-- ones no longer block a new invitation
+ALTER TABLE household_invitations DROP CONSTRAINT IF EXISTS household_invitations_household_id_email_key;
+CREATE UNIQUE INDEX idx_household_invitations_open_email
+    ON household_invitations(household_id, email)
+    WHERE accepted_at IS NULL AND revoked_at IS NULL;
+
+-- Audit actions
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'HOUSEHOLD_INVITATION_RESENT';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'HOUSEHOLD_INVITATION_REVOKED';
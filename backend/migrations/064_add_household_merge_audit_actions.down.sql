@@ -0,0 +1,2 @@
+-- Note: audit_action enum values cannot be removed in PostgreSQL; they are left in place.
+SELECT 1;
@@ -0,0 +1,3 @@
+-- Audit actions for household merges and ownership transfers
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'HOUSEHOLD_MERGED';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'HOUSEHOLD_OWNERSHIP_TRANSFERRED';
@@ -0,0 +1,2 @@
+-- Audit action for folding a duplicate contact into another
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'CONTACT_MERGED';
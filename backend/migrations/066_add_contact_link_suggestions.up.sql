@@ -0,0 +1,17 @@
+-- Link suggestions: when a user verifies an email that other households keep
+-- on a contact, the user is offered a link to that contact. A suggestion lives
+-- here, apart from the contact, so the other household's contact is untouched
+-- until the user accepts it. Phone numbers are never matched: they are not
+-- verified, so anyone could claim a contact by entering someone else's number.
+CREATE TABLE contact_link_suggestions (
+    contact_id UUID NOT NULL REFERENCES contacts(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(10) NOT NULL DEFAULT 'PENDING'
+        CHECK (status IN ('PENDING', 'ACCEPTED', 'REJECTED')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    responded_at TIMESTAMPTZ,
+
+    PRIMARY KEY (contact_id, user_id)
+);
+
+CREATE INDEX idx_contact_link_suggestions_user ON contact_link_suggestions(user_id, status);
@@ -0,0 +1,12 @@
+-- Per-category budget settings. A category without a row uses the defaults.
+CREATE TABLE category_budget_settings (
+    category_id UUID PRIMARY KEY REFERENCES categories(id) ON DELETE CASCADE,
+    -- Rollover carries each month's remainder (positive or negative) into the
+    -- next month's effective budget, starting at rollover_since
+    rollover BOOLEAN NOT NULL DEFAULT FALSE,
+    rollover_since DATE, -- First day of month the remainder chain starts at
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CHECK (NOT rollover OR rollover_since IS NOT NULL)
+);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS budget_alerts;
+DROP TABLE IF EXISTS budget_alert_mutes;
+ALTER TABLE category_budget_settings DROP COLUMN IF EXISTS alert_thresholds;
+DROP TABLE IF EXISTS household_budget_settings;
@@ -0,0 +1,36 @@
+-- Budget alert thresholds: percentages of a category's budget that notify the
+-- household when spending crosses them. Categories without their own
+-- thresholds use the household default.
+CREATE TABLE household_budget_settings (
+    household_id UUID PRIMARY KEY REFERENCES households(id) ON DELETE CASCADE,
+    alert_thresholds INT[] NOT NULL DEFAULT '{80,100}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- NULL means the household default
+ALTER TABLE category_budget_settings ADD COLUMN alert_thresholds INT[];
+
+-- Members who do not want alerts for a category
+CREATE TABLE budget_alert_mutes (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    category_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, category_id)
+);
+
+-- Every alert sent; the unique key makes each crossing fire once per month
+CREATE TABLE budget_alerts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    category_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    month DATE NOT NULL, -- First day of month (YYYY-MM-01)
+    threshold INT NOT NULL,
+    budget NUMERIC(15, 2) NOT NULL,
+    spent NUMERIC(15, 2) NOT NULL,
+    recipient_count INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    UNIQUE (category_id, month, threshold)
+);
+
+CREATE INDEX idx_budget_alerts_month ON budget_alerts(month);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS period_budgets;
+DROP TYPE IF EXISTS budget_period;
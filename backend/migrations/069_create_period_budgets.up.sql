@@ -0,0 +1,28 @@
+-- Budgets that span more than a month: YEARLY budgets cover a calendar year and,
+-- like monthly_budgets, are inherited by later years until a newer record
+-- exists; CUSTOM budgets cover an explicit range of months.
+CREATE TYPE budget_period AS ENUM ('YEARLY', 'CUSTOM');
+
+CREATE TABLE period_budgets (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    household_id UUID NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+    category_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    period budget_period NOT NULL,
+    start_month DATE NOT NULL, -- First day of the first month (January for YEARLY)
+    end_month DATE NOT NULL,   -- First day of the last month, inclusive
+    amount NUMERIC(15, 2) NOT NULL CHECK (amount >= 0),
+    currency CHAR(3) NOT NULL DEFAULT 'COP',
+
+    -- Sinking fund: money is set aside every month to pay the amount by end_month.
+    -- Savings are read from the linked pocket when there is one.
+    sinking_fund BOOLEAN NOT NULL DEFAULT FALSE,
+    pocket_id UUID REFERENCES pockets(id) ON DELETE SET NULL,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CHECK (end_month >= start_month),
+    UNIQUE (household_id, category_id, period, start_month)
+);
+
+CREATE INDEX idx_period_budgets_household ON period_budgets(household_id);
@@ -0,0 +1,17 @@
+-- Zero-based budgeting: history of amounts moved from one category's budget to
+-- another's within a month
+CREATE TABLE budget_reassignments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    household_id UUID NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+    month DATE NOT NULL, -- First day of month (YYYY-MM-01)
+    from_category_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    to_category_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    amount NUMERIC(15, 2) NOT NULL CHECK (amount > 0),
+    note VARCHAR(255),
+    created_by_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CHECK (from_category_id <> to_category_id)
+);
+
+CREATE INDEX idx_budget_reassignments_household_month ON budget_reassignments(household_id, month);
@@ -0,0 +1,4 @@
+ALTER TABLE category_budget_settings
+    DROP COLUMN IF EXISTS projection_months,
+    DROP COLUMN IF EXISTS projection_method;
+DROP TYPE IF EXISTS budget_projection_method;
@@ -0,0 +1,9 @@
+-- Month-end spending projection. PACE adds to what was spent the pending
+-- recurring templates and budget items plus an estimate of the rest of the
+-- month's discretionary spending from the last projection_months months;
+-- PLANNED adds only the pending templates and items; NONE disables it.
+CREATE TYPE budget_projection_method AS ENUM ('PACE', 'PLANNED', 'NONE');
+
+ALTER TABLE category_budget_settings
+    ADD COLUMN projection_method budget_projection_method NOT NULL DEFAULT 'PACE',
+    ADD COLUMN projection_months INT NOT NULL DEFAULT 3 CHECK (projection_months BETWEEN 1 AND 12);
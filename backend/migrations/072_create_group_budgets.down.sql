@@ -0,0 +1 @@
+DROP TABLE IF EXISTS monthly_group_budgets;
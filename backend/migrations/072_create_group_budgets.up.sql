@@ -0,0 +1,19 @@
+-- Monthly budgets for a whole category group. Like monthly_budgets, a month
+-- without a record inherits the latest earlier one. Spent is the sum over the
+-- group's categories, and category budgets in the group are carved out of the
+-- group's amount rather than added to it.
+CREATE TABLE monthly_group_budgets (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    household_id UUID NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+    category_group_id UUID NOT NULL REFERENCES category_groups(id) ON DELETE CASCADE,
+    month DATE NOT NULL, -- First day of month (YYYY-MM-01)
+    amount DECIMAL(15, 2) NOT NULL CHECK (amount >= 0),
+    currency CHAR(3) NOT NULL DEFAULT 'COP',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    UNIQUE(household_id, category_group_id, month)
+);
+
+CREATE INDEX idx_monthly_group_budgets_household_month ON monthly_group_budgets(household_id, month);
+CREATE INDEX idx_monthly_group_budgets_group_month ON monthly_group_budgets(category_group_id, month);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS member_budget_categories;
+DROP TABLE IF EXISTS member_budgets;
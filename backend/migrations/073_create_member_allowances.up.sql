@@ -0,0 +1,28 @@
+-- Personal spending allowances inside the household budget. Like
+-- monthly_budgets, a month without a record inherits the latest earlier one.
+-- Spent is the member's HOUSEHOLD movements: those they paid plus any in the
+-- categories they own.
+CREATE TABLE member_budgets (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    household_id UUID NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    month DATE NOT NULL, -- First day of month (YYYY-MM-01)
+    amount DECIMAL(15, 2) NOT NULL CHECK (amount >= 0),
+    currency CHAR(3) NOT NULL DEFAULT 'COP',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    UNIQUE(household_id, user_id, month)
+);
+
+CREATE INDEX idx_member_budgets_household_month ON member_budgets(household_id, month);
+
+-- Categories owned by a member; their spending counts against that member's allowance
+CREATE TABLE member_budget_categories (
+    category_id UUID PRIMARY KEY REFERENCES categories(id) ON DELETE CASCADE,
+    household_id UUID NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_member_budget_categories_user ON member_budget_categories(household_id, user_id);
@@ -0,0 +1,2 @@
+-- Dropping the function drops the triggers that use it
+DROP FUNCTION IF EXISTS notify_household_change() CASCADE;
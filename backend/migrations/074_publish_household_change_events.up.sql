@@ -0,0 +1,86 @@
+-- Household change events for the real-time stream are published by the
+-- database on every write, whichever code path makes it. Each statement sends
+-- one notification per household it touched on the channel the API listens to
+-- (see internal/events). The notification carries the row's ID when the
+-- statement changed a single row, and none for bulk changes.
+--
+-- Trigger arguments: the event resource type, and the column that identifies
+-- the resource (empty for settings that are reported without an ID). Rows
+-- without household_id are attributed to the household of their category.
+CREATE FUNCTION notify_household_change() RETURNS trigger
+LANGUAGE plpgsql AS $$
+DECLARE
+    resource_type TEXT := TG_ARGV[0];
+    id_column TEXT := TG_ARGV[1];
+    action TEXT := CASE TG_OP WHEN 'INSERT' THEN 'created' WHEN 'UPDATE' THEN 'updated' ELSE 'deleted' END;
+    changed_rows JSONB;
+    changed RECORD;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        SELECT jsonb_agg(to_jsonb(r)) INTO changed_rows FROM old_rows r;
+    ELSE
+        SELECT jsonb_agg(to_jsonb(r)) INTO changed_rows FROM new_rows r;
+    END IF;
+
+    FOR changed IN
+        SELECT household_id, CASE WHEN COUNT(*) = 1 THEN MIN(resource_id) END AS resource_id
+        FROM (
+            SELECT
+                COALESCE((e.data ->> 'household_id')::uuid, c.household_id) AS household_id,
+                NULLIF(e.data ->> id_column, '') AS resource_id
+            FROM jsonb_array_elements(changed_rows) AS e(data)
+            LEFT JOIN categories c ON c.id = (e.data ->> 'category_id')::uuid
+        ) changes
+        WHERE household_id IS NOT NULL
+        GROUP BY household_id
+    LOOP
+        PERFORM pg_notify('household_events', json_build_object(
+            'household_id', changed.household_id,
+            'resource_type', resource_type,
+            'resource_id', COALESCE(changed.resource_id, ''),
+            'action', action,
+            'occurred_at', NOW()
+        )::text);
+    END LOOP;
+
+    RETURN NULL;
+END;
+$$;
+
+-- Transition tables need one trigger per operation
+DO $$
+DECLARE
+    t RECORD;
+BEGIN
+    FOR t IN
+        SELECT * FROM (VALUES
+            ('movements', 'movement', 'id'),
+            ('income', 'income', 'id'),
+            ('recurring_movement_templates', 'template', 'id'),
+            ('pockets', 'pocket', 'id'),
+            ('pocket_transactions', 'pocket', 'pocket_id'),
+            ('monthly_budgets', 'budget', 'id'),
+            ('monthly_budget_items', 'budget', 'id'),
+            ('monthly_group_budgets', 'budget', 'id'),
+            ('period_budgets', 'budget', 'id'),
+            ('member_budgets', 'budget', 'id'),
+            ('member_budget_categories', 'budget', ''),
+            ('category_budget_settings', 'budget', ''),
+            ('household_budget_settings', 'budget', '')
+        ) AS v(table_name, resource_type, id_column)
+    LOOP
+        EXECUTE format(
+            'CREATE TRIGGER %I AFTER INSERT ON %I REFERENCING NEW TABLE AS new_rows
+             FOR EACH STATEMENT EXECUTE FUNCTION notify_household_change(%L, %L)',
+            t.table_name || '_notify_insert', t.table_name, t.resource_type, t.id_column);
+        EXECUTE format(
+            'CREATE TRIGGER %I AFTER UPDATE ON %I REFERENCING NEW TABLE AS new_rows
+             FOR EACH STATEMENT EXECUTE FUNCTION notify_household_change(%L, %L)',
+            t.table_name || '_notify_update', t.table_name, t.resource_type, t.id_column);
+        EXECUTE format(
+            'CREATE TRIGGER %I AFTER DELETE ON %I REFERENCING OLD TABLE AS old_rows
+             FOR EACH STATEMENT EXECUTE FUNCTION notify_household_change(%L, %L)',
+            t.table_name || '_notify_delete', t.table_name, t.resource_type, t.id_column);
+    END LOOP;
+END;
+$$;
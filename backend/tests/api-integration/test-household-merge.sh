@@ -0,0 +1,181 @@
+#!/bin/bash
+# Household Merge API Integration Tests
+# Tests that merging a household folds duplicate categories into the target
+# without losing their yearly/custom (period) budgets
+
+set -e
+set -o pipefail
+
+BASE_URL="${API_BASE_URL:-http://localhost:8080}"
+COOKIES_FILE="/tmp/gastos-merge-cookies.txt"
+EMAIL="test+merge$(date +%s%N)@test.com"
+PASSWORD="Test1234!"
+DEBUG="${DEBUG:-false}"
+
+CURL_FLAGS="-s"
+if [ "$DEBUG" = "true" ]; then
+  CURL_FLAGS="-v"
+fi
+
+GREEN='\033[0;32m'
+RED='\033[0;31m'
+YELLOW='\033[1;33m'
+BLUE='\033[0;34m'
+CYAN='\033[0;36m'
+NC='\033[0m'
+
+echo -e "${YELLOW}"
+echo "╔═══════════════════════════════════════════════════════════╗"
+echo "║  🧪 Household Merge Integration Tests                     ║"
+echo "╚═══════════════════════════════════════════════════════════╝"
+echo -e "${NC}\n"
+
+rm -f $COOKIES_FILE
+
+error_handler() {
+  local line=$1
+  echo -e "\n${RED}╔═══════════════════════════════════════════════════════════╗${NC}"
+  echo -e "${RED}║  ✗ TEST FAILED at line $line${NC}"
+  echo -e "${RED}╚═══════════════════════════════════════════════════════════╝${NC}"
+  if [ -n "$LAST_RESPONSE" ]; then
+    echo -e "${YELLOW}Last API Response:${NC}"
+    echo "$LAST_RESPONSE" | jq '.' 2>/dev/null || echo "$LAST_RESPONSE"
+  fi
+  exit 1
+}
+
+trap 'error_handler $LINENO' ERR
+
+api_call() {
+  LAST_RESPONSE=$(curl "$@")
+  echo "$LAST_RESPONSE"
+}
+
+run_test() {
+  echo -e "${CYAN}▶ $1${NC}"
+}
+
+# ═══════════════════════════════════════════════════════════
+# SETUP
+# ═══════════════════════════════════════════════════════════
+
+run_test "Health Check"
+HEALTH=$(api_call $CURL_FLAGS $BASE_URL/health)
+echo "$HEALTH" | jq -e '.status == "healthy"' > /dev/null
+echo -e "${GREEN}✓ Server is healthy${NC}\n"
+
+run_test "Register User"
+api_call $CURL_FLAGS -X POST $BASE_URL/auth/register \
+  -H "Content-Type: application/json" \
+  -d "{\"email\":\"$EMAIL\",\"name\":\"Merge Test User\",\"password\":\"$PASSWORD\",\"password_confirm\":\"$PASSWORD\"}" \
+  -c $COOKIES_FILE > /dev/null
+echo -e "${GREEN}✓ User registered${NC}\n"
+
+run_test "Create Target Household"
+TARGET_RESPONSE=$(api_call $CURL_FLAGS -X POST $BASE_URL/households \
+  -H "Content-Type: application/json" \
+  -b $COOKIES_FILE \
+  -d '{"name":"Merge Target"}')
+TARGET_ID=$(echo "$TARGET_RESPONSE" | jq -r '.id')
+echo -e "${GREEN}✓ Target household created (ID: $TARGET_ID)${NC}\n"
+
+run_test "Create Source Household"
+SOURCE_RESPONSE=$(api_call $CURL_FLAGS -X POST $BASE_URL/households \
+  -H "Content-Type: application/json" \
+  -b $COOKIES_FILE \
+  -d '{"name":"Merge Source"}')
+SOURCE_ID=$(echo "$SOURCE_RESPONSE" | jq -r '.id')
+echo -e "${GREEN}✓ Source household created (ID: $SOURCE_ID)${NC}\n"
+
+# Same group and category name in both households, so the source category is
+# a duplicate folded into the target's
+for SIDE in TARGET SOURCE; do
+  HH_VAR="${SIDE}_ID"
+  HH_ID="${!HH_VAR}"
+
+  run_test "Create Category Group in $SIDE"
+  GROUP_RESPONSE=$(api_call $CURL_FLAGS -X POST "$BASE_URL/category-groups" \
+    -H "Content-Type: application/json" \
+    -H "X-Household-ID: $HH_ID" \
+    -b $COOKIES_FILE \
+    -d '{"name":"Merge Test Group","icon":"🧪"}')
+  GROUP_ID=$(echo "$GROUP_RESPONSE" | jq -r '.id')
+  [ "$GROUP_ID" != "null" ]
+
+  run_test "Create Category in $SIDE"
+  CAT_RESPONSE=$(api_call $CURL_FLAGS -X POST $BASE_URL/categories \
+    -H "Content-Type: application/json" \
+    -H "X-Household-ID: $HH_ID" \
+    -b $COOKIES_FILE \
+    -d "{\"name\":\"Seguro Anual\",\"category_group_id\":\"$GROUP_ID\"}")
+  CAT_ID=$(echo "$CAT_RESPONSE" | jq -r '.id')
+  [ "$CAT_ID" != "null" ]
+  printf -v "${SIDE}_CAT_ID" '%s' "$CAT_ID"
+  echo -e "${GREEN}✓ Category created in $SIDE (ID: $CAT_ID)${NC}\n"
+done
+
+# ═══════════════════════════════════════════════════════════
+# PERIOD BUDGETS ON DUPLICATE CATEGORIES
+# ═══════════════════════════════════════════════════════════
+
+echo -e "${BLUE}═══════════════════════════════════════════════════════════${NC}"
+echo -e "${BLUE}Period budgets survive a category merge${NC}"
+echo -e "${BLUE}═══════════════════════════════════════════════════════════${NC}\n"
+
+run_test "Set yearly budget on the source category"
+api_call $CURL_FLAGS -X PUT "$BASE_URL/budgets/periods" \
+  -H "Content-Type: application/json" \
+  -H "X-Household-ID: $SOURCE_ID" \
+  -b $COOKIES_FILE \
+  -d "{\"category_id\":\"$SOURCE_CAT_ID\",\"period\":\"YEARLY\",\"start_month\":\"2025-01\",\"amount\":1200000,\"scope\":\"THIS\"}" \
+  | jq -e '.id != null' > /dev/null
+echo -e "${GREEN}✓ Source yearly budget: 1,200,000${NC}\n"
+
+run_test "Set custom budget on the source category"
+api_call $CURL_FLAGS -X PUT "$BASE_URL/budgets/periods" \
+  -H "Content-Type: application/json" \
+  -H "X-Household-ID: $SOURCE_ID" \
+  -b $COOKIES_FILE \
+  -d "{\"category_id\":\"$SOURCE_CAT_ID\",\"period\":\"CUSTOM\",\"start_month\":\"2025-03\",\"end_month\":\"2025-08\",\"amount\":300000}" \
+  | jq -e '.id != null' > /dev/null
+echo -e "${GREEN}✓ Source custom budget: 300,000${NC}\n"
+
+run_test "Set yearly budget on the target category for the same year"
+api_call $CURL_FLAGS -X PUT "$BASE_URL/budgets/periods" \
+  -H "Content-Type: application/json" \
+  -H "X-Household-ID: $TARGET_ID" \
+  -b $COOKIES_FILE \
+  -d "{\"category_id\":\"$TARGET_CAT_ID\",\"period\":\"YEARLY\",\"start_month\":\"2025-01\",\"amount\":800000,\"scope\":\"THIS\"}" \
+  | jq -e '.id != null' > /dev/null
+echo -e "${GREEN}✓ Target yearly budget: 800,000${NC}\n"
+
+run_test "Merge source into target"
+MERGE_RESPONSE=$(api_call $CURL_FLAGS -X POST "$BASE_URL/households/$TARGET_ID/merge" \
+  -H "Content-Type: application/json" \
+  -b $COOKIES_FILE \
+  -d "{\"source_household_id\":\"$SOURCE_ID\",\"dry_run\":false}")
+echo "$MERGE_RESPONSE" | jq -e --arg s "$SOURCE_CAT_ID" --arg t "$TARGET_CAT_ID" \
+  '.remapped | any(.kind == "category" and .source_id == $s and .target_id == $t)' > /dev/null
+echo "$MERGE_RESPONSE" | jq -e '.counts.budgets_combined >= 1 and .counts.period_budgets >= 1' > /dev/null
+echo -e "${GREEN}✓ Duplicate category folded into the target${NC}\n"
+
+run_test "Merge report counts the moved history"
+echo "$MERGE_RESPONSE" | jq -e '(.counts.audit_logs | type) == "number" and (.counts.notifications | type) == "number"' > /dev/null
+echo -e "${GREEN}✓ Notifications and audit logs moved to the target${NC}\n"
+
+run_test "Target keeps both period budgets on its category"
+PERIODS=$(api_call $CURL_FLAGS -X GET "$BASE_URL/budgets/periods" \
+  -H "X-Household-ID: $TARGET_ID" \
+  -b $COOKIES_FILE)
+echo "$PERIODS" | jq -e --arg c "$TARGET_CAT_ID" \
+  '[.period_budgets[] | select(.category_id == $c and .period == "YEARLY")] | length == 1 and .[0].amount == 2000000' > /dev/null
+echo -e "${GREEN}✓ Yearly budgets combined: 2,000,000${NC}"
+echo "$PERIODS" | jq -e --arg c "$TARGET_CAT_ID" \
+  '[.period_budgets[] | select(.category_id == $c and .period == "CUSTOM")] | length == 1 and .[0].amount == 300000' > /dev/null
+echo -e "${GREEN}✓ Custom budget moved to the target category${NC}\n"
+
+echo -e "${GREEN}"
+echo "╔═══════════════════════════════════════════════════════════╗"
+echo "║  ✓ ALL HOUSEHOLD MERGE TESTS PASSED                       ║"
+echo "╚═══════════════════════════════════════════════════════════╝"
+echo -e "${NC}"
@@ -20,6 +20,9 @@ async function loadPage(name) {
       case 'login': pageCache[name] = await import('./pages/login.js'); break;
       case 'forgot-password': pageCache[name] = await import('./pages/forgot-password.js'); break;
       case 'reset-password': pageCache[name] = await import('./pages/reset-password.js'); break;
+      case 'verify-email': pageCache[name] = await import('./pages/verify-email.js'); break;
+      case 'magic-login': pageCache[name] = await import('./pages/magic-login.js'); break;
+      case 'unlock-account': pageCache[name] = await import('./pages/unlock-account.js'); break;
       case 'home': pageCache[name] = await import('./pages/home.js'); break;
       case 'registrar-movimiento': pageCache[name] = await import('./pages/registrar-movimiento.js'); break;
       case 'profile': pageCache[name] = await import('./pages/profile.js'); break;
@@ -65,6 +68,33 @@ function initRouter() {
     if (loadingEl) loadingEl.style.display = 'none';
   });
 
+  router.route('/verify-email', async () => {
+    const VerifyEmailPage = await loadPage('verify-email');
+    const appEl = document.getElementById('app');
+    appEl.innerHTML = VerifyEmailPage.render();
+    VerifyEmailPage.init();
+    const loadingEl = document.getElementById('loading');
+    if (loadingEl) loadingEl.style.display = 'none';
+  });
+
+  router.route('/magic-login', async () => {
+    const MagicLoginPage = await loadPage('magic-login');
+    const appEl = document.getElementById('app');
+    appEl.innerHTML = MagicLoginPage.render();
+    MagicLoginPage.init();
+    const loadingEl = document.getElementById('loading');
+    if (loadingEl) loadingEl.style.display = 'none';
+  });
+
+  router.route('/unlock-account', async () => {
+    const UnlockAccountPage = await loadPage('unlock-account');
+    const appEl = document.getElementById('app');
+    appEl.innerHTML = UnlockAccountPage.render();
+    UnlockAccountPage.init();
+    const loadingEl = document.getElementById('loading');
+    if (loadingEl) loadingEl.style.display = 'none';
+  });
+
   router.route('/', async () => {
     // Start loading home.js in parallel with auth check
     const [{ authenticated, user }, HomePage] = await Promise.all([
@@ -245,7 +275,7 @@ function initRouter() {
   // Auth guard - check before every route
   router.beforeEach(async (to) => {
     // Public routes that don't require authentication
-    const publicRoutes = ['/login', '/forgot-password', '/reset-password', '/invite'];
+    const publicRoutes = ['/login', '/forgot-password', '/reset-password', '/verify-email', '/magic-login', '/unlock-account', '/invite'];
     const isPublicRoute = publicRoutes.includes(to) || to.startsWith('/invite');
 
     // Check authentication status
@@ -253,7 +283,7 @@ function initRouter() {
 
     // If authenticated and trying to access login-type routes, redirect to main page
     // But NOT for /invite - authenticated users can still view invites
-    const authOnlyPublicRoutes = ['/login', '/forgot-password', '/reset-password'];
+    const authOnlyPublicRoutes = ['/login', '/forgot-password', '/reset-password', '/magic-login'];
     if (authenticated && authOnlyPublicRoutes.includes(to)) {
       router.navigate('/');
       return false;
@@ -57,6 +57,34 @@ export function clearAuthCache() {
   authCacheTime = 0;
 }
 
+/**
+ * Second login step for accounts with two-factor authentication.
+ * Accepts a code from the authenticator app or a recovery code.
+ */
+async function completeTwoFactorLogin(challengeId) {
+  const code = window.prompt("Ingresa el código de tu app de autenticación o un código de recuperación");
+  if (!code) {
+    return { success: false, error: "Se requiere el código de verificación en dos pasos" };
+  }
+
+  const response = await fetch(`${API_URL}/auth/login/2fa`, {
+    method: "POST",
+    credentials: "include",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    body: JSON.stringify({ challenge_id: challengeId, code: code.trim() }),
+  });
+
+  const data = await response.json();
+
+  if (response.ok) {
+    clearAuthCache();
+    return { success: true, user: data };
+  }
+  return { success: false, error: data.error || "Código de verificación inválido" };
+}
+
 /**
  * Login user with email and password
  * @param {string} email 
@@ -86,7 +114,9 @@ export async function login(email, password) {
 
     const data = await response.json();
 
-    if (response.ok) {
+    if (response.ok && data.two_factor_required) {
+      return await completeTwoFactorLogin(data.challenge_id);
+    } else if (response.ok) {
       clearAuthCache(); // Clear cache so next checkAuth() fetches fresh user data
       return { success: true, user: data };
     } else {
@@ -98,6 +128,37 @@ export async function login(email, password) {
   }
 }
 
+/**
+ * Login with a one-time link sent by email
+ * @param {string} token - Token from the link
+ * @returns {Promise<{success: boolean, user?: Object, error?: string}>}
+ */
+export async function loginWithMagicLink(token) {
+  try {
+    const response = await fetch(`${API_URL}/auth/magic-link/login`, {
+      method: "POST",
+      credentials: "include",
+      headers: {
+        "Content-Type": "application/json",
+      },
+      body: JSON.stringify({ token }),
+    });
+
+    const data = await response.json();
+
+    if (response.ok && data.two_factor_required) {
+      return await completeTwoFactorLogin(data.challenge_id);
+    } else if (response.ok) {
+      clearAuthCache();
+      return { success: true, user: data };
+    }
+    return { success: false, error: data.error || "El enlace expiró o ya fue usado" };
+  } catch (error) {
+    console.error("Magic link login failed:", error);
+    return { success: false, error: "Error de conexión. Intenta de nuevo." };
+  }
+}
+
 /**
  * Register new user
  * @param {string} name 
@@ -256,7 +256,7 @@ function renderMembersList(isOwner) {
             <div class="member-email">${member.user_email || ''}</div>
           </div>
           <div class="member-role ${member.role === 'owner' ? 'role-owner' : 'role-member'}">
-            ${member.role === 'owner' ? 'Dueño' : 'Miembro'}
+            ${ROLE_LABELS[member.role] || 'Miembro'}
           </div>
           ${renderMemberActions(member, isOwner, userMember)}
         </div>
@@ -265,6 +265,13 @@ function renderMembersList(isOwner) {
   `;
 }
 
+const ROLE_LABELS = {
+  owner: 'Dueño',
+  member: 'Miembro',
+  bookkeeper: 'Contador',
+  viewer: 'Solo lectura',
+};
+
 /**
  * Render member action buttons
  */
@@ -280,8 +287,11 @@ function renderMemberActions(member, isOwner, userMember) {
   }
   
   if (isOwner && !isSelf) {
-    if (member.role === 'member') {
+    if (member.role !== 'owner') {
       menuItems.push(`<button class="menu-item" data-action="promote" data-user-id="${member.user_id}">Promover a dueño</button>`);
+      ['member', 'bookkeeper', 'viewer'].filter(role => role !== member.role).forEach(role => {
+        menuItems.push(`<button class="menu-item" data-action="set-role" data-role="${role}" data-user-id="${member.user_id}">Cambiar a ${ROLE_LABELS[role].toLowerCase()}</button>`);
+      });
     } else if (!isLastOwner) {
       menuItems.push(`<button class="menu-item" data-action="demote" data-user-id="${member.user_id}">Quitar como dueño</button>`);
     }
@@ -737,6 +747,7 @@ function setupEventHandlers() {
       else if (action === 'leave') await handleLeaveMember();
       else if (action === 'promote') await handlePromoteMember(userId);
       else if (action === 'demote') await handleDemoteMember(userId);
+      else if (action === 'set-role') await handleSetMemberRole(userId, e.target.dataset.role);
       else if (action === 'toggle-active') await handleToggleContactActive(contactId, e.target.dataset.isActive === 'true');
       else if (action === 'edit-contact') handleEditContact(contactId);
       else if (action === 'delete-contact') await handleDeleteContact(contactId);
@@ -964,7 +975,9 @@ async function handlePromoteMember(userId) {
   const message = `
     <p><strong>¿Cuál es la diferencia?</strong></p>
     <ul style="text-align: left; margin: 12px 0; padding-left: 20px;">
-      <li><strong>Miembro:</strong> Puede ver y registrar movimientos del hogar.</li>
+      <li><strong>Solo lectura:</strong> Puede ver el hogar pero no cambiar nada.</li>
+      <li><strong>Contador:</strong> Puede ver y registrar movimientos, pero no gestionar cuentas ni miembros.</li>
+      <li><strong>Miembro:</strong> Puede ver y registrar movimientos, cuentas, presupuestos y contactos.</li>
       <li><strong>Dueño:</strong> Además puede invitar personas, gestionar miembros, y eliminar el hogar.</li>
     </ul>
     <p>¿Deseas promover este miembro a dueño?</p>
@@ -1023,6 +1036,33 @@ async function handleDemoteMember(userId) {
   }
 }
 
+/**
+ * Handle changing a non-owner member's role
+ */
+async function handleSetMemberRole(userId, role) {
+  const label = ROLE_LABELS[role];
+  if (!label) return;
+  if (!await showConfirmation('Cambiar rol', `<p>¿Deseas cambiar el rol de este miembro a <strong>${label}</strong>?</p>`, 'Cambiar')) return;
+
+  try {
+    const response = await fetch(`${API_URL}/households/${household.id}/members/${userId}/role`, {
+      method: 'PATCH',
+      credentials: 'include',
+      headers: { 'Content-Type': 'application/json' },
+      body: JSON.stringify({ role })
+    });
+
+    if (!response.ok) {
+      const data = await response.json();
+      throw new Error(data.error || 'Error al cambiar rol');
+    }
+
+    await loadHousehold();
+  } catch (error) {
+    await showError('Error', error.message);
+  }
+}
+
 /**
  * Handle edit contact
  */
@@ -70,6 +70,10 @@ export function render() {
             <a href="/forgot-password" id="forgotPasswordLink">¿Olvidaste tu contraseña?</a>
           </p>
 
+          <p class="auth-switch">
+            <a href="/magic-login" id="magicLoginLink">Iniciar sesión con un enlace por email</a>
+          </p>
+
           <p class="auth-switch">
             ¿No tienes cuenta?
             <a href="#" id="showRegister">Regístrate</a>
@@ -0,0 +1,140 @@
+/**
+ * Magic Link Login Page
+ * 
+ * Without a token, lets users request a one-time sign-in link by email.
+ * With a token (from the email), signs the user in.
+ */
+
+import { API_URL } from '../config.js';
+import { validateEmail, loginWithMagicLink } from '../auth-utils.js';
+import router from '../router.js';
+
+/**
+ * Render magic link page HTML
+ */
+export function render() {
+  return `
+    <div class="auth-wrapper">
+      <div class="auth-box">
+        <form id="magicLinkForm">
+          <h2>Iniciar Sesión con Enlace</h2>
+          
+          <p class="form-description" id="magicLinkDescription">
+            Ingresa tu email y te enviaremos un enlace para iniciar sesión sin contraseña.
+          </p>
+
+          <div class="form-group" id="magicEmailGroup">
+            <label for="magicEmail">Email</label>
+            <input
+              type="email"
+              id="magicEmail"
+              autocomplete="email"
+              required
+              placeholder="tu@email.com"
+            />
+          </div>
+
+          <div id="magicError" class="error hidden"></div>
+          <div id="magicSuccess" class="success hidden"></div>
+
+          <button type="submit" id="magicBtn" class="btn btn-primary">
+            Enviar Enlace
+          </button>
+
+          <p class="auth-switch">
+            <a href="/" id="magicBackToLogin">Volver al inicio de sesión</a>
+          </p>
+        </form>
+      </div>
+    </div>
+  `;
+}
+
+/**
+ * Initialize magic link page interactions
+ */
+export async function init() {
+  const form = document.getElementById('magicLinkForm');
+  const description = document.getElementById('magicLinkDescription');
+  const emailGroup = document.getElementById('magicEmailGroup');
+  const emailInput = document.getElementById('magicEmail');
+  const errorDiv = document.getElementById('magicError');
+  const successDiv = document.getElementById('magicSuccess');
+  const submitBtn = document.getElementById('magicBtn');
+  const backToLoginLink = document.getElementById('magicBackToLogin');
+
+  backToLoginLink.addEventListener('click', (e) => {
+    e.preventDefault();
+    router.navigate('/login');
+  });
+
+  // Arrived from the email: sign in with the token
+  const token = new URLSearchParams(window.location.search).get('token');
+  if (token) {
+    description.textContent = 'Iniciando sesión...';
+    emailGroup.classList.add('hidden');
+    submitBtn.classList.add('hidden');
+
+    const result = await loginWithMagicLink(token);
+    if (result.success) {
+      router.navigate('/');
+      return;
+    }
+    description.textContent = 'No pudimos iniciar sesión con este enlace.';
+    errorDiv.textContent = result.error;
+    errorDiv.classList.remove('hidden');
+    return;
+  }
+
+  form.addEventListener('submit', async (e) => {
+    e.preventDefault();
+
+    errorDiv.classList.add('hidden');
+    successDiv.classList.add('hidden');
+
+    const email = emailInput.value.trim();
+    if (!validateEmail(email)) {
+      errorDiv.textContent = 'Por favor ingresa un email válido';
+      errorDiv.classList.remove('hidden');
+      return;
+    }
+
+    submitBtn.disabled = true;
+    submitBtn.textContent = 'Enviando...';
+
+    try {
+      const response = await fetch(`${API_URL}/auth/magic-link`, {
+        method: 'POST',
+        headers: {
+          'Content-Type': 'application/json',
+        },
+        body: JSON.stringify({ email }),
+      });
+
+      const data = await response.json();
+
+      if (response.ok) {
+        successDiv.innerHTML = `
+          <strong>¡Enlace enviado!</strong><br>
+          Si existe una cuenta con ese email, recibirás un enlace para iniciar sesión.
+          <br><br>
+          <small>Revisa tu bandeja de entrada y spam. El enlace expira en 15 minutos.</small>
+        `;
+        successDiv.classList.remove('hidden');
+        form.reset();
+      } else {
+        errorDiv.textContent = data.error || 'Error al enviar el enlace';
+        errorDiv.classList.remove('hidden');
+      }
+    } catch (error) {
+      console.error('Magic link error:', error);
+      errorDiv.textContent = 'Error de conexión. Intenta nuevamente.';
+      errorDiv.classList.remove('hidden');
+    } finally {
+      submitBtn.disabled = false;
+      submitBtn.textContent = 'Enviar Enlace';
+    }
+  });
+
+  emailInput.focus();
+}
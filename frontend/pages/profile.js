@@ -155,6 +155,15 @@ function renderProfileContent() {
           <span class="info-label">Email</span>
           <span class="info-value">${currentUser.email}</span>
         </div>
+        ${currentUser.email_verified === false ? `
+          <div class="info-item">
+            <span class="info-label">Verificación</span>
+            <span class="info-value">
+              Email sin verificar
+              <button id="resend-verification-btn" class="btn-secondary btn-small">Reenviar enlace</button>
+            </span>
+          </div>
+        ` : ''}
       </div>
     </div>
 
@@ -399,6 +408,30 @@ function setupEventListeners() {
     });
   }
 
+  const resendVerificationBtn = document.getElementById('resend-verification-btn');
+  if (resendVerificationBtn) {
+    resendVerificationBtn.addEventListener('click', async () => {
+      resendVerificationBtn.disabled = true;
+      try {
+        const response = await fetch(`${API_URL}/me/email-verification`, {
+          method: 'POST',
+          credentials: 'include',
+        });
+        const data = await response.json();
+        if (response.ok) {
+          showSuccess('Enlace enviado', 'Revisa tu bandeja de entrada para verificar tu email.');
+        } else {
+          showError('Error', data.error || 'No se pudo enviar el enlace');
+          resendVerificationBtn.disabled = false;
+        }
+      } catch (error) {
+        console.error('Resend verification error:', error);
+        showError('Error', 'Error de conexión. Intenta nuevamente.');
+        resendVerificationBtn.disabled = false;
+      }
+    });
+  }
+
   // Add account buttons (may be multiple with same ID in different states)
   document.querySelectorAll('#add-account-btn').forEach(btn => {
     btn.addEventListener('click', () => {
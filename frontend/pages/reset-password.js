@@ -253,7 +253,7 @@ export function init() {
     submitBtn.textContent = 'Restableciendo...';
 
     try {
-      const response = await fetch(`${API_URL}/auth/reset-password`, {
+      const sendReset = (twoFactorCode) => fetch(`${API_URL}/auth/reset-password`, {
         method: 'POST',
         headers: {
           'Content-Type': 'application/json',
@@ -262,10 +262,21 @@ export function init() {
           token: token,
           new_password: newPassword,
           new_password_confirm: confirmPassword,
+          two_factor_code: twoFactorCode,
         }),
       });
 
-      const data = await response.json();
+      let response = await sendReset();
+      let data = await response.json();
+
+      // Accounts with two-factor authentication must confirm the reset with a code
+      if (response.status === 403) {
+        const code = window.prompt('Ingresa el código de tu app de autenticación o un código de recuperación');
+        if (code) {
+          response = await sendReset(code.trim());
+          data = await response.json();
+        }
+      }
 
       if (response.ok) {
         // Show success message
@@ -0,0 +1,82 @@
+/**
+ * Unlock Account Page
+ * 
+ * Lifts a temporary lockout using the token from the "account locked" email.
+ */
+
+import { API_URL } from '../config.js';
+import router from '../router.js';
+
+/**
+ * Render unlock account page HTML
+ */
+export function render() {
+  return `
+    <div class="auth-wrapper">
+      <div class="auth-box">
+        <h2>Desbloquear Cuenta</h2>
+
+        <p id="unlockStatus" class="form-description">Desbloqueando tu cuenta...</p>
+
+        <div id="unlockError" class="error hidden"></div>
+        <div id="unlockSuccess" class="success hidden"></div>
+
+        <p class="auth-switch">
+          <a href="/login" id="unlockToLogin">Ir al inicio de sesión</a>
+        </p>
+      </div>
+    </div>
+  `;
+}
+
+/**
+ * Initialize unlock account page: consume the token right away
+ */
+export async function init() {
+  const statusEl = document.getElementById('unlockStatus');
+  const errorDiv = document.getElementById('unlockError');
+  const successDiv = document.getElementById('unlockSuccess');
+  const loginLink = document.getElementById('unlockToLogin');
+
+  loginLink.addEventListener('click', (e) => {
+    e.preventDefault();
+    router.navigate('/login');
+  });
+
+  const token = new URLSearchParams(window.location.search).get('token');
+  if (!token) {
+    statusEl.classList.add('hidden');
+    errorDiv.textContent = 'Enlace inválido.';
+    errorDiv.classList.remove('hidden');
+    return;
+  }
+
+  try {
+    const response = await fetch(`${API_URL}/auth/unlock-account`, {
+      method: 'POST',
+      headers: {
+        'Content-Type': 'application/json',
+      },
+      body: JSON.stringify({ token }),
+    });
+
+    const data = await response.json();
+    statusEl.classList.add('hidden');
+
+    if (response.ok) {
+      successDiv.innerHTML = `
+        <strong>¡Cuenta desbloqueada!</strong><br>
+        Ya puedes iniciar sesión. Si no fuiste tú quien intentó entrar, cambia tu contraseña.
+      `;
+      successDiv.classList.remove('hidden');
+    } else {
+      errorDiv.textContent = data.error || 'El enlace expiró o ya fue usado. El bloqueo se levantará solo en unos minutos.';
+      errorDiv.classList.remove('hidden');
+    }
+  } catch (error) {
+    console.error('Unlock account error:', error);
+    statusEl.classList.add('hidden');
+    errorDiv.textContent = 'Error de conexión. Intenta nuevamente.';
+    errorDiv.classList.remove('hidden');
+  }
+}
@@ -0,0 +1,85 @@
+/**
+ * Verify Email Page
+ * 
+ * Confirms the user's email address using the token from the verification email.
+ */
+
+import { API_URL } from '../config.js';
+import { clearAuthCache } from '../auth-utils.js';
+import router from '../router.js';
+
+/**
+ * Render verify email page HTML
+ */
+export function render() {
+  return `
+    <div class="auth-wrapper">
+      <div class="auth-box">
+        <h2>Verificar Email</h2>
+
+        <p id="verifyStatus" class="form-description">Verificando tu email...</p>
+
+        <div id="verifyError" class="error hidden"></div>
+        <div id="verifySuccess" class="success hidden"></div>
+
+        <p class="auth-switch">
+          <a href="/" id="verifyContinue">Ir a Conti</a>
+        </p>
+      </div>
+    </div>
+  `;
+}
+
+/**
+ * Initialize verify email page: consume the token right away
+ */
+export async function init() {
+  const statusEl = document.getElementById('verifyStatus');
+  const errorDiv = document.getElementById('verifyError');
+  const successDiv = document.getElementById('verifySuccess');
+  const continueLink = document.getElementById('verifyContinue');
+
+  continueLink.addEventListener('click', (e) => {
+    e.preventDefault();
+    router.navigate('/');
+  });
+
+  const token = new URLSearchParams(window.location.search).get('token');
+  if (!token) {
+    statusEl.classList.add('hidden');
+    errorDiv.textContent = 'Enlace inválido. Solicita un nuevo enlace de verificación desde tu perfil.';
+    errorDiv.classList.remove('hidden');
+    return;
+  }
+
+  try {
+    const response = await fetch(`${API_URL}/auth/verify-email`, {
+      method: 'POST',
+      credentials: 'include',
+      headers: {
+        'Content-Type': 'application/json',
+      },
+      body: JSON.stringify({ token }),
+    });
+
+    const data = await response.json();
+    statusEl.classList.add('hidden');
+
+    if (response.ok) {
+      clearAuthCache();
+      successDiv.innerHTML = `
+        <strong>¡Email verificado!</strong><br>
+        Ya puedes vincular contactos con otros hogares.
+      `;
+      successDiv.classList.remove('hidden');
+    } else {
+      errorDiv.textContent = data.error || 'El enlace expiró o ya fue usado. Solicita uno nuevo desde tu perfil.';
+      errorDiv.classList.remove('hidden');
+    }
+  } catch (error) {
+    console.error('Verify email error:', error);
+    statusEl.classList.add('hidden');
+    errorDiv.textContent = 'Error de conexión. Intenta nuevamente.';
+    errorDiv.classList.remove('hidden');
+  }
+}